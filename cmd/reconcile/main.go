@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"os/signal"
+	"th-application-technical-assignment/pkg/database"
+	"th-application-technical-assignment/pkg/reconcile"
+	"th-application-technical-assignment/pkg/search"
+	"th-application-technical-assignment/pkg/tasks"
+
+	"github.com/caarlos0/env/v11"
+)
+
+type Config struct {
+	Reconcile reconcile.Config `envPrefix:"RECONCILE_"`
+	Search    search.Config    `envPrefix:"OPENSEARCH_"`
+	Database  database.Config  `envPrefix:"DB_"`
+}
+
+func main() {
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	var cfg Config
+	if err := env.Parse(&cfg); err != nil {
+		slog.ErrorContext(ctx, "failed to parse config", "err", err)
+		os.Exit(1)
+	}
+
+	p, err := database.NewPgPoolFromCfg(ctx, &cfg.Database)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to create database pool", "err", err)
+		os.Exit(1)
+	}
+
+	store := database.New(ctx, p)
+	defer store.Close(ctx)
+
+	searchClient, err := search.NewClient(&cfg.Search)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to create search client", "err", err)
+		os.Exit(1)
+	}
+
+	// repair tasks go through the outbox like every other indexing task
+	queue := tasks.NewOutboxQueue(store.Queries)
+	reconciler := reconcile.New(store, searchClient, queue, &cfg.Search, &cfg.Reconcile)
+
+	if cfg.Reconcile.Interval > 0 {
+		slog.InfoContext(ctx, "reconciler started", "interval", cfg.Reconcile.Interval, "repair", cfg.Reconcile.Repair)
+		reconciler.Run(ctx)
+		return
+	}
+
+	if _, err := reconciler.Check(ctx); err != nil {
+		slog.ErrorContext(ctx, "reconcile failed", "err", err)
+		store.Close(ctx)
+		os.Exit(1)
+	}
+}
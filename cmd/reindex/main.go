@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"os/signal"
+	"th-application-technical-assignment/pkg/database"
+	"th-application-technical-assignment/pkg/reindex"
+	"th-application-technical-assignment/pkg/search"
+
+	"github.com/caarlos0/env/v11"
+)
+
+type Config struct {
+	Reindex  reindex.Config  `envPrefix:"REINDEX_"`
+	Search   search.Config   `envPrefix:"OPENSEARCH_"`
+	Database database.Config `envPrefix:"DB_"`
+}
+
+func main() {
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	var cfg Config
+	if err := env.Parse(&cfg); err != nil {
+		slog.ErrorContext(ctx, "failed to parse config", "err", err)
+		os.Exit(1)
+	}
+
+	p, err := database.NewPgPoolFromCfg(ctx, &cfg.Database)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to create database pool", "err", err)
+		os.Exit(1)
+	}
+
+	store := database.New(ctx, p)
+	defer store.Close(ctx)
+
+	searchClient, err := search.NewClient(&cfg.Search)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to create search client", "err", err)
+		os.Exit(1)
+	}
+
+	results, err := reindex.New(store, searchClient, &cfg.Search, &cfg.Reindex).Run(ctx)
+	for _, res := range results {
+		slog.InfoContext(ctx, "rebuilt index", "alias", res.Alias, "index", res.Index, "documents", res.Documents)
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "reindex failed", "err", err)
+		store.Close(ctx)
+		os.Exit(1)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"os"
 	"os/signal"
 	"th-application-technical-assignment/pkg/database"
+	"th-application-technical-assignment/pkg/storage"
 	"th-application-technical-assignment/pkg/tasks"
 	"time"
 
@@ -14,9 +15,13 @@ import (
 )
 
 type Config struct {
-	Redis    tasks.RedisConfig `envPrefix:"REDIS_"`
-	Queue    tasks.QueueConfig `envPrefix:"QUEUE_"`
-	Database database.Config   `envPrefix:"DB_"`
+	Redis    tasks.RedisConfig   `envPrefix:"REDIS_"`
+	Queue    tasks.QueueConfig   `envPrefix:"QUEUE_"`
+	Import   tasks.ImportConfig  `envPrefix:"IMPORT_"`
+	Trash    tasks.TrashConfig   `envPrefix:"TRASH_"`
+	Publish  tasks.PublishConfig `envPrefix:"PUBLISH_"`
+	Database database.Config     `envPrefix:"DB_"`
+	Storage  storage.Config      `envPrefix:"MINIO_"`
 }
 
 func main() {
@@ -37,6 +42,12 @@ func main() {
 	store := database.New(ctx, p)
 	defer store.Close(ctx)
 
+	minioClient, err := storage.NewMinIOClient(&cfg.Storage)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to create minio client", "err", err)
+		os.Exit(1)
+	}
+
 	redisOpt := asynq.RedisClientOpt{
 		Addr:     cfg.Redis.RedisAddr,
 		Password: cfg.Redis.RedisPassword,
@@ -63,9 +74,36 @@ func main() {
 
 	mux := asynq.NewServeMux()
 
-	importProcessor := tasks.NewImportEpisodeTaskProcessor(store, client)
+	importProcessor := tasks.NewImportEpisodeTaskProcessor(store, client, &cfg.Import)
+	syncProcessor := tasks.NewSyncSubscriptionsTaskProcessor(store, client, &cfg.Import)
+	deletionProcessor := tasks.NewDeleteSeriesContentTaskProcessor(store, minioClient)
+	purgeProcessor := tasks.NewPurgeTrashTaskProcessor(store, minioClient, &cfg.Trash)
+	publishProcessor := tasks.NewPublishScheduledTaskProcessor(store, &cfg.Publish)
 
 	mux.Handle(tasks.TypeImportContent, importProcessor)
+	mux.Handle(tasks.TypeSyncSubscriptions, syncProcessor)
+	mux.Handle(tasks.TypeDeleteSeriesContent, deletionProcessor)
+	mux.Handle(tasks.TypePurgeTrash, purgeProcessor)
+	mux.Handle(tasks.TypePublishScheduled, publishProcessor)
+
+	// every replica runs a scheduler; due subscriptions and scheduled
+	// episodes are claimed in the database and purging twice removes nothing
+	// twice, so duplicate tasks are harmless
+	scheduler := asynq.NewScheduler(redisOpt, &asynq.SchedulerOpts{Location: time.UTC})
+	if _, err := scheduler.Register(cfg.Import.SyncSchedule, asynq.NewTask(tasks.TypeSyncSubscriptions, nil)); err != nil {
+		slog.ErrorContext(ctx, "failed to register subscription sync", "err", err, "schedule", cfg.Import.SyncSchedule)
+		os.Exit(1)
+	}
+	if _, err := scheduler.Register(cfg.Publish.Schedule, asynq.NewTask(tasks.TypePublishScheduled, nil)); err != nil {
+		slog.ErrorContext(ctx, "failed to register scheduled publishing", "err", err, "schedule", cfg.Publish.Schedule)
+		os.Exit(1)
+	}
+	if cfg.Trash.RetentionDays > 0 {
+		if _, err := scheduler.Register(cfg.Trash.PurgeSchedule, asynq.NewTask(tasks.TypePurgeTrash, nil)); err != nil {
+			slog.ErrorContext(ctx, "failed to register trash purge", "err", err, "schedule", cfg.Trash.PurgeSchedule)
+			os.Exit(1)
+		}
+	}
 
 	go func() {
 		if err := srv.Start(mux); err != nil {
@@ -74,6 +112,11 @@ func main() {
 		}
 	}()
 
+	if err := scheduler.Start(); err != nil {
+		slog.ErrorContext(ctx, "failed to start scheduler", "err", err)
+		os.Exit(1)
+	}
+
 	<-ctx.Done()
 
 	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -81,6 +124,7 @@ func main() {
 
 	slog.InfoContext(shutdownCtx, "shutting down worker...")
 
+	scheduler.Shutdown()
 	srv.Shutdown()
 	slog.InfoContext(shutdownCtx, "worker stopped")
 }
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"th-application-technical-assignment/pkg/database"
+	"th-application-technical-assignment/pkg/tasks"
+	"time"
+
+	"github.com/caarlos0/env/v11"
+)
+
+type Config struct {
+	Redis    tasks.RedisConfig `envPrefix:"REDIS_"`
+	Relay    tasks.RelayConfig `envPrefix:"RELAY_"`
+	Database database.Config   `envPrefix:"DB_"`
+}
+
+func main() {
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	var cfg Config
+	if err := env.Parse(&cfg); err != nil {
+		slog.ErrorContext(ctx, "failed to parse config", "err", err)
+		os.Exit(1)
+	}
+
+	p, err := database.NewPgPoolFromCfg(ctx, &cfg.Database)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to create database pool", "err", err)
+		os.Exit(1)
+	}
+
+	store := database.New(ctx, p)
+	defer store.Close(ctx)
+
+	client, err := tasks.NewClient(&cfg.Redis)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to create queue client", "err", err)
+		os.Exit(1)
+	}
+	defer func() {
+		if err := client.Close(); err != nil {
+			slog.ErrorContext(ctx, "failed to close queue client", "err", err)
+		}
+	}()
+
+	relay := tasks.NewRelay(store, client, &cfg.Relay)
+
+	mux := http.NewServeMux()
+	mux.Handle("GET /metrics", relay.MetricsHandler())
+	mux.HandleFunc("GET /health", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	metricsSrv := &http.Server{
+		Addr:              cfg.Relay.MetricsAddr,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	go func() {
+		if err := metricsSrv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			slog.ErrorContext(ctx, "metrics server error", "err", err)
+			os.Exit(1)
+		}
+	}()
+
+	slog.InfoContext(ctx, "outbox relay started", "batch_size", cfg.Relay.BatchSize, "interval", cfg.Relay.Interval)
+	relay.Run(ctx)
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	slog.InfoContext(shutdownCtx, "shutting down relay...")
+
+	if err := metricsSrv.Shutdown(shutdownCtx); err != nil {
+		slog.ErrorContext(shutdownCtx, "failed to shut down metrics server", "err", err)
+	}
+	slog.InfoContext(shutdownCtx, "relay stopped")
+}
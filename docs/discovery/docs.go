@@ -56,6 +56,12 @@ const docTemplate = `{
                         "description": "Filter by series ID",
                         "name": "series_id",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Preferred languages of titles and descriptions",
+                        "name": "Accept-Language",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -124,6 +130,12 @@ const docTemplate = `{
                         "name": "category_id",
                         "in": "query"
                     },
+                    {
+                        "type": "boolean",
+                        "description": "Also match series of the categories below category_id",
+                        "name": "include_descendants",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
                         "description": "Filter by series type",
@@ -135,6 +147,12 @@ const docTemplate = `{
                         "description": "Filter by language",
                         "name": "language",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Preferred languages of titles and descriptions",
+                        "name": "Accept-Language",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -164,6 +182,133 @@ const docTemplate = `{
                     }
                 }
             }
+        },
+        "/series/by-slug/{slug}": {
+            "get": {
+                "description": "Get a single series document by its slug. A slug the series had before it was renamed redirects to its current slug",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Discovery"
+                ],
+                "summary": "Get series by slug",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Series slug",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Preferred languages of titles and descriptions",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "301": {
+                        "description": "Moved Permanently, Location names the current slug"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/series/by-slug/{slug}/episodes/{episodeSlug}": {
+            "get": {
+                "description": "Get a single episode document by the slug of its series and its own slug. Slugs the series or the episode had before they were renamed redirect to the current ones",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Discovery"
+                ],
+                "summary": "Get episode by slug",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Series slug",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Episode slug",
+                        "name": "episodeSlug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Preferred languages of titles and descriptions",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "301": {
+                        "description": "Moved Permanently, Location names the current slugs"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
         }
     },
     "definitions": {
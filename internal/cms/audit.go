@@ -0,0 +1,95 @@
+package cms
+
+import (
+	"context"
+	"net/http"
+	"th-application-technical-assignment/internal/middleware"
+	"th-application-technical-assignment/internal/response"
+	v1 "th-application-technical-assignment/pkg/api/cms/v1"
+	"th-application-technical-assignment/pkg/audit"
+	"th-application-technical-assignment/pkg/mapping"
+	"th-application-technical-assignment/pkg/util"
+	"th-application-technical-assignment/sqlc"
+
+	"github.com/google/uuid"
+)
+
+// record writes the audit event of a change made through q, attributed to
+// the subject of the request token.
+func (h *Handler) record(ctx context.Context, q sqlc.Querier, action, entityType string, entityID uuid.UUID, before, after any) error {
+	return audit.Record(ctx, q, audit.Event{
+		Actor:      middleware.Subject(ctx),
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Before:     before,
+		After:      after,
+	})
+}
+
+// listAuditEvents godoc
+// @Summary      List the audit history of an entity
+// @Description  Get a paginated list of the changes made to a series, episode, category or asset, newest first
+// @Tags         Audit
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        entity_id  query     string  true   "Entity ID"
+// @Param        page       query     int     false  "Page number (default: 1)"
+// @Param        page_size  query     int     false  "Page size (default: 20, max: 100)"
+// @Success      200        {object}  v1.PaginatedAuditEventResponse
+// @Failure      400        {object}  map[string]string
+// @Failure      401        {object}  map[string]string
+// @Failure      403        {object}  map[string]string
+// @Failure      500        {object}  map[string]string
+// @Router       /audit [get]
+func (h *Handler) listAuditEvents(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	entityIDParam := r.URL.Query().Get("entity_id")
+	if entityIDParam == "" {
+		response.RespondWithError(ctx, w, http.StatusBadRequest, "Entity ID is required.")
+		return
+	}
+
+	entityID, err := uuid.Parse(entityIDParam)
+	if err != nil {
+		response.RespondWithError(ctx, w, http.StatusBadRequest, "Invalid entity ID format.")
+		return
+	}
+
+	pagination := middleware.GetPagination(ctx)
+	offset := (pagination.Page - 1) * pagination.PageSize
+
+	fetchCount := func(ctx context.Context) (int64, error) {
+		return h.s.Queries.CountAuditEventsByEntity(ctx, entityID)
+	}
+
+	fetchEvents := func(ctx context.Context) ([]sqlc.AuditEvent, error) {
+		params := sqlc.ListAuditEventsByEntityPaginatedParams{
+			EntityID: entityID,
+			Limit:    int32(pagination.PageSize),
+			Offset:   int32(offset),
+		}
+		return h.s.Queries.ListAuditEventsByEntityPaginated(ctx, params)
+	}
+
+	itemCount, dbEvents, err := util.FetchPaginatedData(ctx, fetchCount, fetchEvents)
+	if err != nil {
+		response.HandleDBError(ctx, w, err, "We couldn't retrieve the audit history.")
+		return
+	}
+
+	eventsData := make([]v1.AuditEventResponse, len(dbEvents))
+	for i, e := range dbEvents {
+		eventsData[i] = mapping.AuditEvent(e)
+	}
+
+	paginationMeta := util.CalculatePaginationResponse(pagination.Page, pagination.PageSize, itemCount)
+	res := util.PaginatedResponse[v1.AuditEventResponse]{
+		Data:       eventsData,
+		Pagination: paginationMeta,
+	}
+
+	response.RespondWithJSON(ctx, w, http.StatusOK, res)
+}
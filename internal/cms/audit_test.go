@@ -0,0 +1,113 @@
+package cms
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	v1 "th-application-technical-assignment/pkg/api/cms/v1"
+	"th-application-technical-assignment/pkg/audit"
+	"th-application-technical-assignment/pkg/database"
+	"th-application-technical-assignment/sqlc"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler_listAuditEvents(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		entityID       string
+		mockEvents     []sqlc.AuditEvent
+		dbError        error
+		expectedStatus int
+	}{
+		{
+			name:     "successful list",
+			entityID: uuid.New().String(),
+			mockEvents: []sqlc.AuditEvent{
+				{
+					ID:         uuid.New(),
+					Actor:      "user-1",
+					Action:     audit.ActionUpdate,
+					EntityType: audit.EntitySeries,
+					Changes:    []byte(`{"title":{"before":"Old Title","after":"New Title"}}`),
+				},
+				{
+					ID:         uuid.New(),
+					Actor:      "user-1",
+					Action:     audit.ActionCreate,
+					EntityType: audit.EntitySeries,
+					Changes:    []byte(`{}`),
+				},
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "missing entity ID",
+			entityID:       "",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid entity ID",
+			entityID:       "invalid-uuid",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "database error",
+			entityID:       uuid.New().String(),
+			dbError:        assert.AnError,
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockQueries := new(database.MockQuerier)
+			handler := &Handler{
+				s: &database.Store{Queries: mockQueries},
+				v: validator.New(),
+			}
+
+			entityUUID, parseErr := uuid.Parse(tt.entityID)
+			if parseErr == nil {
+				// count and list run concurrently, so the list may not be reached on error
+				mockQueries.On("CountAuditEventsByEntity", mock.Anything, entityUUID).Return(int64(len(tt.mockEvents)), tt.dbError)
+				mockQueries.On("ListAuditEventsByEntityPaginated", mock.Anything, sqlc.ListAuditEventsByEntityPaginatedParams{
+					EntityID: entityUUID,
+					Limit:    20,
+					Offset:   0,
+				}).Return(tt.mockEvents, nil).Maybe()
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/audit?entity_id="+tt.entityID, nil)
+			recorder := httptest.NewRecorder()
+
+			handler.listAuditEvents(recorder, req)
+
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+
+			if tt.expectedStatus == http.StatusOK {
+				var res v1.PaginatedAuditEventResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				require.Len(t, res.Data, 2)
+				assert.Equal(t, audit.ActionUpdate, res.Data[0].Action)
+				assert.Equal(t, "user-1", res.Data[0].Actor)
+				require.Contains(t, res.Data[0].Changes, "title")
+				assert.JSONEq(t, `"Old Title"`, string(res.Data[0].Changes["title"].Before))
+				assert.JSONEq(t, `"New Title"`, string(res.Data[0].Changes["title"].After))
+				assert.Empty(t, res.Data[1].Changes)
+			}
+
+			mockQueries.AssertExpectations(t)
+		})
+	}
+}
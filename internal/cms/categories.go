@@ -2,29 +2,83 @@ package cms
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"fmt"
 	"net/http"
+	"slices"
+	"strconv"
 	"th-application-technical-assignment/internal/middleware"
 	"th-application-technical-assignment/internal/response"
 	"th-application-technical-assignment/pkg/api/cms/v1"
+	"th-application-technical-assignment/pkg/audit"
+	"th-application-technical-assignment/pkg/database"
 	"th-application-technical-assignment/pkg/mapping"
+	"th-application-technical-assignment/pkg/tasks"
 	"th-application-technical-assignment/pkg/util"
 	"th-application-technical-assignment/pkg/validation"
 	"th-application-technical-assignment/sqlc"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 )
 
+// maxSlugAttempts bounds how often a write with auto_suffix is retried when
+// concurrent writes keep taking the picked slug.
+const maxSlugAttempts = 3
+
+// maxDependentSeries caps the series listed when a category in use cannot
+// be deleted.
+const maxDependentSeries = 100
+
+var (
+	// errReassignTargetNotFound rejects moving series to a category that does
+	// not exist or is deleted.
+	errReassignTargetNotFound = errors.New("reassign target category not found")
+	// errCategoryDeleted rejects creating, moving or restoring a series into
+	// a category that does not exist or is deleted, or rolling one back to
+	// a deleted category.
+	errCategoryDeleted = errors.New("category is deleted")
+	// errParentCategoryNotFound rejects placing a category below one that
+	// does not exist or is deleted.
+	errParentCategoryNotFound = errors.New("parent category not found")
+	// errParentCategoryDeleted rejects restoring a category whose parent is
+	// deleted.
+	errParentCategoryDeleted = errors.New("parent category is deleted")
+	// errCategoryCycle rejects moving a category below itself or one of its
+	// subcategories.
+	errCategoryCycle = errors.New("category cannot be moved below itself")
+	// errCategoryHasChildren rejects deleting a category that live
+	// subcategories still belong to.
+	errCategoryHasChildren = errors.New("category has subcategories")
+)
+
+// categoryInUseError aborts the deletion of a category that live series
+// still belong to.
+type categoryInUseError struct {
+	count  int64
+	series []sqlc.Series
+}
+
+func (e *categoryInUseError) Error() string {
+	return fmt.Sprintf("category is used by %d series", e.count)
+}
+
 // listCategories godoc
 // @Summary      List all categories with pagination
 // @Description  Get a paginated list of all categories available in the system
 // @Tags         Categories
+// @Security     BearerAuth
 // @Accept       json
 // @Produce      json
 // @Param        page       query     int  false  "Page number (default: 1)"
 // @Param        page_size  query     int  false  "Page size (default: 20, max: 100)"
+// @Param        Accept-Language  header  string  false  "Preferred languages of category names"
 // @Success      200        {object}  v1.PaginatedCategoryResponse
 // @Failure      400        {object}  map[string]string
+// @Failure      401        {object}  map[string]string
+// @Failure      403        {object}  map[string]string
 // @Failure      500        {object}  map[string]string
 // @Router       /categories [get]
 func (h *Handler) listCategories(w http.ResponseWriter, r *http.Request) {
@@ -54,6 +108,10 @@ func (h *Handler) listCategories(w http.ResponseWriter, r *http.Request) {
 	for i, c := range dbCategories {
 		categoriesData[i] = mapping.Category(c)
 	}
+	if err := h.localizeCategories(ctx, categoriesData); err != nil {
+		response.HandleDBError(ctx, w, err, "We couldn't retrieve the category translations.")
+		return
+	}
 
 	paginationMeta := util.CalculatePaginationResponse(pagination.Page, pagination.PageSize, itemCount)
 	res := util.PaginatedResponse[v1.CategoryResponse]{
@@ -64,15 +122,54 @@ func (h *Handler) listCategories(w http.ResponseWriter, r *http.Request) {
 	response.RespondWithJSON(ctx, w, http.StatusOK, res)
 }
 
+// getCategoryTree godoc
+// @Summary      Get the category tree
+// @Description  Get all live categories nested below their parent categories, siblings ordered by name
+// @Tags         Categories
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        Accept-Language  header  string  false  "Preferred languages of category names"
+// @Success      200  {array}   v1.CategoryTreeNode
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /categories/tree [get]
+func (h *Handler) getCategoryTree(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	dbCategories, err := h.s.Queries.ListCategories(ctx)
+	if err != nil {
+		response.HandleDBError(ctx, w, err, "We couldn't retrieve the categories.")
+		return
+	}
+
+	ids := make([]uuid.UUID, len(dbCategories))
+	for i, c := range dbCategories {
+		ids[i] = c.ID
+	}
+	translations, err := h.categoryTranslations(ctx, ids)
+	if err != nil {
+		response.HandleDBError(ctx, w, err, "We couldn't retrieve the category translations.")
+		return
+	}
+
+	response.RespondWithJSON(ctx, w, http.StatusOK, mapping.CategoryTree(dbCategories, translations))
+}
+
 // getCategory godoc
 // @Summary      Get category by ID
 // @Description  Get a single category by its ID
 // @Tags         Categories
+// @Security     BearerAuth
 // @Accept       json
 // @Produce      json
 // @Param        id   path      string  true  "Category ID"
+// @Param        Accept-Language  header  string  false  "Preferred languages of category names"
 // @Success      200  {object}  v1.CategoryResponse
 // @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
 // @Failure      404  {object}  map[string]string
 // @Failure      500  {object}  map[string]string
 // @Router       /categories/{id} [get]
@@ -97,33 +194,89 @@ func (h *Handler) getCategory(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	res := mapping.Category(dbCategory)
-	response.RespondWithJSON(ctx, w, http.StatusOK, res)
+	res := []v1.CategoryResponse{mapping.Category(dbCategory)}
+	if err := h.localizeCategories(ctx, res); err != nil {
+		response.HandleDBError(ctx, w, err, "We couldn't retrieve the category translations.")
+		return
+	}
+	response.RespondWithJSON(ctx, w, http.StatusOK, res[0])
 }
 
 // postCategory godoc
 // @Summary      Create a new category
-// @Description  Create a new category with the provided data
+// @Description  Create a new category with the provided data, optionally below a parent category. The slug is built from the name; with auto_suffix a taken slug gets the first free numbered suffix, such as news-2, instead of failing.
 // @Tags         Categories
+// @Security     BearerAuth
 // @Accept       json
 // @Produce      json
-// @Param        category  body      v1.CreateCategoryRequest  true  "Category data"
-// @Success      201       {object}  v1.CategoryResponse
-// @Failure      400       {object}  map[string]string
-// @Failure      500       {object}  map[string]string
+// @Param        category     body      v1.CreateCategoryRequest  true   "Category data"
+// @Param        auto_suffix  query     bool                      false  "Add a numbered suffix to a taken slug"
+// @Success      201          {object}  v1.CategoryResponse
+// @Failure      400          {object}  map[string]string
+// @Failure      401          {object}  map[string]string
+// @Failure      403          {object}  map[string]string
+// @Failure      409          {object}  map[string]string
+// @Failure      500          {object}  map[string]string
 // @Router       /categories [post]
 func (h *Handler) postCategory(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
+	autoSuffix, err := parseAutoSuffix(r)
+	if err != nil {
+		response.RespondWithError(ctx, w, http.StatusBadRequest, "Invalid auto_suffix value.")
+		return
+	}
+
 	req, err := validation.DecodeAndValidate[v1.CreateCategoryRequest](r, h.v)
 	if err != nil {
 		response.RespondWithError(ctx, w, http.StatusBadRequest, "Invalid request: "+err.Error())
 		return
 	}
 
-	slug := util.CreateSlug(req.Name)
-	dbCategory, err := h.s.Queries.CreateCategory(ctx, slug)
+	baseSlug, err := util.CreateSlug(req.Name)
 	if err != nil {
+		response.RespondWithError(ctx, w, http.StatusBadRequest, "The name must contain a letter or a digit.")
+		return
+	}
+
+	var parentID *uuid.UUID
+	if req.ParentID != nil {
+		id, err := uuid.Parse(*req.ParentID)
+		if err != nil {
+			response.RespondWithError(ctx, w, http.StatusBadRequest, "Invalid parent category ID format.")
+			return
+		}
+		parentID = &id
+	}
+
+	var dbCategory sqlc.Category
+	err = withSlugRetry(autoSuffix, func() error {
+		return h.s.WithTx(ctx, func(q sqlc.Querier) error {
+			if parentID != nil {
+				if err := checkParentCategory(ctx, q, *parentID); err != nil {
+					return err
+				}
+			}
+			slug, err := categorySlug(ctx, q, baseSlug, autoSuffix, "")
+			if err != nil {
+				return err
+			}
+			dbCategory, err = q.CreateCategory(ctx, sqlc.CreateCategoryParams{
+				Name:     req.Name,
+				Slug:     slug,
+				ParentID: parentID,
+			})
+			if err != nil {
+				return err
+			}
+			return h.record(ctx, q, audit.ActionCreate, audit.EntityCategory, dbCategory.ID, nil, dbCategory)
+		})
+	})
+	if err != nil {
+		if errors.Is(err, errParentCategoryNotFound) {
+			response.RespondWithError(ctx, w, http.StatusBadRequest, "The parent category does not exist.")
+			return
+		}
 		response.HandleDBError(ctx, w, err, "We couldn't create the category.")
 		return
 	}
@@ -134,16 +287,21 @@ func (h *Handler) postCategory(w http.ResponseWriter, r *http.Request) {
 
 // putCategory godoc
 // @Summary      Update category by ID
-// @Description  Update an existing category with the provided data
+// @Description  Update an existing category with the provided data. The slug is built from the name; with auto_suffix a taken slug gets the first free numbered suffix, such as news-2, instead of failing.
 // @Tags         Categories
+// @Security     BearerAuth
 // @Accept       json
 // @Produce      json
-// @Param        id        path      string                    true  "Category ID"
-// @Param        category  body      v1.UpdateCategoryRequest  true  "Category data"
-// @Success      200       {object}  v1.CategoryResponse
-// @Failure      400       {object}  map[string]string
-// @Failure      404       {object}  map[string]string
-// @Failure      500       {object}  map[string]string
+// @Param        id           path      string                    true   "Category ID"
+// @Param        category     body      v1.UpdateCategoryRequest  true   "Category data"
+// @Param        auto_suffix  query     bool                      false  "Add a numbered suffix to a taken slug"
+// @Success      200          {object}  v1.CategoryResponse
+// @Failure      400          {object}  map[string]string
+// @Failure      401          {object}  map[string]string
+// @Failure      403          {object}  map[string]string
+// @Failure      404          {object}  map[string]string
+// @Failure      409          {object}  map[string]string
+// @Failure      500          {object}  map[string]string
 // @Router       /categories/{id} [put]
 func (h *Handler) putCategory(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -160,18 +318,46 @@ func (h *Handler) putCategory(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	autoSuffix, err := parseAutoSuffix(r)
+	if err != nil {
+		response.RespondWithError(ctx, w, http.StatusBadRequest, "Invalid auto_suffix value.")
+		return
+	}
+
 	req, err := validation.DecodeAndValidate[v1.UpdateCategoryRequest](r, h.v)
 	if err != nil {
 		response.RespondWithError(ctx, w, http.StatusBadRequest, "Invalid request: "+err.Error())
 		return
 	}
 
-	params := sqlc.UpdateCategoryParams{
-		ID:   categoryID,
-		Slug: util.CreateSlug(req.Name),
+	baseSlug, err := util.CreateSlug(req.Name)
+	if err != nil {
+		response.RespondWithError(ctx, w, http.StatusBadRequest, "The name must contain a letter or a digit.")
+		return
 	}
 
-	dbCategory, err := h.s.Queries.UpdateCategory(ctx, params)
+	var dbCategory sqlc.Category
+	err = withSlugRetry(autoSuffix, func() error {
+		return h.s.WithTx(ctx, func(q sqlc.Querier) error {
+			before, err := q.GetCategory(ctx, categoryID)
+			if err != nil {
+				return err
+			}
+			slug, err := categorySlug(ctx, q, baseSlug, autoSuffix, before.Slug)
+			if err != nil {
+				return err
+			}
+			dbCategory, err = q.UpdateCategory(ctx, sqlc.UpdateCategoryParams{
+				ID:   categoryID,
+				Name: req.Name,
+				Slug: slug,
+			})
+			if err != nil {
+				return err
+			}
+			return h.record(ctx, q, audit.ActionUpdate, audit.EntityCategory, categoryID, before, dbCategory)
+		})
+	})
 	if err != nil {
 		response.HandleDBError(ctx, w, err, "Category not found.")
 		return
@@ -181,16 +367,180 @@ func (h *Handler) putCategory(w http.ResponseWriter, r *http.Request) {
 	response.RespondWithJSON(ctx, w, http.StatusOK, res)
 }
 
+// moveCategory godoc
+// @Summary      Move category
+// @Description  Move a category with its subcategories below another category, or make it a root category with a null parent_id. A category cannot be moved below itself or one of its subcategories. The series of the moved categories are indexed again.
+// @Tags         Categories
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id        path      string                  true  "Category ID"
+// @Param        category  body      v1.MoveCategoryRequest  true  "New parent category"
+// @Success      200       {object}  v1.CategoryResponse
+// @Failure      400       {object}  map[string]string
+// @Failure      401       {object}  map[string]string
+// @Failure      403       {object}  map[string]string
+// @Failure      404       {object}  map[string]string
+// @Failure      409       {object}  map[string]string
+// @Failure      500       {object}  map[string]string
+// @Router       /categories/{id}/move [post]
+func (h *Handler) moveCategory(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	idParam := chi.URLParam(r, "id")
+	if idParam == "" {
+		response.RespondWithError(ctx, w, http.StatusBadRequest, "Category ID is required.")
+		return
+	}
+
+	categoryID, err := uuid.Parse(idParam)
+	if err != nil {
+		response.RespondWithError(ctx, w, http.StatusBadRequest, "Invalid category ID format.")
+		return
+	}
+
+	req, err := validation.DecodeAndValidate[v1.MoveCategoryRequest](r, h.v)
+	if err != nil {
+		response.RespondWithError(ctx, w, http.StatusBadRequest, "Invalid request: "+err.Error())
+		return
+	}
+
+	var parentID *uuid.UUID
+	if req.ParentID != nil {
+		id, err := uuid.Parse(*req.ParentID)
+		if err != nil {
+			response.RespondWithError(ctx, w, http.StatusBadRequest, "Invalid parent category ID format.")
+			return
+		}
+		parentID = &id
+	}
+
+	// Serializable, so that two moves placing categories below each other
+	// cannot both pass the cycle check; one of them is retried and fails it.
+	var dbCategory sqlc.Category
+	err = h.s.WithTxOptions(ctx, database.TxOptions{IsoLevel: pgx.Serializable}, func(q sqlc.Querier) error {
+		before, err := q.GetCategory(ctx, categoryID)
+		if err != nil {
+			return err
+		}
+
+		subtree, err := q.ListCategoryDescendantIDs(ctx, categoryID)
+		if err != nil {
+			return err
+		}
+		if parentID != nil {
+			if slices.Contains(subtree, *parentID) {
+				return errCategoryCycle
+			}
+			if err := checkParentCategory(ctx, q, *parentID); err != nil {
+				return err
+			}
+		}
+
+		dbCategory, err = q.MoveCategory(ctx, sqlc.MoveCategoryParams{
+			ID:       categoryID,
+			ParentID: parentID,
+		})
+		if err != nil {
+			return err
+		}
+		if err := h.record(ctx, q, audit.ActionUpdate, audit.EntityCategory, categoryID, before, dbCategory); err != nil {
+			return err
+		}
+
+		// The category path of every series in the subtree changed.
+		series, err := q.TouchSeriesByCategories(ctx, subtree)
+		if err != nil {
+			return err
+		}
+		outbox := tasks.NewOutboxQueue(q)
+		for _, s := range series {
+			if err := outbox.EnqueueIndexSeries(ctx, s); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, errCategoryCycle) {
+			response.RespondWithError(ctx, w, http.StatusConflict, "A category cannot be moved below itself or one of its subcategories.")
+			return
+		}
+		if errors.Is(err, errParentCategoryNotFound) {
+			response.RespondWithError(ctx, w, http.StatusBadRequest, "The parent category does not exist.")
+			return
+		}
+		response.HandleDBError(ctx, w, err, "Category not found.")
+		return
+	}
+
+	response.RespondWithJSON(ctx, w, http.StatusOK, mapping.Category(dbCategory))
+}
+
+// checkParentCategory returns errParentCategoryNotFound unless the category
+// id is live.
+func checkParentCategory(ctx context.Context, q sqlc.Querier, id uuid.UUID) error {
+	if _, err := q.GetCategory(ctx, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errParentCategoryNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// parseAutoSuffix reads the optional auto_suffix query parameter.
+func parseAutoSuffix(r *http.Request) (bool, error) {
+	v := r.URL.Query().Get("auto_suffix")
+	if v == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(v)
+}
+
+// categorySlug returns slug, or with autoSuffix the first free numbered
+// variant of it when another category, deleted ones included, has taken it.
+// current is the slug of the category being renamed, which it may keep.
+func categorySlug(ctx context.Context, q sqlc.Querier, slug string, autoSuffix bool, current string) (string, error) {
+	if !autoSuffix || slug == current {
+		return slug, nil
+	}
+
+	taken, err := q.ListCategorySlugs(ctx, slug)
+	if err != nil {
+		return "", err
+	}
+	taken = slices.DeleteFunc(taken, func(s string) bool { return s == current })
+	return util.UniqueSlug(slug, taken), nil
+}
+
+// withSlugRetry runs write again when it failed because a concurrent request
+// took the same slug in between, so that the next free suffix is picked.
+// Without autoSuffix the conflict is returned right away.
+func withSlugRetry(autoSuffix bool, write func() error) error {
+	for attempt := 1; ; attempt++ {
+		err := write()
+		if !autoSuffix || attempt >= maxSlugAttempts || !database.IsUniqueViolation(err) {
+			return err
+		}
+	}
+}
+
 // deleteCategory godoc
 // @Summary      Delete category by ID
-// @Description  Soft delete a category by its ID
+// @Description  Soft delete a category by its ID. A category with live subcategories cannot be deleted. A category that live series still belong to is only deleted when reassign_to names another category to move them to; the moved series are indexed again.
 // @Tags         Categories
+// @Security     BearerAuth
 // @Accept       json
 // @Produce      json
-// @Param        id   path      string  true  "Category ID"
+// @Param        id           path      string  true   "Category ID"
+// @Param        reassign_to  query     string  false  "ID of the category to move the series of the deleted category to"
 // @Success      204  "No Content"
 // @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
 // @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  v1.CategoryInUseResponse
 // @Failure      500  {object}  map[string]string
 // @Router       /categories/{id} [delete]
 func (h *Handler) deleteCategory(w http.ResponseWriter, r *http.Request) {
@@ -208,11 +558,198 @@ func (h *Handler) deleteCategory(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = h.s.Queries.DeleteCategory(ctx, categoryID)
+	var reassignTo *uuid.UUID
+	if v := r.URL.Query().Get("reassign_to"); v != "" {
+		id, err := uuid.Parse(v)
+		if err != nil {
+			response.RespondWithError(ctx, w, http.StatusBadRequest, "Invalid reassign_to category ID format.")
+			return
+		}
+		if id == categoryID {
+			response.RespondWithError(ctx, w, http.StatusBadRequest, "Series cannot be reassigned to the deleted category.")
+			return
+		}
+		reassignTo = &id
+	}
+
+	err = h.s.WithTx(ctx, func(q sqlc.Querier) error {
+		// series placed in the category by concurrent requests are counted
+		// once they commit, and later ones find it deleted
+		before, err := q.GetCategoryForUpdate(ctx, categoryID)
+		if err != nil {
+			return err
+		}
+
+		children, err := q.CountChildCategories(ctx, &categoryID)
+		if err != nil {
+			return err
+		}
+		if children > 0 {
+			return errCategoryHasChildren
+		}
+
+		if reassignTo != nil {
+			if err := h.reassignSeries(ctx, q, categoryID, *reassignTo); err != nil {
+				return err
+			}
+		} else {
+			count, err := q.CountSeriesByCategory(ctx, categoryID)
+			if err != nil {
+				return err
+			}
+			if count > 0 {
+				series, err := q.ListSeriesByCategory(ctx, sqlc.ListSeriesByCategoryParams{
+					CategoryID: categoryID,
+					Limit:      maxDependentSeries,
+				})
+				if err != nil {
+					return err
+				}
+				return &categoryInUseError{count: count, series: series}
+			}
+		}
+
+		if err := q.DeleteCategory(ctx, categoryID); err != nil {
+			return err
+		}
+		return h.record(ctx, q, audit.ActionDelete, audit.EntityCategory, categoryID, before, nil)
+	})
 	if err != nil {
-		response.HandleDBError(ctx, w, err, "We couldn't delete the category.")
+		var inUse *categoryInUseError
+		if errors.As(err, &inUse) {
+			res := v1.CategoryInUseResponse{
+				Error:       "The category is still used by series, reassign them with reassign_to.",
+				SeriesCount: inUse.count,
+				Series:      make([]v1.DependentSeries, len(inUse.series)),
+			}
+			for i, s := range inUse.series {
+				res.Series[i] = mapping.DependentSeries(s)
+			}
+			response.RespondWithJSON(ctx, w, http.StatusConflict, res)
+			return
+		}
+		if errors.Is(err, errCategoryHasChildren) {
+			response.RespondWithError(ctx, w, http.StatusConflict, "The category has subcategories, move or delete them first.")
+			return
+		}
+		if errors.Is(err, errReassignTargetNotFound) {
+			response.RespondWithError(ctx, w, http.StatusBadRequest, "The reassign_to category does not exist.")
+			return
+		}
+		response.HandleDBError(ctx, w, err, "Category not found.")
 		return
 	}
 
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// reassignSeries moves the live series of a category to the category to and
+// indexes them again.
+func (h *Handler) reassignSeries(ctx context.Context, q sqlc.Querier, from, to uuid.UUID) error {
+	if _, err := q.GetCategoryForShare(ctx, to); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errReassignTargetNotFound
+		}
+		return err
+	}
+
+	series, err := q.ReassignSeriesCategory(ctx, sqlc.ReassignSeriesCategoryParams{
+		ToCategoryID:   to,
+		FromCategoryID: from,
+	})
+	if err != nil {
+		return err
+	}
+
+	outbox := tasks.NewOutboxQueue(q)
+	for _, s := range series {
+		before := s
+		before.CategoryID = from
+		if err := h.record(ctx, q, audit.ActionUpdate, audit.EntitySeries, s.ID, before, s); err != nil {
+			return err
+		}
+		if err := h.revise(ctx, q, audit.EntitySeries, s.ID, nil); err != nil {
+			return err
+		}
+		if err := outbox.EnqueueIndexSeries(ctx, s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// useCategory keeps the live category a series is placed in from being
+// deleted until the end of the transaction of q. It returns
+// errCategoryDeleted when the category does not exist or is deleted.
+func useCategory(ctx context.Context, q sqlc.Querier, id uuid.UUID) error {
+	if _, err := q.GetCategoryForShare(ctx, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errCategoryDeleted
+		}
+		return err
+	}
+	return nil
+}
+
+// restoreCategory godoc
+// @Summary      Restore a deleted category
+// @Description  Undo the soft delete of a category. A category can only be restored while its parent category is live.
+// @Tags         Categories
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Category ID"
+// @Success      200  {object}  v1.CategoryResponse
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /categories/{id}/restore [post]
+func (h *Handler) restoreCategory(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	idParam := chi.URLParam(r, "id")
+	if idParam == "" {
+		response.RespondWithError(ctx, w, http.StatusBadRequest, "Category ID is required.")
+		return
+	}
+
+	categoryID, err := uuid.Parse(idParam)
+	if err != nil {
+		response.RespondWithError(ctx, w, http.StatusBadRequest, "Invalid category ID format.")
+		return
+	}
+
+	var dbCategory sqlc.Category
+	err = h.s.WithTx(ctx, func(q sqlc.Querier) error {
+		before, err := q.GetDeletedCategory(ctx, categoryID)
+		if err != nil {
+			return err
+		}
+		if before.ParentID != nil {
+			if err := checkParentCategory(ctx, q, *before.ParentID); err != nil {
+				if errors.Is(err, errParentCategoryNotFound) {
+					return errParentCategoryDeleted
+				}
+				return err
+			}
+		}
+		dbCategory, err = q.RestoreCategory(ctx, categoryID)
+		if err != nil {
+			return err
+		}
+		return h.record(ctx, q, audit.ActionRestore, audit.EntityCategory, categoryID, before, dbCategory)
+	})
+	if err != nil {
+		if errors.Is(err, errParentCategoryDeleted) {
+			response.RespondWithError(ctx, w, http.StatusConflict, "The parent category is deleted, restore it first.")
+			return
+		}
+		response.HandleDBError(ctx, w, err, "Deleted category not found.")
+		return
+	}
+
+	response.RespondWithJSON(ctx, w, http.StatusOK, mapping.Category(dbCategory))
+}
@@ -7,15 +7,20 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
+	v1 "th-application-technical-assignment/pkg/api/cms/v1"
+	"th-application-technical-assignment/pkg/audit"
 	"th-application-technical-assignment/pkg/database"
+	"th-application-technical-assignment/pkg/tasks"
 	"th-application-technical-assignment/sqlc"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -24,9 +29,17 @@ import (
 func TestHandler_postCategory(t *testing.T) {
 	t.Parallel()
 
+	slugTaken := &pgconn.PgError{Code: "23505", Detail: "Key (slug)=(technology) already exists."}
+	parentID := uuid.New()
+
 	tests := []struct {
 		name           string
 		requestBody    map[string]any
+		parentID       *uuid.UUID
+		parentError    error
+		autoSuffix     string
+		takenSlugs     []string
+		expectedSlug   string
 		mockCategory   sqlc.Category
 		dbError        error
 		expectedStatus int
@@ -37,8 +50,10 @@ func TestHandler_postCategory(t *testing.T) {
 			requestBody: map[string]any{
 				"name": "Technology",
 			},
+			expectedSlug: "technology",
 			mockCategory: sqlc.Category{
 				ID:        uuid.New(),
+				Name:      "Technology",
 				Slug:      "technology",
 				CreatedAt: time.Now(),
 				UpdatedAt: time.Now(),
@@ -51,15 +66,113 @@ func TestHandler_postCategory(t *testing.T) {
 			requestBody: map[string]any{
 				"name": "Health & Wellness!",
 			},
+			expectedSlug: "health-wellness",
+			mockCategory: sqlc.Category{
+				ID:        uuid.New(),
+				Name:      "Health & Wellness!",
+				Slug:      "health-wellness",
+				CreatedAt: time.Now(),
+				UpdatedAt: time.Now(),
+			},
+			expectedStatus: http.StatusCreated,
+			expectError:    false,
+		},
+		{
+			name: "subcategory",
+			requestBody: map[string]any{
+				"name":      "Astronomy",
+				"parent_id": parentID.String(),
+			},
+			parentID:     &parentID,
+			expectedSlug: "astronomy",
+			mockCategory: sqlc.Category{
+				ID:        uuid.New(),
+				Name:      "Astronomy",
+				Slug:      "astronomy",
+				ParentID:  &parentID,
+				CreatedAt: time.Now(),
+				UpdatedAt: time.Now(),
+			},
+			expectedStatus: http.StatusCreated,
+			expectError:    false,
+		},
+		{
+			name: "parent category not found",
+			requestBody: map[string]any{
+				"name":      "Astronomy",
+				"parent_id": parentID.String(),
+			},
+			parentID:       &parentID,
+			parentError:    sql.ErrNoRows,
+			expectedStatus: http.StatusBadRequest,
+			expectError:    true,
+		},
+		{
+			name: "invalid parent category ID",
+			requestBody: map[string]any{
+				"name":      "Astronomy",
+				"parent_id": "invalid-uuid",
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectError:    true,
+		},
+		{
+			name: "auto suffix on taken slug",
+			requestBody: map[string]any{
+				"name": "Technology",
+			},
+			autoSuffix:   "true",
+			takenSlugs:   []string{"technology", "technology-2"},
+			expectedSlug: "technology-3",
 			mockCategory: sqlc.Category{
 				ID:        uuid.New(),
-				Slug:      "health--wellness",
+				Name:      "Technology",
+				Slug:      "technology-3",
 				CreatedAt: time.Now(),
 				UpdatedAt: time.Now(),
 			},
 			expectedStatus: http.StatusCreated,
 			expectError:    false,
 		},
+		{
+			name: "slug taken",
+			requestBody: map[string]any{
+				"name": "Technology",
+			},
+			expectedSlug:   "technology",
+			dbError:        slugTaken,
+			expectedStatus: http.StatusConflict,
+			expectError:    true,
+		},
+		{
+			name: "slug taken with auto suffix retries give up",
+			requestBody: map[string]any{
+				"name": "Technology",
+			},
+			autoSuffix:     "true",
+			takenSlugs:     []string{},
+			expectedSlug:   "technology",
+			dbError:        slugTaken,
+			expectedStatus: http.StatusConflict,
+			expectError:    true,
+		},
+		{
+			name: "invalid auto suffix",
+			requestBody: map[string]any{
+				"name": "Technology",
+			},
+			autoSuffix:     "maybe",
+			expectedStatus: http.StatusBadRequest,
+			expectError:    true,
+		},
+		{
+			name: "name without letters or digits",
+			requestBody: map[string]any{
+				"name": "!!!",
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectError:    true,
+		},
 		{
 			name:           "validation error - missing name",
 			requestBody:    map[string]any{},
@@ -87,6 +200,7 @@ func TestHandler_postCategory(t *testing.T) {
 			requestBody: map[string]any{
 				"name": "Technology",
 			},
+			expectedSlug:   "technology",
 			dbError:        assert.AnError,
 			expectedStatus: http.StatusInternalServerError,
 			expectError:    true,
@@ -104,23 +218,39 @@ func TestHandler_postCategory(t *testing.T) {
 				v: validator,
 			}
 
-			if !tt.expectError || tt.dbError != nil {
+			if tt.parentID != nil {
+				mockQueries.On("GetCategory", mock.Anything, *tt.parentID).
+					Return(sqlc.Category{ID: *tt.parentID, Slug: "science"}, tt.parentError)
+			}
+			if tt.expectedSlug != "" {
+				params := sqlc.CreateCategoryParams{Name: tt.requestBody["name"].(string), Slug: tt.expectedSlug, ParentID: tt.parentID}
+				attempts := 1
+				if tt.autoSuffix == "true" && tt.dbError != nil {
+					attempts = maxSlugAttempts
+				}
+				if tt.takenSlugs != nil {
+					mockQueries.On("ListCategorySlugs", mock.Anything, "technology").
+						Return(tt.takenSlugs, nil).Times(attempts)
+				}
+
 				if tt.dbError != nil {
-					mockQueries.On("CreateCategory", mock.Anything, mock.AnythingOfType("string")).
-						Return(sqlc.Category{}, tt.dbError)
+					mockQueries.On("CreateCategory", mock.Anything, params).
+						Return(sqlc.Category{}, tt.dbError).Times(attempts)
 				} else {
-					expectedSlug := "technology"
-					if tt.requestBody["name"] == "Health & Wellness!" {
-						expectedSlug = "health--wellness"
-					}
-
-					mockQueries.On("CreateCategory", mock.Anything, expectedSlug).
+					mockQueries.On("CreateCategory", mock.Anything, params).
 						Return(tt.mockCategory, nil)
+					mockQueries.On("CreateAuditEvent", mock.Anything, mock.MatchedBy(func(params sqlc.CreateAuditEventParams) bool {
+						return params.Action == audit.ActionCreate && params.EntityType == audit.EntityCategory && params.EntityID == tt.mockCategory.ID
+					})).Return(nil)
 				}
 			}
 
+			target := "/categories"
+			if tt.autoSuffix != "" {
+				target += "?auto_suffix=" + tt.autoSuffix
+			}
 			requestJSON, _ := json.Marshal(tt.requestBody)
-			req := httptest.NewRequest(http.MethodPost, "/categories", bytes.NewBuffer(requestJSON))
+			req := httptest.NewRequest(http.MethodPost, target, bytes.NewBuffer(requestJSON))
 			req.Header.Set("Content-Type", "application/json")
 			recorder := httptest.NewRecorder()
 
@@ -136,6 +266,16 @@ func TestHandler_postCategory(t *testing.T) {
 				assert.Contains(t, response, "id")
 				assert.Contains(t, response, "slug")
 				assert.Equal(t, tt.mockCategory.Slug, response["slug"])
+				assert.Equal(t, tt.mockCategory.Name, response["name"])
+				if tt.parentID != nil {
+					assert.Equal(t, tt.parentID.String(), response["parent_id"])
+				} else {
+					assert.NotContains(t, response, "parent_id")
+				}
+			}
+
+			if tt.expectedStatus == http.StatusConflict {
+				assert.Contains(t, recorder.Body.String(), `A record with slug \"technology\" already exists.`)
 			}
 
 			mockQueries.AssertExpectations(t)
@@ -143,6 +283,109 @@ func TestHandler_postCategory(t *testing.T) {
 	}
 }
 
+func TestHandler_putCategory(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		categoryName   string
+		currentSlug    string
+		autoSuffix     bool
+		takenSlugs     []string
+		expectedSlug   string
+		expectedStatus int
+	}{
+		{
+			name:           "rename",
+			categoryName:   "Science",
+			currentSlug:    "technology",
+			expectedSlug:   "science",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "auto suffix on taken slug",
+			categoryName:   "Science",
+			currentSlug:    "technology",
+			autoSuffix:     true,
+			takenSlugs:     []string{"science"},
+			expectedSlug:   "science-2",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "auto suffix keeps the current suffixed slug",
+			categoryName:   "Science",
+			currentSlug:    "science-2",
+			autoSuffix:     true,
+			takenSlugs:     []string{"science", "science-2"},
+			expectedSlug:   "science-2",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "same slug is not looked up",
+			categoryName:   "Science",
+			currentSlug:    "science",
+			autoSuffix:     true,
+			expectedSlug:   "science",
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockQueries := new(database.MockQuerier)
+			handler := &Handler{
+				s: &database.Store{Queries: mockQueries},
+				v: validator.New(),
+			}
+
+			categoryID := uuid.New()
+			before := sqlc.Category{ID: categoryID, Name: "Before", Slug: tt.currentSlug}
+			after := sqlc.Category{ID: categoryID, Name: tt.categoryName, Slug: tt.expectedSlug}
+
+			mockQueries.On("GetCategory", mock.Anything, categoryID).Return(before, nil)
+			if tt.takenSlugs != nil {
+				mockQueries.On("ListCategorySlugs", mock.Anything, "science").Return(tt.takenSlugs, nil)
+			}
+			mockQueries.On("UpdateCategory", mock.Anything, sqlc.UpdateCategoryParams{
+				ID:   categoryID,
+				Name: tt.categoryName,
+				Slug: tt.expectedSlug,
+			}).Return(after, nil)
+			mockQueries.On("CreateAuditEvent", mock.Anything, mock.MatchedBy(func(params sqlc.CreateAuditEventParams) bool {
+				return params.Action == audit.ActionUpdate && params.EntityType == audit.EntityCategory && params.EntityID == categoryID
+			})).Return(nil)
+
+			target := "/categories/" + categoryID.String()
+			if tt.autoSuffix {
+				target += "?auto_suffix=true"
+			}
+			requestJSON, _ := json.Marshal(map[string]any{"name": tt.categoryName})
+			req := httptest.NewRequest(http.MethodPut, target, bytes.NewBuffer(requestJSON))
+			req.Header.Set("Content-Type", "application/json")
+
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", categoryID.String())
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+			recorder := httptest.NewRecorder()
+
+			handler.putCategory(recorder, req)
+
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+
+			var res v1.CategoryResponse
+			err := json.Unmarshal(recorder.Body.Bytes(), &res)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedSlug, res.Slug)
+			assert.Equal(t, tt.categoryName, res.Name)
+
+			mockQueries.AssertExpectations(t)
+		})
+	}
+}
+
 func TestHandler_getCategory(t *testing.T) {
 	t.Parallel()
 
@@ -232,9 +475,15 @@ func TestHandler_getCategory(t *testing.T) {
 func TestHandler_deleteCategory(t *testing.T) {
 	t.Parallel()
 
+	targetID := uuid.New()
+
 	tests := []struct {
 		name           string
 		categoryID     string
+		reassignTo     string
+		children       int64
+		dependents     int64
+		targetError    error
 		dbError        error
 		expectedStatus int
 		expectError    bool
@@ -258,6 +507,43 @@ func TestHandler_deleteCategory(t *testing.T) {
 			expectedStatus: http.StatusInternalServerError,
 			expectError:    true,
 		},
+		{
+			name:           "category used by series",
+			categoryID:     uuid.New().String(),
+			dependents:     2,
+			expectedStatus: http.StatusConflict,
+			expectError:    true,
+		},
+		{
+			name:           "category with subcategories",
+			categoryID:     uuid.New().String(),
+			children:       1,
+			expectedStatus: http.StatusConflict,
+			expectError:    true,
+		},
+		{
+			name:           "series reassigned to another category",
+			categoryID:     uuid.New().String(),
+			reassignTo:     targetID.String(),
+			dependents:     2,
+			expectedStatus: http.StatusNoContent,
+			expectError:    false,
+		},
+		{
+			name:           "reassign target not found",
+			categoryID:     uuid.New().String(),
+			reassignTo:     targetID.String(),
+			targetError:    sql.ErrNoRows,
+			expectedStatus: http.StatusBadRequest,
+			expectError:    true,
+		},
+		{
+			name:           "invalid reassign target",
+			categoryID:     uuid.New().String(),
+			reassignTo:     "invalid-uuid",
+			expectedStatus: http.StatusBadRequest,
+			expectError:    true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -271,19 +557,74 @@ func TestHandler_deleteCategory(t *testing.T) {
 				v: validator,
 			}
 
-			if tt.categoryID != "invalid-uuid" {
+			if tt.categoryID != "invalid-uuid" && tt.reassignTo != "invalid-uuid" {
 				categoryUUID, _ := uuid.Parse(tt.categoryID)
+				series := make([]sqlc.Series, tt.dependents)
+				for i := range series {
+					series[i] = sqlc.Series{ID: uuid.New(), Title: "Series", CategoryID: categoryUUID}
+				}
 
-				if tt.dbError != nil {
+				mockQueries.On("GetCategoryForUpdate", mock.Anything, categoryUUID).
+					Return(sqlc.Category{ID: categoryUUID, Slug: "technology"}, nil)
+				mockQueries.On("CountChildCategories", mock.Anything, &categoryUUID).Return(tt.children, nil)
+
+				deleted := true
+				if tt.children > 0 {
+					deleted = false
+				} else if tt.reassignTo != "" {
+					mockQueries.On("GetCategoryForShare", mock.Anything, targetID).
+						Return(sqlc.Category{ID: targetID, Slug: "science"}, tt.targetError)
+					if tt.targetError == nil {
+						moved := make([]sqlc.Series, len(series))
+						for i, s := range series {
+							moved[i] = s
+							moved[i].CategoryID = targetID
+						}
+						mockQueries.On("ReassignSeriesCategory", mock.Anything, sqlc.ReassignSeriesCategoryParams{
+							ToCategoryID:   targetID,
+							FromCategoryID: categoryUUID,
+						}).Return(moved, nil)
+						mockQueries.On("CreateAuditEvent", mock.Anything, mock.MatchedBy(func(params sqlc.CreateAuditEventParams) bool {
+							return params.Action == audit.ActionUpdate && params.EntityType == audit.EntitySeries
+						})).Return(nil).Times(len(moved))
+						mockQueries.On("CreateSeriesRevision", mock.Anything, mock.Anything).
+							Return(sqlc.ContentRevision{Revision: 2}, nil).Times(len(moved))
+						mockQueries.On("ListCategoryPath", mock.Anything, mock.Anything).Return([]uuid.UUID{}, nil)
+						mockQueries.On("ListSeriesTranslations", mock.Anything, mock.Anything).Return([]sqlc.SeriesTranslation{}, nil)
+						mockQueries.On("CreateOutboxEvent", mock.Anything, mock.MatchedBy(func(params sqlc.CreateOutboxEventParams) bool {
+							return params.TaskType == tasks.TypeIndexSeries
+						})).Return(nil).Times(len(moved))
+					} else {
+						deleted = false
+					}
+				} else {
+					mockQueries.On("CountSeriesByCategory", mock.Anything, categoryUUID).Return(tt.dependents, nil)
+					if tt.dependents > 0 {
+						mockQueries.On("ListSeriesByCategory", mock.Anything, sqlc.ListSeriesByCategoryParams{
+							CategoryID: categoryUUID,
+							Limit:      maxDependentSeries,
+						}).Return(series, nil)
+						deleted = false
+					}
+				}
+
+				if deleted && tt.dbError != nil {
 					mockQueries.On("DeleteCategory", mock.Anything, categoryUUID).
 						Return(tt.dbError)
-				} else {
+				} else if deleted {
 					mockQueries.On("DeleteCategory", mock.Anything, categoryUUID).
 						Return(nil)
+					mockQueries.On("CreateAuditEvent", mock.Anything, mock.MatchedBy(func(params sqlc.CreateAuditEventParams) bool {
+						return params.Action == audit.ActionDelete && params.EntityType == audit.EntityCategory && params.EntityID == categoryUUID
+					})).Return(nil)
 				}
 			}
 
-			req := httptest.NewRequest(http.MethodDelete, "/categories/"+tt.categoryID, nil)
+			target := "/categories/" + tt.categoryID
+			if tt.reassignTo != "" {
+				target += "?reassign_to=" + tt.reassignTo
+			}
+			req := httptest.NewRequest(http.MethodDelete, target, nil)
 
 			rctx := chi.NewRouteContext()
 			rctx.URLParams.Add("id", tt.categoryID)
@@ -299,7 +640,338 @@ func TestHandler_deleteCategory(t *testing.T) {
 				assert.Empty(t, recorder.Body.String())
 			}
 
+			if tt.expectedStatus == http.StatusConflict {
+				var res v1.CategoryInUseResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				assert.Equal(t, tt.dependents, res.SeriesCount)
+				assert.Len(t, res.Series, int(tt.dependents))
+			}
+
+			mockQueries.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_deleteCategory_reassignToItself(t *testing.T) {
+	t.Parallel()
+
+	mockQueries := new(database.MockQuerier)
+	handler := &Handler{
+		s: &database.Store{Queries: mockQueries},
+		v: validator.New(),
+	}
+
+	categoryID := uuid.New().String()
+	req := httptest.NewRequest(http.MethodDelete, "/categories/"+categoryID+"?reassign_to="+categoryID, nil)
+
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", categoryID)
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+	recorder := httptest.NewRecorder()
+
+	handler.deleteCategory(recorder, req)
+
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	mockQueries.AssertExpectations(t)
+}
+
+func TestHandler_restoreCategory(t *testing.T) {
+	t.Parallel()
+
+	deletedAt := time.Now().Add(-time.Hour)
+	parentID := uuid.New()
+
+	tests := []struct {
+		name           string
+		categoryID     string
+		parentID       *uuid.UUID
+		getError       error
+		parentError    error
+		restoreError   error
+		expectedStatus int
+	}{
+		{
+			name:           "successful category restore",
+			categoryID:     uuid.New().String(),
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "subcategory of a live category",
+			categoryID:     uuid.New().String(),
+			parentID:       &parentID,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "parent category deleted",
+			categoryID:     uuid.New().String(),
+			parentID:       &parentID,
+			parentError:    sql.ErrNoRows,
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "invalid category ID",
+			categoryID:     "invalid-uuid",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "category not deleted",
+			categoryID:     uuid.New().String(),
+			getError:       sql.ErrNoRows,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "database error",
+			categoryID:     uuid.New().String(),
+			restoreError:   assert.AnError,
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockQueries := new(database.MockQuerier)
+			handler := &Handler{
+				s: &database.Store{Queries: mockQueries},
+				v: validator.New(),
+			}
+
+			if tt.categoryID != "invalid-uuid" {
+				categoryUUID, _ := uuid.Parse(tt.categoryID)
+
+				mockQueries.On("GetDeletedCategory", mock.Anything, categoryUUID).
+					Return(sqlc.Category{ID: categoryUUID, Slug: "technology", DeletedAt: &deletedAt, ParentID: tt.parentID}, tt.getError)
+
+				if tt.getError == nil && tt.parentID != nil {
+					mockQueries.On("GetCategory", mock.Anything, *tt.parentID).
+						Return(sqlc.Category{ID: *tt.parentID, Slug: "science"}, tt.parentError)
+				}
+				if tt.getError == nil && tt.parentError == nil {
+					mockQueries.On("RestoreCategory", mock.Anything, categoryUUID).
+						Return(sqlc.Category{ID: categoryUUID, Slug: "technology", ParentID: tt.parentID}, tt.restoreError)
+				}
+				if tt.getError == nil && tt.parentError == nil && tt.restoreError == nil {
+					mockQueries.On("CreateAuditEvent", mock.Anything, mock.MatchedBy(func(params sqlc.CreateAuditEventParams) bool {
+						return params.Action == audit.ActionRestore && params.EntityType == audit.EntityCategory &&
+							params.EntityID == categoryUUID && bytes.Contains(params.Changes, []byte("deleted_at"))
+					})).Return(nil)
+				}
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/categories/"+tt.categoryID+"/restore", nil)
+
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", tt.categoryID)
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+			recorder := httptest.NewRecorder()
+
+			handler.restoreCategory(recorder, req)
+
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+
+			if tt.expectedStatus == http.StatusOK {
+				var res v1.CategoryResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				assert.Equal(t, tt.categoryID, res.ID)
+				assert.Equal(t, "technology", res.Slug)
+				if tt.parentID != nil {
+					require.NotNil(t, res.ParentID)
+					assert.Equal(t, tt.parentID.String(), *res.ParentID)
+				} else {
+					assert.Nil(t, res.ParentID)
+				}
+			}
+
 			mockQueries.AssertExpectations(t)
 		})
 	}
 }
+
+func TestHandler_moveCategory(t *testing.T) {
+	t.Parallel()
+
+	categoryID := uuid.New()
+	childID := uuid.New()
+	parentID := uuid.New()
+
+	tests := []struct {
+		name           string
+		categoryID     string
+		requestBody    string
+		parentID       *uuid.UUID
+		getError       error
+		parentError    error
+		expectedStatus int
+	}{
+		{
+			name:           "move below another category",
+			categoryID:     categoryID.String(),
+			requestBody:    `{"parent_id":"` + parentID.String() + `"}`,
+			parentID:       &parentID,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "make a root category",
+			categoryID:     categoryID.String(),
+			requestBody:    `{"parent_id":null}`,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "move below itself",
+			categoryID:     categoryID.String(),
+			requestBody:    `{"parent_id":"` + categoryID.String() + `"}`,
+			parentID:       &categoryID,
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "move below a subcategory",
+			categoryID:     categoryID.String(),
+			requestBody:    `{"parent_id":"` + childID.String() + `"}`,
+			parentID:       &childID,
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "parent category not found",
+			categoryID:     categoryID.String(),
+			requestBody:    `{"parent_id":"` + parentID.String() + `"}`,
+			parentID:       &parentID,
+			parentError:    sql.ErrNoRows,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "category not found",
+			categoryID:     categoryID.String(),
+			requestBody:    `{"parent_id":null}`,
+			getError:       sql.ErrNoRows,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "invalid category ID",
+			categoryID:     "invalid-uuid",
+			requestBody:    `{"parent_id":null}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid parent category ID",
+			categoryID:     categoryID.String(),
+			requestBody:    `{"parent_id":"invalid-uuid"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockQueries := new(database.MockQuerier)
+			handler := &Handler{
+				s: &database.Store{Queries: mockQueries},
+				v: validator.New(),
+			}
+
+			if tt.categoryID != "invalid-uuid" && !strings.Contains(tt.requestBody, "invalid-uuid") {
+				mockQueries.On("GetCategory", mock.Anything, categoryID).
+					Return(sqlc.Category{ID: categoryID, Slug: "space"}, tt.getError)
+				if tt.getError == nil {
+					mockQueries.On("ListCategoryDescendantIDs", mock.Anything, categoryID).
+						Return([]uuid.UUID{categoryID, childID}, nil)
+				}
+				// parents inside the subtree are rejected before they are looked up
+				if tt.parentID != nil && *tt.parentID == parentID {
+					mockQueries.On("GetCategory", mock.Anything, parentID).
+						Return(sqlc.Category{ID: parentID, Slug: "science"}, tt.parentError)
+				}
+			}
+			if tt.expectedStatus == http.StatusOK {
+				series := sqlc.Series{ID: uuid.New(), CategoryID: childID}
+				mockQueries.On("MoveCategory", mock.Anything, sqlc.MoveCategoryParams{ID: categoryID, ParentID: tt.parentID}).
+					Return(sqlc.Category{ID: categoryID, Slug: "space", ParentID: tt.parentID}, nil)
+				mockQueries.On("CreateAuditEvent", mock.Anything, mock.MatchedBy(func(params sqlc.CreateAuditEventParams) bool {
+					return params.Action == audit.ActionUpdate && params.EntityType == audit.EntityCategory && params.EntityID == categoryID
+				})).Return(nil)
+				mockQueries.On("TouchSeriesByCategories", mock.Anything, []uuid.UUID{categoryID, childID}).
+					Return([]sqlc.Series{series}, nil)
+				mockQueries.On("ListCategoryPath", mock.Anything, childID).
+					Return([]uuid.UUID{categoryID, childID}, nil)
+				mockQueries.On("ListSeriesTranslations", mock.Anything, mock.Anything).Return([]sqlc.SeriesTranslation{}, nil)
+				mockQueries.On("CreateOutboxEvent", mock.Anything, mock.MatchedBy(func(params sqlc.CreateOutboxEventParams) bool {
+					return params.TaskType == tasks.TypeIndexSeries && bytes.Contains(params.Payload, []byte(childID.String()))
+				})).Return(nil)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/categories/"+tt.categoryID+"/move", bytes.NewBufferString(tt.requestBody))
+			req.Header.Set("Content-Type", "application/json")
+
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", tt.categoryID)
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+			recorder := httptest.NewRecorder()
+
+			handler.moveCategory(recorder, req)
+
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+
+			if tt.expectedStatus == http.StatusOK {
+				var res v1.CategoryResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				if tt.parentID != nil {
+					require.NotNil(t, res.ParentID)
+					assert.Equal(t, tt.parentID.String(), *res.ParentID)
+				} else {
+					assert.Nil(t, res.ParentID)
+				}
+			}
+
+			mockQueries.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_getCategoryTree(t *testing.T) {
+	t.Parallel()
+
+	science := sqlc.Category{ID: uuid.New(), Name: "Science", Slug: "science"}
+	space := sqlc.Category{ID: uuid.New(), Name: "Space", Slug: "space", ParentID: &science.ID}
+	astronomy := sqlc.Category{ID: uuid.New(), Name: "Astronomy", Slug: "astronomy", ParentID: &space.ID}
+	biology := sqlc.Category{ID: uuid.New(), Name: "Biology", Slug: "biology", ParentID: &science.ID}
+	arts := sqlc.Category{ID: uuid.New(), Name: "Arts", Slug: "arts"}
+
+	mockQueries := new(database.MockQuerier)
+	mockQueries.On("ListCategories", mock.Anything).
+		Return([]sqlc.Category{astronomy, science, space, arts, biology}, nil)
+
+	handler := &Handler{
+		s: &database.Store{Queries: mockQueries},
+		v: validator.New(),
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/categories/tree", nil)
+	recorder := httptest.NewRecorder()
+
+	handler.getCategoryTree(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	var tree []v1.CategoryTreeNode
+	err := json.Unmarshal(recorder.Body.Bytes(), &tree)
+	require.NoError(t, err)
+
+	require.Len(t, tree, 2)
+	assert.Equal(t, "arts", tree[0].Slug)
+	assert.Empty(t, tree[0].Children)
+	assert.Equal(t, "science", tree[1].Slug)
+	require.Len(t, tree[1].Children, 2)
+	assert.Equal(t, "biology", tree[1].Children[0].Slug)
+	assert.Equal(t, "space", tree[1].Children[1].Slug)
+	require.Len(t, tree[1].Children[1].Children, 1)
+	assert.Equal(t, "astronomy", tree[1].Children[1].Children[0].Slug)
+
+	mockQueries.AssertExpectations(t)
+}
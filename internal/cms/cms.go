@@ -5,18 +5,16 @@ import (
 	"log/slog"
 	"net/http"
 	"os"
+	"th-application-technical-assignment/pkg/auth"
 	"th-application-technical-assignment/pkg/database"
 	"th-application-technical-assignment/pkg/storage"
 	"th-application-technical-assignment/pkg/tasks"
 	"th-application-technical-assignment/pkg/telemetry"
-	"time"
 
 	"github.com/caarlos0/env/v11"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
-	"github.com/go-chi/jwtauth/v5"
 	"github.com/go-playground/validator/v10"
-	"github.com/lestrrat-go/jwx/v2/jwt"
 	"github.com/pkg/errors"
 	"github.com/riandyrn/otelchi"
 	slogchi "github.com/samber/slog-chi"
@@ -29,7 +27,7 @@ type Server struct {
 	Store       *database.Store
 	Queue       tasks.TaskQueue
 	Storage     storage.ObjectStorage
-	Auth        *jwtauth.JWTAuth
+	Auth        *auth.Verifier
 	Validator   *validator.Validate
 	Router      chi.Router
 	Middlewares chi.Middlewares
@@ -65,7 +63,11 @@ func NewServer(ctx context.Context) (*Server, error) {
 		return nil, errors.Wrap(err, "failed to create queue client")
 	}
 
-	tokenAuth := jwtauth.New(cfg.Auth.SigningAlg, []byte(cfg.Auth.SigningKey), cfg.Auth.VerificationKey, jwt.WithAcceptableSkew(30*time.Second))
+	verifier, err := auth.NewVerifier(ctx, &cfg.Auth)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create token verifier")
+	}
+	verifier.Start(ctx)
 
 	tp, err := telemetry.InitTracer(ctx, &cfg.Telemetry)
 	if err != nil {
@@ -81,8 +83,6 @@ func NewServer(ctx context.Context) (*Server, error) {
 		middleware.Recoverer,
 		middleware.AllowContentType("application/json"),
 		middleware.CleanPath,
-		// jwtauth.Verifier(tokenAuth),
-		// jwtauth.Authenticator(tokenAuth),
 	)
 
 	return &Server{
@@ -90,7 +90,7 @@ func NewServer(ctx context.Context) (*Server, error) {
 		Store:       s,
 		Queue:       tasksClient,
 		Storage:     minioClient,
-		Auth:        tokenAuth,
+		Auth:        verifier,
 		Validator:   validator.New(),
 		Router:      r,
 		Middlewares: mw,
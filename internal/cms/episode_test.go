@@ -3,19 +3,24 @@ package cms
 import (
 	"bytes"
 	"context"
+	"database/sql"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 	"time"
 
+	v1 "th-application-technical-assignment/pkg/api/cms/v1"
+	"th-application-technical-assignment/pkg/audit"
 	"th-application-technical-assignment/pkg/database"
+	"th-application-technical-assignment/pkg/publishing"
 	"th-application-technical-assignment/pkg/tasks"
 	"th-application-technical-assignment/sqlc"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -24,13 +29,18 @@ import (
 func TestHandler_postSeriesEpisode(t *testing.T) {
 	t.Parallel()
 
+	slugTaken := &pgconn.PgError{Code: "23505", Detail: "Key (series_id, slug)=(1, test-episode) already exists."}
+
 	tests := []struct {
 		name           string
 		requestBody    map[string]any
 		mockEpisode    sqlc.Episode
 		mockAssets     []sqlc.EpisodeAsset
+		takenSlugs     []string
+		expectedSlug   string
+		slugConflict   bool
 		dbError        error
-		queueError     error
+		outboxError    error
 		expectedStatus int
 		expectError    bool
 	}{
@@ -50,10 +60,12 @@ func TestHandler_postSeriesEpisode(t *testing.T) {
 				Description:     stringPtr("A test episode description"),
 				DurationSeconds: int32Ptr(3600),
 				PublishDate:     timePtr(time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)),
+				Slug:            "test-episode",
 				CreatedAt:       time.Now(),
 				UpdatedAt:       time.Now(),
 			},
 			mockAssets:     []sqlc.EpisodeAsset{},
+			expectedSlug:   "test-episode",
 			expectedStatus: http.StatusCreated,
 			expectError:    false,
 		},
@@ -67,10 +79,52 @@ func TestHandler_postSeriesEpisode(t *testing.T) {
 				ID:        uuid.New(),
 				SeriesID:  uuid.New(),
 				Title:     "Minimal Episode",
+				Slug:      "minimal-episode",
+				CreatedAt: time.Now(),
+				UpdatedAt: time.Now(),
+			},
+			mockAssets:     []sqlc.EpisodeAsset{},
+			expectedSlug:   "minimal-episode",
+			expectedStatus: http.StatusCreated,
+			expectError:    false,
+		},
+		{
+			name: "slug taken in the series gets a numbered suffix",
+			requestBody: map[string]any{
+				"series_id": uuid.New().String(),
+				"title":     "Test Episode",
+			},
+			mockEpisode: sqlc.Episode{
+				ID:        uuid.New(),
+				SeriesID:  uuid.New(),
+				Title:     "Test Episode",
+				Slug:      "test-episode-2",
 				CreatedAt: time.Now(),
 				UpdatedAt: time.Now(),
 			},
 			mockAssets:     []sqlc.EpisodeAsset{},
+			takenSlugs:     []string{"test-episode"},
+			expectedSlug:   "test-episode-2",
+			expectedStatus: http.StatusCreated,
+			expectError:    false,
+		},
+		{
+			name: "slug taken concurrently is retried",
+			requestBody: map[string]any{
+				"series_id": uuid.New().String(),
+				"title":     "Test Episode",
+			},
+			mockEpisode: sqlc.Episode{
+				ID:        uuid.New(),
+				SeriesID:  uuid.New(),
+				Title:     "Test Episode",
+				Slug:      "test-episode",
+				CreatedAt: time.Now(),
+				UpdatedAt: time.Now(),
+			},
+			mockAssets:     []sqlc.EpisodeAsset{},
+			expectedSlug:   "test-episode",
+			slugConflict:   true,
 			expectedStatus: http.StatusCreated,
 			expectError:    false,
 		},
@@ -97,12 +151,13 @@ func TestHandler_postSeriesEpisode(t *testing.T) {
 				"series_id": uuid.New().String(),
 				"title":     "Test Episode",
 			},
+			expectedSlug:   "test-episode",
 			dbError:        assert.AnError,
 			expectedStatus: http.StatusInternalServerError,
 			expectError:    true,
 		},
 		{
-			name: "queue error - should still succeed",
+			name: "outbox error - episode is rolled back",
 			requestBody: map[string]any{
 				"series_id": uuid.New().String(),
 				"title":     "Test Episode",
@@ -115,9 +170,10 @@ func TestHandler_postSeriesEpisode(t *testing.T) {
 				UpdatedAt: time.Now(),
 			},
 			mockAssets:     []sqlc.EpisodeAsset{},
-			queueError:     assert.AnError,
-			expectedStatus: http.StatusCreated,
-			expectError:    false,
+			expectedSlug:   "test-episode",
+			outboxError:    assert.AnError,
+			expectedStatus: http.StatusInternalServerError,
+			expectError:    true,
 		},
 	}
 
@@ -134,25 +190,38 @@ func TestHandler_postSeriesEpisode(t *testing.T) {
 				q: mockQueue,
 			}
 
-			if !tt.expectError || tt.dbError != nil {
+			if !tt.expectError || tt.dbError != nil || tt.outboxError != nil {
+				taken := tt.takenSlugs
+				if taken == nil {
+					taken = []string{}
+				}
+				mockQueries.On("ListEpisodeSlugs", mock.Anything, mock.Anything).Return(taken, nil)
+				if tt.slugConflict {
+					mockQueries.On("CreateEpisode", mock.Anything, mock.Anything).Return(sqlc.Episode{}, slugTaken).Once()
+				}
 				if tt.dbError != nil {
 					mockQueries.On("CreateEpisode", mock.Anything, mock.AnythingOfType("sqlc.CreateEpisodeParams")).
 						Return(sqlc.Episode{}, tt.dbError)
 				} else {
 					mockQueries.On("CreateEpisode", mock.Anything, mock.MatchedBy(func(params sqlc.CreateEpisodeParams) bool {
-						return params.Title == tt.requestBody["title"].(string)
+						return params.Title == tt.requestBody["title"].(string) && params.Slug == tt.expectedSlug &&
+							params.Status == publishing.StatusDraft
 					})).Return(tt.mockEpisode, nil)
+					mockQueries.On("CreateAuditEvent", mock.Anything, mock.MatchedBy(func(params sqlc.CreateAuditEventParams) bool {
+						return params.Action == audit.ActionCreate && params.EntityType == audit.EntityEpisode && params.EntityID == tt.mockEpisode.ID
+					})).Return(nil)
+					mockQueries.On("CreateEpisodeRevision", mock.Anything, mock.MatchedBy(func(params sqlc.CreateEpisodeRevisionParams) bool {
+						return params.ID == tt.mockEpisode.ID && params.RollbackOf == nil
+					})).Return(sqlc.ContentRevision{Revision: 2}, nil)
 
 					mockQueries.On("ListAssetsByEpisode", mock.Anything, tt.mockEpisode.ID).
 						Return(tt.mockAssets, nil)
 
-					if tt.queueError != nil {
-						mockQueue.On("EnqueueIndexEpisode", mock.Anything, tt.mockEpisode, tt.mockAssets).
-							Return(tt.queueError)
-					} else {
-						mockQueue.On("EnqueueIndexEpisode", mock.Anything, tt.mockEpisode, tt.mockAssets).
-							Return(nil)
-					}
+					mockQueries.On("ListSeriesLanguages", mock.Anything, mock.Anything).Return([]sqlc.ListSeriesLanguagesRow{}, nil)
+					mockQueries.On("ListEpisodeTranslations", mock.Anything, mock.Anything).Return([]sqlc.EpisodeTranslation{}, nil)
+					mockQueries.On("CreateOutboxEvent", mock.Anything, mock.MatchedBy(func(params sqlc.CreateOutboxEventParams) bool {
+						return params.TaskType == tasks.TypeIndexEpisode
+					})).Return(tt.outboxError)
 				}
 			}
 
@@ -178,6 +247,7 @@ func TestHandler_postSeriesEpisode(t *testing.T) {
 				assert.Contains(t, response, "assets")
 
 				assert.Equal(t, tt.mockEpisode.Title, response["title"])
+				assert.Equal(t, tt.expectedSlug, response["slug"])
 			}
 
 			mockQueries.AssertExpectations(t)
@@ -290,6 +360,115 @@ func TestHandler_getSeriesEpisode(t *testing.T) {
 	}
 }
 
+func TestHandler_getSeriesEpisodeBySlug(t *testing.T) {
+	t.Parallel()
+
+	series := sqlc.Series{ID: uuid.New(), Title: "Tech Talk", Slug: "tech-talk", PreviousSlugs: []string{"old-talk"}}
+	episode := sqlc.Episode{ID: uuid.New(), SeriesID: series.ID, Title: "Pilot", Slug: "pilot", PreviousSlugs: []string{"first"}}
+	bySlug := func(slug string) sqlc.GetEpisodeBySlugParams {
+		return sqlc.GetEpisodeBySlugParams{SeriesID: series.ID, Slug: slug}
+	}
+	byPreviousSlug := func(slug string) sqlc.GetEpisodeByPreviousSlugParams {
+		return sqlc.GetEpisodeByPreviousSlugParams{SeriesID: series.ID, Slug: slug}
+	}
+
+	tests := []struct {
+		name             string
+		seriesSlug       string
+		episodeSlug      string
+		setup            func(m *database.MockQuerier)
+		expectedStatus   int
+		expectedLocation string
+	}{
+		{
+			name:        "current slugs",
+			seriesSlug:  "tech-talk",
+			episodeSlug: "pilot",
+			setup: func(m *database.MockQuerier) {
+				m.On("GetSeriesBySlug", mock.Anything, "tech-talk").Return(series, nil)
+				m.On("GetEpisodeBySlug", mock.Anything, bySlug("pilot")).Return(episode, nil)
+				m.On("ListAssetsByEpisode", mock.Anything, episode.ID).Return([]sqlc.EpisodeAsset{}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:        "previous episode slug",
+			seriesSlug:  "tech-talk",
+			episodeSlug: "first",
+			setup: func(m *database.MockQuerier) {
+				m.On("GetSeriesBySlug", mock.Anything, "tech-talk").Return(series, nil)
+				m.On("GetEpisodeBySlug", mock.Anything, bySlug("first")).Return(sqlc.Episode{}, sql.ErrNoRows)
+				m.On("GetEpisodeByPreviousSlug", mock.Anything, byPreviousSlug("first")).Return(episode, nil)
+			},
+			expectedStatus:   http.StatusMovedPermanently,
+			expectedLocation: "/v1/series/by-slug/tech-talk/episodes/pilot",
+		},
+		{
+			name:        "previous series slug",
+			seriesSlug:  "old-talk",
+			episodeSlug: "pilot",
+			setup: func(m *database.MockQuerier) {
+				m.On("GetSeriesBySlug", mock.Anything, "old-talk").Return(sqlc.Series{}, sql.ErrNoRows)
+				m.On("GetSeriesByPreviousSlug", mock.Anything, "old-talk").Return(series, nil)
+				m.On("GetEpisodeBySlug", mock.Anything, bySlug("pilot")).Return(episode, nil)
+			},
+			expectedStatus:   http.StatusMovedPermanently,
+			expectedLocation: "/v1/series/by-slug/tech-talk/episodes/pilot",
+		},
+		{
+			name:        "unknown episode",
+			seriesSlug:  "tech-talk",
+			episodeSlug: "unknown",
+			setup: func(m *database.MockQuerier) {
+				m.On("GetSeriesBySlug", mock.Anything, "tech-talk").Return(series, nil)
+				m.On("GetEpisodeBySlug", mock.Anything, bySlug("unknown")).Return(sqlc.Episode{}, sql.ErrNoRows)
+				m.On("GetEpisodeByPreviousSlug", mock.Anything, byPreviousSlug("unknown")).Return(sqlc.Episode{}, sql.ErrNoRows)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:        "unknown series",
+			seriesSlug:  "unknown",
+			episodeSlug: "pilot",
+			setup: func(m *database.MockQuerier) {
+				m.On("GetSeriesBySlug", mock.Anything, "unknown").Return(sqlc.Series{}, sql.ErrNoRows)
+				m.On("GetSeriesByPreviousSlug", mock.Anything, "unknown").Return(sqlc.Series{}, sql.ErrNoRows)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockQueries := new(database.MockQuerier)
+			handler := &Handler{s: &database.Store{Queries: mockQueries}, v: validator.New()}
+			tt.setup(mockQueries)
+
+			req := httptest.NewRequest(http.MethodGet, "/v1/series/by-slug/"+tt.seriesSlug+"/episodes/"+tt.episodeSlug, nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("slug", tt.seriesSlug)
+			rctx.URLParams.Add("episodeSlug", tt.episodeSlug)
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			recorder := httptest.NewRecorder()
+
+			handler.getSeriesEpisodeBySlug(recorder, req)
+
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+			assert.Equal(t, tt.expectedLocation, recorder.Header().Get("Location"))
+			if tt.expectedStatus == http.StatusOK {
+				var res v1.EpisodeResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+				assert.Equal(t, episode.ID.String(), res.ID)
+				assert.Equal(t, "pilot", res.Slug)
+			}
+
+			mockQueries.AssertExpectations(t)
+		})
+	}
+}
+
 func TestHandler_deleteSeriesEpisode(t *testing.T) {
 	t.Parallel()
 
@@ -297,7 +476,7 @@ func TestHandler_deleteSeriesEpisode(t *testing.T) {
 		name           string
 		episodeID      string
 		dbError        error
-		queueError     error
+		outboxError    error
 		expectedStatus int
 		expectError    bool
 	}{
@@ -321,11 +500,11 @@ func TestHandler_deleteSeriesEpisode(t *testing.T) {
 			expectError:    true,
 		},
 		{
-			name:           "queue error - should still succeed",
+			name:           "outbox error - deletion is rolled back",
 			episodeID:      uuid.New().String(),
-			queueError:     assert.AnError,
-			expectedStatus: http.StatusNoContent,
-			expectError:    false,
+			outboxError:    assert.AnError,
+			expectedStatus: http.StatusInternalServerError,
+			expectError:    true,
 		},
 	}
 
@@ -345,20 +524,25 @@ func TestHandler_deleteSeriesEpisode(t *testing.T) {
 			if tt.episodeID != "invalid-uuid" {
 				episodeUUID, _ := uuid.Parse(tt.episodeID)
 
+				mockQueries.On("GetEpisode", mock.Anything, episodeUUID).
+					Return(sqlc.Episode{ID: episodeUUID, Title: "Test Episode"}, nil)
+
+				deletedAt := time.Now()
 				if tt.dbError != nil {
 					mockQueries.On("DeleteEpisode", mock.Anything, episodeUUID).
-						Return(tt.dbError)
+						Return((*time.Time)(nil), tt.dbError)
 				} else {
 					mockQueries.On("DeleteEpisode", mock.Anything, episodeUUID).
-						Return(nil)
-
-					if tt.queueError != nil {
-						mockQueue.On("EnqueueDeleteEpisode", mock.Anything, tt.episodeID).
-							Return(tt.queueError)
-					} else {
-						mockQueue.On("EnqueueDeleteEpisode", mock.Anything, tt.episodeID).
-							Return(nil)
-					}
+						Return(&deletedAt, nil)
+					mockQueries.On("CreateAuditEvent", mock.Anything, mock.MatchedBy(func(params sqlc.CreateAuditEventParams) bool {
+						return params.Action == audit.ActionDelete && params.EntityType == audit.EntityEpisode && params.EntityID == episodeUUID
+					})).Return(nil)
+
+					mockQueries.On("CreateOutboxEvent", mock.Anything, mock.MatchedBy(func(params sqlc.CreateOutboxEventParams) bool {
+						var payload tasks.DeleteEpisodePayload
+						return params.TaskType == tasks.TypeDeleteEpisode &&
+							json.Unmarshal(params.Payload, &payload) == nil && payload.DeletedAt.Equal(deletedAt)
+					})).Return(tt.outboxError)
 				}
 			}
 
@@ -384,6 +568,317 @@ func TestHandler_deleteSeriesEpisode(t *testing.T) {
 	}
 }
 
+func TestHandler_restoreSeriesEpisode(t *testing.T) {
+	t.Parallel()
+
+	deletedAt := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name           string
+		episodeID      string
+		getError       error
+		seriesError    error
+		outboxError    error
+		expectedStatus int
+	}{
+		{
+			name:           "successful episode restore",
+			episodeID:      uuid.New().String(),
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "invalid episode ID",
+			episodeID:      "invalid-uuid",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "episode not deleted",
+			episodeID:      uuid.New().String(),
+			getError:       sql.ErrNoRows,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "series is deleted",
+			episodeID:      uuid.New().String(),
+			seriesError:    sql.ErrNoRows,
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "outbox error - restore is rolled back",
+			episodeID:      uuid.New().String(),
+			outboxError:    assert.AnError,
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockQueries := new(database.MockQuerier)
+			handler := &Handler{
+				s: &database.Store{Queries: mockQueries},
+				v: validator.New(),
+			}
+
+			if tt.episodeID != "invalid-uuid" {
+				episodeUUID, _ := uuid.Parse(tt.episodeID)
+				seriesID := uuid.New()
+				deleted := sqlc.Episode{ID: episodeUUID, SeriesID: seriesID, Title: "Episode", DeletedAt: &deletedAt}
+				restored := sqlc.Episode{ID: episodeUUID, SeriesID: seriesID, Title: "Episode"}
+				asset := sqlc.EpisodeAsset{ID: uuid.New(), EpisodeID: episodeUUID, AssetType: "video"}
+
+				mockQueries.On("GetDeletedEpisode", mock.Anything, episodeUUID).Return(deleted, tt.getError)
+				if tt.getError == nil {
+					mockQueries.On("GetSeries", mock.Anything, seriesID).Return(sqlc.Series{ID: seriesID}, tt.seriesError)
+				}
+				if tt.getError == nil && tt.seriesError == nil {
+					mockQueries.On("RestoreEpisode", mock.Anything, episodeUUID).Return(restored, nil)
+					mockQueries.On("RestoreAssetsByEpisodes", mock.Anything, sqlc.RestoreAssetsByEpisodesParams{
+						EpisodeIds: []uuid.UUID{episodeUUID},
+						DeletedAt:  &deletedAt,
+					}).Return([]sqlc.EpisodeAsset{asset}, nil)
+					mockQueries.On("CreateAuditEvent", mock.Anything, mock.MatchedBy(func(params sqlc.CreateAuditEventParams) bool {
+						return params.Action == audit.ActionRestore && params.EntityType == audit.EntityEpisode && params.EntityID == episodeUUID
+					})).Return(nil)
+					mockQueries.On("CreateAuditEvent", mock.Anything, mock.MatchedBy(func(params sqlc.CreateAuditEventParams) bool {
+						return params.Action == audit.ActionRestore && params.EntityType == audit.EntityAsset && params.EntityID == asset.ID
+					})).Return(nil)
+					mockQueries.On("CreateEpisodeRevision", mock.Anything, mock.MatchedBy(func(params sqlc.CreateEpisodeRevisionParams) bool {
+						return params.ID == episodeUUID && params.RollbackOf == nil
+					})).Return(sqlc.ContentRevision{Revision: 2}, nil)
+					mockQueries.On("ListAssetsByEpisode", mock.Anything, episodeUUID).Return([]sqlc.EpisodeAsset{asset}, nil)
+					mockQueries.On("ListSeriesLanguages", mock.Anything, mock.Anything).Return([]sqlc.ListSeriesLanguagesRow{}, nil)
+					mockQueries.On("ListEpisodeTranslations", mock.Anything, mock.Anything).Return([]sqlc.EpisodeTranslation{}, nil)
+					mockQueries.On("CreateOutboxEvent", mock.Anything, mock.MatchedBy(func(params sqlc.CreateOutboxEventParams) bool {
+						return params.TaskType == tasks.TypeIndexEpisode
+					})).Return(tt.outboxError)
+				}
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/series/episodes/"+tt.episodeID+"/restore", nil)
+
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", tt.episodeID)
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+			recorder := httptest.NewRecorder()
+
+			handler.restoreSeriesEpisode(recorder, req)
+
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+
+			if tt.expectedStatus == http.StatusOK {
+				var res v1.EpisodeResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				assert.Equal(t, tt.episodeID, res.ID)
+				assert.Len(t, res.Assets, 1)
+			}
+
+			mockQueries.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_changeEpisodeStatus(t *testing.T) {
+	t.Parallel()
+
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name           string
+		unpublish      bool
+		episodeID      string
+		status         string
+		publishDate    *time.Time
+		getError       error
+		setError       error
+		expectedStatus int
+		expectedState  string
+	}{
+		{
+			name:           "publish a draft",
+			status:         publishing.StatusDraft,
+			expectedStatus: http.StatusOK,
+			expectedState:  publishing.StatusPublished,
+		},
+		{
+			name:           "publish a draft with a future publish date schedules it",
+			status:         publishing.StatusDraft,
+			publishDate:    &future,
+			expectedStatus: http.StatusOK,
+			expectedState:  publishing.StatusScheduled,
+		},
+		{
+			name:           "publish a scheduled episode early",
+			status:         publishing.StatusScheduled,
+			publishDate:    &past,
+			expectedStatus: http.StatusOK,
+			expectedState:  publishing.StatusPublished,
+		},
+		{
+			name:           "publish an unpublished episode",
+			status:         publishing.StatusUnpublished,
+			publishDate:    &past,
+			expectedStatus: http.StatusOK,
+			expectedState:  publishing.StatusPublished,
+		},
+		{
+			name:           "publish a published episode",
+			status:         publishing.StatusPublished,
+			publishDate:    &past,
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "publish a scheduled episode that is not due",
+			status:         publishing.StatusScheduled,
+			publishDate:    &future,
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "unpublish a published episode",
+			unpublish:      true,
+			status:         publishing.StatusPublished,
+			publishDate:    &past,
+			expectedStatus: http.StatusOK,
+			expectedState:  publishing.StatusUnpublished,
+		},
+		{
+			name:           "unpublish a scheduled episode cancels the schedule",
+			unpublish:      true,
+			status:         publishing.StatusScheduled,
+			publishDate:    &future,
+			expectedStatus: http.StatusOK,
+			expectedState:  publishing.StatusDraft,
+		},
+		{
+			name:           "unpublish a draft",
+			unpublish:      true,
+			status:         publishing.StatusDraft,
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "status changed meanwhile",
+			status:         publishing.StatusDraft,
+			setError:       sql.ErrNoRows,
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "episode not found",
+			getError:       sql.ErrNoRows,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "invalid episode ID",
+			episodeID:      "invalid-uuid",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockQueries := new(database.MockQuerier)
+			handler := &Handler{
+				s: &database.Store{Queries: mockQueries},
+				v: validator.New(),
+			}
+
+			episodeID := tt.episodeID
+			if episodeID == "" {
+				id := uuid.New()
+				episodeID = id.String()
+				before := sqlc.Episode{ID: id, SeriesID: uuid.New(), Title: "Episode", PublishDate: tt.publishDate, Status: tt.status}
+
+				mockQueries.On("GetEpisode", mock.Anything, id).Return(before, tt.getError)
+				after := before
+				after.Status = tt.expectedState
+				if after.PublishDate == nil {
+					after.PublishDate = &past
+				}
+				if tt.expectedState != "" || tt.setError != nil {
+					mockQueries.On("SetEpisodeStatus", mock.Anything, mock.MatchedBy(func(params sqlc.SetEpisodeStatusParams) bool {
+						// publishing sets a missing publish date, unpublishing keeps it
+						return params.ID == id && params.FromStatus == tt.status && (tt.setError != nil || params.Status == tt.expectedState) &&
+							(params.PublishDate != nil) == (tt.publishDate != nil || !tt.unpublish)
+					})).Return(after, tt.setError)
+				}
+				if tt.expectedState != "" {
+					action := audit.ActionPublish
+					if tt.unpublish {
+						action = audit.ActionUnpublish
+					}
+					mockQueries.On("CreateAuditEvent", mock.Anything, mock.MatchedBy(func(params sqlc.CreateAuditEventParams) bool {
+						return params.Action == action && params.EntityType == audit.EntityEpisode && params.EntityID == id
+					})).Return(nil)
+					mockQueries.On("CreateEpisodeRevision", mock.Anything, mock.MatchedBy(func(params sqlc.CreateEpisodeRevisionParams) bool {
+						return params.ID == id && params.RollbackOf == nil
+					})).Return(sqlc.ContentRevision{Revision: 2}, nil)
+					mockQueries.On("ListAssetsByEpisode", mock.Anything, id).Return([]sqlc.EpisodeAsset{}, nil)
+					mockQueries.On("ListSeriesLanguages", mock.Anything, mock.Anything).Return([]sqlc.ListSeriesLanguagesRow{}, nil)
+					mockQueries.On("ListEpisodeTranslations", mock.Anything, mock.Anything).Return([]sqlc.EpisodeTranslation{}, nil)
+					mockQueries.On("CreateOutboxEvent", mock.Anything, mock.MatchedBy(func(params sqlc.CreateOutboxEventParams) bool {
+						return params.TaskType == tasks.TypeIndexEpisode
+					})).Return(nil)
+				}
+			}
+
+			action, handle := "/publish", handler.publishSeriesEpisode
+			if tt.unpublish {
+				action, handle = "/unpublish", handler.unpublishSeriesEpisode
+			}
+			req := httptest.NewRequest(http.MethodPost, "/series/episodes/"+episodeID+action, nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", episodeID)
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			recorder := httptest.NewRecorder()
+
+			handle(recorder, req)
+
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var res v1.EpisodeResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+				assert.Equal(t, episodeID, res.ID)
+				assert.Equal(t, tt.expectedState, res.Status)
+				assert.NotNil(t, res.PublishDate)
+			}
+
+			mockQueries.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCheckPublishDate(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	tests := []struct {
+		name        string
+		status      string
+		publishDate *time.Time
+		expected    error
+	}{
+		{name: "draft without publish date", status: publishing.StatusDraft},
+		{name: "scheduled with publish date", status: publishing.StatusScheduled, publishDate: timePtr(now.Add(time.Hour))},
+		{name: "scheduled without publish date", status: publishing.StatusScheduled, expected: errScheduledWithoutDate},
+		{name: "published with past publish date", status: publishing.StatusPublished, publishDate: timePtr(now.Add(-time.Hour))},
+		{name: "published with future publish date", status: publishing.StatusPublished, publishDate: timePtr(now.Add(time.Hour)), expected: errPublishedFutureDate},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expected, checkPublishDate(tt.status, tt.publishDate, now))
+		})
+	}
+}
+
 func stringPtr(s string) *string     { return &s }
 func int32Ptr(i int32) *int32        { return &i }
 func timePtr(t time.Time) *time.Time { return &t }
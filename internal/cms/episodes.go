@@ -2,31 +2,61 @@ package cms
 
 import (
 	"context"
-	"log/slog"
+	"database/sql"
+	"errors"
 	"net/http"
+	"net/url"
 	"th-application-technical-assignment/internal/middleware"
 	"th-application-technical-assignment/internal/response"
 	"th-application-technical-assignment/pkg/api/cms/v1"
+	"th-application-technical-assignment/pkg/audit"
+	"th-application-technical-assignment/pkg/database"
 	"th-application-technical-assignment/pkg/mapping"
+	"th-application-technical-assignment/pkg/publishing"
+	"th-application-technical-assignment/pkg/tasks"
 	"th-application-technical-assignment/pkg/util"
 	"th-application-technical-assignment/pkg/validation"
 	"th-application-technical-assignment/sqlc"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
 )
 
+// errSeriesDeleted rejects changes to an episode whose series is deleted.
+var errSeriesDeleted = errors.New("series is deleted")
+
+// errScheduledWithoutDate rejects removing the publish date of a scheduled
+// episode, it would never be published.
+var errScheduledWithoutDate = errors.New("scheduled episode without publish date")
+
+// errPublishedFutureDate rejects moving the publish date of a published
+// episode into the future, it would stay searchable before that date.
+var errPublishedFutureDate = errors.New("published episode with future publish date")
+
+// errStatusConflict rejects a status change the current status of an
+// episode does not allow.
+var errStatusConflict = errors.New("status change not allowed")
+
+// statusChange returns the status an episode moves to and its publish date
+// afterwards, or false when the change is not allowed.
+type statusChange func(ep sqlc.Episode, now time.Time) (string, *time.Time, bool)
+
 // getSeriesEpisodes godoc
 // @Summary      List episodes by series with pagination
 // @Description  Get a paginated list of all episodes for a specific series
 // @Tags         Episodes
+// @Security     BearerAuth
 // @Accept       json
 // @Produce      json
 // @Param        series_id  query     string  true   "Series ID"
 // @Param        page       query     int     false  "Page number (default: 1)"
 // @Param        page_size  query     int     false  "Page size (default: 20, max: 100)"
+// @Param        Accept-Language  header  string  false  "Preferred languages of titles and descriptions"
 // @Success      200        {object}  v1.PaginatedEpisodeResponse
 // @Failure      400        {object}  map[string]string
+// @Failure      401        {object}  map[string]string
+// @Failure      403        {object}  map[string]string
 // @Failure      500        {object}  map[string]string
 // @Router       /series/episodes [get]
 func (h *Handler) listSeriesEpisodes(w http.ResponseWriter, r *http.Request) {
@@ -67,6 +97,10 @@ func (h *Handler) listSeriesEpisodes(w http.ResponseWriter, r *http.Request) {
 	}
 
 	episodesData := mapping.EpisodesWithAssets(dbEpisodes)
+	if err := h.localizeEpisodes(ctx, seriesID, episodesData); err != nil {
+		response.HandleDBError(ctx, w, err, "We couldn't retrieve the episode translations.")
+		return
+	}
 
 	paginationMeta := util.CalculatePaginationResponse(pagination.Page, pagination.PageSize, itemCount)
 	res := util.PaginatedResponse[v1.EpisodeResponse]{
@@ -81,11 +115,15 @@ func (h *Handler) listSeriesEpisodes(w http.ResponseWriter, r *http.Request) {
 // @Summary      Get episode by ID
 // @Description  Get a single episode by its ID
 // @Tags         Episodes
+// @Security     BearerAuth
 // @Accept       json
 // @Produce      json
 // @Param        id   path      string  true  "Episode ID"
+// @Param        Accept-Language  header  string  false  "Preferred languages of titles and descriptions"
 // @Success      200  {object}  v1.EpisodeResponse
 // @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
 // @Failure      404  {object}  map[string]string
 // @Failure      500  {object}  map[string]string
 // @Router       /series/episodes/{id} [get]
@@ -116,19 +154,88 @@ func (h *Handler) getSeriesEpisode(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	res := mapping.Episode(dbEpisode, assets)
-	response.RespondWithJSON(ctx, w, http.StatusOK, res)
+	res := []v1.EpisodeResponse{mapping.Episode(dbEpisode, assets)}
+	if err := h.localizeEpisodes(ctx, dbEpisode.SeriesID, res); err != nil {
+		response.HandleDBError(ctx, w, err, "We couldn't retrieve the episode translations.")
+		return
+	}
+	response.RespondWithJSON(ctx, w, http.StatusOK, res[0])
+}
+
+// getSeriesEpisodeBySlug godoc
+// @Summary      Get episode by slug
+// @Description  Get a single episode by the slug of its series and its own slug. Slugs the series or the episode had before they were renamed redirect to the current ones
+// @Tags         Episodes
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        slug         path      string  true  "Series slug"
+// @Param        episodeSlug  path      string  true  "Episode slug"
+// @Param        Accept-Language  header  string  false  "Preferred languages of titles and descriptions"
+// @Success      200          {object}  v1.EpisodeResponse
+// @Success      301          "Moved Permanently, Location names the current slugs"
+// @Failure      401          {object}  map[string]string
+// @Failure      403          {object}  map[string]string
+// @Failure      404          {object}  map[string]string
+// @Failure      500          {object}  map[string]string
+// @Router       /series/by-slug/{slug}/episodes/{episodeSlug} [get]
+func (h *Handler) getSeriesEpisodeBySlug(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	dbSeries, seriesMoved, err := findSeriesBySlug(ctx, h.s.Queries, chi.URLParam(r, "slug"))
+	if err != nil {
+		response.HandleDBError(ctx, w, err, "Series not found.")
+		return
+	}
+
+	dbEpisode, episodeMoved, err := findEpisodeBySlug(ctx, h.s.Queries, dbSeries.ID, chi.URLParam(r, "episodeSlug"))
+	if err != nil {
+		response.HandleDBError(ctx, w, err, "Episode not found.")
+		return
+	}
+	if seriesMoved || episodeMoved {
+		path := seriesSlugPath(dbSeries.Slug) + "/episodes/" + url.PathEscape(dbEpisode.Slug)
+		http.Redirect(w, r, path, http.StatusMovedPermanently)
+		return
+	}
+
+	assets, err := h.s.Queries.ListAssetsByEpisode(ctx, dbEpisode.ID)
+	if err != nil {
+		response.HandleDBError(ctx, w, err, "We couldn't retrieve the episode assets.")
+		return
+	}
+
+	res := []v1.EpisodeResponse{mapping.Episode(dbEpisode, assets)}
+	if err := h.localizeEpisodes(ctx, dbEpisode.SeriesID, res); err != nil {
+		response.HandleDBError(ctx, w, err, "We couldn't retrieve the episode translations.")
+		return
+	}
+	response.RespondWithJSON(ctx, w, http.StatusOK, res[0])
+}
+
+// findEpisodeBySlug looks a live episode of a series up by its current slug,
+// then by the slugs it had before. moved reports a match on a previous slug.
+func findEpisodeBySlug(ctx context.Context, q sqlc.Querier, seriesID uuid.UUID, slug string) (ep sqlc.Episode, moved bool, err error) {
+	ep, err = q.GetEpisodeBySlug(ctx, sqlc.GetEpisodeBySlugParams{SeriesID: seriesID, Slug: slug})
+	if !errors.Is(err, sql.ErrNoRows) {
+		return ep, false, err
+	}
+	ep, err = q.GetEpisodeByPreviousSlug(ctx, sqlc.GetEpisodeByPreviousSlugParams{SeriesID: seriesID, Slug: slug})
+	return ep, err == nil, err
 }
 
 // postSeriesEpisode godoc
 // @Summary      Create a new episode
-// @Description  Create a new episode for a series
+// @Description  Create a new episode for a series. New episodes are drafts, they are searchable once they are published
 // @Tags         Episodes
+// @Security     BearerAuth
 // @Accept       json
 // @Produce      json
 // @Param        episode  body      v1.CreateEpisodeRequest  true  "Episode data"
 // @Success      201      {object}  v1.EpisodeResponse
 // @Failure      400      {object}  map[string]string
+// @Failure      401      {object}  map[string]string
+// @Failure      403      {object}  map[string]string
 // @Failure      500      {object}  map[string]string
 // @Router       /series/episodes [post]
 func (h *Handler) postSeriesEpisode(w http.ResponseWriter, r *http.Request) {
@@ -152,39 +259,73 @@ func (h *Handler) postSeriesEpisode(w http.ResponseWriter, r *http.Request) {
 		Description:     req.Description,
 		DurationSeconds: req.DurationSeconds,
 		PublishDate:     req.PublishDate,
+		// episodes are searchable once they are published
+		Status: publishing.StatusDraft,
 	}
 
-	dbEpisode, err := h.s.Queries.CreateEpisode(ctx, params)
+	var dbEpisode sqlc.Episode
+	var assets []sqlc.EpisodeAsset
+	err = withSlugRetry(true, func() error {
+		return h.s.WithTx(ctx, func(q sqlc.Querier) error {
+			var err error
+			params.Slug, err = database.EpisodeSlug(ctx, q, seriesID, req.Title, "")
+			if err != nil {
+				return err
+			}
+			dbEpisode, err = q.CreateEpisode(ctx, params)
+			if err != nil {
+				return err
+			}
+			if err := h.record(ctx, q, audit.ActionCreate, audit.EntityEpisode, dbEpisode.ID, nil, dbEpisode); err != nil {
+				return err
+			}
+			if err := h.revise(ctx, q, audit.EntityEpisode, dbEpisode.ID, nil); err != nil {
+				return err
+			}
+			assets, err = q.ListAssetsByEpisode(ctx, dbEpisode.ID)
+			if err != nil {
+				return err
+			}
+			return tasks.NewOutboxQueue(q).EnqueueIndexEpisode(ctx, dbEpisode, assets)
+		})
+	})
 	if err != nil {
 		response.HandleDBError(ctx, w, err, "We couldn't create the episode.")
 		return
 	}
 
-	if err := h.q.EnqueueIndexEpisode(ctx, dbEpisode, []sqlc.EpisodeAsset{}); err != nil {
-		slog.ErrorContext(ctx, "failed to enqueue index episode task", "err", err, "episode_id", dbEpisode.ID)
-	}
-
-	assets, err := h.s.Queries.ListAssetsByEpisode(ctx, dbEpisode.ID)
-	if err != nil {
-		response.HandleDBError(ctx, w, err, "We couldn't retrieve the episode assets.")
-		return
-	}
-
 	res := mapping.Episode(dbEpisode, assets)
 	response.RespondWithJSON(ctx, w, http.StatusCreated, res)
 }
 
+// checkPublishDate rejects a publish date an episode in status cannot have
+// at now: a scheduled episode needs one to be published on, and a published
+// episode cannot wait for one in the future.
+func checkPublishDate(status string, publishDate *time.Time, now time.Time) error {
+	switch {
+	case status == publishing.StatusScheduled && publishDate == nil:
+		return errScheduledWithoutDate
+	case status == publishing.StatusPublished && publishDate != nil && publishDate.After(now):
+		return errPublishedFutureDate
+	}
+	return nil
+}
+
 // putSeriesEpisode godoc
 // @Summary      Update episode by ID
 // @Description  Update an existing episode with the provided data
 // @Tags         Episodes
+// @Security     BearerAuth
 // @Accept       json
 // @Produce      json
 // @Param        id       path      string                   true  "Episode ID"
 // @Param        episode  body      v1.UpdateEpisodeRequest  true  "Episode data"
 // @Success      200      {object}  v1.EpisodeResponse
 // @Failure      400      {object}  map[string]string
+// @Failure      401      {object}  map[string]string
+// @Failure      403      {object}  map[string]string
 // @Failure      404      {object}  map[string]string
+// @Failure      409      {object}  map[string]string
 // @Failure      500      {object}  map[string]string
 // @Router       /series/episodes/{id} [put]
 func (h *Handler) putSeriesEpisode(w http.ResponseWriter, r *http.Request) {
@@ -216,22 +357,54 @@ func (h *Handler) putSeriesEpisode(w http.ResponseWriter, r *http.Request) {
 		PublishDate:     req.PublishDate,
 	}
 
-	dbEpisode, err := h.s.Queries.UpdateEpisode(ctx, params)
+	var dbEpisode sqlc.Episode
+	var assets []sqlc.EpisodeAsset
+	err = withSlugRetry(true, func() error {
+		return h.s.WithTx(ctx, func(q sqlc.Querier) error {
+			before, err := q.GetEpisode(ctx, episodeID)
+			if err != nil {
+				return err
+			}
+			if err := checkPublishDate(before.Status, req.PublishDate, time.Now()); err != nil {
+				return err
+			}
+			params.Slug = before.Slug
+			if req.Title != before.Title {
+				params.Slug, err = database.EpisodeSlug(ctx, q, before.SeriesID, req.Title, before.Slug)
+				if err != nil {
+					return err
+				}
+			}
+			dbEpisode, err = q.UpdateEpisode(ctx, params)
+			if err != nil {
+				return err
+			}
+			if err := h.record(ctx, q, audit.ActionUpdate, audit.EntityEpisode, episodeID, before, dbEpisode); err != nil {
+				return err
+			}
+			if err := h.revise(ctx, q, audit.EntityEpisode, episodeID, nil); err != nil {
+				return err
+			}
+			assets, err = q.ListAssetsByEpisode(ctx, episodeID)
+			if err != nil {
+				return err
+			}
+			return tasks.NewOutboxQueue(q).EnqueueIndexEpisode(ctx, dbEpisode, assets)
+		})
+	})
 	if err != nil {
+		if errors.Is(err, errScheduledWithoutDate) {
+			response.RespondWithError(ctx, w, http.StatusBadRequest, "A scheduled episode needs a publish date, unpublish it first to remove it.")
+			return
+		}
+		if errors.Is(err, errPublishedFutureDate) {
+			response.RespondWithError(ctx, w, http.StatusConflict, "The episode is published, unpublish it first to move its publish date into the future.")
+			return
+		}
 		response.HandleDBError(ctx, w, err, "Episode not found.")
 		return
 	}
 
-	assets, err := h.s.Queries.ListAssetsByEpisode(ctx, episodeID)
-	if err != nil {
-		response.HandleDBError(ctx, w, err, "We couldn't retrieve the episode assets.")
-		return
-	}
-
-	if err := h.q.EnqueueIndexEpisode(ctx, dbEpisode, assets); err != nil {
-		slog.ErrorContext(ctx, "failed to enqueue index episode task", "err", err, "episode_id", dbEpisode.ID)
-	}
-
 	res := mapping.Episode(dbEpisode, assets)
 	response.RespondWithJSON(ctx, w, http.StatusOK, res)
 }
@@ -240,11 +413,14 @@ func (h *Handler) putSeriesEpisode(w http.ResponseWriter, r *http.Request) {
 // @Summary      Delete episode by ID
 // @Description  Soft delete an episode by its ID
 // @Tags         Episodes
+// @Security     BearerAuth
 // @Accept       json
 // @Produce      json
 // @Param        id   path      string  true  "Episode ID"
 // @Success      204  "No Content"
 // @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
 // @Failure      404  {object}  map[string]string
 // @Failure      500  {object}  map[string]string
 // @Router       /series/episodes/{id} [delete]
@@ -263,15 +439,242 @@ func (h *Handler) deleteSeriesEpisode(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = h.s.Queries.DeleteEpisode(ctx, episodeID)
+	err = h.s.WithTx(ctx, func(q sqlc.Querier) error {
+		before, err := q.GetEpisode(ctx, episodeID)
+		if err != nil {
+			return err
+		}
+		deletedAt, err := q.DeleteEpisode(ctx, episodeID)
+		if err != nil {
+			return err
+		}
+		if err := h.record(ctx, q, audit.ActionDelete, audit.EntityEpisode, episodeID, before, nil); err != nil {
+			return err
+		}
+		return tasks.NewOutboxQueue(q).EnqueueDeleteEpisode(ctx, episodeID.String(), *deletedAt)
+	})
 	if err != nil {
-		response.HandleDBError(ctx, w, err, "We couldn't delete the episode.")
+		response.HandleDBError(ctx, w, err, "Episode not found.")
 		return
 	}
 
-	if err := h.q.EnqueueDeleteEpisode(ctx, episodeID.String()); err != nil {
-		slog.ErrorContext(ctx, "failed to enqueue delete episode task", "err", err, "episode_id", episodeID)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// restoreSeriesEpisode godoc
+// @Summary      Restore a deleted episode
+// @Description  Undo the soft delete of an episode and the assets deleted with it, and index it again. The series of the episode must not be deleted
+// @Tags         Episodes
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Episode ID"
+// @Success      200  {object}  v1.EpisodeResponse
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /series/episodes/{id}/restore [post]
+func (h *Handler) restoreSeriesEpisode(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	idParam := chi.URLParam(r, "id")
+	if idParam == "" {
+		response.RespondWithError(ctx, w, http.StatusBadRequest, "Episode ID is required.")
+		return
 	}
 
-	w.WriteHeader(http.StatusNoContent)
+	episodeID, err := uuid.Parse(idParam)
+	if err != nil {
+		response.RespondWithError(ctx, w, http.StatusBadRequest, "Invalid episode ID format.")
+		return
+	}
+
+	var dbEpisode sqlc.Episode
+	var assets []sqlc.EpisodeAsset
+	err = h.s.WithTx(ctx, func(q sqlc.Querier) error {
+		before, err := q.GetDeletedEpisode(ctx, episodeID)
+		if err != nil {
+			return err
+		}
+		if _, err := q.GetSeries(ctx, before.SeriesID); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return errSeriesDeleted
+			}
+			return err
+		}
+
+		dbEpisode, err = q.RestoreEpisode(ctx, episodeID)
+		if err != nil {
+			return err
+		}
+		if err := h.record(ctx, q, audit.ActionRestore, audit.EntityEpisode, episodeID, before, dbEpisode); err != nil {
+			return err
+		}
+		if err := h.revise(ctx, q, audit.EntityEpisode, episodeID, nil); err != nil {
+			return err
+		}
+
+		restored, err := q.RestoreAssetsByEpisodes(ctx, sqlc.RestoreAssetsByEpisodesParams{
+			EpisodeIds: []uuid.UUID{episodeID},
+			DeletedAt:  before.DeletedAt,
+		})
+		if err != nil {
+			return err
+		}
+		if err := h.recordAssetRestores(ctx, q, restored, before.DeletedAt); err != nil {
+			return err
+		}
+
+		assets, err = q.ListAssetsByEpisode(ctx, episodeID)
+		if err != nil {
+			return err
+		}
+		return tasks.NewOutboxQueue(q).EnqueueIndexEpisode(ctx, dbEpisode, assets)
+	})
+	if err != nil {
+		if errors.Is(err, errSeriesDeleted) {
+			response.RespondWithError(ctx, w, http.StatusConflict, "The series of the episode is deleted, restore the series first.")
+			return
+		}
+		response.HandleDBError(ctx, w, err, "Deleted episode not found.")
+		return
+	}
+
+	response.RespondWithJSON(ctx, w, http.StatusOK, mapping.Episode(dbEpisode, assets))
+}
+
+// recordAssetRestores writes the audit events of assets restored together
+// with their episode, which had been deleted at deletedAt.
+func (h *Handler) recordAssetRestores(ctx context.Context, q sqlc.Querier, assets []sqlc.EpisodeAsset, deletedAt *time.Time) error {
+	for _, a := range assets {
+		before := a
+		before.DeletedAt = deletedAt
+		if err := h.record(ctx, q, audit.ActionRestore, audit.EntityAsset, a.ID, before, a); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// publishSeriesEpisode godoc
+// @Summary      Publish an episode
+// @Description  Make a draft or unpublished episode searchable. An episode whose publish date lies in the future is scheduled and published once the date arrives, one without a publish date is published now
+// @Tags         Episodes
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Episode ID"
+// @Success      200  {object}  v1.EpisodeResponse
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /series/episodes/{id}/publish [post]
+func (h *Handler) publishSeriesEpisode(w http.ResponseWriter, r *http.Request) {
+	h.changeEpisodeStatus(w, r, audit.ActionPublish, func(ep sqlc.Episode, now time.Time) (string, *time.Time, bool) {
+		status := publishing.PublishTarget(ep.PublishDate, now)
+		publishDate := ep.PublishDate
+		if publishDate == nil {
+			publishDate = &now
+		}
+		return status, publishDate, publishing.CanTransition(ep.Status, status)
+	})
+}
+
+// unpublishSeriesEpisode godoc
+// @Summary      Unpublish an episode
+// @Description  Remove a published episode from search, or cancel the schedule of a scheduled one, which makes it a draft again
+// @Tags         Episodes
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Episode ID"
+// @Success      200  {object}  v1.EpisodeResponse
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /series/episodes/{id}/unpublish [post]
+func (h *Handler) unpublishSeriesEpisode(w http.ResponseWriter, r *http.Request) {
+	h.changeEpisodeStatus(w, r, audit.ActionUnpublish, func(ep sqlc.Episode, now time.Time) (string, *time.Time, bool) {
+		status, ok := publishing.UnpublishTarget(ep.Status)
+		return status, ep.PublishDate, ok
+	})
+}
+
+// changeEpisodeStatus applies a status change to the episode named in the
+// path, records it and updates the search index: an episode that is not
+// published anymore is removed from it.
+func (h *Handler) changeEpisodeStatus(w http.ResponseWriter, r *http.Request, action string, change statusChange) {
+	ctx := r.Context()
+
+	idParam := chi.URLParam(r, "id")
+	if idParam == "" {
+		response.RespondWithError(ctx, w, http.StatusBadRequest, "Episode ID is required.")
+		return
+	}
+
+	episodeID, err := uuid.Parse(idParam)
+	if err != nil {
+		response.RespondWithError(ctx, w, http.StatusBadRequest, "Invalid episode ID format.")
+		return
+	}
+
+	var status string
+	var dbEpisode sqlc.Episode
+	var assets []sqlc.EpisodeAsset
+	err = h.s.WithTx(ctx, func(q sqlc.Querier) error {
+		before, err := q.GetEpisode(ctx, episodeID)
+		if err != nil {
+			return err
+		}
+		status = before.Status
+
+		to, publishDate, ok := change(before, time.Now())
+		if !ok {
+			return errStatusConflict
+		}
+		dbEpisode, err = q.SetEpisodeStatus(ctx, sqlc.SetEpisodeStatusParams{
+			ID:          episodeID,
+			FromStatus:  before.Status,
+			Status:      to,
+			PublishDate: publishDate,
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			// changed by another request since it was read
+			return errStatusConflict
+		}
+		if err != nil {
+			return err
+		}
+		if err := h.record(ctx, q, action, audit.EntityEpisode, episodeID, before, dbEpisode); err != nil {
+			return err
+		}
+		if err := h.revise(ctx, q, audit.EntityEpisode, episodeID, nil); err != nil {
+			return err
+		}
+
+		assets, err = q.ListAssetsByEpisode(ctx, episodeID)
+		if err != nil {
+			return err
+		}
+		return tasks.NewOutboxQueue(q).EnqueueIndexEpisode(ctx, dbEpisode, assets)
+	})
+	if err != nil {
+		if errors.Is(err, errStatusConflict) {
+			response.RespondWithError(ctx, w, http.StatusConflict, "The episode is "+status+" and cannot be "+action+"ed.")
+			return
+		}
+		response.HandleDBError(ctx, w, err, "Episode not found.")
+		return
+	}
+
+	response.RespondWithJSON(ctx, w, http.StatusOK, mapping.Episode(dbEpisode, assets))
 }
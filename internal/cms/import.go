@@ -1,27 +1,37 @@
 package cms
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"log/slog"
 	"net/http"
+	"th-application-technical-assignment/internal/middleware"
 	"th-application-technical-assignment/internal/response"
 	v1 "th-application-technical-assignment/pkg/api/cms/v1"
+	"th-application-technical-assignment/pkg/mapping"
 	"th-application-technical-assignment/pkg/tasks"
+	"th-application-technical-assignment/pkg/util"
 	"th-application-technical-assignment/pkg/validation"
+	"th-application-technical-assignment/sqlc"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
 )
 
 // postImportContent godoc
 // @Summary      Import content from external source
-// @Description  Import content from YouTube, and other sources
+// @Description  Import content from YouTube, RSS/Atom podcast feeds, and other sources
 // @Tags         Import
+// @Security     BearerAuth
 // @Accept       json
 // @Produce      json
 // @Param        import  body      v1.ImportRequest  true  "Import request data"
-// @Success      204  "No Content"
+// @Success      202     {object}  v1.ImportJobResponse
 // @Failure      400     {object}  map[string]string
+// @Failure      401     {object}  map[string]string
+// @Failure      403     {object}  map[string]string
+// @Failure      404     {object}  map[string]string
 // @Failure      500     {object}  map[string]string
 // @Router       /import [post]
 func (h *Handler) postImportContent(w http.ResponseWriter, r *http.Request) {
@@ -50,17 +60,144 @@ func (h *Handler) postImportContent(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	job, err := h.s.Queries.CreateImportJob(ctx, sqlc.CreateImportJobParams{
+		SeriesID:   seriesID,
+		SourceType: req.SourceType,
+		SourceUrl:  req.SourceURL,
+	})
+	if err != nil {
+		response.HandleDBError(ctx, w, err, "Could not create import job.")
+		return
+	}
+
 	p := tasks.ImportContentPayload{
+		JobID:      job.ID.String(),
 		SourceType: req.SourceType,
 		SourceURL:  req.SourceURL,
 		SeriesID:   seriesID.String(),
 	}
 
 	if err := h.q.EnqueueImportContent(ctx, p); err != nil {
-		slog.ErrorContext(ctx, "failed to enqueue import task", "err", err, "series_id", seriesID)
+		slog.ErrorContext(ctx, "failed to enqueue import task", "err", err, "series_id", seriesID, "job_id", job.ID)
+
+		failed := sqlc.FinishImportJobParams{
+			ID:     job.ID,
+			Status: tasks.ImportJobFailed,
+			Errors: []byte(`[{"error":"could not queue import task"}]`),
+		}
+		if err := h.s.Queries.FinishImportJob(ctx, failed); err != nil {
+			slog.ErrorContext(ctx, "failed to mark import job failed", "err", err, "job_id", job.ID)
+		}
+
 		response.RespondWithError(ctx, w, http.StatusInternalServerError, "Could not queue import task.")
 		return
 	}
 
-	w.WriteHeader(http.StatusNoContent)
+	response.RespondWithJSON(ctx, w, http.StatusAccepted, mapping.ImportJob(job))
+}
+
+// getImportJob godoc
+// @Summary      Get import job by ID
+// @Description  Get the status, item counts and errors of an import job
+// @Tags         Import
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Import job ID"
+// @Success      200  {object}  v1.ImportJobResponse
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /imports/{id} [get]
+func (h *Handler) getImportJob(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	idParam := chi.URLParam(r, "id")
+	if idParam == "" {
+		response.RespondWithError(ctx, w, http.StatusBadRequest, "Import job ID is required.")
+		return
+	}
+
+	jobID, err := uuid.Parse(idParam)
+	if err != nil {
+		response.RespondWithError(ctx, w, http.StatusBadRequest, "Invalid import job ID format.")
+		return
+	}
+
+	job, err := h.s.Queries.GetImportJob(ctx, jobID)
+	if err != nil {
+		response.HandleDBError(ctx, w, err, "Import job not found.")
+		return
+	}
+
+	response.RespondWithJSON(ctx, w, http.StatusOK, mapping.ImportJob(job))
+}
+
+// listImportJobs godoc
+// @Summary      List import jobs of a series
+// @Description  Get a paginated list of the import jobs of a series, newest first
+// @Tags         Import
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        series_id  query     string  true   "Series ID"
+// @Param        page       query     int     false  "Page number (default: 1)"
+// @Param        page_size  query     int     false  "Page size (default: 20, max: 100)"
+// @Success      200        {object}  v1.PaginatedImportJobResponse
+// @Failure      400        {object}  map[string]string
+// @Failure      401        {object}  map[string]string
+// @Failure      403        {object}  map[string]string
+// @Failure      500        {object}  map[string]string
+// @Router       /imports [get]
+func (h *Handler) listImportJobs(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	seriesIDParam := r.URL.Query().Get("series_id")
+	if seriesIDParam == "" {
+		response.RespondWithError(ctx, w, http.StatusBadRequest, "Series ID is required.")
+		return
+	}
+
+	seriesID, err := uuid.Parse(seriesIDParam)
+	if err != nil {
+		response.RespondWithError(ctx, w, http.StatusBadRequest, "Invalid series ID format.")
+		return
+	}
+
+	pagination := middleware.GetPagination(ctx)
+	offset := (pagination.Page - 1) * pagination.PageSize
+
+	fetchCount := func(ctx context.Context) (int64, error) {
+		return h.s.Queries.CountImportJobsBySeries(ctx, seriesID)
+	}
+
+	fetchJobs := func(ctx context.Context) ([]sqlc.ImportJob, error) {
+		params := sqlc.ListImportJobsBySeriesPaginatedParams{
+			SeriesID: seriesID,
+			Limit:    int32(pagination.PageSize),
+			Offset:   int32(offset),
+		}
+		return h.s.Queries.ListImportJobsBySeriesPaginated(ctx, params)
+	}
+
+	itemCount, dbJobs, err := util.FetchPaginatedData(ctx, fetchCount, fetchJobs)
+	if err != nil {
+		response.HandleDBError(ctx, w, err, "We couldn't retrieve the import jobs.")
+		return
+	}
+
+	jobsData := make([]v1.ImportJobResponse, len(dbJobs))
+	for i, j := range dbJobs {
+		jobsData[i] = mapping.ImportJob(j)
+	}
+
+	paginationMeta := util.CalculatePaginationResponse(pagination.Page, pagination.PageSize, itemCount)
+	res := util.PaginatedResponse[v1.ImportJobResponse]{
+		Data:       jobsData,
+		Pagination: paginationMeta,
+	}
+
+	response.RespondWithJSON(ctx, w, http.StatusOK, res)
 }
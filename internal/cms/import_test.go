@@ -2,19 +2,24 @@ package cms
 
 import (
 	"bytes"
+	"context"
+	"database/sql"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	v1 "th-application-technical-assignment/pkg/api/cms/v1"
 	"th-application-technical-assignment/pkg/database"
 	"th-application-technical-assignment/pkg/tasks"
 	"th-application-technical-assignment/sqlc"
 	"time"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestHandler_postImportContent(t *testing.T) {
@@ -25,6 +30,7 @@ func TestHandler_postImportContent(t *testing.T) {
 		requestBody    map[string]any
 		mockSeries     sqlc.Series
 		dbError        error
+		jobError       error
 		queueError     error
 		expectedStatus int
 		expectError    bool
@@ -45,7 +51,7 @@ func TestHandler_postImportContent(t *testing.T) {
 				CreatedAt:   time.Now(),
 				UpdatedAt:   time.Now(),
 			},
-			expectedStatus: http.StatusNoContent,
+			expectedStatus: http.StatusAccepted,
 			expectError:    false,
 		},
 		{
@@ -106,6 +112,23 @@ func TestHandler_postImportContent(t *testing.T) {
 			expectedStatus: http.StatusInternalServerError,
 			expectError:    true,
 		},
+		{
+			name: "create job error",
+			requestBody: map[string]any{
+				"source_type": "youtube",
+				"source_url":  "https://youtube.com/watch?v=test123",
+				"series_id":   uuid.New().String(),
+			},
+			mockSeries: sqlc.Series{
+				ID:         uuid.New(),
+				Title:      "Test Series",
+				CategoryID: uuid.New(),
+				SeriesType: "podcast",
+			},
+			jobError:       assert.AnError,
+			expectedStatus: http.StatusInternalServerError,
+			expectError:    true,
+		},
 		{
 			name: "queue error",
 			requestBody: map[string]any{
@@ -143,15 +166,34 @@ func TestHandler_postImportContent(t *testing.T) {
 				q: mockQueue,
 			}
 
-			if !tt.expectError || tt.dbError != nil || tt.queueError != nil {
+			jobID := uuid.New()
+
+			if !tt.expectError || tt.dbError != nil || tt.jobError != nil || tt.queueError != nil {
 				seriesUUID, _ := uuid.Parse(tt.requestBody["series_id"].(string))
 
 				if tt.dbError != nil {
 					mockQueries.On("GetSeries", mock.Anything, seriesUUID).
 						Return(sqlc.Series{}, tt.dbError)
+				} else if tt.jobError != nil {
+					mockQueries.On("GetSeries", mock.Anything, seriesUUID).
+						Return(tt.mockSeries, nil)
+					mockQueries.On("CreateImportJob", mock.Anything, mock.Anything).
+						Return(sqlc.ImportJob{}, tt.jobError)
 				} else {
 					mockQueries.On("GetSeries", mock.Anything, seriesUUID).
 						Return(tt.mockSeries, nil)
+					mockQueries.On("CreateImportJob", mock.Anything, sqlc.CreateImportJobParams{
+						SeriesID:   seriesUUID,
+						SourceType: tt.requestBody["source_type"].(string),
+						SourceUrl:  tt.requestBody["source_url"].(string),
+					}).Return(sqlc.ImportJob{
+						ID:         jobID,
+						SeriesID:   seriesUUID,
+						SourceType: tt.requestBody["source_type"].(string),
+						SourceUrl:  tt.requestBody["source_url"].(string),
+						Status:     tasks.ImportJobQueued,
+						Errors:     []byte("[]"),
+					}, nil)
 
 					if tt.queueError != nil {
 						mockQueue.On("EnqueueImportContent", mock.Anything, mock.MatchedBy(func(p tasks.ImportContentPayload) bool {
@@ -159,9 +201,13 @@ func TestHandler_postImportContent(t *testing.T) {
 								p.SourceURL == tt.requestBody["source_url"].(string) &&
 								p.SeriesID == tt.requestBody["series_id"].(string)
 						})).Return(tt.queueError)
+						mockQueries.On("FinishImportJob", mock.Anything, mock.MatchedBy(func(p sqlc.FinishImportJobParams) bool {
+							return p.ID == jobID && p.Status == tasks.ImportJobFailed
+						})).Return(nil)
 					} else {
 						mockQueue.On("EnqueueImportContent", mock.Anything, mock.MatchedBy(func(p tasks.ImportContentPayload) bool {
-							return p.SourceType == tt.requestBody["source_type"].(string) &&
+							return p.JobID == jobID.String() &&
+								p.SourceType == tt.requestBody["source_type"].(string) &&
 								p.SourceURL == tt.requestBody["source_url"].(string) &&
 								p.SeriesID == tt.requestBody["series_id"].(string)
 						})).Return(nil)
@@ -179,7 +225,12 @@ func TestHandler_postImportContent(t *testing.T) {
 			assert.Equal(t, tt.expectedStatus, recorder.Code)
 
 			if !tt.expectError {
-				assert.Empty(t, recorder.Body.String())
+				var res v1.ImportJobResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &res)
+				assert.NoError(t, err)
+				assert.Equal(t, jobID.String(), res.ID)
+				assert.Equal(t, tasks.ImportJobQueued, res.Status)
+				assert.Empty(t, res.Errors)
 			}
 
 			mockQueries.AssertExpectations(t)
@@ -236,6 +287,8 @@ func TestHandler_postImportContent_SourceTypes(t *testing.T) {
 
 				mockQueries.On("GetSeries", mock.Anything, seriesUUID).
 					Return(mockSeries, nil)
+				mockQueries.On("CreateImportJob", mock.Anything, mock.AnythingOfType("sqlc.CreateImportJobParams")).
+					Return(sqlc.ImportJob{ID: uuid.New(), Status: tasks.ImportJobQueued}, nil)
 				mockQueue.On("EnqueueImportContent", mock.Anything, mock.AnythingOfType("tasks.ImportContentPayload")).
 					Return(nil)
 			}
@@ -248,7 +301,7 @@ func TestHandler_postImportContent_SourceTypes(t *testing.T) {
 			handler.postImportContent(recorder, req)
 
 			if tt.expectValid {
-				assert.Equal(t, http.StatusNoContent, recorder.Code)
+				assert.Equal(t, http.StatusAccepted, recorder.Code)
 			} else {
 				assert.Equal(t, http.StatusBadRequest, recorder.Code)
 			}
@@ -274,7 +327,7 @@ func TestHandler_postImportContent_EdgeCases(t *testing.T) {
 				"source_url":  "https://youtube.com/watch?v=" + string(make([]byte, 1000)),
 				"series_id":   uuid.New().String(),
 			},
-			expectedStatus: http.StatusNoContent,
+			expectedStatus: http.StatusAccepted,
 		},
 		{
 			name: "URL with special characters",
@@ -283,7 +336,7 @@ func TestHandler_postImportContent_EdgeCases(t *testing.T) {
 				"source_url":  "https://youtube.com/watch?v=test&param=value#fragment",
 				"series_id":   uuid.New().String(),
 			},
-			expectedStatus: http.StatusNoContent,
+			expectedStatus: http.StatusAccepted,
 		},
 		{
 			name: "empty source URL",
@@ -311,7 +364,7 @@ func TestHandler_postImportContent_EdgeCases(t *testing.T) {
 				q: mockQueue,
 			}
 
-			if tt.expectedStatus == http.StatusNoContent {
+			if tt.expectedStatus == http.StatusAccepted {
 				seriesUUID, _ := uuid.Parse(tt.requestBody["series_id"].(string))
 				mockSeries := sqlc.Series{
 					ID:          uuid.New(),
@@ -325,6 +378,8 @@ func TestHandler_postImportContent_EdgeCases(t *testing.T) {
 
 				mockQueries.On("GetSeries", mock.Anything, seriesUUID).
 					Return(mockSeries, nil)
+				mockQueries.On("CreateImportJob", mock.Anything, mock.AnythingOfType("sqlc.CreateImportJobParams")).
+					Return(sqlc.ImportJob{ID: uuid.New(), Status: tasks.ImportJobQueued}, nil)
 				mockQueue.On("EnqueueImportContent", mock.Anything, mock.AnythingOfType("tasks.ImportContentPayload")).
 					Return(nil)
 			}
@@ -378,6 +433,10 @@ func TestHandler_postImportContent_PayloadVerification(t *testing.T) {
 	mockQueries.On("GetSeries", mock.Anything, seriesUUID).
 		Return(mockSeries, nil)
 
+	jobID := uuid.New()
+	mockQueries.On("CreateImportJob", mock.Anything, mock.AnythingOfType("sqlc.CreateImportJobParams")).
+		Return(sqlc.ImportJob{ID: jobID, SeriesID: seriesUUID, Status: tasks.ImportJobQueued}, nil)
+
 	var capturedPayload tasks.ImportContentPayload
 	mockQueue.On("EnqueueImportContent", mock.Anything, mock.MatchedBy(func(p tasks.ImportContentPayload) bool {
 		capturedPayload = p
@@ -391,9 +450,9 @@ func TestHandler_postImportContent_PayloadVerification(t *testing.T) {
 
 	handler.postImportContent(recorder, req)
 
-	assert.Equal(t, http.StatusNoContent, recorder.Code)
-	assert.Empty(t, recorder.Body.String())
+	assert.Equal(t, http.StatusAccepted, recorder.Code)
 
+	assert.Equal(t, jobID.String(), capturedPayload.JobID)
 	assert.Equal(t, requestBody["source_type"], capturedPayload.SourceType)
 	assert.Equal(t, requestBody["source_url"], capturedPayload.SourceURL)
 	assert.Equal(t, requestBody["series_id"], capturedPayload.SeriesID)
@@ -401,3 +460,178 @@ func TestHandler_postImportContent_PayloadVerification(t *testing.T) {
 	mockQueries.AssertExpectations(t)
 	mockQueue.AssertExpectations(t)
 }
+
+func TestHandler_getImportJob(t *testing.T) {
+	t.Parallel()
+
+	startedAt := time.Now().Add(-time.Minute)
+	finishedAt := time.Now()
+
+	tests := []struct {
+		name           string
+		jobID          string
+		mockJob        sqlc.ImportJob
+		dbError        error
+		expectedStatus int
+		expectError    bool
+	}{
+		{
+			name:  "successful job retrieval",
+			jobID: uuid.New().String(),
+			mockJob: sqlc.ImportJob{
+				ID:           uuid.New(),
+				SeriesID:     uuid.New(),
+				SourceType:   "rss",
+				SourceUrl:    "https://example.com/feed.xml",
+				Status:       tasks.ImportJobSucceeded,
+				CreatedCount: 3,
+				UpdatedCount: 1,
+				SkippedCount: 2,
+				Errors:       []byte(`[{"external_id":"ep-4","title":"Episode 4","error":"failed to create asset"}]`),
+				StartedAt:    &startedAt,
+				FinishedAt:   &finishedAt,
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "invalid job ID",
+			jobID:          "invalid-uuid",
+			expectedStatus: http.StatusBadRequest,
+			expectError:    true,
+		},
+		{
+			name:           "job not found",
+			jobID:          uuid.New().String(),
+			dbError:        sql.ErrNoRows,
+			expectedStatus: http.StatusNotFound,
+			expectError:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockQueries := new(database.MockQuerier)
+			handler := &Handler{
+				s: &database.Store{Queries: mockQueries},
+				v: validator.New(),
+			}
+
+			if tt.jobID != "invalid-uuid" {
+				jobUUID, _ := uuid.Parse(tt.jobID)
+				mockQueries.On("GetImportJob", mock.Anything, jobUUID).Return(tt.mockJob, tt.dbError)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/imports/"+tt.jobID, nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", tt.jobID)
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			recorder := httptest.NewRecorder()
+
+			handler.getImportJob(recorder, req)
+
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+
+			if !tt.expectError {
+				var res v1.ImportJobResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+
+				assert.Equal(t, tt.mockJob.ID.String(), res.ID)
+				assert.Equal(t, tasks.ImportJobSucceeded, res.Status)
+				assert.Equal(t, int32(3), res.CreatedCount)
+				assert.Equal(t, int32(1), res.UpdatedCount)
+				assert.Equal(t, int32(2), res.SkippedCount)
+				require.Len(t, res.Errors, 1)
+				assert.Equal(t, "ep-4", res.Errors[0].ExternalID)
+				assert.Equal(t, "failed to create asset", res.Errors[0].Error)
+				assert.NotNil(t, res.StartedAt)
+				assert.NotNil(t, res.FinishedAt)
+			}
+
+			mockQueries.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_listImportJobs(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		seriesID       string
+		mockJobs       []sqlc.ImportJob
+		dbError        error
+		expectedStatus int
+	}{
+		{
+			name:     "successful list",
+			seriesID: uuid.New().String(),
+			mockJobs: []sqlc.ImportJob{
+				{ID: uuid.New(), Status: tasks.ImportJobRunning, Errors: []byte("[]")},
+				{ID: uuid.New(), Status: tasks.ImportJobFailed, Errors: []byte(`[{"error":"failed to fetch episodes"}]`)},
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "missing series ID",
+			seriesID:       "",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid series ID",
+			seriesID:       "invalid-uuid",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "database error",
+			seriesID:       uuid.New().String(),
+			dbError:        assert.AnError,
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockQueries := new(database.MockQuerier)
+			handler := &Handler{
+				s: &database.Store{Queries: mockQueries},
+				v: validator.New(),
+			}
+
+			seriesUUID, parseErr := uuid.Parse(tt.seriesID)
+			if parseErr == nil {
+				// count and list run concurrently, so the list may not be reached on error
+				mockQueries.On("CountImportJobsBySeries", mock.Anything, seriesUUID).Return(int64(len(tt.mockJobs)), tt.dbError)
+				mockQueries.On("ListImportJobsBySeriesPaginated", mock.Anything, sqlc.ListImportJobsBySeriesPaginatedParams{
+					SeriesID: seriesUUID,
+					Limit:    20,
+					Offset:   0,
+				}).Return(tt.mockJobs, nil).Maybe()
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/imports?series_id="+tt.seriesID, nil)
+			recorder := httptest.NewRecorder()
+
+			handler.listImportJobs(recorder, req)
+
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+
+			if tt.expectedStatus == http.StatusOK {
+				var res v1.PaginatedImportJobResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				require.Len(t, res.Data, 2)
+				assert.Equal(t, tasks.ImportJobRunning, res.Data[0].Status)
+				assert.Empty(t, res.Data[0].Errors)
+				require.Len(t, res.Data[1].Errors, 1)
+				assert.Equal(t, "failed to fetch episodes", res.Data[1].Errors[0].Error)
+			}
+
+			mockQueries.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,470 @@
+package cms
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"th-application-technical-assignment/internal/middleware"
+	"th-application-technical-assignment/internal/response"
+	"th-application-technical-assignment/pkg/api/cms/v1"
+	"th-application-technical-assignment/pkg/audit"
+	"th-application-technical-assignment/pkg/database"
+	"th-application-technical-assignment/pkg/mapping"
+	"th-application-technical-assignment/pkg/revisions"
+	"th-application-technical-assignment/pkg/tasks"
+	"th-application-technical-assignment/pkg/util"
+	"th-application-technical-assignment/sqlc"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// errRevisionNotFound rejects diffing or rolling back to a revision the
+// entity does not have.
+var errRevisionNotFound = errors.New("revision not found")
+
+// revise stores the current row of a series or episode changed through q as
+// its next revision, authored by the subject of the request token.
+func (h *Handler) revise(ctx context.Context, q sqlc.Querier, entityType string, entityID uuid.UUID, rollbackOf *int32) error {
+	_, err := revisions.Record(ctx, q, revisions.Revision{
+		Author:     middleware.Subject(ctx),
+		EntityType: entityType,
+		EntityID:   entityID,
+		RollbackOf: rollbackOf,
+	})
+	return err
+}
+
+// getRevision returns revision n of an entity, errRevisionNotFound when it
+// has no such revision.
+func getRevision(ctx context.Context, q sqlc.Querier, entityType string, entityID uuid.UUID, n int32) (sqlc.ContentRevision, error) {
+	rev, err := q.GetContentRevision(ctx, sqlc.GetContentRevisionParams{
+		EntityType: entityType,
+		EntityID:   entityID,
+		Revision:   n,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return rev, errRevisionNotFound
+	}
+	return rev, err
+}
+
+// parseRevision parses a revision number, which starts at 1.
+func parseRevision(s string) (int32, bool) {
+	n, err := strconv.ParseInt(s, 10, 32)
+	if err != nil || n < 1 {
+		return 0, false
+	}
+	return int32(n), true
+}
+
+// listSeriesRevisions godoc
+// @Summary      List the revisions of a series
+// @Description  Get a paginated list of the stored versions of a series, newest first. Every create, update, restore and rollback adds a revision.
+// @Tags         Revisions
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id         path      string  true   "Series ID"
+// @Param        page       query     int     false  "Page number (default: 1)"
+// @Param        page_size  query     int     false  "Page size (default: 20, max: 100)"
+// @Success      200        {object}  v1.PaginatedRevisionResponse
+// @Failure      400        {object}  map[string]string
+// @Failure      401        {object}  map[string]string
+// @Failure      403        {object}  map[string]string
+// @Failure      500        {object}  map[string]string
+// @Router       /series/{id}/revisions [get]
+func (h *Handler) listSeriesRevisions(w http.ResponseWriter, r *http.Request) {
+	h.listRevisions(w, r, audit.EntitySeries, "Series")
+}
+
+// listEpisodeRevisions godoc
+// @Summary      List the revisions of an episode
+// @Description  Get a paginated list of the stored versions of an episode, newest first. Every create, update, restore, status change, import and rollback adds a revision.
+// @Tags         Revisions
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id         path      string  true   "Episode ID"
+// @Param        page       query     int     false  "Page number (default: 1)"
+// @Param        page_size  query     int     false  "Page size (default: 20, max: 100)"
+// @Success      200        {object}  v1.PaginatedRevisionResponse
+// @Failure      400        {object}  map[string]string
+// @Failure      401        {object}  map[string]string
+// @Failure      403        {object}  map[string]string
+// @Failure      500        {object}  map[string]string
+// @Router       /series/episodes/{id}/revisions [get]
+func (h *Handler) listEpisodeRevisions(w http.ResponseWriter, r *http.Request) {
+	h.listRevisions(w, r, audit.EntityEpisode, "Episode")
+}
+
+// listRevisions responds with a page of the revisions of the entity in the
+// id URL parameter. label names the entity type in error messages.
+func (h *Handler) listRevisions(w http.ResponseWriter, r *http.Request, entityType, label string) {
+	ctx := r.Context()
+
+	idParam := chi.URLParam(r, "id")
+	if idParam == "" {
+		response.RespondWithError(ctx, w, http.StatusBadRequest, label+" ID is required.")
+		return
+	}
+
+	entityID, err := uuid.Parse(idParam)
+	if err != nil {
+		response.RespondWithError(ctx, w, http.StatusBadRequest, "Invalid "+strings.ToLower(label)+" ID format.")
+		return
+	}
+
+	pagination := middleware.GetPagination(ctx)
+	offset := (pagination.Page - 1) * pagination.PageSize
+
+	fetchCount := func(ctx context.Context) (int64, error) {
+		return h.s.Queries.CountContentRevisions(ctx, sqlc.CountContentRevisionsParams{
+			EntityType: entityType,
+			EntityID:   entityID,
+		})
+	}
+
+	fetchRevisions := func(ctx context.Context) ([]sqlc.ContentRevision, error) {
+		params := sqlc.ListContentRevisionsPaginatedParams{
+			EntityType: entityType,
+			EntityID:   entityID,
+			Limit:      int32(pagination.PageSize),
+			Offset:     int32(offset),
+		}
+		return h.s.Queries.ListContentRevisionsPaginated(ctx, params)
+	}
+
+	itemCount, dbRevisions, err := util.FetchPaginatedData(ctx, fetchCount, fetchRevisions)
+	if err != nil {
+		response.HandleDBError(ctx, w, err, "We couldn't retrieve the revisions.")
+		return
+	}
+
+	revisionsData := make([]v1.RevisionResponse, len(dbRevisions))
+	for i, rev := range dbRevisions {
+		revisionsData[i] = mapping.Revision(rev)
+	}
+
+	paginationMeta := util.CalculatePaginationResponse(pagination.Page, pagination.PageSize, itemCount)
+	res := util.PaginatedResponse[v1.RevisionResponse]{
+		Data:       revisionsData,
+		Pagination: paginationMeta,
+	}
+
+	response.RespondWithJSON(ctx, w, http.StatusOK, res)
+}
+
+// diffSeriesRevisions godoc
+// @Summary      Compare two revisions of a series
+// @Description  Get the fields that differ between two revisions of a series
+// @Tags         Revisions
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string  true  "Series ID"
+// @Param        from  query     int     true  "Revision to compare from"
+// @Param        to    query     int     true  "Revision to compare to"
+// @Success      200   {object}  v1.RevisionDiffResponse
+// @Failure      400   {object}  map[string]string
+// @Failure      401   {object}  map[string]string
+// @Failure      403   {object}  map[string]string
+// @Failure      404   {object}  map[string]string
+// @Failure      500   {object}  map[string]string
+// @Router       /series/{id}/revisions/diff [get]
+func (h *Handler) diffSeriesRevisions(w http.ResponseWriter, r *http.Request) {
+	h.diffRevisions(w, r, audit.EntitySeries, "Series")
+}
+
+// diffEpisodeRevisions godoc
+// @Summary      Compare two revisions of an episode
+// @Description  Get the fields that differ between two revisions of an episode
+// @Tags         Revisions
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string  true  "Episode ID"
+// @Param        from  query     int     true  "Revision to compare from"
+// @Param        to    query     int     true  "Revision to compare to"
+// @Success      200   {object}  v1.RevisionDiffResponse
+// @Failure      400   {object}  map[string]string
+// @Failure      401   {object}  map[string]string
+// @Failure      403   {object}  map[string]string
+// @Failure      404   {object}  map[string]string
+// @Failure      500   {object}  map[string]string
+// @Router       /series/episodes/{id}/revisions/diff [get]
+func (h *Handler) diffEpisodeRevisions(w http.ResponseWriter, r *http.Request) {
+	h.diffRevisions(w, r, audit.EntityEpisode, "Episode")
+}
+
+// diffRevisions responds with the fields that differ between the from and
+// to revisions of the entity in the id URL parameter.
+func (h *Handler) diffRevisions(w http.ResponseWriter, r *http.Request, entityType, label string) {
+	ctx := r.Context()
+
+	idParam := chi.URLParam(r, "id")
+	if idParam == "" {
+		response.RespondWithError(ctx, w, http.StatusBadRequest, label+" ID is required.")
+		return
+	}
+
+	entityID, err := uuid.Parse(idParam)
+	if err != nil {
+		response.RespondWithError(ctx, w, http.StatusBadRequest, "Invalid "+strings.ToLower(label)+" ID format.")
+		return
+	}
+
+	from, ok := parseRevision(r.URL.Query().Get("from"))
+	if !ok {
+		response.RespondWithError(ctx, w, http.StatusBadRequest, "Invalid from revision, expected a number from 1.")
+		return
+	}
+	to, ok := parseRevision(r.URL.Query().Get("to"))
+	if !ok {
+		response.RespondWithError(ctx, w, http.StatusBadRequest, "Invalid to revision, expected a number from 1.")
+		return
+	}
+
+	var fromRev, toRev sqlc.ContentRevision
+	var changes map[string]audit.FieldChange
+	err = func() error {
+		var err error
+		if fromRev, err = getRevision(ctx, h.s.Queries, entityType, entityID, from); err != nil {
+			return err
+		}
+		if toRev, err = getRevision(ctx, h.s.Queries, entityType, entityID, to); err != nil {
+			return err
+		}
+		changes, err = revisions.Diff(fromRev, toRev)
+		return err
+	}()
+	if err != nil {
+		if errors.Is(err, errRevisionNotFound) {
+			response.RespondWithError(ctx, w, http.StatusNotFound, "Revision not found.")
+			return
+		}
+		response.HandleDBError(ctx, w, err, "We couldn't compare the revisions.")
+		return
+	}
+
+	response.RespondWithJSON(ctx, w, http.StatusOK, mapping.RevisionDiff(fromRev, toRev, changes))
+}
+
+// rollbackSeries godoc
+// @Summary      Roll a series back to a revision
+// @Description  Restore the title, type, description, language and category a series had in an earlier revision. The rollback is stored as a new revision and the series is indexed again.
+// @Tags         Revisions
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id        path      string  true  "Series ID"
+// @Param        revision  path      int     true  "Revision to roll back to"
+// @Success      200       {object}  v1.SeriesResponse
+// @Failure      400       {object}  map[string]string
+// @Failure      401       {object}  map[string]string
+// @Failure      403       {object}  map[string]string
+// @Failure      404       {object}  map[string]string
+// @Failure      409       {object}  map[string]string
+// @Failure      500       {object}  map[string]string
+// @Router       /series/{id}/revisions/{revision}/rollback [post]
+func (h *Handler) rollbackSeries(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	idParam := chi.URLParam(r, "id")
+	if idParam == "" {
+		response.RespondWithError(ctx, w, http.StatusBadRequest, "Series ID is required.")
+		return
+	}
+
+	seriesID, err := uuid.Parse(idParam)
+	if err != nil {
+		response.RespondWithError(ctx, w, http.StatusBadRequest, "Invalid series ID format.")
+		return
+	}
+
+	revision, ok := parseRevision(chi.URLParam(r, "revision"))
+	if !ok {
+		response.RespondWithError(ctx, w, http.StatusBadRequest, "Invalid revision, expected a number from 1.")
+		return
+	}
+
+	var dbSeries sqlc.Series
+	err = withSlugRetry(true, func() error {
+		return h.s.WithTx(ctx, func(q sqlc.Querier) error {
+			before, err := q.GetSeries(ctx, seriesID)
+			if err != nil {
+				return err
+			}
+			rev, err := getRevision(ctx, q, audit.EntitySeries, seriesID, revision)
+			if err != nil {
+				return err
+			}
+			target, err := revisions.Snapshot[sqlc.Series](rev)
+			if err != nil {
+				return err
+			}
+			if target.CategoryID != before.CategoryID {
+				if err := useCategory(ctx, q, target.CategoryID); err != nil {
+					return err
+				}
+			}
+
+			params := sqlc.UpdateSeriesParams{
+				ID:          seriesID,
+				Title:       target.Title,
+				Description: target.Description,
+				CategoryID:  target.CategoryID,
+				Language:    target.Language,
+				SeriesType:  target.SeriesType,
+				Slug:        before.Slug,
+			}
+			if target.Title != before.Title {
+				params.Slug, err = database.SeriesSlug(ctx, q, target.Title, before.Slug)
+				if err != nil {
+					return err
+				}
+			}
+			dbSeries, err = q.UpdateSeries(ctx, params)
+			if err != nil {
+				return err
+			}
+			if err := h.record(ctx, q, audit.ActionUpdate, audit.EntitySeries, seriesID, before, dbSeries); err != nil {
+				return err
+			}
+			if err := h.revise(ctx, q, audit.EntitySeries, seriesID, &revision); err != nil {
+				return err
+			}
+			outbox := tasks.NewOutboxQueue(q)
+			if err := outbox.EnqueueIndexSeries(ctx, dbSeries); err != nil {
+				return err
+			}
+			if languageChanged(before, dbSeries) {
+				return outbox.EnqueueIndexSeriesEpisodes(ctx, seriesID)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, errRevisionNotFound):
+			response.RespondWithError(ctx, w, http.StatusNotFound, "Revision not found.")
+		case errors.Is(err, errCategoryDeleted):
+			response.RespondWithError(ctx, w, http.StatusConflict, "The category of the revision is deleted, restore the category first.")
+		default:
+			response.HandleDBError(ctx, w, err, "Series not found.")
+		}
+		return
+	}
+
+	response.RespondWithJSON(ctx, w, http.StatusOK, mapping.Series(dbSeries))
+}
+
+// rollbackSeriesEpisode godoc
+// @Summary      Roll an episode back to a revision
+// @Description  Restore the title, description, duration and publish date an episode had in an earlier revision. The publishing status stays as it is. The rollback is stored as a new revision and the episode is indexed again.
+// @Tags         Revisions
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id        path      string  true  "Episode ID"
+// @Param        revision  path      int     true  "Revision to roll back to"
+// @Success      200       {object}  v1.EpisodeResponse
+// @Failure      400       {object}  map[string]string
+// @Failure      401       {object}  map[string]string
+// @Failure      403       {object}  map[string]string
+// @Failure      404       {object}  map[string]string
+// @Failure      409       {object}  map[string]string
+// @Failure      500       {object}  map[string]string
+// @Router       /series/episodes/{id}/revisions/{revision}/rollback [post]
+func (h *Handler) rollbackSeriesEpisode(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	idParam := chi.URLParam(r, "id")
+	if idParam == "" {
+		response.RespondWithError(ctx, w, http.StatusBadRequest, "Episode ID is required.")
+		return
+	}
+
+	episodeID, err := uuid.Parse(idParam)
+	if err != nil {
+		response.RespondWithError(ctx, w, http.StatusBadRequest, "Invalid episode ID format.")
+		return
+	}
+
+	revision, ok := parseRevision(chi.URLParam(r, "revision"))
+	if !ok {
+		response.RespondWithError(ctx, w, http.StatusBadRequest, "Invalid revision, expected a number from 1.")
+		return
+	}
+
+	var dbEpisode sqlc.Episode
+	var assets []sqlc.EpisodeAsset
+	err = withSlugRetry(true, func() error {
+		return h.s.WithTx(ctx, func(q sqlc.Querier) error {
+			before, err := q.GetEpisode(ctx, episodeID)
+			if err != nil {
+				return err
+			}
+			rev, err := getRevision(ctx, q, audit.EntityEpisode, episodeID, revision)
+			if err != nil {
+				return err
+			}
+			target, err := revisions.Snapshot[sqlc.Episode](rev)
+			if err != nil {
+				return err
+			}
+			if err := checkPublishDate(before.Status, target.PublishDate, time.Now()); err != nil {
+				return err
+			}
+
+			params := sqlc.UpdateEpisodeParams{
+				ID:              episodeID,
+				Title:           target.Title,
+				Description:     target.Description,
+				DurationSeconds: target.DurationSeconds,
+				PublishDate:     target.PublishDate,
+				Slug:            before.Slug,
+			}
+			if target.Title != before.Title {
+				params.Slug, err = database.EpisodeSlug(ctx, q, before.SeriesID, target.Title, before.Slug)
+				if err != nil {
+					return err
+				}
+			}
+			dbEpisode, err = q.UpdateEpisode(ctx, params)
+			if err != nil {
+				return err
+			}
+			if err := h.record(ctx, q, audit.ActionUpdate, audit.EntityEpisode, episodeID, before, dbEpisode); err != nil {
+				return err
+			}
+			if err := h.revise(ctx, q, audit.EntityEpisode, episodeID, &revision); err != nil {
+				return err
+			}
+			assets, err = q.ListAssetsByEpisode(ctx, episodeID)
+			if err != nil {
+				return err
+			}
+			return tasks.NewOutboxQueue(q).EnqueueIndexEpisode(ctx, dbEpisode, assets)
+		})
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, errRevisionNotFound):
+			response.RespondWithError(ctx, w, http.StatusNotFound, "Revision not found.")
+		case errors.Is(err, errScheduledWithoutDate):
+			response.RespondWithError(ctx, w, http.StatusBadRequest, "The revision has no publish date and the episode is scheduled, unpublish it first.")
+		case errors.Is(err, errPublishedFutureDate):
+			response.RespondWithError(ctx, w, http.StatusConflict, "The revision has a future publish date and the episode is published, unpublish it first.")
+		default:
+			response.HandleDBError(ctx, w, err, "Episode not found.")
+		}
+		return
+	}
+
+	response.RespondWithJSON(ctx, w, http.StatusOK, mapping.Episode(dbEpisode, assets))
+}
@@ -0,0 +1,466 @@
+package cms
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	v1 "th-application-technical-assignment/pkg/api/cms/v1"
+	"th-application-technical-assignment/pkg/audit"
+	"th-application-technical-assignment/pkg/database"
+	"th-application-technical-assignment/pkg/publishing"
+	"th-application-technical-assignment/pkg/tasks"
+	"th-application-technical-assignment/sqlc"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// withURLParams adds chi URL parameters to req, given as name and value
+// pairs.
+func withURLParams(req *http.Request, params ...string) *http.Request {
+	rctx := chi.NewRouteContext()
+	for i := 0; i+1 < len(params); i += 2 {
+		rctx.URLParams.Add(params[i], params[i+1])
+	}
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+func TestHandler_listRevisions(t *testing.T) {
+	t.Parallel()
+
+	rollbackOf := int32(1)
+
+	tests := []struct {
+		name           string
+		entityType     string
+		entityID       string
+		mockRevisions  []sqlc.ContentRevision
+		dbError        error
+		expectedStatus int
+	}{
+		{
+			name:       "series revisions",
+			entityType: audit.EntitySeries,
+			entityID:   uuid.New().String(),
+			mockRevisions: []sqlc.ContentRevision{
+				{ID: uuid.New(), EntityType: audit.EntitySeries, Revision: 2, Author: "user-1", Snapshot: []byte(`{"title":"Old Title"}`), RollbackOf: &rollbackOf},
+				{ID: uuid.New(), EntityType: audit.EntitySeries, Revision: 1, Author: "user-1", Snapshot: []byte(`{"title":"Old Title"}`)},
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:       "episode revisions",
+			entityType: audit.EntityEpisode,
+			entityID:   uuid.New().String(),
+			mockRevisions: []sqlc.ContentRevision{
+				{ID: uuid.New(), EntityType: audit.EntityEpisode, Revision: 2, Author: "user-1", Snapshot: []byte(`{"title":"Pilot"}`), RollbackOf: &rollbackOf},
+				{ID: uuid.New(), EntityType: audit.EntityEpisode, Revision: 1, Author: "user-1", Snapshot: []byte(`{"title":"Pilot"}`)},
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "invalid ID",
+			entityType:     audit.EntitySeries,
+			entityID:       "invalid-uuid",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "database error",
+			entityType:     audit.EntityEpisode,
+			entityID:       uuid.New().String(),
+			dbError:        assert.AnError,
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockQueries := new(database.MockQuerier)
+			handler := &Handler{
+				s: &database.Store{Queries: mockQueries},
+				v: validator.New(),
+			}
+
+			entityUUID, parseErr := uuid.Parse(tt.entityID)
+			if parseErr == nil {
+				// count and list run concurrently, so the list may not be reached on error
+				mockQueries.On("CountContentRevisions", mock.Anything, sqlc.CountContentRevisionsParams{
+					EntityType: tt.entityType,
+					EntityID:   entityUUID,
+				}).Return(int64(len(tt.mockRevisions)), tt.dbError)
+				mockQueries.On("ListContentRevisionsPaginated", mock.Anything, sqlc.ListContentRevisionsPaginatedParams{
+					EntityType: tt.entityType,
+					EntityID:   entityUUID,
+					Limit:      20,
+					Offset:     0,
+				}).Return(tt.mockRevisions, nil).Maybe()
+			}
+
+			path, handle := "/series/"+tt.entityID+"/revisions", handler.listSeriesRevisions
+			if tt.entityType == audit.EntityEpisode {
+				path, handle = "/series/episodes/"+tt.entityID+"/revisions", handler.listEpisodeRevisions
+			}
+			req := withURLParams(httptest.NewRequest(http.MethodGet, path, nil), "id", tt.entityID)
+			recorder := httptest.NewRecorder()
+
+			handle(recorder, req)
+
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+
+			if tt.expectedStatus == http.StatusOK {
+				var res v1.PaginatedRevisionResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+				require.Len(t, res.Data, 2)
+				assert.Equal(t, int32(2), res.Data[0].Revision)
+				assert.Equal(t, &rollbackOf, res.Data[0].RollbackOf)
+				assert.Equal(t, tt.entityType, res.Data[0].EntityType)
+				assert.JSONEq(t, string(tt.mockRevisions[0].Snapshot), string(res.Data[0].Snapshot))
+				assert.Nil(t, res.Data[1].RollbackOf)
+			}
+
+			mockQueries.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_diffRevisions(t *testing.T) {
+	t.Parallel()
+
+	seriesID := uuid.New()
+	first := sqlc.ContentRevision{EntityType: audit.EntitySeries, EntityID: seriesID, Revision: 1,
+		Snapshot: []byte(`{"title":"Old Title","slug":"old-title","updated_at":"2025-09-18T09:00:00+00:00"}`)}
+	third := sqlc.ContentRevision{EntityType: audit.EntitySeries, EntityID: seriesID, Revision: 3,
+		Snapshot: []byte(`{"title":"New Title","slug":"new-title","updated_at":"2025-09-19T09:00:00+00:00"}`)}
+
+	tests := []struct {
+		name           string
+		query          string
+		setupMock      func(*database.MockQuerier)
+		expectedStatus int
+	}{
+		{
+			name:  "changed fields",
+			query: "?from=1&to=3",
+			setupMock: func(m *database.MockQuerier) {
+				m.On("GetContentRevision", mock.Anything, sqlc.GetContentRevisionParams{EntityType: audit.EntitySeries, EntityID: seriesID, Revision: 1}).Return(first, nil)
+				m.On("GetContentRevision", mock.Anything, sqlc.GetContentRevisionParams{EntityType: audit.EntitySeries, EntityID: seriesID, Revision: 3}).Return(third, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:  "revision not found",
+			query: "?from=1&to=4",
+			setupMock: func(m *database.MockQuerier) {
+				m.On("GetContentRevision", mock.Anything, sqlc.GetContentRevisionParams{EntityType: audit.EntitySeries, EntityID: seriesID, Revision: 1}).Return(first, nil)
+				m.On("GetContentRevision", mock.Anything, sqlc.GetContentRevisionParams{EntityType: audit.EntitySeries, EntityID: seriesID, Revision: 4}).
+					Return(sqlc.ContentRevision{}, sql.ErrNoRows)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "missing revision",
+			query:          "?from=1",
+			setupMock:      func(m *database.MockQuerier) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "revision below one",
+			query:          "?from=0&to=3",
+			setupMock:      func(m *database.MockQuerier) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "database error",
+			query: "?from=1&to=3",
+			setupMock: func(m *database.MockQuerier) {
+				m.On("GetContentRevision", mock.Anything, mock.Anything).Return(sqlc.ContentRevision{}, assert.AnError)
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockQueries := new(database.MockQuerier)
+			handler := &Handler{
+				s: &database.Store{Queries: mockQueries},
+				v: validator.New(),
+			}
+			tt.setupMock(mockQueries)
+
+			req := httptest.NewRequest(http.MethodGet, "/series/"+seriesID.String()+"/revisions/diff"+tt.query, nil)
+			req = withURLParams(req, "id", seriesID.String())
+			recorder := httptest.NewRecorder()
+
+			handler.diffSeriesRevisions(recorder, req)
+
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+
+			if tt.expectedStatus == http.StatusOK {
+				var res v1.RevisionDiffResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+				assert.Equal(t, int32(1), res.From)
+				assert.Equal(t, int32(3), res.To)
+				require.Len(t, res.Changes, 2)
+				assert.JSONEq(t, `"Old Title"`, string(res.Changes["title"].Before))
+				assert.JSONEq(t, `"New Title"`, string(res.Changes["title"].After))
+				assert.JSONEq(t, `"new-title"`, string(res.Changes["slug"].After))
+			}
+
+			mockQueries.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_rollbackSeries(t *testing.T) {
+	t.Parallel()
+
+	seriesID := uuid.New()
+	categoryID := uuid.New()
+	oldCategoryID := uuid.New()
+	current := sqlc.Series{ID: seriesID, Title: "New Title", Slug: "new-title", CategoryID: categoryID, SeriesType: "podcast"}
+	description := "The original description"
+
+	snapshot := func(categoryID uuid.UUID) []byte {
+		data, _ := json.Marshal(sqlc.Series{ID: seriesID, Title: "Old Title", Slug: "old-title", CategoryID: categoryID, SeriesType: "podcast", Description: &description})
+		return data
+	}
+
+	tests := []struct {
+		name           string
+		revision       string
+		snapshot       []byte
+		getError       error
+		revisionError  error
+		categoryError  error
+		expectedStatus int
+	}{
+		{
+			name:           "restores the revision",
+			revision:       "1",
+			snapshot:       snapshot(categoryID),
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "restores the category of the revision",
+			revision:       "1",
+			snapshot:       snapshot(oldCategoryID),
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "category of the revision is deleted",
+			revision:       "1",
+			snapshot:       snapshot(oldCategoryID),
+			categoryError:  sql.ErrNoRows,
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "revision not found",
+			revision:       "7",
+			revisionError:  sql.ErrNoRows,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "series not found",
+			revision:       "1",
+			getError:       sql.ErrNoRows,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "invalid revision",
+			revision:       "first",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockQueries := new(database.MockQuerier)
+			handler := &Handler{
+				s: &database.Store{Queries: mockQueries},
+				v: validator.New(),
+			}
+
+			if tt.revision != "first" {
+				mockQueries.On("GetSeries", mock.Anything, seriesID).Return(current, tt.getError)
+			}
+			if tt.getError == nil && tt.revision != "first" {
+				mockQueries.On("GetContentRevision", mock.Anything, mock.MatchedBy(func(params sqlc.GetContentRevisionParams) bool {
+					return params.EntityType == audit.EntitySeries && params.EntityID == seriesID
+				})).Return(sqlc.ContentRevision{EntityType: audit.EntitySeries, EntityID: seriesID, Revision: 1, Snapshot: tt.snapshot}, tt.revisionError)
+			}
+			var target sqlc.Series
+			if tt.snapshot != nil {
+				require.NoError(t, json.Unmarshal(tt.snapshot, &target))
+				if target.CategoryID != current.CategoryID {
+					mockQueries.On("GetCategoryForShare", mock.Anything, target.CategoryID).Return(sqlc.Category{ID: target.CategoryID}, tt.categoryError)
+				}
+			}
+			if tt.expectedStatus == http.StatusOK {
+				mockQueries.On("ListSeriesSlugs", mock.Anything, "old-title").Return([]string{}, nil)
+				after := current
+				after.Title, after.Slug, after.Description, after.CategoryID = target.Title, target.Slug, target.Description, target.CategoryID
+				mockQueries.On("UpdateSeries", mock.Anything, sqlc.UpdateSeriesParams{
+					ID:          seriesID,
+					Title:       "Old Title",
+					Description: &description,
+					CategoryID:  target.CategoryID,
+					SeriesType:  "podcast",
+					Slug:        "old-title",
+				}).Return(after, nil)
+				mockQueries.On("CreateAuditEvent", mock.Anything, mock.MatchedBy(func(params sqlc.CreateAuditEventParams) bool {
+					return params.Action == audit.ActionUpdate && params.EntityType == audit.EntitySeries && params.EntityID == seriesID
+				})).Return(nil)
+				mockQueries.On("CreateSeriesRevision", mock.Anything, mock.MatchedBy(func(params sqlc.CreateSeriesRevisionParams) bool {
+					return params.ID == seriesID && params.RollbackOf != nil && *params.RollbackOf == 1
+				})).Return(sqlc.ContentRevision{Revision: 4}, nil)
+				mockQueries.On("ListCategoryPath", mock.Anything, mock.Anything).Return([]uuid.UUID{}, nil)
+				mockQueries.On("ListSeriesTranslations", mock.Anything, mock.Anything).Return([]sqlc.SeriesTranslation{}, nil)
+				mockQueries.On("CreateOutboxEvent", mock.Anything, mock.MatchedBy(func(params sqlc.CreateOutboxEventParams) bool {
+					return params.TaskType == tasks.TypeIndexSeries
+				})).Return(nil)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/series/"+seriesID.String()+"/revisions/"+tt.revision+"/rollback", nil)
+			req = withURLParams(req, "id", seriesID.String(), "revision", tt.revision)
+			recorder := httptest.NewRecorder()
+
+			handler.rollbackSeries(recorder, req)
+
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var res v1.SeriesResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+				assert.Equal(t, "Old Title", res.Title)
+				assert.Equal(t, "old-title", res.Slug)
+			}
+
+			mockQueries.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_rollbackSeriesEpisode(t *testing.T) {
+	t.Parallel()
+
+	episodeID := uuid.New()
+	seriesID := uuid.New()
+	publishDate := time.Now().Add(time.Hour)
+
+	snapshot := func(publishDate *time.Time) []byte {
+		data, _ := json.Marshal(sqlc.Episode{ID: episodeID, SeriesID: seriesID, Title: "Pilot", Slug: "pilot", DurationSeconds: int32Ptr(1800), PublishDate: publishDate, Status: publishing.StatusDraft})
+		return data
+	}
+
+	tests := []struct {
+		name           string
+		status         string
+		snapshot       []byte
+		revisionError  error
+		expectedStatus int
+	}{
+		{
+			name:           "restores the revision and keeps the status",
+			status:         publishing.StatusPublished,
+			snapshot:       snapshot(nil),
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "scheduled episode keeps a publish date",
+			status:         publishing.StatusScheduled,
+			snapshot:       snapshot(&publishDate),
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "scheduled episode without publish date",
+			status:         publishing.StatusScheduled,
+			snapshot:       snapshot(nil),
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "published episode with future publish date",
+			status:         publishing.StatusPublished,
+			snapshot:       snapshot(&publishDate),
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "revision not found",
+			status:         publishing.StatusPublished,
+			revisionError:  sql.ErrNoRows,
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockQueries := new(database.MockQuerier)
+			handler := &Handler{
+				s: &database.Store{Queries: mockQueries},
+				v: validator.New(),
+			}
+
+			current := sqlc.Episode{ID: episodeID, SeriesID: seriesID, Title: "Pilot", Slug: "pilot", DurationSeconds: int32Ptr(1750), PublishDate: &publishDate, Status: tt.status}
+			mockQueries.On("GetEpisode", mock.Anything, episodeID).Return(current, nil)
+			mockQueries.On("GetContentRevision", mock.Anything, sqlc.GetContentRevisionParams{
+				EntityType: audit.EntityEpisode,
+				EntityID:   episodeID,
+				Revision:   2,
+			}).Return(sqlc.ContentRevision{EntityType: audit.EntityEpisode, EntityID: episodeID, Revision: 2, Snapshot: tt.snapshot}, tt.revisionError)
+
+			if tt.expectedStatus == http.StatusOK {
+				var target sqlc.Episode
+				require.NoError(t, json.Unmarshal(tt.snapshot, &target))
+				after := current
+				after.DurationSeconds, after.PublishDate = target.DurationSeconds, target.PublishDate
+				mockQueries.On("UpdateEpisode", mock.Anything, mock.MatchedBy(func(params sqlc.UpdateEpisodeParams) bool {
+					return params.ID == episodeID && params.Slug == "pilot" && *params.DurationSeconds == 1800 &&
+						(params.PublishDate != nil) == (target.PublishDate != nil)
+				})).Return(after, nil)
+				mockQueries.On("CreateAuditEvent", mock.Anything, mock.MatchedBy(func(params sqlc.CreateAuditEventParams) bool {
+					return params.Action == audit.ActionUpdate && params.EntityType == audit.EntityEpisode && params.EntityID == episodeID
+				})).Return(nil)
+				mockQueries.On("CreateEpisodeRevision", mock.Anything, mock.MatchedBy(func(params sqlc.CreateEpisodeRevisionParams) bool {
+					return params.ID == episodeID && params.RollbackOf != nil && *params.RollbackOf == 2
+				})).Return(sqlc.ContentRevision{Revision: 3}, nil)
+				mockQueries.On("ListAssetsByEpisode", mock.Anything, episodeID).Return([]sqlc.EpisodeAsset{}, nil)
+				mockQueries.On("ListSeriesLanguages", mock.Anything, mock.Anything).Return([]sqlc.ListSeriesLanguagesRow{}, nil)
+				mockQueries.On("ListEpisodeTranslations", mock.Anything, mock.Anything).Return([]sqlc.EpisodeTranslation{}, nil)
+				mockQueries.On("CreateOutboxEvent", mock.Anything, mock.MatchedBy(func(params sqlc.CreateOutboxEventParams) bool {
+					return params.TaskType == tasks.TypeIndexEpisode
+				})).Return(nil)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/series/episodes/"+episodeID.String()+"/revisions/2/rollback", nil)
+			req = withURLParams(req, "id", episodeID.String(), "revision", "2")
+			recorder := httptest.NewRecorder()
+
+			handler.rollbackSeriesEpisode(recorder, req)
+
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var res v1.EpisodeResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+				assert.Equal(t, tt.status, res.Status)
+				assert.Equal(t, int32(1800), *res.DurationSeconds)
+			}
+
+			mockQueries.AssertExpectations(t)
+		})
+	}
+}
@@ -2,6 +2,7 @@ package cms
 
 import (
 	"context"
+	"th-application-technical-assignment/pkg/auth"
 	"th-application-technical-assignment/pkg/database"
 	"th-application-technical-assignment/pkg/storage"
 	"th-application-technical-assignment/pkg/tasks"
@@ -9,7 +10,6 @@ import (
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	mw "th-application-technical-assignment/internal/middleware"
-	"github.com/go-chi/jwtauth/v5"
 	"github.com/go-playground/validator/v10"
 )
 
@@ -18,7 +18,7 @@ type Handler struct {
 	v   *validator.Validate
 	q   tasks.TaskQueue
 	mc  storage.ObjectStorage
-	jwt *jwtauth.JWTAuth
+	jwt *auth.Verifier
 }
 
 func Routes(ctx context.Context, h *Handler) chi.Router {
@@ -28,31 +28,80 @@ func Routes(ctx context.Context, h *Handler) chi.Router {
 		r.Use(middleware.AllowContentType("application/json"))
 		r.Use(middleware.CleanPath)
 
-		// r.Use(jwtauth.Verifier(jwt))
-		// r.Use(jwtauth.Authenticator(jwt))
+		r.Use(h.jwt.Verifier)
+		r.Use(mw.Authenticator)
 
-		r.With(mw.PaginationCtx(h.v)).Get("/series", h.listSeries)
-		r.Get("/series/{id}", h.getSeries)
-		r.Post("/series", h.postSeries)
-		r.Put("/series/{id}", h.putSeries)
-		r.Delete("/series/{id}", h.deleteSeries)
+		// viewers can read everything
+		r.Group(func(r chi.Router) {
+			r.Use(mw.RequireRole(mw.RoleViewer))
+			r.Use(mw.LanguageCtx)
 
-		r.With(mw.PaginationCtx(h.v)).Get("/series/episodes", h.listSeriesEpisodes)
-		r.Get("/series/episodes/{id}", h.getSeriesEpisode)
-		r.Post("/series/episodes", h.postSeriesEpisode)
-		r.Put("/series/episodes/{id}", h.putSeriesEpisode)
-		r.Delete("/series/episodes/{id}", h.deleteSeriesEpisode)
+			r.With(mw.PaginationCtx(h.v)).Get("/series", h.listSeries)
+			r.Get("/series/{id}", h.getSeries)
+			r.Get("/series/by-slug/{slug}", h.getSeriesBySlug)
+			r.Get("/series/by-slug/{slug}/episodes/{episodeSlug}", h.getSeriesEpisodeBySlug)
+			r.With(mw.PaginationCtx(h.v)).Get("/series/episodes", h.listSeriesEpisodes)
+			r.Get("/series/episodes/{id}", h.getSeriesEpisode)
+			r.With(mw.PaginationCtx(h.v)).Get("/categories", h.listCategories)
+			r.Get("/categories/tree", h.getCategoryTree)
+			r.Get("/categories/{id}", h.getCategory)
+			r.With(mw.PaginationCtx(h.v)).Get("/imports", h.listImportJobs)
+			r.Get("/imports/{id}", h.getImportJob)
+			r.Get("/deletions/{id}", h.getDeletionJob)
+			r.With(mw.PaginationCtx(h.v)).Get("/trash", h.listTrash)
+			r.Get("/series/{id}/subscription", h.getSeriesSubscription)
+			r.Get("/series/{id}/translations", h.listSeriesTranslations)
+			r.Get("/series/episodes/{id}/translations", h.listEpisodeTranslations)
+			r.Get("/categories/{id}/translations", h.listCategoryTranslations)
+			r.With(mw.PaginationCtx(h.v)).Get("/audit", h.listAuditEvents)
+			r.With(mw.PaginationCtx(h.v)).Get("/series/{id}/revisions", h.listSeriesRevisions)
+			r.Get("/series/{id}/revisions/diff", h.diffSeriesRevisions)
+			r.With(mw.PaginationCtx(h.v)).Get("/series/episodes/{id}/revisions", h.listEpisodeRevisions)
+			r.Get("/series/episodes/{id}/revisions/diff", h.diffEpisodeRevisions)
+		})
 
-		r.With(mw.PaginationCtx(h.v)).Get("/categories", h.listCategories)
-		r.Get("/categories/{id}", h.getCategory)
-		r.Post("/categories", h.postCategory)
-		r.Put("/categories/{id}", h.putCategory)
-		r.Delete("/categories/{id}", h.deleteCategory)
+		// editors manage content, imports and uploads
+		r.Group(func(r chi.Router) {
+			r.Use(mw.RequireRole(mw.RoleEditor))
 
-		r.Post("/import", h.postImportContent)
+			r.Post("/series", h.postSeries)
+			r.Put("/series/{id}", h.putSeries)
+			r.Post("/series/episodes", h.postSeriesEpisode)
+			r.Put("/series/episodes/{id}", h.putSeriesEpisode)
+			r.Post("/series/episodes/{id}/publish", h.publishSeriesEpisode)
+			r.Post("/series/episodes/{id}/unpublish", h.unpublishSeriesEpisode)
+			r.Post("/series/{id}/revisions/{revision}/rollback", h.rollbackSeries)
+			r.Post("/series/episodes/{id}/revisions/{revision}/rollback", h.rollbackSeriesEpisode)
+			r.Post("/categories", h.postCategory)
+			r.Put("/categories/{id}", h.putCategory)
+			r.Post("/categories/{id}/move", h.moveCategory)
+			r.Put("/series/{id}/translations/{locale}", h.putSeriesTranslation)
+			r.Delete("/series/{id}/translations/{locale}", h.deleteSeriesTranslation)
+			r.Put("/series/episodes/{id}/translations/{locale}", h.putEpisodeTranslation)
+			r.Delete("/series/episodes/{id}/translations/{locale}", h.deleteEpisodeTranslation)
+			r.Put("/categories/{id}/translations/{locale}", h.putCategoryTranslation)
+			r.Delete("/categories/{id}/translations/{locale}", h.deleteCategoryTranslation)
 
-		r.Post("/series/episodes/{id}/upload-url", h.getEpisodeUploadURL)
-		r.Post("/series/episodes/{id}/upload-confirm", h.confirmEpisodeUpload)
+			r.Post("/import", h.postImportContent)
+			r.Put("/series/{id}/subscription", h.putSeriesSubscription)
+			r.Delete("/series/{id}/subscription", h.deleteSeriesSubscription)
+
+			r.Post("/series/episodes/{id}/upload-url", h.getEpisodeUploadURL)
+			r.Post("/series/episodes/{id}/upload-confirm", h.confirmEpisodeUpload)
+		})
+
+		// deleting and restoring content is reserved to admins
+		r.Group(func(r chi.Router) {
+			r.Use(mw.RequireRole(mw.RoleAdmin))
+
+			r.Delete("/series/{id}", h.deleteSeries)
+			r.Delete("/series/episodes/{id}", h.deleteSeriesEpisode)
+			r.Delete("/categories/{id}", h.deleteCategory)
+
+			r.Post("/series/{id}/restore", h.restoreSeries)
+			r.Post("/series/episodes/{id}/restore", h.restoreSeriesEpisode)
+			r.Post("/categories/{id}/restore", h.restoreCategory)
+		})
 	})
 	return r
 }
@@ -0,0 +1,135 @@
+package cms
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"th-application-technical-assignment/pkg/auth"
+	"th-application-technical-assignment/pkg/database"
+	"th-application-technical-assignment/sqlc"
+
+	"github.com/go-chi/jwtauth/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoutes_Authorization(t *testing.T) {
+	t.Parallel()
+
+	tokenAuth := jwtauth.New("HS256", []byte("secret"), nil)
+	verifier, err := auth.NewVerifier(context.Background(), &auth.Config{SigningKey: "secret", SigningAlg: "HS256"})
+	require.NoError(t, err)
+	categoryID := uuid.New()
+
+	tests := []struct {
+		name           string
+		role           string
+		method         string
+		path           string
+		expectedStatus int
+	}{
+		{
+			name:           "anonymous read",
+			method:         http.MethodGet,
+			path:           "/v1/categories/" + categoryID.String(),
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "viewer cannot create series",
+			role:           "viewer",
+			method:         http.MethodPost,
+			path:           "/v1/series",
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "viewer cannot import",
+			role:           "viewer",
+			method:         http.MethodPost,
+			path:           "/v1/import",
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "viewer cannot move category",
+			role:           "viewer",
+			method:         http.MethodPost,
+			path:           "/v1/categories/" + categoryID.String() + "/move",
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "viewer cannot translate category",
+			role:           "viewer",
+			method:         http.MethodPut,
+			path:           "/v1/categories/" + categoryID.String() + "/translations/ar",
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "viewer cannot publish episode",
+			role:           "viewer",
+			method:         http.MethodPost,
+			path:           "/v1/series/episodes/" + uuid.New().String() + "/publish",
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "viewer cannot roll back series",
+			role:           "viewer",
+			method:         http.MethodPost,
+			path:           "/v1/series/" + uuid.New().String() + "/revisions/1/rollback",
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "editor cannot delete category",
+			role:           "editor",
+			method:         http.MethodDelete,
+			path:           "/v1/categories/" + categoryID.String(),
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "admin deletes category",
+			role:           "admin",
+			method:         http.MethodDelete,
+			path:           "/v1/categories/" + categoryID.String(),
+			expectedStatus: http.StatusNoContent,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockQueries := new(database.MockQuerier)
+			handler := &Handler{
+				s:   &database.Store{Queries: mockQueries},
+				v:   validator.New(),
+				jwt: verifier,
+			}
+			if tt.expectedStatus == http.StatusNoContent {
+				mockQueries.On("GetCategoryForUpdate", mock.Anything, categoryID).Return(sqlc.Category{ID: categoryID}, nil)
+				mockQueries.On("CountChildCategories", mock.Anything, &categoryID).Return(int64(0), nil)
+				mockQueries.On("CountSeriesByCategory", mock.Anything, categoryID).Return(int64(0), nil)
+				mockQueries.On("DeleteCategory", mock.Anything, categoryID).Return(nil)
+				// the audit row is attributed to the token subject
+				mockQueries.On("CreateAuditEvent", mock.Anything, mock.MatchedBy(func(params sqlc.CreateAuditEventParams) bool {
+					return params.Actor == "user-1" && params.EntityID == categoryID
+				})).Return(nil)
+			}
+
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			req.Header.Set("Content-Type", "application/json")
+			if tt.role != "" {
+				_, token, err := tokenAuth.Encode(map[string]any{"sub": "user-1", "role": tt.role})
+				require.NoError(t, err)
+				req.Header.Set("Authorization", "Bearer "+token)
+			}
+			recorder := httptest.NewRecorder()
+
+			Routes(context.Background(), handler).ServeHTTP(recorder, req)
+
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+			mockQueries.AssertExpectations(t)
+		})
+	}
+}
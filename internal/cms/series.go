@@ -2,12 +2,19 @@ package cms
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"log/slog"
 	"net/http"
+	"net/url"
+	"strconv"
 	"th-application-technical-assignment/internal/middleware"
 	"th-application-technical-assignment/internal/response"
 	"th-application-technical-assignment/pkg/api/cms/v1"
+	"th-application-technical-assignment/pkg/audit"
+	"th-application-technical-assignment/pkg/database"
 	"th-application-technical-assignment/pkg/mapping"
+	"th-application-technical-assignment/pkg/tasks"
 	"th-application-technical-assignment/pkg/util"
 	"th-application-technical-assignment/pkg/validation"
 	"th-application-technical-assignment/sqlc"
@@ -20,12 +27,16 @@ import (
 // @Summary      List all series with pagination
 // @Description  Get a paginated list of all series available in the system
 // @Tags         Series
+// @Security     BearerAuth
 // @Accept       json
 // @Produce      json
 // @Param        page       query     int  false  "Page number (default: 1)"
 // @Param        page_size  query     int  false  "Page size (default: 20, max: 100)"
+// @Param        Accept-Language  header  string  false  "Preferred languages of titles and descriptions"
 // @Success      200        {object}  v1.PaginatedSeriesResponse
 // @Failure      400        {object}  map[string]string
+// @Failure      401        {object}  map[string]string
+// @Failure      403        {object}  map[string]string
 // @Failure      500        {object}  map[string]string
 // @Router       /series [get]
 func (h *Handler) listSeries(w http.ResponseWriter, r *http.Request) {
@@ -56,6 +67,10 @@ func (h *Handler) listSeries(w http.ResponseWriter, r *http.Request) {
 	for i, s := range dbSeries {
 		seriesData[i] = mapping.Series(s)
 	}
+	if err := h.localizeSeries(ctx, seriesData); err != nil {
+		response.HandleDBError(ctx, w, err, "We couldn't retrieve the series translations.")
+		return
+	}
 
 	paginationMeta := util.CalculatePaginationResponse(pagination.Page, pagination.PageSize, itemCount)
 	res := util.PaginatedResponse[v1.SeriesResponse]{
@@ -70,11 +85,15 @@ func (h *Handler) listSeries(w http.ResponseWriter, r *http.Request) {
 // @Summary      Get series by ID
 // @Description  Get a single series by its ID
 // @Tags         Series
+// @Security     BearerAuth
 // @Accept       json
 // @Produce      json
 // @Param        id   path      string  true  "Series ID"
+// @Param        Accept-Language  header  string  false  "Preferred languages of titles and descriptions"
 // @Success      200  {object}  v1.SeriesResponse
 // @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
 // @Failure      404  {object}  map[string]string
 // @Failure      500  {object}  map[string]string
 // @Router       /series/{id} [get]
@@ -99,19 +118,89 @@ func (h *Handler) getSeries(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	res := mapping.Series(dbSeries)
-	response.RespondWithJSON(ctx, w, http.StatusOK, res)
+	res := []v1.SeriesResponse{mapping.Series(dbSeries)}
+	if err := h.localizeSeries(ctx, res); err != nil {
+		response.HandleDBError(ctx, w, err, "We couldn't retrieve the series translations.")
+		return
+	}
+	response.RespondWithJSON(ctx, w, http.StatusOK, res[0])
+}
+
+// getSeriesBySlug godoc
+// @Summary      Get series by slug
+// @Description  Get a single series by its slug. A slug the series had before it was renamed redirects to its current slug
+// @Tags         Series
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        slug  path      string  true  "Series slug"
+// @Param        Accept-Language  header  string  false  "Preferred languages of titles and descriptions"
+// @Success      200   {object}  v1.SeriesResponse
+// @Success      301   "Moved Permanently, Location names the current slug"
+// @Failure      401   {object}  map[string]string
+// @Failure      403   {object}  map[string]string
+// @Failure      404   {object}  map[string]string
+// @Failure      500   {object}  map[string]string
+// @Router       /series/by-slug/{slug} [get]
+func (h *Handler) getSeriesBySlug(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	dbSeries, moved, err := findSeriesBySlug(ctx, h.s.Queries, chi.URLParam(r, "slug"))
+	if err != nil {
+		response.HandleDBError(ctx, w, err, "Series not found.")
+		return
+	}
+	if moved {
+		http.Redirect(w, r, seriesSlugPath(dbSeries.Slug), http.StatusMovedPermanently)
+		return
+	}
+
+	res := []v1.SeriesResponse{mapping.Series(dbSeries)}
+	if err := h.localizeSeries(ctx, res); err != nil {
+		response.HandleDBError(ctx, w, err, "We couldn't retrieve the series translations.")
+		return
+	}
+	response.RespondWithJSON(ctx, w, http.StatusOK, res[0])
+}
+
+// findSeriesBySlug looks a live series up by its current slug, then by the
+// slugs it had before. moved reports a match on a previous slug.
+func findSeriesBySlug(ctx context.Context, q sqlc.Querier, slug string) (s sqlc.Series, moved bool, err error) {
+	s, err = q.GetSeriesBySlug(ctx, slug)
+	if !errors.Is(err, sql.ErrNoRows) {
+		return s, false, err
+	}
+	s, err = q.GetSeriesByPreviousSlug(ctx, slug)
+	return s, err == nil, err
+}
+
+func seriesSlugPath(slug string) string {
+	return "/v1/series/by-slug/" + url.PathEscape(slug)
+}
+
+// languageChanged reports whether an update changed the language of a
+// series. Episode documents are analyzed in the language of their series and
+// have to be indexed again then.
+func languageChanged(before, after sqlc.Series) bool {
+	if before.Language == nil || after.Language == nil {
+		return before.Language != after.Language
+	}
+	return *before.Language != *after.Language
 }
 
 // postSeries godoc
 // @Summary      Create a new series
 // @Description  Create a new series with the provided data
 // @Tags         Series
+// @Security     BearerAuth
 // @Accept       json
 // @Produce      json
 // @Param        series  body      v1.CreateSeriesRequest  true  "Series data"
 // @Success      201     {object}  v1.SeriesResponse
 // @Failure      400     {object}  map[string]string
+// @Failure      401     {object}  map[string]string
+// @Failure      403     {object}  map[string]string
+// @Failure      409     {object}  map[string]string
 // @Failure      500     {object}  map[string]string
 // @Router       /series [post]
 func (h *Handler) postSeries(w http.ResponseWriter, r *http.Request) {
@@ -137,16 +226,39 @@ func (h *Handler) postSeries(w http.ResponseWriter, r *http.Request) {
 		Language:    req.Language,
 	}
 
-	dbSeries, err := h.s.Queries.CreateSeries(ctx, params)
+	var dbSeries sqlc.Series
+	err = withSlugRetry(true, func() error {
+		return h.s.WithTx(ctx, func(q sqlc.Querier) error {
+			if err := useCategory(ctx, q, categoryID); err != nil {
+				return err
+			}
+			var err error
+			params.Slug, err = database.SeriesSlug(ctx, q, req.Title, "")
+			if err != nil {
+				return err
+			}
+			dbSeries, err = q.CreateSeries(ctx, params)
+			if err != nil {
+				return err
+			}
+			if err := h.record(ctx, q, audit.ActionCreate, audit.EntitySeries, dbSeries.ID, nil, dbSeries); err != nil {
+				return err
+			}
+			if err := h.revise(ctx, q, audit.EntitySeries, dbSeries.ID, nil); err != nil {
+				return err
+			}
+			return tasks.NewOutboxQueue(q).EnqueueIndexSeries(ctx, dbSeries)
+		})
+	})
 	if err != nil {
+		if errors.Is(err, errCategoryDeleted) {
+			response.RespondWithError(ctx, w, http.StatusConflict, "The category does not exist or is deleted.")
+			return
+		}
 		response.HandleDBError(ctx, w, err, "We couldn't create the series.")
 		return
 	}
 
-	if err := h.q.EnqueueIndexSeries(ctx, dbSeries); err != nil {
-		slog.ErrorContext(ctx, "failed to enqueue index series task", "err", err, "series_id", dbSeries.ID)
-	}
-
 	res := mapping.Series(dbSeries)
 	response.RespondWithJSON(ctx, w, http.StatusCreated, res)
 }
@@ -155,13 +267,17 @@ func (h *Handler) postSeries(w http.ResponseWriter, r *http.Request) {
 // @Summary      Update series by ID
 // @Description  Update an existing series with the provided data
 // @Tags         Series
+// @Security     BearerAuth
 // @Accept       json
 // @Produce      json
 // @Param        id      path      string                  true  "Series ID"
 // @Param        series  body      v1.UpdateSeriesRequest  true  "Series data"
 // @Success      200     {object}  v1.SeriesResponse
 // @Failure      400     {object}  map[string]string
+// @Failure      401     {object}  map[string]string
+// @Failure      403     {object}  map[string]string
 // @Failure      404     {object}  map[string]string
+// @Failure      409     {object}  map[string]string
 // @Failure      500     {object}  map[string]string
 // @Router       /series/{id} [put]
 func (h *Handler) putSeries(w http.ResponseWriter, r *http.Request) {
@@ -204,33 +320,153 @@ func (h *Handler) putSeries(w http.ResponseWriter, r *http.Request) {
 		params.CategoryID = categoryID
 	}
 
-	dbSeries, err := h.s.Queries.UpdateSeries(ctx, params)
+	var dbSeries sqlc.Series
+	err = withSlugRetry(true, func() error {
+		return h.s.WithTx(ctx, func(q sqlc.Querier) error {
+			if req.CategoryID != nil {
+				if err := useCategory(ctx, q, params.CategoryID); err != nil {
+					return err
+				}
+			}
+			before, err := q.GetSeries(ctx, seriesID)
+			if err != nil {
+				return err
+			}
+			// the slug only follows a new title, links to it stay valid otherwise
+			params.Slug = before.Slug
+			if req.Title != before.Title {
+				params.Slug, err = database.SeriesSlug(ctx, q, req.Title, before.Slug)
+				if err != nil {
+					return err
+				}
+			}
+			dbSeries, err = q.UpdateSeries(ctx, params)
+			if err != nil {
+				return err
+			}
+			if err := h.record(ctx, q, audit.ActionUpdate, audit.EntitySeries, seriesID, before, dbSeries); err != nil {
+				return err
+			}
+			if err := h.revise(ctx, q, audit.EntitySeries, seriesID, nil); err != nil {
+				return err
+			}
+			outbox := tasks.NewOutboxQueue(q)
+			if err := outbox.EnqueueIndexSeries(ctx, dbSeries); err != nil {
+				return err
+			}
+			if languageChanged(before, dbSeries) {
+				return outbox.EnqueueIndexSeriesEpisodes(ctx, seriesID)
+			}
+			return nil
+		})
+	})
 	if err != nil {
+		if errors.Is(err, errCategoryDeleted) {
+			response.RespondWithError(ctx, w, http.StatusConflict, "The category does not exist or is deleted.")
+			return
+		}
 		response.HandleDBError(ctx, w, err, "Series not found.")
 		return
 	}
 
-	if err := h.q.EnqueueIndexSeries(ctx, dbSeries); err != nil {
-		slog.ErrorContext(ctx, "failed to enqueue index series task", "err", err, "series_id", dbSeries.ID)
-	}
-
 	res := mapping.Series(dbSeries)
 	response.RespondWithJSON(ctx, w, http.StatusOK, res)
 }
 
 // deleteSeries godoc
 // @Summary      Delete series by ID
-// @Description  Soft delete a series by its ID
+// @Description  Soft delete a series by its ID. Its episodes and assets are deleted and removed from search by the returned deletion job
+// @Tags         Series
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id              path      string  true   "Series ID"
+// @Param        delete_objects  query     bool    false  "Also remove the stored files of uploaded assets"
+// @Success      202             {object}  v1.DeletionJobResponse
+// @Failure      400             {object}  map[string]string
+// @Failure      401             {object}  map[string]string
+// @Failure      403             {object}  map[string]string
+// @Failure      404             {object}  map[string]string
+// @Failure      500             {object}  map[string]string
+// @Router       /series/{id} [delete]
+func (h *Handler) deleteSeries(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	idParam := chi.URLParam(r, "id")
+	if idParam == "" {
+		response.RespondWithError(ctx, w, http.StatusBadRequest, "Series ID is required.")
+		return
+	}
+
+	seriesID, err := uuid.Parse(idParam)
+	if err != nil {
+		response.RespondWithError(ctx, w, http.StatusBadRequest, "Invalid series ID format.")
+		return
+	}
+
+	deleteObjects := false
+	if v := r.URL.Query().Get("delete_objects"); v != "" {
+		deleteObjects, err = strconv.ParseBool(v)
+		if err != nil {
+			response.RespondWithError(ctx, w, http.StatusBadRequest, "Invalid delete_objects value.")
+			return
+		}
+	}
+
+	// the series is deleted right away, its episodes and assets follow in
+	// the deletion job
+	var job sqlc.DeletionJob
+	err = h.s.WithTx(ctx, func(q sqlc.Querier) error {
+		before, err := q.GetSeries(ctx, seriesID)
+		if err != nil {
+			return err
+		}
+		deletedAt, err := q.DeleteSeries(ctx, seriesID)
+		if err != nil {
+			return err
+		}
+		if err := h.record(ctx, q, audit.ActionDelete, audit.EntitySeries, seriesID, before, nil); err != nil {
+			return err
+		}
+		job, err = q.CreateDeletionJob(ctx, sqlc.CreateDeletionJobParams{
+			SeriesID:      seriesID,
+			RequestedBy:   middleware.Subject(ctx),
+			DeleteObjects: deleteObjects,
+		})
+		if err != nil {
+			return err
+		}
+		outbox := tasks.NewOutboxQueue(q)
+		if err := outbox.EnqueueDeleteSeries(ctx, seriesID.String(), *deletedAt); err != nil {
+			return err
+		}
+		return outbox.EnqueueDeleteSeriesContent(ctx, job.ID.String())
+	})
+	if err != nil {
+		response.HandleDBError(ctx, w, err, "Series not found.")
+		return
+	}
+
+	response.RespondWithJSON(ctx, w, http.StatusAccepted, mapping.DeletionJob(job))
+}
+
+// restoreSeries godoc
+// @Summary      Restore a deleted series
+// @Description  Undo the soft delete of a series together with the episodes and assets deleted with it, and index them again
 // @Tags         Series
+// @Security     BearerAuth
 // @Accept       json
 // @Produce      json
 // @Param        id   path      string  true  "Series ID"
-// @Success      204  "No Content"
+// @Success      200  {object}  v1.SeriesResponse
 // @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
 // @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
 // @Failure      500  {object}  map[string]string
-// @Router       /series/{id} [delete]
-func (h *Handler) deleteSeries(w http.ResponseWriter, r *http.Request) {
+// @Router       /series/{id}/restore [post]
+func (h *Handler) restoreSeries(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	idParam := chi.URLParam(r, "id")
@@ -245,15 +481,129 @@ func (h *Handler) deleteSeries(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = h.s.Queries.DeleteSeries(ctx, seriesID)
+	// episodes deleted on their own before the series keep their own
+	// deletion time and stay deleted
+	var dbSeries sqlc.Series
+	err = h.s.WithTx(ctx, func(q sqlc.Querier) error {
+		before, err := q.GetDeletedSeries(ctx, seriesID)
+		if err != nil {
+			return err
+		}
+		if err := useCategory(ctx, q, before.CategoryID); err != nil {
+			return err
+		}
+		dbSeries, err = q.RestoreSeries(ctx, seriesID)
+		if err != nil {
+			return err
+		}
+		if err := h.record(ctx, q, audit.ActionRestore, audit.EntitySeries, seriesID, before, dbSeries); err != nil {
+			return err
+		}
+		if err := h.revise(ctx, q, audit.EntitySeries, seriesID, nil); err != nil {
+			return err
+		}
+
+		episodes, err := q.RestoreEpisodesBySeries(ctx, sqlc.RestoreEpisodesBySeriesParams{
+			SeriesID:  seriesID,
+			DeletedAt: before.DeletedAt,
+		})
+		if err != nil {
+			return err
+		}
+		ids := make([]uuid.UUID, 0, len(episodes))
+		for _, ep := range episodes {
+			deleted := ep
+			deleted.DeletedAt = before.DeletedAt
+			if err := h.record(ctx, q, audit.ActionRestore, audit.EntityEpisode, ep.ID, deleted, ep); err != nil {
+				return err
+			}
+			if err := h.revise(ctx, q, audit.EntityEpisode, ep.ID, nil); err != nil {
+				return err
+			}
+			ids = append(ids, ep.ID)
+		}
+
+		outbox := tasks.NewOutboxQueue(q)
+		if err := outbox.EnqueueIndexSeries(ctx, dbSeries); err != nil {
+			return err
+		}
+		if len(episodes) == 0 {
+			return nil
+		}
+
+		restored, err := q.RestoreAssetsByEpisodes(ctx, sqlc.RestoreAssetsByEpisodesParams{
+			EpisodeIds: ids,
+			DeletedAt:  before.DeletedAt,
+		})
+		if err != nil {
+			return err
+		}
+		if err := h.recordAssetRestores(ctx, q, restored, before.DeletedAt); err != nil {
+			return err
+		}
+
+		assets, err := q.ListAssetsByEpisodes(ctx, ids)
+		if err != nil {
+			return err
+		}
+		byEpisode := make(map[uuid.UUID][]sqlc.EpisodeAsset, len(episodes))
+		for _, a := range assets {
+			byEpisode[a.EpisodeID] = append(byEpisode[a.EpisodeID], a)
+		}
+		for _, ep := range episodes {
+			if err := outbox.EnqueueIndexEpisode(ctx, ep, byEpisode[ep.ID]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 	if err != nil {
-		response.HandleDBError(ctx, w, err, "We couldn't delete the series.")
+		if errors.Is(err, errCategoryDeleted) {
+			response.RespondWithError(ctx, w, http.StatusConflict, "The category of the series is deleted, restore the category first.")
+			return
+		}
+		response.HandleDBError(ctx, w, err, "Deleted series not found.")
 		return
 	}
 
-	if err := h.q.EnqueueDeleteSeries(ctx, seriesID.String()); err != nil {
-		slog.ErrorContext(ctx, "failed to enqueue delete series task", "err", err, "series_id", seriesID)
+	response.RespondWithJSON(ctx, w, http.StatusOK, mapping.Series(dbSeries))
+}
+
+// getDeletionJob godoc
+// @Summary      Get deletion job by ID
+// @Description  Get the status, counts and errors of the job that deletes the episodes and assets of a deleted series
+// @Tags         Series
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Deletion job ID"
+// @Success      200  {object}  v1.DeletionJobResponse
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /deletions/{id} [get]
+func (h *Handler) getDeletionJob(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	idParam := chi.URLParam(r, "id")
+	if idParam == "" {
+		response.RespondWithError(ctx, w, http.StatusBadRequest, "Deletion job ID is required.")
+		return
+	}
+
+	jobID, err := uuid.Parse(idParam)
+	if err != nil {
+		response.RespondWithError(ctx, w, http.StatusBadRequest, "Invalid deletion job ID format.")
+		return
+	}
+
+	job, err := h.s.Queries.GetDeletionJob(ctx, jobID)
+	if err != nil {
+		response.HandleDBError(ctx, w, err, "Deletion job not found.")
+		return
 	}
 
-	w.WriteHeader(http.StatusNoContent)
+	response.RespondWithJSON(ctx, w, http.StatusOK, mapping.DeletionJob(job))
 }
@@ -10,6 +10,8 @@ import (
 	"testing"
 	"time"
 
+	v1 "th-application-technical-assignment/pkg/api/cms/v1"
+	"th-application-technical-assignment/pkg/audit"
 	"th-application-technical-assignment/pkg/database"
 	"th-application-technical-assignment/pkg/tasks"
 	"th-application-technical-assignment/sqlc"
@@ -29,8 +31,11 @@ func TestHandler_postSeries(t *testing.T) {
 		name           string
 		requestBody    map[string]any
 		mockSeries     sqlc.Series
+		takenSlugs     []string
+		expectedSlug   string
 		dbError        error
-		queueError     error
+		categoryError  error
+		outboxError    error
 		expectedStatus int
 		expectError    bool
 	}{
@@ -50,9 +55,32 @@ func TestHandler_postSeries(t *testing.T) {
 				CategoryID:  uuid.New(),
 				Language:    stringPtr("en"),
 				SeriesType:  "podcast",
+				Slug:        "tech-talk-podcast",
 				CreatedAt:   time.Now(),
 				UpdatedAt:   time.Now(),
 			},
+			expectedSlug:   "tech-talk-podcast",
+			expectedStatus: http.StatusCreated,
+			expectError:    false,
+		},
+		{
+			name: "taken slug gets a numbered suffix",
+			requestBody: map[string]any{
+				"title":       "Tech Talk Podcast",
+				"category_id": uuid.New().String(),
+				"type":        "podcast",
+			},
+			mockSeries: sqlc.Series{
+				ID:         uuid.New(),
+				Title:      "Tech Talk Podcast",
+				CategoryID: uuid.New(),
+				SeriesType: "podcast",
+				Slug:       "tech-talk-podcast-3",
+				CreatedAt:  time.Now(),
+				UpdatedAt:  time.Now(),
+			},
+			takenSlugs:     []string{"tech-talk-podcast", "tech-talk-podcast-2"},
+			expectedSlug:   "tech-talk-podcast-3",
 			expectedStatus: http.StatusCreated,
 			expectError:    false,
 		},
@@ -68,9 +96,11 @@ func TestHandler_postSeries(t *testing.T) {
 				Title:      "Minimal Series",
 				CategoryID: uuid.New(),
 				SeriesType: "documentary",
+				Slug:       "minimal-series",
 				CreatedAt:  time.Now(),
 				UpdatedAt:  time.Now(),
 			},
+			expectedSlug:   "minimal-series",
 			expectedStatus: http.StatusCreated,
 			expectError:    false,
 		},
@@ -122,12 +152,24 @@ func TestHandler_postSeries(t *testing.T) {
 				"category_id": uuid.New().String(),
 				"type":        "podcast",
 			},
+			expectedSlug:   "test-series",
 			dbError:        assert.AnError,
 			expectedStatus: http.StatusInternalServerError,
 			expectError:    true,
 		},
 		{
-			name: "queue error - should still succeed",
+			name: "deleted category",
+			requestBody: map[string]any{
+				"title":       "Test Series",
+				"category_id": uuid.New().String(),
+				"type":        "podcast",
+			},
+			categoryError:  sql.ErrNoRows,
+			expectedStatus: http.StatusConflict,
+			expectError:    true,
+		},
+		{
+			name: "outbox error - series is rolled back",
 			requestBody: map[string]any{
 				"title":       "Test Series",
 				"category_id": uuid.New().String(),
@@ -141,9 +183,10 @@ func TestHandler_postSeries(t *testing.T) {
 				CreatedAt:  time.Now(),
 				UpdatedAt:  time.Now(),
 			},
-			queueError:     assert.AnError,
-			expectedStatus: http.StatusCreated,
-			expectError:    false,
+			expectedSlug:   "test-series",
+			outboxError:    assert.AnError,
+			expectedStatus: http.StatusInternalServerError,
+			expectError:    true,
 		},
 	}
 
@@ -161,23 +204,37 @@ func TestHandler_postSeries(t *testing.T) {
 				q: mockQueue,
 			}
 
-			if !tt.expectError || tt.dbError != nil {
+			if !tt.expectError || tt.dbError != nil || tt.outboxError != nil || tt.categoryError != nil {
+				categoryID := uuid.MustParse(tt.requestBody["category_id"].(string))
+				mockQueries.On("GetCategoryForShare", mock.Anything, categoryID).Return(sqlc.Category{ID: categoryID}, tt.categoryError)
+			}
+			if !tt.expectError || tt.dbError != nil || tt.outboxError != nil {
+				taken := tt.takenSlugs
+				if taken == nil {
+					taken = []string{}
+				}
+				mockQueries.On("ListSeriesSlugs", mock.Anything, mock.Anything).Return(taken, nil)
 				if tt.dbError != nil {
 					mockQueries.On("CreateSeries", mock.Anything, mock.AnythingOfType("sqlc.CreateSeriesParams")).
 						Return(sqlc.Series{}, tt.dbError)
 				} else {
 					mockQueries.On("CreateSeries", mock.Anything, mock.MatchedBy(func(params sqlc.CreateSeriesParams) bool {
 						return params.Title == tt.requestBody["title"].(string) &&
-							params.SeriesType == tt.requestBody["type"].(string)
+							params.SeriesType == tt.requestBody["type"].(string) &&
+							params.Slug == tt.expectedSlug
 					})).Return(tt.mockSeries, nil)
-
-					if tt.queueError != nil {
-						mockQueue.On("EnqueueIndexSeries", mock.Anything, tt.mockSeries).
-							Return(tt.queueError)
-					} else {
-						mockQueue.On("EnqueueIndexSeries", mock.Anything, tt.mockSeries).
-							Return(nil)
-					}
+					mockQueries.On("CreateAuditEvent", mock.Anything, mock.MatchedBy(func(params sqlc.CreateAuditEventParams) bool {
+						return params.Action == audit.ActionCreate && params.EntityType == audit.EntitySeries && params.EntityID == tt.mockSeries.ID
+					})).Return(nil)
+					mockQueries.On("CreateSeriesRevision", mock.Anything, mock.MatchedBy(func(params sqlc.CreateSeriesRevisionParams) bool {
+						return params.ID == tt.mockSeries.ID && params.RollbackOf == nil
+					})).Return(sqlc.ContentRevision{Revision: 2}, nil)
+
+					mockQueries.On("ListCategoryPath", mock.Anything, mock.Anything).Return([]uuid.UUID{}, nil)
+					mockQueries.On("ListSeriesTranslations", mock.Anything, mock.Anything).Return([]sqlc.SeriesTranslation{}, nil)
+					mockQueries.On("CreateOutboxEvent", mock.Anything, mock.MatchedBy(func(params sqlc.CreateOutboxEventParams) bool {
+						return params.TaskType == tasks.TypeIndexSeries
+					})).Return(tt.outboxError)
 				}
 			}
 
@@ -204,6 +261,7 @@ func TestHandler_postSeries(t *testing.T) {
 				assert.Contains(t, response, "type")
 				assert.Equal(t, tt.mockSeries.Title, response["title"])
 				assert.Equal(t, tt.mockSeries.SeriesType, response["type"])
+				assert.Equal(t, tt.expectedSlug, response["slug"])
 			}
 
 			mockQueries.AssertExpectations(t)
@@ -307,6 +365,91 @@ func TestHandler_getSeries(t *testing.T) {
 	}
 }
 
+func TestHandler_getSeriesBySlug(t *testing.T) {
+	t.Parallel()
+
+	series := sqlc.Series{
+		ID:            uuid.New(),
+		Title:         "Tech Talk",
+		SeriesType:    "podcast",
+		Slug:          "tech-talk",
+		PreviousSlugs: []string{"tech-talk-podcast"},
+	}
+
+	tests := []struct {
+		name             string
+		slug             string
+		setup            func(m *database.MockQuerier)
+		expectedStatus   int
+		expectedLocation string
+	}{
+		{
+			name: "current slug",
+			slug: "tech-talk",
+			setup: func(m *database.MockQuerier) {
+				m.On("GetSeriesBySlug", mock.Anything, "tech-talk").Return(series, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "previous slug redirects to the current one",
+			slug: "tech-talk-podcast",
+			setup: func(m *database.MockQuerier) {
+				m.On("GetSeriesBySlug", mock.Anything, "tech-talk-podcast").Return(sqlc.Series{}, sql.ErrNoRows)
+				m.On("GetSeriesByPreviousSlug", mock.Anything, "tech-talk-podcast").Return(series, nil)
+			},
+			expectedStatus:   http.StatusMovedPermanently,
+			expectedLocation: "/v1/series/by-slug/tech-talk",
+		},
+		{
+			name: "unknown slug",
+			slug: "unknown",
+			setup: func(m *database.MockQuerier) {
+				m.On("GetSeriesBySlug", mock.Anything, "unknown").Return(sqlc.Series{}, sql.ErrNoRows)
+				m.On("GetSeriesByPreviousSlug", mock.Anything, "unknown").Return(sqlc.Series{}, sql.ErrNoRows)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name: "database error",
+			slug: "tech-talk",
+			setup: func(m *database.MockQuerier) {
+				m.On("GetSeriesBySlug", mock.Anything, "tech-talk").Return(sqlc.Series{}, assert.AnError)
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockQueries := new(database.MockQuerier)
+			handler := &Handler{s: &database.Store{Queries: mockQueries}, v: validator.New()}
+			tt.setup(mockQueries)
+
+			req := httptest.NewRequest(http.MethodGet, "/v1/series/by-slug/"+tt.slug, nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("slug", tt.slug)
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			recorder := httptest.NewRecorder()
+
+			handler.getSeriesBySlug(recorder, req)
+
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+			assert.Equal(t, tt.expectedLocation, recorder.Header().Get("Location"))
+			if tt.expectedStatus == http.StatusOK {
+				var res v1.SeriesResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+				assert.Equal(t, series.ID.String(), res.ID)
+				assert.Equal(t, "tech-talk", res.Slug)
+			}
+
+			mockQueries.AssertExpectations(t)
+		})
+	}
+}
+
 func TestHandler_putSeries(t *testing.T) {
 	t.Parallel()
 
@@ -315,8 +458,11 @@ func TestHandler_putSeries(t *testing.T) {
 		seriesID       string
 		requestBody    map[string]any
 		mockSeries     sqlc.Series
+		episodes       []sqlc.Episode
+		expectedSlug   string
 		dbError        error
-		queueError     error
+		categoryError  error
+		outboxError    error
 		expectedStatus int
 		expectError    bool
 	}{
@@ -337,9 +483,34 @@ func TestHandler_putSeries(t *testing.T) {
 				CategoryID:  uuid.New(),
 				Language:    stringPtr("en"),
 				SeriesType:  "podcast",
+				Slug:        "updated-tech-talk",
+				CreatedAt:   time.Now(),
+				UpdatedAt:   time.Now(),
+			},
+			// the language changed, the episodes are indexed again
+			episodes:       []sqlc.Episode{{ID: uuid.New(), Title: "Pilot"}, {ID: uuid.New(), Title: "Finale"}},
+			expectedSlug:   "updated-tech-talk",
+			expectedStatus: http.StatusOK,
+			expectError:    false,
+		},
+		{
+			name:     "unchanged title keeps the slug",
+			seriesID: uuid.New().String(),
+			requestBody: map[string]any{
+				"title":       "Old Title",
+				"description": "Only the description changed",
+				"type":        "podcast",
+			},
+			mockSeries: sqlc.Series{
+				ID:          uuid.New(),
+				Title:       "Old Title",
+				Description: stringPtr("Only the description changed"),
+				SeriesType:  "podcast",
+				Slug:        "old-title-2",
 				CreatedAt:   time.Now(),
 				UpdatedAt:   time.Now(),
 			},
+			expectedSlug:   "old-title-2",
 			expectedStatus: http.StatusOK,
 			expectError:    false,
 		},
@@ -360,6 +531,18 @@ func TestHandler_putSeries(t *testing.T) {
 			expectedStatus: http.StatusBadRequest,
 			expectError:    true,
 		},
+		{
+			name:     "deleted category",
+			seriesID: uuid.New().String(),
+			requestBody: map[string]any{
+				"title":       "Test Series",
+				"category_id": uuid.New().String(),
+				"type":        "podcast",
+			},
+			categoryError:  sql.ErrNoRows,
+			expectedStatus: http.StatusConflict,
+			expectError:    true,
+		},
 		{
 			name:     "database error",
 			seriesID: uuid.New().String(),
@@ -367,6 +550,7 @@ func TestHandler_putSeries(t *testing.T) {
 				"title": "Test Series",
 				"type":  "podcast",
 			},
+			expectedSlug:   "test-series",
 			dbError:        assert.AnError,
 			expectedStatus: http.StatusInternalServerError,
 			expectError:    true,
@@ -386,18 +570,52 @@ func TestHandler_putSeries(t *testing.T) {
 				q: mockQueue,
 			}
 
+			if categoryID, ok := tt.requestBody["category_id"].(string); ok {
+				mockQueries.On("GetCategoryForShare", mock.Anything, uuid.MustParse(categoryID)).
+					Return(sqlc.Category{ID: uuid.MustParse(categoryID)}, tt.categoryError)
+			}
 			if tt.seriesID != "invalid-uuid" && (!tt.expectError || tt.dbError != nil) {
 				seriesUUID, _ := uuid.Parse(tt.seriesID)
 
+				mockQueries.On("GetSeries", mock.Anything, seriesUUID).
+					Return(sqlc.Series{ID: seriesUUID, Title: "Old Title", Slug: "old-title-2"}, nil)
+				if tt.requestBody["title"] != "Old Title" {
+					mockQueries.On("ListSeriesSlugs", mock.Anything, tt.expectedSlug).Return([]string{}, nil)
+				}
+
 				if tt.dbError != nil {
 					mockQueries.On("UpdateSeries", mock.Anything, mock.AnythingOfType("sqlc.UpdateSeriesParams")).
 						Return(sqlc.Series{}, tt.dbError)
 				} else {
 					mockQueries.On("UpdateSeries", mock.Anything, mock.MatchedBy(func(params sqlc.UpdateSeriesParams) bool {
-						return params.ID == seriesUUID && params.Title == tt.requestBody["title"].(string)
+						return params.ID == seriesUUID && params.Title == tt.requestBody["title"].(string) &&
+							params.Slug == tt.expectedSlug
 					})).Return(tt.mockSeries, nil)
-
-					mockQueue.On("EnqueueIndexSeries", mock.Anything, tt.mockSeries).Return(tt.queueError)
+					mockQueries.On("CreateAuditEvent", mock.Anything, mock.MatchedBy(func(params sqlc.CreateAuditEventParams) bool {
+						return params.Action == audit.ActionUpdate && params.EntityType == audit.EntitySeries && params.EntityID == seriesUUID
+					})).Return(nil)
+					mockQueries.On("CreateSeriesRevision", mock.Anything, mock.MatchedBy(func(params sqlc.CreateSeriesRevisionParams) bool {
+						return params.ID == seriesUUID && params.RollbackOf == nil
+					})).Return(sqlc.ContentRevision{Revision: 2}, nil)
+
+					mockQueries.On("ListCategoryPath", mock.Anything, mock.Anything).Return([]uuid.UUID{}, nil)
+					mockQueries.On("ListSeriesTranslations", mock.Anything, mock.Anything).Return([]sqlc.SeriesTranslation{}, nil)
+					mockQueries.On("CreateOutboxEvent", mock.Anything, mock.MatchedBy(func(params sqlc.CreateOutboxEventParams) bool {
+						return params.TaskType == tasks.TypeIndexSeries
+					})).Return(tt.outboxError)
+
+					if tt.episodes != nil {
+						ids := []uuid.UUID{tt.episodes[0].ID, tt.episodes[1].ID}
+						mockQueries.On("ListEpisodesBySeries", mock.Anything, seriesUUID).Return(tt.episodes, nil)
+						mockQueries.On("ListAssetsByEpisodes", mock.Anything, ids).Return([]sqlc.EpisodeAsset{}, nil)
+						mockQueries.On("ListSeriesLanguages", mock.Anything, mock.Anything).Return([]sqlc.ListSeriesLanguagesRow{}, nil)
+						mockQueries.On("ListEpisodeTranslations", mock.Anything, ids).Return([]sqlc.EpisodeTranslation{}, nil)
+						mockQueries.On("CreateOutboxEvent", mock.Anything, mock.MatchedBy(func(params sqlc.CreateOutboxEventParams) bool {
+							var payload tasks.IndexEpisodesPayload
+							return params.TaskType == tasks.TypeIndexEpisodes &&
+								json.Unmarshal(params.Payload, &payload) == nil && len(payload.Episodes) == 2
+						})).Return(nil).Once()
+					}
 				}
 			}
 
@@ -421,6 +639,7 @@ func TestHandler_putSeries(t *testing.T) {
 				require.NoError(t, err)
 
 				assert.Equal(t, tt.mockSeries.Title, response["title"])
+				assert.Equal(t, tt.expectedSlug, response["slug"])
 			}
 
 			mockQueries.AssertExpectations(t)
@@ -435,15 +654,25 @@ func TestHandler_deleteSeries(t *testing.T) {
 	tests := []struct {
 		name           string
 		seriesID       string
+		query          string
+		deleteObjects  bool
 		dbError        error
-		queueError     error
+		outboxError    error
 		expectedStatus int
 		expectError    bool
 	}{
 		{
 			name:           "successful series deletion",
 			seriesID:       uuid.New().String(),
-			expectedStatus: http.StatusNoContent,
+			expectedStatus: http.StatusAccepted,
+			expectError:    false,
+		},
+		{
+			name:           "successful series deletion with stored objects",
+			seriesID:       uuid.New().String(),
+			query:          "?delete_objects=true",
+			deleteObjects:  true,
+			expectedStatus: http.StatusAccepted,
 			expectError:    false,
 		},
 		{
@@ -452,6 +681,13 @@ func TestHandler_deleteSeries(t *testing.T) {
 			expectedStatus: http.StatusBadRequest,
 			expectError:    true,
 		},
+		{
+			name:           "invalid delete_objects value",
+			seriesID:       uuid.New().String(),
+			query:          "?delete_objects=maybe",
+			expectedStatus: http.StatusBadRequest,
+			expectError:    true,
+		},
 		{
 			name:           "database error",
 			seriesID:       uuid.New().String(),
@@ -460,11 +696,11 @@ func TestHandler_deleteSeries(t *testing.T) {
 			expectError:    true,
 		},
 		{
-			name:           "queue error - should still succeed",
+			name:           "outbox error - deletion is rolled back",
 			seriesID:       uuid.New().String(),
-			queueError:     assert.AnError,
-			expectedStatus: http.StatusNoContent,
-			expectError:    false,
+			outboxError:    assert.AnError,
+			expectedStatus: http.StatusInternalServerError,
+			expectError:    true,
 		},
 	}
 
@@ -481,27 +717,48 @@ func TestHandler_deleteSeries(t *testing.T) {
 				q: mockQueue,
 			}
 
-			if tt.seriesID != "invalid-uuid" {
-				seriesUUID, _ := uuid.Parse(tt.seriesID)
+			seriesUUID, _ := uuid.Parse(tt.seriesID)
+			jobID := uuid.New()
+			if tt.expectedStatus != http.StatusBadRequest {
+				mockQueries.On("GetSeries", mock.Anything, seriesUUID).
+					Return(sqlc.Series{ID: seriesUUID, Title: "Test Series"}, nil)
 
+				deletedAt := time.Now()
 				if tt.dbError != nil {
 					mockQueries.On("DeleteSeries", mock.Anything, seriesUUID).
-						Return(tt.dbError)
+						Return((*time.Time)(nil), tt.dbError)
 				} else {
 					mockQueries.On("DeleteSeries", mock.Anything, seriesUUID).
-						Return(nil)
-
-					if tt.queueError != nil {
-						mockQueue.On("EnqueueDeleteSeries", mock.Anything, tt.seriesID).
-							Return(tt.queueError)
-					} else {
-						mockQueue.On("EnqueueDeleteSeries", mock.Anything, tt.seriesID).
-							Return(nil)
+						Return(&deletedAt, nil)
+					mockQueries.On("CreateAuditEvent", mock.Anything, mock.MatchedBy(func(params sqlc.CreateAuditEventParams) bool {
+						return params.Action == audit.ActionDelete && params.EntityType == audit.EntitySeries && params.EntityID == seriesUUID
+					})).Return(nil)
+					mockQueries.On("CreateDeletionJob", mock.Anything, sqlc.CreateDeletionJobParams{
+						SeriesID:      seriesUUID,
+						DeleteObjects: tt.deleteObjects,
+					}).Return(sqlc.DeletionJob{
+						ID:            jobID,
+						SeriesID:      seriesUUID,
+						DeleteObjects: tt.deleteObjects,
+						Status:        tasks.DeletionJobQueued,
+						Errors:        []byte("[]"),
+					}, nil)
+
+					mockQueries.On("CreateOutboxEvent", mock.Anything, mock.MatchedBy(func(params sqlc.CreateOutboxEventParams) bool {
+						// the delete is versioned with the deletion time of the row
+						var payload tasks.DeleteSeriesPayload
+						return params.TaskType == tasks.TypeDeleteSeries &&
+							json.Unmarshal(params.Payload, &payload) == nil && payload.DeletedAt.Equal(deletedAt)
+					})).Return(tt.outboxError)
+					if tt.outboxError == nil {
+						mockQueries.On("CreateOutboxEvent", mock.Anything, mock.MatchedBy(func(params sqlc.CreateOutboxEventParams) bool {
+							return params.TaskType == tasks.TypeDeleteSeriesContent
+						})).Return(nil)
 					}
 				}
 			}
 
-			req := httptest.NewRequest(http.MethodDelete, "/series/"+tt.seriesID, nil)
+			req := httptest.NewRequest(http.MethodDelete, "/series/"+tt.seriesID+tt.query, nil)
 
 			rctx := chi.NewRouteContext()
 			rctx.URLParams.Add("id", tt.seriesID)
@@ -514,7 +771,14 @@ func TestHandler_deleteSeries(t *testing.T) {
 			assert.Equal(t, tt.expectedStatus, recorder.Code)
 
 			if !tt.expectError {
-				assert.Empty(t, recorder.Body.String())
+				var res v1.DeletionJobResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+
+				assert.Equal(t, jobID.String(), res.ID)
+				assert.Equal(t, tt.seriesID, res.SeriesID)
+				assert.Equal(t, tasks.DeletionJobQueued, res.Status)
+				assert.Equal(t, tt.deleteObjects, res.DeleteObjects)
 			}
 
 			mockQueries.AssertExpectations(t)
@@ -522,3 +786,235 @@ func TestHandler_deleteSeries(t *testing.T) {
 		})
 	}
 }
+
+func TestHandler_getDeletionJob(t *testing.T) {
+	t.Parallel()
+
+	startedAt := time.Now().Add(-time.Minute)
+	finishedAt := time.Now()
+
+	tests := []struct {
+		name           string
+		jobID          string
+		mockJob        sqlc.DeletionJob
+		dbError        error
+		expectedStatus int
+		expectError    bool
+	}{
+		{
+			name:  "successful job retrieval",
+			jobID: uuid.New().String(),
+			mockJob: sqlc.DeletionJob{
+				ID:            uuid.New(),
+				SeriesID:      uuid.New(),
+				RequestedBy:   "admin@example.com",
+				DeleteObjects: true,
+				Status:        tasks.DeletionJobFailed,
+				EpisodeCount:  12,
+				AssetCount:    20,
+				ObjectCount:   7,
+				Errors:        []byte(`[{"asset_id":"a1","key":"episodes/e1/video.mp4","error":"access denied"}]`),
+				StartedAt:     &startedAt,
+				FinishedAt:    &finishedAt,
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "invalid job ID",
+			jobID:          "invalid-uuid",
+			expectedStatus: http.StatusBadRequest,
+			expectError:    true,
+		},
+		{
+			name:           "job not found",
+			jobID:          uuid.New().String(),
+			dbError:        sql.ErrNoRows,
+			expectedStatus: http.StatusNotFound,
+			expectError:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockQueries := new(database.MockQuerier)
+			handler := &Handler{
+				s: &database.Store{Queries: mockQueries},
+				v: validator.New(),
+			}
+
+			if tt.jobID != "invalid-uuid" {
+				jobUUID, _ := uuid.Parse(tt.jobID)
+				mockQueries.On("GetDeletionJob", mock.Anything, jobUUID).Return(tt.mockJob, tt.dbError)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/deletions/"+tt.jobID, nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", tt.jobID)
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			recorder := httptest.NewRecorder()
+
+			handler.getDeletionJob(recorder, req)
+
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+
+			if !tt.expectError {
+				var res v1.DeletionJobResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+
+				assert.Equal(t, tt.mockJob.ID.String(), res.ID)
+				assert.Equal(t, tt.mockJob.SeriesID.String(), res.SeriesID)
+				assert.Equal(t, tasks.DeletionJobFailed, res.Status)
+				assert.True(t, res.DeleteObjects)
+				assert.Equal(t, int32(12), res.EpisodeCount)
+				assert.Equal(t, int32(20), res.AssetCount)
+				assert.Equal(t, int32(7), res.ObjectCount)
+				require.Len(t, res.Errors, 1)
+				assert.Equal(t, "episodes/e1/video.mp4", res.Errors[0].Key)
+				assert.Equal(t, "access denied", res.Errors[0].Error)
+				assert.NotNil(t, res.StartedAt)
+				assert.NotNil(t, res.FinishedAt)
+			}
+
+			mockQueries.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_restoreSeries(t *testing.T) {
+	t.Parallel()
+
+	deletedAt := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name           string
+		seriesID       string
+		getError       error
+		categoryError  error
+		outboxError    error
+		expectedStatus int
+	}{
+		{
+			name:           "successful series restore",
+			seriesID:       uuid.New().String(),
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "invalid series ID",
+			seriesID:       "invalid-uuid",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "series not deleted",
+			seriesID:       uuid.New().String(),
+			getError:       sql.ErrNoRows,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "category deleted",
+			seriesID:       uuid.New().String(),
+			categoryError:  sql.ErrNoRows,
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "outbox error - restore is rolled back",
+			seriesID:       uuid.New().String(),
+			outboxError:    assert.AnError,
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockQueries := new(database.MockQuerier)
+			handler := &Handler{
+				s: &database.Store{Queries: mockQueries},
+				v: validator.New(),
+			}
+
+			if tt.seriesID != "invalid-uuid" {
+				seriesUUID, _ := uuid.Parse(tt.seriesID)
+				categoryUUID := uuid.New()
+				deleted := sqlc.Series{ID: seriesUUID, Title: "Test Series", CategoryID: categoryUUID, DeletedAt: &deletedAt}
+				restored := sqlc.Series{ID: seriesUUID, Title: "Test Series", CategoryID: categoryUUID}
+				episodes := []sqlc.Episode{
+					{ID: uuid.New(), SeriesID: seriesUUID, Title: "Episode 1"},
+					{ID: uuid.New(), SeriesID: seriesUUID, Title: "Episode 2"},
+				}
+				ids := []uuid.UUID{episodes[0].ID, episodes[1].ID}
+				asset := sqlc.EpisodeAsset{ID: uuid.New(), EpisodeID: episodes[0].ID, AssetType: "video"}
+
+				mockQueries.On("GetDeletedSeries", mock.Anything, seriesUUID).Return(deleted, tt.getError)
+				if tt.getError == nil {
+					mockQueries.On("GetCategoryForShare", mock.Anything, categoryUUID).Return(sqlc.Category{ID: categoryUUID}, tt.categoryError)
+				}
+				if tt.getError == nil && tt.categoryError == nil {
+					mockQueries.On("RestoreSeries", mock.Anything, seriesUUID).Return(restored, nil)
+					mockQueries.On("RestoreEpisodesBySeries", mock.Anything, sqlc.RestoreEpisodesBySeriesParams{
+						SeriesID:  seriesUUID,
+						DeletedAt: &deletedAt,
+					}).Return(episodes, nil)
+					mockQueries.On("CreateAuditEvent", mock.Anything, mock.MatchedBy(func(params sqlc.CreateAuditEventParams) bool {
+						return params.Action == audit.ActionRestore && params.EntityType == audit.EntitySeries && params.EntityID == seriesUUID
+					})).Return(nil)
+					mockQueries.On("CreateAuditEvent", mock.Anything, mock.MatchedBy(func(params sqlc.CreateAuditEventParams) bool {
+						return params.Action == audit.ActionRestore && params.EntityType == audit.EntityEpisode
+					})).Return(nil).Times(2)
+					mockQueries.On("CreateSeriesRevision", mock.Anything, mock.MatchedBy(func(params sqlc.CreateSeriesRevisionParams) bool {
+						return params.ID == seriesUUID && params.RollbackOf == nil
+					})).Return(sqlc.ContentRevision{Revision: 3}, nil)
+					for _, ep := range episodes {
+						mockQueries.On("CreateEpisodeRevision", mock.Anything, mock.MatchedBy(func(params sqlc.CreateEpisodeRevisionParams) bool {
+							return params.ID == ep.ID && params.RollbackOf == nil
+						})).Return(sqlc.ContentRevision{Revision: 2}, nil).Once()
+					}
+					mockQueries.On("ListCategoryPath", mock.Anything, mock.Anything).Return([]uuid.UUID{}, nil)
+					mockQueries.On("ListSeriesTranslations", mock.Anything, mock.Anything).Return([]sqlc.SeriesTranslation{}, nil)
+					mockQueries.On("CreateOutboxEvent", mock.Anything, mock.MatchedBy(func(params sqlc.CreateOutboxEventParams) bool {
+						return params.TaskType == tasks.TypeIndexSeries
+					})).Return(tt.outboxError)
+				}
+				if tt.getError == nil && tt.categoryError == nil && tt.outboxError == nil {
+					mockQueries.On("RestoreAssetsByEpisodes", mock.Anything, sqlc.RestoreAssetsByEpisodesParams{
+						EpisodeIds: ids,
+						DeletedAt:  &deletedAt,
+					}).Return([]sqlc.EpisodeAsset{asset}, nil)
+					mockQueries.On("CreateAuditEvent", mock.Anything, mock.MatchedBy(func(params sqlc.CreateAuditEventParams) bool {
+						return params.Action == audit.ActionRestore && params.EntityType == audit.EntityAsset && params.EntityID == asset.ID
+					})).Return(nil)
+					mockQueries.On("ListAssetsByEpisodes", mock.Anything, ids).Return([]sqlc.EpisodeAsset{asset}, nil)
+					mockQueries.On("ListSeriesLanguages", mock.Anything, mock.Anything).Return([]sqlc.ListSeriesLanguagesRow{}, nil)
+					mockQueries.On("ListEpisodeTranslations", mock.Anything, mock.Anything).Return([]sqlc.EpisodeTranslation{}, nil)
+					mockQueries.On("CreateOutboxEvent", mock.Anything, mock.MatchedBy(func(params sqlc.CreateOutboxEventParams) bool {
+						return params.TaskType == tasks.TypeIndexEpisode
+					})).Return(nil).Times(2)
+				}
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/series/"+tt.seriesID+"/restore", nil)
+
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", tt.seriesID)
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+			recorder := httptest.NewRecorder()
+
+			handler.restoreSeries(recorder, req)
+
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+
+			if tt.expectedStatus == http.StatusOK {
+				var res v1.SeriesResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				assert.Equal(t, tt.seriesID, res.ID)
+			}
+
+			mockQueries.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,155 @@
+package cms
+
+import (
+	"context"
+	"net/http"
+	"th-application-technical-assignment/internal/response"
+	v1 "th-application-technical-assignment/pkg/api/cms/v1"
+	"th-application-technical-assignment/pkg/mapping"
+	"th-application-technical-assignment/pkg/validation"
+	"th-application-technical-assignment/sqlc"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// getSeriesSubscription godoc
+// @Summary      Get the subscription of a series
+// @Description  Get the external source a series is synced from, together with the result of its last sync
+// @Tags         Import
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Series ID"
+// @Success      200  {object}  v1.SubscriptionResponse
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /series/{id}/subscription [get]
+func (h *Handler) getSeriesSubscription(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	seriesID, ok := seriesIDParam(ctx, w, r)
+	if !ok {
+		return
+	}
+
+	sub, err := h.s.Queries.GetSeriesSubscription(ctx, seriesID)
+	if err != nil {
+		response.HandleDBError(ctx, w, err, "Subscription not found.")
+		return
+	}
+
+	h.respondWithSubscription(ctx, w, http.StatusOK, sub)
+}
+
+// putSeriesSubscription godoc
+// @Summary      Subscribe a series to an external source
+// @Description  Create or replace the subscription of a series. The source is re-imported every interval_seconds, starting with the next scheduler run.
+// @Tags         Import
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id            path      string                  true  "Series ID"
+// @Param        subscription  body      v1.SubscriptionRequest  true  "Subscription data"
+// @Success      200           {object}  v1.SubscriptionResponse
+// @Failure      400           {object}  map[string]string
+// @Failure      401           {object}  map[string]string
+// @Failure      403           {object}  map[string]string
+// @Failure      404           {object}  map[string]string
+// @Failure      500           {object}  map[string]string
+// @Router       /series/{id}/subscription [put]
+func (h *Handler) putSeriesSubscription(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	seriesID, ok := seriesIDParam(ctx, w, r)
+	if !ok {
+		return
+	}
+
+	req, err := validation.DecodeAndValidate[v1.SubscriptionRequest](r, h.v)
+	if err != nil {
+		response.RespondWithError(ctx, w, http.StatusBadRequest, "Invalid request: "+err.Error())
+		return
+	}
+
+	if _, err := h.s.Queries.GetSeries(ctx, seriesID); err != nil {
+		response.HandleDBError(ctx, w, err, "Series not found.")
+		return
+	}
+
+	sub, err := h.s.Queries.UpsertSeriesSubscription(ctx, sqlc.UpsertSeriesSubscriptionParams{
+		SeriesID:        seriesID,
+		SourceType:      req.SourceType,
+		SourceUrl:       req.SourceURL,
+		IntervalSeconds: req.IntervalSeconds,
+	})
+	if err != nil {
+		response.HandleDBError(ctx, w, err, "We couldn't save the subscription.")
+		return
+	}
+
+	h.respondWithSubscription(ctx, w, http.StatusOK, sub)
+}
+
+// deleteSeriesSubscription godoc
+// @Summary      Unsubscribe a series
+// @Description  Stop syncing a series from its external source. Imported episodes are kept.
+// @Tags         Import
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Series ID"
+// @Success      204  "No Content"
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /series/{id}/subscription [delete]
+func (h *Handler) deleteSeriesSubscription(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	seriesID, ok := seriesIDParam(ctx, w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.s.Queries.DeleteSeriesSubscription(ctx, seriesID); err != nil {
+		response.HandleDBError(ctx, w, err, "We couldn't delete the subscription.")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) respondWithSubscription(ctx context.Context, w http.ResponseWriter, code int, sub sqlc.SeriesSubscription) {
+	var lastJob *sqlc.ImportJob
+	if sub.LastJobID != nil {
+		job, err := h.s.Queries.GetImportJob(ctx, *sub.LastJobID)
+		if err != nil {
+			response.HandleDBError(ctx, w, err, "We couldn't retrieve the last sync.")
+			return
+		}
+		lastJob = &job
+	}
+
+	response.RespondWithJSON(ctx, w, code, mapping.Subscription(sub, lastJob))
+}
+
+func seriesIDParam(ctx context.Context, w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	idParam := chi.URLParam(r, "id")
+	if idParam == "" {
+		response.RespondWithError(ctx, w, http.StatusBadRequest, "Series ID is required.")
+		return uuid.Nil, false
+	}
+
+	seriesID, err := uuid.Parse(idParam)
+	if err != nil {
+		response.RespondWithError(ctx, w, http.StatusBadRequest, "Invalid series ID format.")
+		return uuid.Nil, false
+	}
+
+	return seriesID, true
+}
@@ -0,0 +1,313 @@
+package cms
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	v1 "th-application-technical-assignment/pkg/api/cms/v1"
+	"th-application-technical-assignment/pkg/database"
+	"th-application-technical-assignment/pkg/tasks"
+	"th-application-technical-assignment/sqlc"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler_getSeriesSubscription(t *testing.T) {
+	t.Parallel()
+
+	jobID := uuid.New()
+	syncedAt := time.Now()
+
+	tests := []struct {
+		name           string
+		seriesID       string
+		mockSub        sqlc.SeriesSubscription
+		dbError        error
+		expectedStatus int
+		expectLastSync bool
+	}{
+		{
+			name:     "subscription with last sync",
+			seriesID: uuid.New().String(),
+			mockSub: sqlc.SeriesSubscription{
+				SourceType:      "rss",
+				SourceUrl:       "https://example.com/feed.xml",
+				IntervalSeconds: 3600,
+				Etag:            stringPtr(`"abc"`),
+				LastSyncedAt:    &syncedAt,
+				LastJobID:       &jobID,
+			},
+			expectedStatus: http.StatusOK,
+			expectLastSync: true,
+		},
+		{
+			name:     "subscription never synced",
+			seriesID: uuid.New().String(),
+			mockSub: sqlc.SeriesSubscription{
+				SourceType:      "rss",
+				SourceUrl:       "https://example.com/feed.xml",
+				IntervalSeconds: 3600,
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "invalid series ID",
+			seriesID:       "invalid-uuid",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "subscription not found",
+			seriesID:       uuid.New().String(),
+			dbError:        sql.ErrNoRows,
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockQueries := new(database.MockQuerier)
+			handler := &Handler{
+				s: &database.Store{Queries: mockQueries},
+				v: validator.New(),
+			}
+
+			if tt.seriesID != "invalid-uuid" {
+				seriesID, _ := uuid.Parse(tt.seriesID)
+				tt.mockSub.SeriesID = seriesID
+				mockQueries.On("GetSeriesSubscription", mock.Anything, seriesID).Return(tt.mockSub, tt.dbError)
+			}
+			if tt.expectLastSync {
+				mockQueries.On("GetImportJob", mock.Anything, jobID).Return(sqlc.ImportJob{
+					ID:           jobID,
+					Status:       tasks.ImportJobSucceeded,
+					CreatedCount: 2,
+					Errors:       []byte(`[]`),
+				}, nil)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/series/"+tt.seriesID+"/subscription", nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", tt.seriesID)
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			recorder := httptest.NewRecorder()
+
+			handler.getSeriesSubscription(recorder, req)
+
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+
+			if tt.expectedStatus == http.StatusOK {
+				var res v1.SubscriptionResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				assert.Equal(t, tt.seriesID, res.SeriesID)
+				assert.Equal(t, tt.mockSub.SourceUrl, res.SourceURL)
+				assert.Equal(t, tt.mockSub.IntervalSeconds, res.IntervalSeconds)
+				if tt.expectLastSync {
+					require.NotNil(t, res.LastSync)
+					assert.Equal(t, jobID.String(), res.LastSync.ID)
+					assert.Equal(t, tasks.ImportJobSucceeded, res.LastSync.Status)
+					assert.Equal(t, int32(2), res.LastSync.CreatedCount)
+					require.NotNil(t, res.ETag)
+					assert.Equal(t, `"abc"`, *res.ETag)
+				} else {
+					assert.Nil(t, res.LastSync)
+				}
+			}
+
+			mockQueries.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_putSeriesSubscription(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		requestBody    map[string]any
+		seriesError    error
+		upsertError    error
+		expectedStatus int
+	}{
+		{
+			name: "successful subscription",
+			requestBody: map[string]any{
+				"source_type":      "rss",
+				"source_url":       "https://example.com/feed.xml",
+				"interval_seconds": 3600,
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "validation error - interval too short",
+			requestBody: map[string]any{
+				"source_type":      "rss",
+				"source_url":       "https://example.com/feed.xml",
+				"interval_seconds": 10,
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "validation error - invalid source_url",
+			requestBody: map[string]any{
+				"source_type":      "rss",
+				"source_url":       "not a url",
+				"interval_seconds": 3600,
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "validation error - unknown source_type",
+			requestBody: map[string]any{
+				"source_type":      "ftp",
+				"source_url":       "https://example.com/feed.xml",
+				"interval_seconds": 3600,
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "series not found",
+			requestBody: map[string]any{
+				"source_type":      "rss",
+				"source_url":       "https://example.com/feed.xml",
+				"interval_seconds": 3600,
+			},
+			seriesError:    sql.ErrNoRows,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name: "database error",
+			requestBody: map[string]any{
+				"source_type":      "rss",
+				"source_url":       "https://example.com/feed.xml",
+				"interval_seconds": 3600,
+			},
+			upsertError:    errors.New("database error"),
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockQueries := new(database.MockQuerier)
+			handler := &Handler{
+				s: &database.Store{Queries: mockQueries},
+				v: validator.New(),
+			}
+
+			seriesID := uuid.New()
+			if tt.expectedStatus != http.StatusBadRequest {
+				mockQueries.On("GetSeries", mock.Anything, seriesID).Return(sqlc.Series{ID: seriesID}, tt.seriesError)
+			}
+			if tt.expectedStatus == http.StatusOK || tt.upsertError != nil {
+				mockQueries.On("UpsertSeriesSubscription", mock.Anything, sqlc.UpsertSeriesSubscriptionParams{
+					SeriesID:        seriesID,
+					SourceType:      "rss",
+					SourceUrl:       "https://example.com/feed.xml",
+					IntervalSeconds: 3600,
+				}).Return(sqlc.SeriesSubscription{
+					SeriesID:        seriesID,
+					SourceType:      "rss",
+					SourceUrl:       "https://example.com/feed.xml",
+					IntervalSeconds: 3600,
+					NextSyncAt:      time.Now(),
+				}, tt.upsertError)
+			}
+
+			body, _ := json.Marshal(tt.requestBody)
+			req := httptest.NewRequest(http.MethodPut, "/series/"+seriesID.String()+"/subscription", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", seriesID.String())
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			recorder := httptest.NewRecorder()
+
+			handler.putSeriesSubscription(recorder, req)
+
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+
+			if tt.expectedStatus == http.StatusOK {
+				var res v1.SubscriptionResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				assert.Equal(t, seriesID.String(), res.SeriesID)
+				assert.Equal(t, "rss", res.SourceType)
+				assert.Equal(t, int32(3600), res.IntervalSeconds)
+				assert.Nil(t, res.LastSync)
+			}
+
+			mockQueries.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_deleteSeriesSubscription(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		seriesID       string
+		dbError        error
+		expectedStatus int
+	}{
+		{
+			name:           "successful deletion",
+			seriesID:       uuid.New().String(),
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:           "invalid series ID",
+			seriesID:       "invalid-uuid",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "database error",
+			seriesID:       uuid.New().String(),
+			dbError:        errors.New("database error"),
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockQueries := new(database.MockQuerier)
+			handler := &Handler{
+				s: &database.Store{Queries: mockQueries},
+				v: validator.New(),
+			}
+
+			if tt.seriesID != "invalid-uuid" {
+				seriesID, _ := uuid.Parse(tt.seriesID)
+				mockQueries.On("DeleteSeriesSubscription", mock.Anything, seriesID).Return(tt.dbError)
+			}
+
+			req := httptest.NewRequest(http.MethodDelete, "/series/"+tt.seriesID+"/subscription", nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", tt.seriesID)
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			recorder := httptest.NewRecorder()
+
+			handler.deleteSeriesSubscription(recorder, req)
+
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+			mockQueries.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,688 @@
+package cms
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"th-application-technical-assignment/internal/middleware"
+	"th-application-technical-assignment/internal/response"
+	"th-application-technical-assignment/pkg/api/cms/v1"
+	"th-application-technical-assignment/pkg/audit"
+	"th-application-technical-assignment/pkg/locale"
+	"th-application-technical-assignment/pkg/mapping"
+	"th-application-technical-assignment/pkg/tasks"
+	"th-application-technical-assignment/pkg/validation"
+	"th-application-technical-assignment/sqlc"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// listSeriesTranslations godoc
+// @Summary      List the translations of a series
+// @Description  Get the title and description of a series in every locale it is translated into, ordered by locale
+// @Tags         Translations
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Series ID"
+// @Success      200  {array}   v1.TranslationResponse
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /series/{id}/translations [get]
+func (h *Handler) listSeriesTranslations(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	seriesID, ok := seriesIDParam(ctx, w, r)
+	if !ok {
+		return
+	}
+
+	if _, err := h.s.Queries.GetSeries(ctx, seriesID); err != nil {
+		response.HandleDBError(ctx, w, err, "Series not found.")
+		return
+	}
+
+	translations, err := h.s.Queries.ListSeriesTranslations(ctx, []uuid.UUID{seriesID})
+	if err != nil {
+		response.HandleDBError(ctx, w, err, "We couldn't retrieve the translations.")
+		return
+	}
+
+	res := make([]v1.TranslationResponse, len(translations))
+	for i, t := range translations {
+		res[i] = mapping.SeriesTranslation(t)
+	}
+
+	response.RespondWithJSON(ctx, w, http.StatusOK, res)
+}
+
+// putSeriesTranslation godoc
+// @Summary      Translate a series
+// @Description  Create or replace the title and description of a series in a locale, a BCP 47 tag such as ar or en-GB. The series is indexed again with the translation.
+// @Tags         Translations
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id           path      string                       true  "Series ID"
+// @Param        locale       path      string                       true  "Locale"
+// @Param        translation  body      v1.SeriesTranslationRequest  true  "Translation data"
+// @Success      200          {object}  v1.TranslationResponse
+// @Failure      400          {object}  map[string]string
+// @Failure      401          {object}  map[string]string
+// @Failure      403          {object}  map[string]string
+// @Failure      404          {object}  map[string]string
+// @Failure      500          {object}  map[string]string
+// @Router       /series/{id}/translations/{locale} [put]
+func (h *Handler) putSeriesTranslation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	seriesID, ok := seriesIDParam(ctx, w, r)
+	if !ok {
+		return
+	}
+
+	loc, ok := localeParam(ctx, w, r)
+	if !ok {
+		return
+	}
+
+	req, err := validation.DecodeAndValidate[v1.SeriesTranslationRequest](r, h.v)
+	if err != nil {
+		response.RespondWithError(ctx, w, http.StatusBadRequest, "Invalid request: "+err.Error())
+		return
+	}
+
+	var translation sqlc.SeriesTranslation
+	err = h.s.WithTx(ctx, func(q sqlc.Querier) error {
+		dbSeries, err := q.TouchSeries(ctx, seriesID)
+		if err != nil {
+			return err
+		}
+
+		before, err := q.GetSeriesTranslation(ctx, sqlc.GetSeriesTranslationParams{SeriesID: seriesID, Locale: loc})
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+		existed := err == nil
+
+		translation, err = q.UpsertSeriesTranslation(ctx, sqlc.UpsertSeriesTranslationParams{
+			SeriesID:    seriesID,
+			Locale:      loc,
+			Title:       req.Title,
+			Description: req.Description,
+		})
+		if err != nil {
+			return err
+		}
+
+		if existed {
+			err = h.record(ctx, q, audit.ActionUpdate, audit.EntitySeriesTranslation, seriesID, before, translation)
+		} else {
+			err = h.record(ctx, q, audit.ActionCreate, audit.EntitySeriesTranslation, seriesID, nil, translation)
+		}
+		if err != nil {
+			return err
+		}
+		return tasks.NewOutboxQueue(q).EnqueueIndexSeries(ctx, dbSeries)
+	})
+	if err != nil {
+		response.HandleDBError(ctx, w, err, "Series not found.")
+		return
+	}
+
+	response.RespondWithJSON(ctx, w, http.StatusOK, mapping.SeriesTranslation(translation))
+}
+
+// deleteSeriesTranslation godoc
+// @Summary      Delete a translation of a series
+// @Description  Delete the title and description of a series in a locale. The series is indexed again without the translation.
+// @Tags         Translations
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id      path      string  true  "Series ID"
+// @Param        locale  path      string  true  "Locale"
+// @Success      204     "No Content"
+// @Failure      400     {object}  map[string]string
+// @Failure      401     {object}  map[string]string
+// @Failure      403     {object}  map[string]string
+// @Failure      404     {object}  map[string]string
+// @Failure      500     {object}  map[string]string
+// @Router       /series/{id}/translations/{locale} [delete]
+func (h *Handler) deleteSeriesTranslation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	seriesID, ok := seriesIDParam(ctx, w, r)
+	if !ok {
+		return
+	}
+
+	loc, ok := localeParam(ctx, w, r)
+	if !ok {
+		return
+	}
+
+	err := h.s.WithTx(ctx, func(q sqlc.Querier) error {
+		dbSeries, err := q.TouchSeries(ctx, seriesID)
+		if err != nil {
+			return err
+		}
+
+		translation, err := q.DeleteSeriesTranslation(ctx, sqlc.DeleteSeriesTranslationParams{SeriesID: seriesID, Locale: loc})
+		if err != nil {
+			return err
+		}
+
+		if err := h.record(ctx, q, audit.ActionDelete, audit.EntitySeriesTranslation, seriesID, translation, nil); err != nil {
+			return err
+		}
+		return tasks.NewOutboxQueue(q).EnqueueIndexSeries(ctx, dbSeries)
+	})
+	if err != nil {
+		response.HandleDBError(ctx, w, err, "Translation not found.")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// listEpisodeTranslations godoc
+// @Summary      List the translations of an episode
+// @Description  Get the title and description of an episode in every locale it is translated into, ordered by locale
+// @Tags         Translations
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Episode ID"
+// @Success      200  {array}   v1.TranslationResponse
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /series/episodes/{id}/translations [get]
+func (h *Handler) listEpisodeTranslations(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	episodeID, ok := episodeIDParam(ctx, w, r)
+	if !ok {
+		return
+	}
+
+	if _, err := h.s.Queries.GetEpisode(ctx, episodeID); err != nil {
+		response.HandleDBError(ctx, w, err, "Episode not found.")
+		return
+	}
+
+	translations, err := h.s.Queries.ListEpisodeTranslations(ctx, []uuid.UUID{episodeID})
+	if err != nil {
+		response.HandleDBError(ctx, w, err, "We couldn't retrieve the translations.")
+		return
+	}
+
+	res := make([]v1.TranslationResponse, len(translations))
+	for i, t := range translations {
+		res[i] = mapping.EpisodeTranslation(t)
+	}
+
+	response.RespondWithJSON(ctx, w, http.StatusOK, res)
+}
+
+// putEpisodeTranslation godoc
+// @Summary      Translate an episode
+// @Description  Create or replace the title and description of an episode in a locale, a BCP 47 tag such as ar or en-GB. The episode is indexed again with the translation.
+// @Tags         Translations
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id           path      string                        true  "Episode ID"
+// @Param        locale       path      string                        true  "Locale"
+// @Param        translation  body      v1.EpisodeTranslationRequest  true  "Translation data"
+// @Success      200          {object}  v1.TranslationResponse
+// @Failure      400          {object}  map[string]string
+// @Failure      401          {object}  map[string]string
+// @Failure      403          {object}  map[string]string
+// @Failure      404          {object}  map[string]string
+// @Failure      500          {object}  map[string]string
+// @Router       /series/episodes/{id}/translations/{locale} [put]
+func (h *Handler) putEpisodeTranslation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	episodeID, ok := episodeIDParam(ctx, w, r)
+	if !ok {
+		return
+	}
+
+	loc, ok := localeParam(ctx, w, r)
+	if !ok {
+		return
+	}
+
+	req, err := validation.DecodeAndValidate[v1.EpisodeTranslationRequest](r, h.v)
+	if err != nil {
+		response.RespondWithError(ctx, w, http.StatusBadRequest, "Invalid request: "+err.Error())
+		return
+	}
+
+	var translation sqlc.EpisodeTranslation
+	err = h.s.WithTx(ctx, func(q sqlc.Querier) error {
+		dbEpisode, err := q.TouchEpisode(ctx, episodeID)
+		if err != nil {
+			return err
+		}
+
+		before, err := q.GetEpisodeTranslation(ctx, sqlc.GetEpisodeTranslationParams{EpisodeID: episodeID, Locale: loc})
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+		existed := err == nil
+
+		translation, err = q.UpsertEpisodeTranslation(ctx, sqlc.UpsertEpisodeTranslationParams{
+			EpisodeID:   episodeID,
+			Locale:      loc,
+			Title:       req.Title,
+			Description: req.Description,
+		})
+		if err != nil {
+			return err
+		}
+
+		if existed {
+			err = h.record(ctx, q, audit.ActionUpdate, audit.EntityEpisodeTranslation, episodeID, before, translation)
+		} else {
+			err = h.record(ctx, q, audit.ActionCreate, audit.EntityEpisodeTranslation, episodeID, nil, translation)
+		}
+		if err != nil {
+			return err
+		}
+
+		assets, err := q.ListAssetsByEpisode(ctx, episodeID)
+		if err != nil {
+			return err
+		}
+		return tasks.NewOutboxQueue(q).EnqueueIndexEpisode(ctx, dbEpisode, assets)
+	})
+	if err != nil {
+		response.HandleDBError(ctx, w, err, "Episode not found.")
+		return
+	}
+
+	response.RespondWithJSON(ctx, w, http.StatusOK, mapping.EpisodeTranslation(translation))
+}
+
+// deleteEpisodeTranslation godoc
+// @Summary      Delete a translation of an episode
+// @Description  Delete the title and description of an episode in a locale. The episode is indexed again without the translation.
+// @Tags         Translations
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id      path      string  true  "Episode ID"
+// @Param        locale  path      string  true  "Locale"
+// @Success      204     "No Content"
+// @Failure      400     {object}  map[string]string
+// @Failure      401     {object}  map[string]string
+// @Failure      403     {object}  map[string]string
+// @Failure      404     {object}  map[string]string
+// @Failure      500     {object}  map[string]string
+// @Router       /series/episodes/{id}/translations/{locale} [delete]
+func (h *Handler) deleteEpisodeTranslation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	episodeID, ok := episodeIDParam(ctx, w, r)
+	if !ok {
+		return
+	}
+
+	loc, ok := localeParam(ctx, w, r)
+	if !ok {
+		return
+	}
+
+	err := h.s.WithTx(ctx, func(q sqlc.Querier) error {
+		dbEpisode, err := q.TouchEpisode(ctx, episodeID)
+		if err != nil {
+			return err
+		}
+
+		translation, err := q.DeleteEpisodeTranslation(ctx, sqlc.DeleteEpisodeTranslationParams{EpisodeID: episodeID, Locale: loc})
+		if err != nil {
+			return err
+		}
+
+		if err := h.record(ctx, q, audit.ActionDelete, audit.EntityEpisodeTranslation, episodeID, translation, nil); err != nil {
+			return err
+		}
+
+		assets, err := q.ListAssetsByEpisode(ctx, episodeID)
+		if err != nil {
+			return err
+		}
+		return tasks.NewOutboxQueue(q).EnqueueIndexEpisode(ctx, dbEpisode, assets)
+	})
+	if err != nil {
+		response.HandleDBError(ctx, w, err, "Translation not found.")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// listCategoryTranslations godoc
+// @Summary      List the translations of a category
+// @Description  Get the name of a category in every locale it is translated into, ordered by locale
+// @Tags         Translations
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Category ID"
+// @Success      200  {array}   v1.CategoryTranslationResponse
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /categories/{id}/translations [get]
+func (h *Handler) listCategoryTranslations(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	categoryID, ok := categoryIDParam(ctx, w, r)
+	if !ok {
+		return
+	}
+
+	if _, err := h.s.Queries.GetCategory(ctx, categoryID); err != nil {
+		response.HandleDBError(ctx, w, err, "Category not found.")
+		return
+	}
+
+	translations, err := h.s.Queries.ListCategoryTranslations(ctx, []uuid.UUID{categoryID})
+	if err != nil {
+		response.HandleDBError(ctx, w, err, "We couldn't retrieve the translations.")
+		return
+	}
+
+	res := make([]v1.CategoryTranslationResponse, len(translations))
+	for i, t := range translations {
+		res[i] = mapping.CategoryTranslation(t)
+	}
+
+	response.RespondWithJSON(ctx, w, http.StatusOK, res)
+}
+
+// putCategoryTranslation godoc
+// @Summary      Translate a category
+// @Description  Create or replace the name of a category in a locale, a BCP 47 tag such as ar or en-GB. The slug is not translated.
+// @Tags         Translations
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id           path      string                         true  "Category ID"
+// @Param        locale       path      string                         true  "Locale"
+// @Param        translation  body      v1.CategoryTranslationRequest  true  "Translation data"
+// @Success      200          {object}  v1.CategoryTranslationResponse
+// @Failure      400          {object}  map[string]string
+// @Failure      401          {object}  map[string]string
+// @Failure      403          {object}  map[string]string
+// @Failure      404          {object}  map[string]string
+// @Failure      500          {object}  map[string]string
+// @Router       /categories/{id}/translations/{locale} [put]
+func (h *Handler) putCategoryTranslation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	categoryID, ok := categoryIDParam(ctx, w, r)
+	if !ok {
+		return
+	}
+
+	loc, ok := localeParam(ctx, w, r)
+	if !ok {
+		return
+	}
+
+	req, err := validation.DecodeAndValidate[v1.CategoryTranslationRequest](r, h.v)
+	if err != nil {
+		response.RespondWithError(ctx, w, http.StatusBadRequest, "Invalid request: "+err.Error())
+		return
+	}
+
+	var translation sqlc.CategoryTranslation
+	err = h.s.WithTx(ctx, func(q sqlc.Querier) error {
+		if _, err := q.GetCategory(ctx, categoryID); err != nil {
+			return err
+		}
+
+		before, err := q.GetCategoryTranslation(ctx, sqlc.GetCategoryTranslationParams{CategoryID: categoryID, Locale: loc})
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+		existed := err == nil
+
+		translation, err = q.UpsertCategoryTranslation(ctx, sqlc.UpsertCategoryTranslationParams{
+			CategoryID: categoryID,
+			Locale:     loc,
+			Name:       req.Name,
+		})
+		if err != nil {
+			return err
+		}
+
+		if existed {
+			return h.record(ctx, q, audit.ActionUpdate, audit.EntityCategoryTranslation, categoryID, before, translation)
+		}
+		return h.record(ctx, q, audit.ActionCreate, audit.EntityCategoryTranslation, categoryID, nil, translation)
+	})
+	if err != nil {
+		response.HandleDBError(ctx, w, err, "Category not found.")
+		return
+	}
+
+	response.RespondWithJSON(ctx, w, http.StatusOK, mapping.CategoryTranslation(translation))
+}
+
+// deleteCategoryTranslation godoc
+// @Summary      Delete a translation of a category
+// @Description  Delete the name of a category in a locale
+// @Tags         Translations
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id      path      string  true  "Category ID"
+// @Param        locale  path      string  true  "Locale"
+// @Success      204     "No Content"
+// @Failure      400     {object}  map[string]string
+// @Failure      401     {object}  map[string]string
+// @Failure      403     {object}  map[string]string
+// @Failure      404     {object}  map[string]string
+// @Failure      500     {object}  map[string]string
+// @Router       /categories/{id}/translations/{locale} [delete]
+func (h *Handler) deleteCategoryTranslation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	categoryID, ok := categoryIDParam(ctx, w, r)
+	if !ok {
+		return
+	}
+
+	loc, ok := localeParam(ctx, w, r)
+	if !ok {
+		return
+	}
+
+	err := h.s.WithTx(ctx, func(q sqlc.Querier) error {
+		if _, err := q.GetCategory(ctx, categoryID); err != nil {
+			return err
+		}
+
+		translation, err := q.DeleteCategoryTranslation(ctx, sqlc.DeleteCategoryTranslationParams{CategoryID: categoryID, Locale: loc})
+		if err != nil {
+			return err
+		}
+		return h.record(ctx, q, audit.ActionDelete, audit.EntityCategoryTranslation, categoryID, translation, nil)
+	})
+	if err != nil {
+		response.HandleDBError(ctx, w, err, "Translation not found.")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// localizeSeries replaces the title and description of series with their
+// translation that suits the Accept-Language of the request better, if any.
+func (h *Handler) localizeSeries(ctx context.Context, series []v1.SeriesResponse) error {
+	preferred := middleware.GetLanguages(ctx)
+	if len(preferred) == 0 || len(series) == 0 {
+		return nil
+	}
+
+	ids, err := parseIDs(series, func(s v1.SeriesResponse) string { return s.ID })
+	if err != nil {
+		return err
+	}
+
+	translations, err := h.s.Queries.ListSeriesTranslations(ctx, ids)
+	if err != nil {
+		return err
+	}
+	bySeries := groupByID(translations, func(t sqlc.SeriesTranslation) uuid.UUID { return t.SeriesID })
+
+	for i, s := range series {
+		t, ok := locale.Pick(preferred, s.Language, bySeries[ids[i]], func(t sqlc.SeriesTranslation) string { return t.Locale })
+		if ok {
+			series[i] = mapping.LocalizedSeries(s, t)
+		}
+	}
+	return nil
+}
+
+// localizeEpisodes replaces the title and description of episodes of the
+// series with their translation that suits the Accept-Language of the
+// request better, if any. Episodes are in the language of their series.
+func (h *Handler) localizeEpisodes(ctx context.Context, seriesID uuid.UUID, episodes []v1.EpisodeResponse) error {
+	preferred := middleware.GetLanguages(ctx)
+	if len(preferred) == 0 || len(episodes) == 0 {
+		return nil
+	}
+
+	ids, err := parseIDs(episodes, func(ep v1.EpisodeResponse) string { return ep.ID })
+	if err != nil {
+		return err
+	}
+
+	translations, err := h.s.Queries.ListEpisodeTranslations(ctx, ids)
+	if err != nil || len(translations) == 0 {
+		return err
+	}
+	byEpisode := groupByID(translations, func(t sqlc.EpisodeTranslation) uuid.UUID { return t.EpisodeID })
+
+	// a series deleted in the meantime leaves the language unknown
+	dbSeries, err := h.s.Queries.GetSeries(ctx, seriesID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	for i, ep := range episodes {
+		t, ok := locale.Pick(preferred, dbSeries.Language, byEpisode[ids[i]], func(t sqlc.EpisodeTranslation) string { return t.Locale })
+		if ok {
+			episodes[i] = mapping.LocalizedEpisode(ep, t)
+		}
+	}
+	return nil
+}
+
+// localizeCategories replaces the names of categories with their
+// translation the Accept-Language of the request prefers, if any.
+func (h *Handler) localizeCategories(ctx context.Context, categories []v1.CategoryResponse) error {
+	ids, err := parseIDs(categories, func(c v1.CategoryResponse) string { return c.ID })
+	if err != nil {
+		return err
+	}
+
+	picked, err := h.categoryTranslations(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	for i, c := range categories {
+		if t, ok := picked[ids[i]]; ok {
+			categories[i] = mapping.LocalizedCategory(c, t)
+		}
+	}
+	return nil
+}
+
+// categoryTranslations returns the translations of the categories the
+// Accept-Language of the request prefers, by category. Categories have no
+// language of their own, so any translation the client accepts is preferred
+// over their name.
+func (h *Handler) categoryTranslations(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]sqlc.CategoryTranslation, error) {
+	preferred := middleware.GetLanguages(ctx)
+	if len(preferred) == 0 || len(ids) == 0 {
+		return nil, nil
+	}
+
+	translations, err := h.s.Queries.ListCategoryTranslations(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	picked := make(map[uuid.UUID]sqlc.CategoryTranslation)
+	for id, ts := range groupByID(translations, func(t sqlc.CategoryTranslation) uuid.UUID { return t.CategoryID }) {
+		if t, ok := locale.Pick(preferred, nil, ts, func(t sqlc.CategoryTranslation) string { return t.Locale }); ok {
+			picked[id] = t
+		}
+	}
+	return picked, nil
+}
+
+func groupByID[T any](items []T, id func(T) uuid.UUID) map[uuid.UUID][]T {
+	groups := make(map[uuid.UUID][]T)
+	for _, item := range items {
+		groups[id(item)] = append(groups[id(item)], item)
+	}
+	return groups
+}
+
+func parseIDs[T any](items []T, id func(T) string) ([]uuid.UUID, error) {
+	ids := make([]uuid.UUID, len(items))
+	for i, item := range items {
+		var err error
+		if ids[i], err = uuid.Parse(id(item)); err != nil {
+			return nil, err
+		}
+	}
+	return ids, nil
+}
+
+func localeParam(ctx context.Context, w http.ResponseWriter, r *http.Request) (string, bool) {
+	loc, err := locale.Normalize(chi.URLParam(r, "locale"))
+	if err != nil {
+		response.RespondWithError(ctx, w, http.StatusBadRequest, "Invalid locale.")
+		return "", false
+	}
+	return loc, true
+}
+
+func episodeIDParam(ctx context.Context, w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	episodeID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.RespondWithError(ctx, w, http.StatusBadRequest, "Invalid episode ID format.")
+		return uuid.Nil, false
+	}
+	return episodeID, true
+}
+
+func categoryIDParam(ctx context.Context, w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	categoryID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.RespondWithError(ctx, w, http.StatusBadRequest, "Invalid category ID format.")
+		return uuid.Nil, false
+	}
+	return categoryID, true
+}
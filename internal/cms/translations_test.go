@@ -0,0 +1,379 @@
+package cms
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"th-application-technical-assignment/internal/middleware"
+	v1 "th-application-technical-assignment/pkg/api/cms/v1"
+	"th-application-technical-assignment/pkg/audit"
+	"th-application-technical-assignment/pkg/database"
+	"th-application-technical-assignment/pkg/tasks"
+	"th-application-technical-assignment/sqlc"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler_putSeriesTranslation(t *testing.T) {
+	t.Parallel()
+
+	existing := sqlc.SeriesTranslation{Locale: "ar", Title: "حديث", CreatedAt: time.Now(), UpdatedAt: time.Now()}
+
+	tests := []struct {
+		name           string
+		locale         string
+		requestBody    map[string]any
+		touchError     error
+		existing       *sqlc.SeriesTranslation
+		expectedAction string
+		expectedStatus int
+	}{
+		{
+			name:           "new translation",
+			locale:         "ar",
+			requestBody:    map[string]any{"title": "حديث التقنية", "description": "أخبار التقنية"},
+			expectedAction: audit.ActionCreate,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "replaced translation",
+			locale:         "ar",
+			requestBody:    map[string]any{"title": "حديث التقنية"},
+			existing:       &existing,
+			expectedAction: audit.ActionUpdate,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "locale is normalized",
+			locale:         "en_gb",
+			requestBody:    map[string]any{"title": "Tech Talk"},
+			expectedAction: audit.ActionCreate,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "invalid locale",
+			locale:         "english!",
+			requestBody:    map[string]any{"title": "Tech Talk"},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "missing title",
+			locale:         "ar",
+			requestBody:    map[string]any{"description": "أخبار التقنية"},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "series not found",
+			locale:         "ar",
+			requestBody:    map[string]any{"title": "حديث التقنية"},
+			touchError:     sql.ErrNoRows,
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockQueries := new(database.MockQuerier)
+			handler := &Handler{
+				s: &database.Store{Queries: mockQueries},
+				v: validator.New(),
+			}
+
+			seriesID := uuid.New()
+			series := sqlc.Series{ID: seriesID, Title: "Tech Talk", Language: stringPtr("en"), CategoryID: uuid.New()}
+
+			if tt.expectedStatus == http.StatusNotFound {
+				mockQueries.On("TouchSeries", mock.Anything, seriesID).Return(sqlc.Series{}, tt.touchError)
+			}
+			if tt.expectedStatus == http.StatusOK {
+				loc := tt.locale
+				if loc == "en_gb" {
+					loc = "en-GB"
+				}
+				mockQueries.On("TouchSeries", mock.Anything, seriesID).Return(series, nil)
+				if tt.existing != nil {
+					mockQueries.On("GetSeriesTranslation", mock.Anything, sqlc.GetSeriesTranslationParams{SeriesID: seriesID, Locale: loc}).
+						Return(*tt.existing, nil)
+				} else {
+					mockQueries.On("GetSeriesTranslation", mock.Anything, sqlc.GetSeriesTranslationParams{SeriesID: seriesID, Locale: loc}).
+						Return(sqlc.SeriesTranslation{}, sql.ErrNoRows)
+				}
+				mockQueries.On("UpsertSeriesTranslation", mock.Anything, mock.MatchedBy(func(params sqlc.UpsertSeriesTranslationParams) bool {
+					return params.SeriesID == seriesID && params.Locale == loc && params.Title == tt.requestBody["title"]
+				})).Return(sqlc.SeriesTranslation{SeriesID: seriesID, Locale: loc, Title: tt.requestBody["title"].(string)}, nil)
+				mockQueries.On("CreateAuditEvent", mock.Anything, mock.MatchedBy(func(params sqlc.CreateAuditEventParams) bool {
+					return params.Action == tt.expectedAction && params.EntityType == audit.EntitySeriesTranslation && params.EntityID == seriesID
+				})).Return(nil)
+				mockQueries.On("ListCategoryPath", mock.Anything, series.CategoryID).Return([]uuid.UUID{series.CategoryID}, nil)
+				mockQueries.On("ListSeriesTranslations", mock.Anything, []uuid.UUID{seriesID}).
+					Return([]sqlc.SeriesTranslation{{SeriesID: seriesID, Locale: loc, Title: tt.requestBody["title"].(string)}}, nil)
+				mockQueries.On("CreateOutboxEvent", mock.Anything, mock.MatchedBy(func(params sqlc.CreateOutboxEventParams) bool {
+					return params.TaskType == tasks.TypeIndexSeries
+				})).Return(nil)
+			}
+
+			body, _ := json.Marshal(tt.requestBody)
+			req := httptest.NewRequest(http.MethodPut, "/series/"+seriesID.String()+"/translations/"+tt.locale, bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", seriesID.String())
+			rctx.URLParams.Add("locale", tt.locale)
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+			recorder := httptest.NewRecorder()
+
+			handler.putSeriesTranslation(recorder, req)
+
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+
+			if tt.expectedStatus == http.StatusOK {
+				var res v1.TranslationResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+				assert.Equal(t, tt.requestBody["title"], res.Title)
+			}
+
+			mockQueries.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_deleteSeriesTranslation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		deleteError    error
+		expectedStatus int
+	}{
+		{
+			name:           "successful delete",
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:           "translation not found",
+			deleteError:    sql.ErrNoRows,
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockQueries := new(database.MockQuerier)
+			handler := &Handler{
+				s: &database.Store{Queries: mockQueries},
+				v: validator.New(),
+			}
+
+			seriesID := uuid.New()
+			series := sqlc.Series{ID: seriesID, CategoryID: uuid.New()}
+			params := sqlc.DeleteSeriesTranslationParams{SeriesID: seriesID, Locale: "ar"}
+
+			mockQueries.On("TouchSeries", mock.Anything, seriesID).Return(series, nil)
+			if tt.deleteError != nil {
+				mockQueries.On("DeleteSeriesTranslation", mock.Anything, params).Return(sqlc.SeriesTranslation{}, tt.deleteError)
+			} else {
+				mockQueries.On("DeleteSeriesTranslation", mock.Anything, params).
+					Return(sqlc.SeriesTranslation{SeriesID: seriesID, Locale: "ar", Title: "حديث التقنية"}, nil)
+				mockQueries.On("CreateAuditEvent", mock.Anything, mock.MatchedBy(func(params sqlc.CreateAuditEventParams) bool {
+					return params.Action == audit.ActionDelete && params.EntityType == audit.EntitySeriesTranslation && params.EntityID == seriesID
+				})).Return(nil)
+				mockQueries.On("ListCategoryPath", mock.Anything, series.CategoryID).Return([]uuid.UUID{series.CategoryID}, nil)
+				mockQueries.On("ListSeriesTranslations", mock.Anything, []uuid.UUID{seriesID}).Return([]sqlc.SeriesTranslation{}, nil)
+				mockQueries.On("CreateOutboxEvent", mock.Anything, mock.MatchedBy(func(params sqlc.CreateOutboxEventParams) bool {
+					return params.TaskType == tasks.TypeIndexSeries
+				})).Return(nil)
+			}
+
+			req := httptest.NewRequest(http.MethodDelete, "/series/"+seriesID.String()+"/translations/ar", nil)
+
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", seriesID.String())
+			rctx.URLParams.Add("locale", "ar")
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+			recorder := httptest.NewRecorder()
+
+			handler.deleteSeriesTranslation(recorder, req)
+
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+			mockQueries.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_putEpisodeTranslation(t *testing.T) {
+	t.Parallel()
+
+	mockQueries := new(database.MockQuerier)
+	handler := &Handler{
+		s: &database.Store{Queries: mockQueries},
+		v: validator.New(),
+	}
+
+	episodeID := uuid.New()
+	episode := sqlc.Episode{ID: episodeID, SeriesID: uuid.New(), Title: "Pilot"}
+	translation := sqlc.EpisodeTranslation{EpisodeID: episodeID, Locale: "ar", Title: "الحلقة الأولى"}
+
+	mockQueries.On("TouchEpisode", mock.Anything, episodeID).Return(episode, nil)
+	mockQueries.On("GetEpisodeTranslation", mock.Anything, sqlc.GetEpisodeTranslationParams{EpisodeID: episodeID, Locale: "ar"}).
+		Return(sqlc.EpisodeTranslation{}, sql.ErrNoRows)
+	mockQueries.On("UpsertEpisodeTranslation", mock.Anything, sqlc.UpsertEpisodeTranslationParams{EpisodeID: episodeID, Locale: "ar", Title: "الحلقة الأولى"}).
+		Return(translation, nil)
+	mockQueries.On("CreateAuditEvent", mock.Anything, mock.MatchedBy(func(params sqlc.CreateAuditEventParams) bool {
+		return params.Action == audit.ActionCreate && params.EntityType == audit.EntityEpisodeTranslation && params.EntityID == episodeID
+	})).Return(nil)
+	mockQueries.On("ListAssetsByEpisode", mock.Anything, episodeID).Return([]sqlc.EpisodeAsset{}, nil)
+	mockQueries.On("ListSeriesLanguages", mock.Anything, mock.Anything).Return([]sqlc.ListSeriesLanguagesRow{}, nil)
+	mockQueries.On("ListEpisodeTranslations", mock.Anything, []uuid.UUID{episodeID}).Return([]sqlc.EpisodeTranslation{translation}, nil)
+	mockQueries.On("CreateOutboxEvent", mock.Anything, mock.MatchedBy(func(params sqlc.CreateOutboxEventParams) bool {
+		var payload tasks.IndexEpisodePayload
+		return params.TaskType == tasks.TypeIndexEpisode &&
+			json.Unmarshal(params.Payload, &payload) == nil &&
+			len(payload.Translations) == 1
+	})).Return(nil)
+
+	req := httptest.NewRequest(http.MethodPut, "/series/episodes/"+episodeID.String()+"/translations/ar", bytes.NewReader([]byte(`{"title":"الحلقة الأولى"}`)))
+	req.Header.Set("Content-Type", "application/json")
+
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", episodeID.String())
+	rctx.URLParams.Add("locale", "ar")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+	recorder := httptest.NewRecorder()
+
+	handler.putEpisodeTranslation(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	mockQueries.AssertExpectations(t)
+}
+
+func TestHandler_getSeries_Localized(t *testing.T) {
+	t.Parallel()
+
+	seriesID := uuid.New()
+	series := sqlc.Series{ID: seriesID, Title: "Tech Talk", Description: stringPtr("Weekly technology news"), Language: stringPtr("en")}
+
+	tests := []struct {
+		name                string
+		acceptLanguage      string
+		expectedTitle       string
+		expectedDescription any
+		expectedLocale      any
+	}{
+		{
+			name:                "no preference",
+			expectedTitle:       "Tech Talk",
+			expectedDescription: "Weekly technology news",
+		},
+		{
+			name:           "preferred translation",
+			acceptLanguage: "ar-EG, en;q=0.5",
+			expectedTitle:  "حديث التقنية",
+			expectedLocale: "ar",
+		},
+		{
+			name:                "language of the series",
+			acceptLanguage:      "en-US, ar;q=0.5",
+			expectedTitle:       "Tech Talk",
+			expectedDescription: "Weekly technology news",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockQueries := new(database.MockQuerier)
+			handler := &Handler{
+				s: &database.Store{Queries: mockQueries},
+				v: validator.New(),
+			}
+
+			mockQueries.On("GetSeries", mock.Anything, seriesID).Return(series, nil)
+			if tt.acceptLanguage != "" {
+				mockQueries.On("ListSeriesTranslations", mock.Anything, []uuid.UUID{seriesID}).Return([]sqlc.SeriesTranslation{
+					{SeriesID: seriesID, Locale: "ar", Title: "حديث التقنية"},
+				}, nil)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/series/"+seriesID.String(), nil)
+			if tt.acceptLanguage != "" {
+				req.Header.Set("Accept-Language", tt.acceptLanguage)
+			}
+
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", seriesID.String())
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+			recorder := httptest.NewRecorder()
+
+			middleware.LanguageCtx(http.HandlerFunc(handler.getSeries)).ServeHTTP(recorder, req)
+
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			assert.Equal(t, "Accept-Language", recorder.Header().Get("Vary"))
+
+			var res map[string]any
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+			assert.Equal(t, tt.expectedTitle, res["title"])
+			assert.Equal(t, tt.expectedDescription, res["description"])
+			assert.Equal(t, tt.expectedLocale, res["locale"])
+
+			mockQueries.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_getCategory_Localized(t *testing.T) {
+	t.Parallel()
+
+	mockQueries := new(database.MockQuerier)
+	handler := &Handler{
+		s: &database.Store{Queries: mockQueries},
+		v: validator.New(),
+	}
+
+	categoryID := uuid.New()
+	mockQueries.On("GetCategory", mock.Anything, categoryID).Return(sqlc.Category{ID: categoryID, Name: "Technology", Slug: "technology"}, nil)
+	mockQueries.On("ListCategoryTranslations", mock.Anything, []uuid.UUID{categoryID}).Return([]sqlc.CategoryTranslation{
+		{CategoryID: categoryID, Locale: "ar", Name: "تقنية"},
+		{CategoryID: categoryID, Locale: "fr", Name: "Technologie"},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/categories/"+categoryID.String(), nil)
+	req.Header.Set("Accept-Language", "fr-FR")
+
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", categoryID.String())
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+	recorder := httptest.NewRecorder()
+
+	middleware.LanguageCtx(http.HandlerFunc(handler.getCategory)).ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	var res v1.CategoryResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+	assert.Equal(t, "Technologie", res.Name)
+	assert.Equal(t, "technology", res.Slug)
+	require.NotNil(t, res.Locale)
+	assert.Equal(t, "fr", *res.Locale)
+
+	mockQueries.AssertExpectations(t)
+}
@@ -0,0 +1,78 @@
+package cms
+
+import (
+	"context"
+	"net/http"
+	"th-application-technical-assignment/internal/middleware"
+	"th-application-technical-assignment/internal/response"
+	v1 "th-application-technical-assignment/pkg/api/cms/v1"
+	"th-application-technical-assignment/pkg/audit"
+	"th-application-technical-assignment/pkg/mapping"
+	"th-application-technical-assignment/pkg/util"
+	"th-application-technical-assignment/sqlc"
+)
+
+// listTrash godoc
+// @Summary      List deleted content
+// @Description  Get a paginated list of deleted categories, series and episodes, most recently deleted first. Deleted content is purged after the retention period
+// @Tags         Trash
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        type       query     string  false  "Only list this type"  Enums(category, series, episode)
+// @Param        page       query     int     false  "Page number (default: 1)"
+// @Param        page_size  query     int     false  "Page size (default: 20, max: 100)"
+// @Success      200        {object}  v1.PaginatedTrashItemResponse
+// @Failure      400        {object}  map[string]string
+// @Failure      401        {object}  map[string]string
+// @Failure      403        {object}  map[string]string
+// @Failure      500        {object}  map[string]string
+// @Router       /trash [get]
+func (h *Handler) listTrash(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var entityType *string
+	switch t := r.URL.Query().Get("type"); t {
+	case "":
+	case audit.EntityCategory, audit.EntitySeries, audit.EntityEpisode:
+		entityType = &t
+	default:
+		response.RespondWithError(ctx, w, http.StatusBadRequest, "Invalid type, expected category, series or episode.")
+		return
+	}
+
+	pagination := middleware.GetPagination(ctx)
+	offset := (pagination.Page - 1) * pagination.PageSize
+
+	fetchCount := func(ctx context.Context) (int64, error) {
+		return h.s.Queries.CountTrash(ctx, entityType)
+	}
+
+	fetchItems := func(ctx context.Context) ([]sqlc.ListTrashPaginatedRow, error) {
+		params := sqlc.ListTrashPaginatedParams{
+			EntityType: entityType,
+			RowLimit:   int32(pagination.PageSize),
+			RowOffset:  int32(offset),
+		}
+		return h.s.Queries.ListTrashPaginated(ctx, params)
+	}
+
+	itemCount, dbItems, err := util.FetchPaginatedData(ctx, fetchCount, fetchItems)
+	if err != nil {
+		response.HandleDBError(ctx, w, err, "We couldn't retrieve the trash.")
+		return
+	}
+
+	itemsData := make([]v1.TrashItemResponse, len(dbItems))
+	for i, t := range dbItems {
+		itemsData[i] = mapping.TrashItem(t)
+	}
+
+	paginationMeta := util.CalculatePaginationResponse(pagination.Page, pagination.PageSize, itemCount)
+	res := util.PaginatedResponse[v1.TrashItemResponse]{
+		Data:       itemsData,
+		Pagination: paginationMeta,
+	}
+
+	response.RespondWithJSON(ctx, w, http.StatusOK, res)
+}
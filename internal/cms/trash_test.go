@@ -0,0 +1,108 @@
+package cms
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	v1 "th-application-technical-assignment/pkg/api/cms/v1"
+	"th-application-technical-assignment/pkg/audit"
+	"th-application-technical-assignment/pkg/database"
+	"th-application-technical-assignment/sqlc"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler_listTrash(t *testing.T) {
+	t.Parallel()
+
+	deletedAt := time.Now()
+	seriesID := uuid.New()
+
+	tests := []struct {
+		name           string
+		query          string
+		entityType     *string
+		mockItems      []sqlc.ListTrashPaginatedRow
+		dbError        error
+		expectedStatus int
+	}{
+		{
+			name: "successful list",
+			mockItems: []sqlc.ListTrashPaginatedRow{
+				{EntityType: audit.EntityEpisode, ID: uuid.New(), Title: "Episode 1", SeriesID: &seriesID, DeletedAt: &deletedAt},
+				{EntityType: audit.EntitySeries, ID: seriesID, Title: "Series", DeletedAt: &deletedAt},
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:       "filtered by type",
+			query:      "?type=episode",
+			entityType: stringPtr(audit.EntityEpisode),
+			mockItems: []sqlc.ListTrashPaginatedRow{
+				{EntityType: audit.EntityEpisode, ID: uuid.New(), Title: "Episode 1", SeriesID: &seriesID, DeletedAt: &deletedAt},
+				{EntityType: audit.EntityEpisode, ID: uuid.New(), Title: "Episode 2", SeriesID: &seriesID, DeletedAt: &deletedAt},
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "invalid type",
+			query:          "?type=asset",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "database error",
+			dbError:        assert.AnError,
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockQueries := new(database.MockQuerier)
+			handler := &Handler{
+				s: &database.Store{Queries: mockQueries},
+				v: validator.New(),
+			}
+
+			if tt.expectedStatus != http.StatusBadRequest {
+				// count and list run concurrently, so the list may not be reached on error
+				mockQueries.On("CountTrash", mock.Anything, tt.entityType).Return(int64(len(tt.mockItems)), tt.dbError)
+				mockQueries.On("ListTrashPaginated", mock.Anything, sqlc.ListTrashPaginatedParams{
+					EntityType: tt.entityType,
+					RowLimit:   20,
+					RowOffset:  0,
+				}).Return(tt.mockItems, nil).Maybe()
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/trash"+tt.query, nil)
+			recorder := httptest.NewRecorder()
+
+			handler.listTrash(recorder, req)
+
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+
+			if tt.expectedStatus == http.StatusOK {
+				var res v1.PaginatedTrashItemResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				require.Len(t, res.Data, 2)
+				assert.Equal(t, int64(2), res.Pagination.ItemCount)
+				assert.Equal(t, audit.EntityEpisode, res.Data[0].Type)
+				assert.Equal(t, tt.mockItems[0].ID.String(), res.Data[0].ID)
+				require.NotNil(t, res.Data[0].SeriesID)
+				assert.Equal(t, seriesID.String(), *res.Data[0].SeriesID)
+				assert.WithinDuration(t, deletedAt, res.Data[0].DeletedAt, time.Second)
+			}
+
+			mockQueries.AssertExpectations(t)
+		})
+	}
+}
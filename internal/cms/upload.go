@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"th-application-technical-assignment/internal/response"
 	v1 "th-application-technical-assignment/pkg/api/cms/v1"
+	"th-application-technical-assignment/pkg/audit"
 	"th-application-technical-assignment/pkg/mapping"
 	"th-application-technical-assignment/pkg/validation"
 	"th-application-technical-assignment/sqlc"
@@ -18,11 +19,14 @@ import (
 // @Summary      Get a pre-signed URL for an episode media upload
 // @Description  Validates the episode ID and returns a temporary URL for the client to upload a file directly to S3.
 // @Tags         Episodes
+// @Security     BearerAuth
 // @Produce      json
 // @Param        id        path      string  true  "Episode ID"
 // @Param        request   body      v1.UploadURLRequest       true  "Upload request details"
 // @Success      200       {object}  v1.UploadURLResponse "Successfully generated the pre-signed URL"
 // @Failure      400       {object}  map[string]string "Bad Request (e.g., invalid ID, missing filename)"
+// @Failure      401       {object}  map[string]string
+// @Failure      403       {object}  map[string]string
 // @Failure      404       {object}  map[string]string "Episode not found"
 // @Failure      500       {object}  map[string]string "Internal Server Error"
 // @Router       /series/episodes/{id}/upload-url [post]
@@ -80,12 +84,15 @@ func (h *Handler) getEpisodeUploadURL(w http.ResponseWriter, r *http.Request) {
 // @Summary      Confirm episode file upload
 // @Description  Confirm that the file was successfully uploaded and update episode metadata
 // @Tags         Episodes
+// @Security     BearerAuth
 // @Accept       json
 // @Produce      json
 // @Param        id        path      string                      true  "Episode ID"
 // @Param        request   body      v1.ConfirmUploadRequest     true  "Upload confirmation details"
 // @Success      200       {object}  v1.EpisodeResponse
 // @Failure      400       {object}  map[string]string
+// @Failure      401       {object}  map[string]string
+// @Failure      403       {object}  map[string]string
 // @Failure      404       {object}  map[string]string
 // @Failure      500       {object}  map[string]string
 // @Router       /series/episodes/{id}/upload-confirm [post]
@@ -118,24 +125,30 @@ func (h *Handler) confirmEpisodeUpload(w http.ResponseWriter, r *http.Request) {
 		Url:       &req.S3Key,
 	}
 
-	_, err = h.s.Queries.CreateAsset(ctx, assetParams)
-	if err != nil {
-		response.HandleDBError(ctx, w, err, "Failed to confirm upload.")
-		return
-	}
-
-	episode, err := h.s.Queries.GetEpisode(ctx, episodeID)
+	// the asset is only kept if the episode still exists when the
+	// transaction commits
+	var episode sqlc.Episode
+	var assets []sqlc.EpisodeAsset
+	err = h.s.WithTx(ctx, func(q sqlc.Querier) error {
+		asset, err := q.CreateAsset(ctx, assetParams)
+		if err != nil {
+			return err
+		}
+		if err := h.record(ctx, q, audit.ActionCreate, audit.EntityAsset, asset.ID, nil, asset); err != nil {
+			return err
+		}
+		episode, err = q.GetEpisode(ctx, episodeID)
+		if err != nil {
+			return err
+		}
+		assets, err = q.ListAssetsByEpisode(ctx, episodeID)
+		return err
+	})
 	if err != nil {
 		response.HandleDBError(ctx, w, err, "Episode not found.")
 		return
 	}
 
-	assets, err := h.s.Queries.ListAssetsByEpisode(ctx, episodeID)
-	if err != nil {
-		response.HandleDBError(ctx, w, err, "We couldn't retrieve the episode assets.")
-		return
-	}
-
 	res := mapping.Episode(episode, assets)
 
 	slog.InfoContext(ctx, "episode upload confirmed",
@@ -36,6 +36,11 @@ func (m *MockStorageClient) GeneratePresignedPutURL(ctx context.Context, key str
 	return args.Get(0).(*url.URL), args.Error(1)
 }
 
+func (m *MockStorageClient) RemoveObject(ctx context.Context, key string) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
+
 func (m *MockStorageClient) GenerateKey(seriesID, episodeID uuid.UUID, filename string) string {
 	args := m.Called(seriesID, episodeID, filename)
 	return args.String(0)
@@ -437,16 +442,19 @@ func TestHandler_confirmEpisodeUpload(t *testing.T) {
 				} else if tt.dbError != nil && tt.name == "episode not found" {
 					mockQueries.On("CreateAsset", mock.Anything, mock.AnythingOfType("sqlc.CreateAssetParams")).
 						Return(tt.mockAsset, nil)
+					mockQueries.On("CreateAuditEvent", mock.Anything, mock.AnythingOfType("sqlc.CreateAuditEventParams")).Return(nil)
 					mockQueries.On("GetEpisode", mock.Anything, episodeUUID).
 						Return(sqlc.Episode{}, tt.dbError)
 				} else if tt.dbError != nil && tt.name == "get episode after asset creation fails" {
 					mockQueries.On("CreateAsset", mock.Anything, mock.AnythingOfType("sqlc.CreateAssetParams")).
 						Return(tt.mockAsset, nil)
+					mockQueries.On("CreateAuditEvent", mock.Anything, mock.AnythingOfType("sqlc.CreateAuditEventParams")).Return(nil)
 					mockQueries.On("GetEpisode", mock.Anything, episodeUUID).
 						Return(sqlc.Episode{}, tt.dbError)
 				} else if tt.listAssetsError != nil {
 					mockQueries.On("CreateAsset", mock.Anything, mock.AnythingOfType("sqlc.CreateAssetParams")).
 						Return(tt.mockAsset, nil)
+					mockQueries.On("CreateAuditEvent", mock.Anything, mock.AnythingOfType("sqlc.CreateAuditEventParams")).Return(nil)
 					mockQueries.On("GetEpisode", mock.Anything, episodeUUID).
 						Return(tt.mockEpisode, nil)
 					mockQueries.On("ListAssetsByEpisode", mock.Anything, episodeUUID).
@@ -457,6 +465,7 @@ func TestHandler_confirmEpisodeUpload(t *testing.T) {
 							params.AssetType == tt.requestBody["asset_type"].(string) &&
 							params.MimeType == tt.requestBody["mime_type"].(string)
 					})).Return(tt.mockAsset, nil)
+					mockQueries.On("CreateAuditEvent", mock.Anything, mock.AnythingOfType("sqlc.CreateAuditEventParams")).Return(nil)
 
 					mockQueries.On("GetEpisode", mock.Anything, episodeUUID).
 						Return(tt.mockEpisode, nil)
@@ -545,6 +554,7 @@ func TestHandler_confirmEpisodeUpload_AssetTypes(t *testing.T) {
 
 				mockQueries.On("CreateAsset", mock.Anything, mock.AnythingOfType("sqlc.CreateAssetParams")).
 					Return(mockAsset, nil)
+				mockQueries.On("CreateAuditEvent", mock.Anything, mock.AnythingOfType("sqlc.CreateAuditEventParams")).Return(nil)
 				mockQueries.On("GetEpisode", mock.Anything, episodeUUID).
 					Return(mockEpisode, nil)
 				mockQueries.On("ListAssetsByEpisode", mock.Anything, episodeUUID).
@@ -2,6 +2,7 @@ package discovery
 
 import (
 	"context"
+	mw "th-application-technical-assignment/internal/middleware"
 	"th-application-technical-assignment/pkg/search"
 
 	"github.com/go-chi/chi/v5"
@@ -17,8 +18,12 @@ func Routes(ctx context.Context, h *Handler) chi.Router {
 	r := chi.NewRouter()
 
 	r.Route("/v1", func(r chi.Router) {
+		r.Use(mw.LanguageCtx)
+
 		r.Get("/search/series", h.searchSeries)
 		r.Get("/search/episodes", h.searchEpisodes)
+		r.Get("/series/by-slug/{slug}", h.getSeriesBySlug)
+		r.Get("/series/by-slug/{slug}/episodes/{episodeSlug}", h.getEpisodeBySlug)
 	})
 	return r
 }
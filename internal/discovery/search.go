@@ -19,8 +19,10 @@ import (
 // @Param        page        query     int     false  "Page number (default: 1)"
 // @Param        page_size   query     int     false  "Page size (default: 20, max: 100)"
 // @Param        category_id query     string  false  "Filter by category ID"
+// @Param        include_descendants query bool false "Also match series of the categories below category_id"
 // @Param        type        query     string  false  "Filter by series type"
 // @Param        language    query     string  false  "Filter by language"
+// @Param        Accept-Language  header  string  false  "Preferred languages of titles and descriptions"
 // @Success      200         {object}  v1.SearchResponse
 // @Failure      400         {object}  map[string]string
 // @Failure      500         {object}  map[string]string
@@ -50,6 +52,15 @@ func (h *Handler) searchSeries(w http.ResponseWriter, r *http.Request) {
 		req.CategoryID = &categoryID
 	}
 
+	if v := r.URL.Query().Get("include_descendants"); v != "" {
+		include, err := strconv.ParseBool(v)
+		if err != nil {
+			response.RespondWithError(ctx, w, http.StatusBadRequest, "Invalid include_descendants value.")
+			return
+		}
+		req.IncludeDescendants = include
+	}
+
 	if seriesType := r.URL.Query().Get("type"); seriesType != "" {
 		req.Type = &seriesType
 	}
@@ -71,7 +82,12 @@ func (h *Handler) searchSeries(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if req.CategoryID != nil {
-		searchReq.Filters["category_id"] = *req.CategoryID
+		if req.IncludeDescendants {
+			// the path of a series lists its category and all above it
+			searchReq.Filters["category_path"] = *req.CategoryID
+		} else {
+			searchReq.Filters["category_id"] = *req.CategoryID
+		}
 	}
 	if req.Type != nil {
 		searchReq.Filters["type"] = *req.Type
@@ -86,6 +102,9 @@ func (h *Handler) searchSeries(w http.ResponseWriter, r *http.Request) {
 		response.RespondWithError(ctx, w, http.StatusInternalServerError, "Search failed.")
 		return
 	}
+	for _, doc := range searchResult.Hits {
+		localizeDocument(ctx, doc, docLanguage(doc))
+	}
 
 	pageCount := int((searchResult.Total + int64(req.PageSize) - 1) / int64(req.PageSize))
 	res := v1.SearchResponse{
@@ -110,6 +129,7 @@ func (h *Handler) searchSeries(w http.ResponseWriter, r *http.Request) {
 // @Param        page      query     int     false  "Page number (default: 1)"
 // @Param        page_size query     int     false  "Page size (default: 20, max: 100)"
 // @Param        series_id query     string  false  "Filter by series ID"
+// @Param        Accept-Language  header  string  false  "Preferred languages of titles and descriptions"
 // @Success      200       {object}  v1.SearchResponse
 // @Failure      400       {object}  map[string]string
 // @Failure      500       {object}  map[string]string
@@ -161,6 +181,9 @@ func (h *Handler) searchEpisodes(w http.ResponseWriter, r *http.Request) {
 		response.RespondWithError(ctx, w, http.StatusInternalServerError, "Search failed.")
 		return
 	}
+	for _, doc := range searchResult.Hits {
+		localizeDocument(ctx, doc, docLanguage(doc))
+	}
 
 	pageCount := int((searchResult.Total + int64(req.PageSize) - 1) / int64(req.PageSize))
 	res := v1.SearchResponse{
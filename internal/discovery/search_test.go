@@ -41,6 +41,16 @@ func (m *MockSearchClient) DeleteDocument(ctx context.Context, indexName, docume
 	return args.Error(0)
 }
 
+func (m *MockSearchClient) BulkIndex(ctx context.Context, indexName string, docs []search.BulkDocument) (*search.BulkResponse, error) {
+	args := m.Called(ctx, indexName, docs)
+	return args.Get(0).(*search.BulkResponse), args.Error(1)
+}
+
+func (m *MockSearchClient) BulkDelete(ctx context.Context, indexName string, docs []search.BulkDocument) (*search.BulkResponse, error) {
+	args := m.Called(ctx, indexName, docs)
+	return args.Get(0).(*search.BulkResponse), args.Error(1)
+}
+
 func (m *MockSearchClient) IndexExists(ctx context.Context, indexName string) (bool, error) {
 	args := m.Called(ctx, indexName)
 	return args.Bool(0), args.Error(1)
@@ -55,14 +65,15 @@ func TestHandler_searchSeries(t *testing.T) {
 	t.Parallel()
 
 	tests := []struct {
-		name           string
-		queryParams    map[string]string
-		mockResponse   *search.SearchResponse
-		mockError      error
-		expectedStatus int
-		expectedTotal  int64
-		expectedPage   int
-		expectError    bool
+		name            string
+		queryParams     map[string]string
+		mockResponse    *search.SearchResponse
+		mockError       error
+		expectedFilters map[string]any
+		expectedStatus  int
+		expectedTotal   int64
+		expectedPage    int
+		expectError     bool
 	}{
 		{
 			name: "successful search with results",
@@ -121,11 +132,44 @@ func TestHandler_searchSeries(t *testing.T) {
 				Page: 2,
 				Size: 10,
 			},
+			expectedFilters: map[string]any{
+				"category_id": "123e4567-e89b-12d3-a456-426614174000",
+				"type":        "podcast",
+				"language":    "en",
+			},
 			expectedStatus: http.StatusOK,
 			expectedTotal:  11,
 			expectedPage:   2,
 			expectError:    false,
 		},
+		{
+			name: "category with its subcategories",
+			queryParams: map[string]string{
+				"category_id":         "123e4567-e89b-12d3-a456-426614174000",
+				"include_descendants": "true",
+			},
+			mockResponse: &search.SearchResponse{
+				Total: 1,
+				Hits:  []map[string]any{{"id": "4", "title": "Astronomy Hour"}},
+				Page:  1,
+				Size:  20,
+			},
+			expectedFilters: map[string]any{
+				"category_path": "123e4567-e89b-12d3-a456-426614174000",
+			},
+			expectedStatus: http.StatusOK,
+			expectedTotal:  1,
+			expectedPage:   1,
+		},
+		{
+			name: "invalid include_descendants",
+			queryParams: map[string]string{
+				"category_id":         "123e4567-e89b-12d3-a456-426614174000",
+				"include_descendants": "sometimes",
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectError:    true,
+		},
 		{
 			name:           "search client error",
 			queryParams:    map[string]string{"q": "test"},
@@ -181,6 +225,9 @@ func TestHandler_searchSeries(t *testing.T) {
 					if q, exists := tt.queryParams["q"]; exists && req.Query != q {
 						return false
 					}
+					if tt.expectedFilters != nil && !assert.ObjectsAreEqual(tt.expectedFilters, req.Filters) {
+						return false
+					}
 					return true
 				})).Return(tt.mockResponse, nil)
 			}
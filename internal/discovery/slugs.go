@@ -0,0 +1,154 @@
+package discovery
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"th-application-technical-assignment/internal/response"
+	"th-application-technical-assignment/pkg/search"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// slugLookupSize is the number of documents a previous slug is looked up
+// in. Several series can have used the same slug, the one renamed last wins.
+const slugLookupSize = 10
+
+// getSeriesBySlug godoc
+// @Summary      Get series by slug
+// @Description  Get a single series document by its slug. A slug the series had before it was renamed redirects to its current slug
+// @Tags         Discovery
+// @Accept       json
+// @Produce      json
+// @Param        slug  path      string  true  "Series slug"
+// @Param        Accept-Language  header  string  false  "Preferred languages of titles and descriptions"
+// @Success      200   {object}  map[string]interface{}
+// @Success      301   "Moved Permanently, Location names the current slug"
+// @Failure      404   {object}  map[string]string
+// @Failure      500   {object}  map[string]string
+// @Router       /series/by-slug/{slug} [get]
+func (h *Handler) getSeriesBySlug(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	doc, moved, err := findBySlug(ctx, h.searchClient.SearchSeries, nil, chi.URLParam(r, "slug"))
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to look up series by slug", "err", err)
+		response.RespondWithError(ctx, w, http.StatusInternalServerError, "Search failed.")
+		return
+	}
+	if doc == nil {
+		response.RespondWithError(ctx, w, http.StatusNotFound, "Series not found.")
+		return
+	}
+	if moved {
+		http.Redirect(w, r, seriesSlugPath(docSlug(doc)), http.StatusMovedPermanently)
+		return
+	}
+
+	localizeDocument(ctx, doc, docLanguage(doc))
+	response.RespondWithJSON(ctx, w, http.StatusOK, doc)
+}
+
+// getEpisodeBySlug godoc
+// @Summary      Get episode by slug
+// @Description  Get a single episode document by the slug of its series and its own slug. Slugs the series or the episode had before they were renamed redirect to the current ones
+// @Tags         Discovery
+// @Accept       json
+// @Produce      json
+// @Param        slug         path      string  true  "Series slug"
+// @Param        episodeSlug  path      string  true  "Episode slug"
+// @Param        Accept-Language  header  string  false  "Preferred languages of titles and descriptions"
+// @Success      200          {object}  map[string]interface{}
+// @Success      301          "Moved Permanently, Location names the current slugs"
+// @Failure      404          {object}  map[string]string
+// @Failure      500          {object}  map[string]string
+// @Router       /series/by-slug/{slug}/episodes/{episodeSlug} [get]
+func (h *Handler) getEpisodeBySlug(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	series, seriesMoved, err := findBySlug(ctx, h.searchClient.SearchSeries, nil, chi.URLParam(r, "slug"))
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to look up series by slug", "err", err)
+		response.RespondWithError(ctx, w, http.StatusInternalServerError, "Search failed.")
+		return
+	}
+	if series == nil {
+		response.RespondWithError(ctx, w, http.StatusNotFound, "Series not found.")
+		return
+	}
+
+	filters := map[string]any{"series_id": series["id"]}
+	episode, episodeMoved, err := findBySlug(ctx, h.searchClient.SearchEpisodes, filters, chi.URLParam(r, "episodeSlug"))
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to look up episode by slug", "err", err)
+		response.RespondWithError(ctx, w, http.StatusInternalServerError, "Search failed.")
+		return
+	}
+	if episode == nil {
+		response.RespondWithError(ctx, w, http.StatusNotFound, "Episode not found.")
+		return
+	}
+	if seriesMoved || episodeMoved {
+		path := seriesSlugPath(docSlug(series)) + "/episodes/" + url.PathEscape(docSlug(episode))
+		http.Redirect(w, r, path, http.StatusMovedPermanently)
+		return
+	}
+
+	localizeDocument(ctx, episode, docLanguage(series))
+	response.RespondWithJSON(ctx, w, http.StatusOK, episode)
+}
+
+type searchFunc func(ctx context.Context, req search.SearchRequest) (*search.SearchResponse, error)
+
+// findBySlug looks a document up by its current slug, then by the slugs it
+// had before. moved reports a match on a previous slug. A nil document means
+// nothing matched.
+func findBySlug(ctx context.Context, find searchFunc, filters map[string]any, slug string) (doc map[string]any, moved bool, err error) {
+	for _, field := range []string{"slug", "previous_slugs"} {
+		req := search.SearchRequest{
+			Page:     1,
+			PageSize: slugLookupSize,
+			Filters:  map[string]any{field: slug},
+		}
+		for k, v := range filters {
+			req.Filters[k] = v
+		}
+
+		res, err := find(ctx, req)
+		if err != nil {
+			return nil, false, err
+		}
+		if len(res.Hits) > 0 {
+			return latestDocument(res.Hits), field == "previous_slugs", nil
+		}
+	}
+	return nil, false, nil
+}
+
+// latestDocument returns the most recently updated of docs.
+func latestDocument(docs []map[string]any) map[string]any {
+	latest := docs[0]
+	for _, d := range docs[1:] {
+		if docUpdatedAt(d).After(docUpdatedAt(latest)) {
+			latest = d
+		}
+	}
+	return latest
+}
+
+func docUpdatedAt(doc map[string]any) time.Time {
+	s, _ := doc["updated_at"].(string)
+	t, _ := time.Parse(time.RFC3339Nano, s)
+	return t
+}
+
+func docSlug(doc map[string]any) string {
+	s, _ := doc["slug"].(string)
+	return s
+}
+
+func seriesSlugPath(slug string) string {
+	return "/v1/series/by-slug/" + url.PathEscape(slug)
+}
@@ -0,0 +1,214 @@
+package discovery
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"th-application-technical-assignment/pkg/search"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func slugFilter(filters map[string]any) func(req search.SearchRequest) bool {
+	return func(req search.SearchRequest) bool {
+		if len(req.Filters) != len(filters) {
+			return false
+		}
+		for k, v := range filters {
+			if req.Filters[k] != v {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+func hits(docs ...map[string]any) *search.SearchResponse {
+	return &search.SearchResponse{Total: int64(len(docs)), Hits: docs}
+}
+
+func TestHandler_getSeriesBySlug(t *testing.T) {
+	t.Parallel()
+
+	series := map[string]any{"id": "s1", "slug": "tech-talk", "previous_slugs": []any{"tech-talk-podcast"}}
+
+	tests := []struct {
+		name             string
+		slug             string
+		setup            func(m *MockSearchClient)
+		expectedStatus   int
+		expectedLocation string
+	}{
+		{
+			name: "current slug",
+			slug: "tech-talk",
+			setup: func(m *MockSearchClient) {
+				m.On("SearchSeries", mock.Anything, mock.MatchedBy(slugFilter(map[string]any{"slug": "tech-talk"}))).Return(hits(series), nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "previous slug redirects to the current one",
+			slug: "tech-talk-podcast",
+			setup: func(m *MockSearchClient) {
+				m.On("SearchSeries", mock.Anything, mock.MatchedBy(slugFilter(map[string]any{"slug": "tech-talk-podcast"}))).Return(hits(), nil)
+				m.On("SearchSeries", mock.Anything, mock.MatchedBy(slugFilter(map[string]any{"previous_slugs": "tech-talk-podcast"}))).Return(hits(series), nil)
+			},
+			expectedStatus:   http.StatusMovedPermanently,
+			expectedLocation: "/v1/series/by-slug/tech-talk",
+		},
+		{
+			name: "previous slug of several series goes to the one renamed last",
+			slug: "news",
+			setup: func(m *MockSearchClient) {
+				m.On("SearchSeries", mock.Anything, mock.MatchedBy(slugFilter(map[string]any{"slug": "news"}))).Return(hits(), nil)
+				m.On("SearchSeries", mock.Anything, mock.MatchedBy(slugFilter(map[string]any{"previous_slugs": "news"}))).Return(hits(
+					map[string]any{"id": "s2", "slug": "daily-news", "updated_at": "2025-09-01T10:00:00Z"},
+					map[string]any{"id": "s3", "slug": "world-news", "updated_at": "2025-09-10T10:00:00Z"},
+				), nil)
+			},
+			expectedStatus:   http.StatusMovedPermanently,
+			expectedLocation: "/v1/series/by-slug/world-news",
+		},
+		{
+			name: "unknown slug",
+			slug: "unknown",
+			setup: func(m *MockSearchClient) {
+				m.On("SearchSeries", mock.Anything, mock.Anything).Return(hits(), nil)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name: "search error",
+			slug: "tech-talk",
+			setup: func(m *MockSearchClient) {
+				m.On("SearchSeries", mock.Anything, mock.Anything).Return((*search.SearchResponse)(nil), assert.AnError)
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockSearcher := new(MockSearchClient)
+			handler := &Handler{v: validator.New(), searchClient: mockSearcher}
+			tt.setup(mockSearcher)
+
+			req := httptest.NewRequest(http.MethodGet, "/v1/series/by-slug/"+tt.slug, nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("slug", tt.slug)
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			recorder := httptest.NewRecorder()
+
+			handler.getSeriesBySlug(recorder, req)
+
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+			assert.Equal(t, tt.expectedLocation, recorder.Header().Get("Location"))
+			if tt.expectedStatus == http.StatusOK {
+				var doc map[string]any
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &doc))
+				assert.Equal(t, "s1", doc["id"])
+			}
+
+			mockSearcher.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_getEpisodeBySlug(t *testing.T) {
+	t.Parallel()
+
+	series := map[string]any{"id": "s1", "slug": "tech-talk"}
+	episode := map[string]any{"id": "e1", "series_id": "s1", "slug": "pilot"}
+
+	tests := []struct {
+		name             string
+		seriesSlug       string
+		episodeSlug      string
+		setup            func(m *MockSearchClient)
+		expectedStatus   int
+		expectedLocation string
+	}{
+		{
+			name:        "current slugs",
+			seriesSlug:  "tech-talk",
+			episodeSlug: "pilot",
+			setup: func(m *MockSearchClient) {
+				m.On("SearchSeries", mock.Anything, mock.MatchedBy(slugFilter(map[string]any{"slug": "tech-talk"}))).Return(hits(series), nil)
+				m.On("SearchEpisodes", mock.Anything, mock.MatchedBy(slugFilter(map[string]any{"series_id": "s1", "slug": "pilot"}))).Return(hits(episode), nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:        "previous episode slug",
+			seriesSlug:  "tech-talk",
+			episodeSlug: "first",
+			setup: func(m *MockSearchClient) {
+				m.On("SearchSeries", mock.Anything, mock.MatchedBy(slugFilter(map[string]any{"slug": "tech-talk"}))).Return(hits(series), nil)
+				m.On("SearchEpisodes", mock.Anything, mock.MatchedBy(slugFilter(map[string]any{"series_id": "s1", "slug": "first"}))).Return(hits(), nil)
+				m.On("SearchEpisodes", mock.Anything, mock.MatchedBy(slugFilter(map[string]any{"series_id": "s1", "previous_slugs": "first"}))).Return(hits(episode), nil)
+			},
+			expectedStatus:   http.StatusMovedPermanently,
+			expectedLocation: "/v1/series/by-slug/tech-talk/episodes/pilot",
+		},
+		{
+			name:        "previous series slug",
+			seriesSlug:  "old-talk",
+			episodeSlug: "pilot",
+			setup: func(m *MockSearchClient) {
+				m.On("SearchSeries", mock.Anything, mock.MatchedBy(slugFilter(map[string]any{"slug": "old-talk"}))).Return(hits(), nil)
+				m.On("SearchSeries", mock.Anything, mock.MatchedBy(slugFilter(map[string]any{"previous_slugs": "old-talk"}))).Return(hits(series), nil)
+				m.On("SearchEpisodes", mock.Anything, mock.MatchedBy(slugFilter(map[string]any{"series_id": "s1", "slug": "pilot"}))).Return(hits(episode), nil)
+			},
+			expectedStatus:   http.StatusMovedPermanently,
+			expectedLocation: "/v1/series/by-slug/tech-talk/episodes/pilot",
+		},
+		{
+			name:        "unknown episode",
+			seriesSlug:  "tech-talk",
+			episodeSlug: "unknown",
+			setup: func(m *MockSearchClient) {
+				m.On("SearchSeries", mock.Anything, mock.Anything).Return(hits(series), nil)
+				m.On("SearchEpisodes", mock.Anything, mock.Anything).Return(hits(), nil)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockSearcher := new(MockSearchClient)
+			handler := &Handler{v: validator.New(), searchClient: mockSearcher}
+			tt.setup(mockSearcher)
+
+			req := httptest.NewRequest(http.MethodGet, "/v1/series/by-slug/"+tt.seriesSlug+"/episodes/"+tt.episodeSlug, nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("slug", tt.seriesSlug)
+			rctx.URLParams.Add("episodeSlug", tt.episodeSlug)
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			recorder := httptest.NewRecorder()
+
+			handler.getEpisodeBySlug(recorder, req)
+
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+			assert.Equal(t, tt.expectedLocation, recorder.Header().Get("Location"))
+			if tt.expectedStatus == http.StatusOK {
+				var doc map[string]any
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &doc))
+				assert.Equal(t, "e1", doc["id"])
+			}
+
+			mockSearcher.AssertExpectations(t)
+		})
+	}
+}
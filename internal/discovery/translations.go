@@ -0,0 +1,47 @@
+package discovery
+
+import (
+	"context"
+	"maps"
+	"slices"
+	mw "th-application-technical-assignment/internal/middleware"
+	"th-application-technical-assignment/pkg/locale"
+)
+
+// localizeDocument replaces the title and description of doc with the
+// translation that suits the Accept-Language header of the request best and
+// names its locale. base is the language of the text of doc, nil when it is
+// unknown.
+func localizeDocument(ctx context.Context, doc map[string]any, base *string) {
+	translations, _ := doc["translations"].(map[string]any)
+	if len(translations) == 0 {
+		return
+	}
+
+	locales := slices.Sorted(maps.Keys(translations))
+	i, ok := locale.Match(mw.GetLanguages(ctx), base, locales)
+	if !ok {
+		return
+	}
+	translation, _ := translations[locales[i]].(map[string]any)
+	if translation == nil {
+		return
+	}
+
+	doc["title"] = translation["title"]
+	if description, ok := translation["description"]; ok {
+		doc["description"] = description
+	} else {
+		delete(doc, "description")
+	}
+	doc["locale"] = locales[i]
+}
+
+// docLanguage returns the language of the text of a document. Episodes
+// indexed before they carried the language of their series have none.
+func docLanguage(doc map[string]any) *string {
+	if s, ok := doc["language"].(string); ok && s != "" {
+		return &s
+	}
+	return nil
+}
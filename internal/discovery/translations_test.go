@@ -0,0 +1,142 @@
+package discovery
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	mw "th-application-technical-assignment/internal/middleware"
+	"th-application-technical-assignment/pkg/locale"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalizeDocument(t *testing.T) {
+	t.Parallel()
+
+	english := "en"
+	arabic := "ar"
+
+	newDoc := func() map[string]any {
+		return map[string]any{
+			"id":          "s1",
+			"title":       "Tech Talk",
+			"description": "Weekly technology news",
+			"translations": map[string]any{
+				"ar":    map[string]any{"title": "حديث التقنية", "description": "أخبار التقنية الأسبوعية"},
+				"fr-CA": map[string]any{"title": "Parlons tech"},
+			},
+		}
+	}
+
+	tests := []struct {
+		name                string
+		acceptLanguage      string
+		base                *string
+		expectedTitle       string
+		expectedDescription any
+		expectedLocale      any
+	}{
+		{
+			name:                "no preference keeps the text",
+			base:                &english,
+			expectedTitle:       "Tech Talk",
+			expectedDescription: "Weekly technology news",
+		},
+		{
+			name:                "preferred translation",
+			acceptLanguage:      "ar",
+			base:                &english,
+			expectedTitle:       "حديث التقنية",
+			expectedDescription: "أخبار التقنية الأسبوعية",
+			expectedLocale:      "ar",
+		},
+		{
+			name:           "translation without a description drops it",
+			acceptLanguage: "fr",
+			base:           &english,
+			expectedTitle:  "Parlons tech",
+			expectedLocale: "fr-CA",
+		},
+		{
+			name:                "text in the preferred language",
+			acceptLanguage:      "en, ar;q=0.5",
+			base:                &english,
+			expectedTitle:       "Tech Talk",
+			expectedDescription: "Weekly technology news",
+		},
+		{
+			name:                "no acceptable translation",
+			acceptLanguage:      "de",
+			base:                &arabic,
+			expectedTitle:       "Tech Talk",
+			expectedDescription: "Weekly technology news",
+		},
+		{
+			name:                "unknown language of the text",
+			acceptLanguage:      "ar, en;q=0.5",
+			expectedTitle:       "حديث التقنية",
+			expectedDescription: "أخبار التقنية الأسبوعية",
+			expectedLocale:      "ar",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.WithValue(context.Background(), mw.LanguageContextKey, locale.Preferred(tt.acceptLanguage))
+			doc := newDoc()
+
+			localizeDocument(ctx, doc, tt.base)
+
+			assert.Equal(t, tt.expectedTitle, doc["title"])
+			assert.Equal(t, tt.expectedDescription, doc["description"])
+			assert.Equal(t, tt.expectedLocale, doc["locale"])
+		})
+	}
+}
+
+func TestHandler_getEpisodeBySlug_Localized(t *testing.T) {
+	t.Parallel()
+
+	series := map[string]any{"id": "s1", "slug": "tech-talk", "language": "ar"}
+	episode := map[string]any{
+		"id":           "e1",
+		"series_id":    "s1",
+		"slug":         "pilot",
+		"title":        "الحلقة الأولى",
+		"translations": map[string]any{"en": map[string]any{"title": "Pilot"}},
+	}
+
+	mockSearcher := new(MockSearchClient)
+	handler := &Handler{v: validator.New(), searchClient: mockSearcher}
+	mockSearcher.On("SearchSeries", mock.Anything, mock.Anything).Return(hits(series), nil)
+	mockSearcher.On("SearchEpisodes", mock.Anything, mock.Anything).Return(hits(episode), nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/series/by-slug/tech-talk/episodes/pilot", nil)
+	req.Header.Set("Accept-Language", "ar, en;q=0.8")
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("slug", "tech-talk")
+	rctx.URLParams.Add("episodeSlug", "pilot")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	recorder := httptest.NewRecorder()
+
+	mw.LanguageCtx(http.HandlerFunc(handler.getEpisodeBySlug)).ServeHTTP(recorder, req)
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "Accept-Language", recorder.Header().Get("Vary"))
+
+	// the series is in Arabic, the text of its episodes is preferred over the English translation
+	var doc map[string]any
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &doc))
+	assert.Equal(t, "الحلقة الأولى", doc["title"])
+	assert.Nil(t, doc["locale"])
+
+	mockSearcher.AssertExpectations(t)
+}
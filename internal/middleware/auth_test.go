@@ -0,0 +1,124 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/jwtauth/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequireRole(t *testing.T) {
+	t.Parallel()
+
+	tokenAuth := jwtauth.New("HS256", []byte("secret"), nil)
+
+	tests := []struct {
+		name           string
+		claims         map[string]any
+		noToken        bool
+		required       Role
+		expectedStatus int
+	}{
+		{
+			name:           "missing token",
+			noToken:        true,
+			required:       RoleViewer,
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "viewer reads",
+			claims:         map[string]any{"sub": "u1", "role": "viewer"},
+			required:       RoleViewer,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "viewer cannot edit",
+			claims:         map[string]any{"sub": "u1", "role": "viewer"},
+			required:       RoleEditor,
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "admin can edit",
+			claims:         map[string]any{"sub": "u1", "role": "admin"},
+			required:       RoleEditor,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "highest of roles list wins",
+			claims:         map[string]any{"sub": "u1", "roles": []string{"viewer", "editor"}},
+			required:       RoleEditor,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "unknown role",
+			claims:         map[string]any{"sub": "u1", "role": "superuser"},
+			required:       RoleViewer,
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "no role claim",
+			claims:         map[string]any{"sub": "u1"},
+			required:       RoleViewer,
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := chi.NewRouter()
+			r.Use(jwtauth.Verifier(tokenAuth))
+			r.Use(Authenticator)
+			r.With(RequireRole(tt.required)).Get("/", func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if !tt.noToken {
+				_, token, err := tokenAuth.Encode(tt.claims)
+				require.NoError(t, err)
+				req.Header.Set("Authorization", "Bearer "+token)
+			}
+			recorder := httptest.NewRecorder()
+
+			r.ServeHTTP(recorder, req)
+
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+			if tt.expectedStatus != http.StatusOK {
+				assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+				assert.Contains(t, recorder.Body.String(), `"error"`)
+			}
+		})
+	}
+}
+
+func TestAuthenticator_InvalidToken(t *testing.T) {
+	t.Parallel()
+
+	tokenAuth := jwtauth.New("HS256", []byte("secret"), nil)
+	otherAuth := jwtauth.New("HS256", []byte("other"), nil)
+
+	r := chi.NewRouter()
+	r.Use(jwtauth.Verifier(tokenAuth))
+	r.Use(Authenticator)
+	r.Get("/", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	_, token, err := otherAuth.Encode(map[string]any{"sub": "u1", "role": "admin"})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	recorder := httptest.NewRecorder()
+
+	r.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+	assert.JSONEq(t, `{"error":"Authentication required."}`, recorder.Body.String())
+}
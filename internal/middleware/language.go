@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"th-application-technical-assignment/pkg/locale"
+
+	"golang.org/x/text/language"
+)
+
+const (
+	LanguageContextKey = contextKey("language")
+)
+
+// LanguageCtx stores the languages the Accept-Language header of a request
+// prefers in its context. Responses are localized by the header, so it is
+// added to their Vary header for caches.
+func LanguageCtx(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Accept-Language")
+
+		preferred := locale.Preferred(r.Header.Get("Accept-Language"))
+		ctx := context.WithValue(r.Context(), LanguageContextKey, preferred)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// GetLanguages returns the languages stored by LanguageCtx, most preferred
+// first. It returns none when the client has no preference.
+func GetLanguages(ctx context.Context) []language.Tag {
+	preferred, _ := ctx.Value(LanguageContextKey).([]language.Tag)
+	return preferred
+}
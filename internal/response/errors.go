@@ -4,15 +4,39 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
+	"regexp"
+	"th-application-technical-assignment/pkg/database"
+
+	"github.com/jackc/pgx/v5/pgconn"
 )
 
+// uniqueKeyDetail matches the detail of a unique violation, such as
+// "Key (slug)=(news) already exists."
+var uniqueKeyDetail = regexp.MustCompile(`^Key \((.+)\)=\((.*)\) already exists\.$`)
+
 func HandleDBError(ctx context.Context, w http.ResponseWriter, err error, message string) {
 	if errors.Is(err, sql.ErrNoRows) {
 		RespondWithError(ctx, w, http.StatusNotFound, message)
 		return
 	}
+	if database.IsUniqueViolation(err) {
+		RespondWithError(ctx, w, http.StatusConflict, conflictMessage(err))
+		return
+	}
 	slog.ErrorContext(ctx, "database error", "err", err)
 	RespondWithError(ctx, w, http.StatusInternalServerError, "A database error occurred.")
 }
+
+// conflictMessage names the key of a unique violation and its value.
+func conflictMessage(err error) string {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		if m := uniqueKeyDetail.FindStringSubmatch(pgErr.Detail); m != nil {
+			return fmt.Sprintf("A record with %s %q already exists.", m[1], m[2])
+		}
+	}
+	return "A record with the same unique value already exists."
+}
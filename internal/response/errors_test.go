@@ -0,0 +1,69 @@
+package response
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandleDBError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "sql no rows",
+			err:            sql.ErrNoRows,
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"error":"Category not found."}`,
+		},
+		{
+			name:           "pgx no rows",
+			err:            errors.Wrap(pgx.ErrNoRows, "failed to get category"),
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"error":"Category not found."}`,
+		},
+		{
+			name:           "unique violation",
+			err:            &pgconn.PgError{Code: "23505", Detail: "Key (slug)=(news) already exists."},
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `{"error":"A record with slug \"news\" already exists."}`,
+		},
+		{
+			name:           "unique violation without detail",
+			err:            errors.Wrap(&pgconn.PgError{Code: "23505"}, "failed to create category"),
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `{"error":"A record with the same unique value already exists."}`,
+		},
+		{
+			name:           "other error",
+			err:            &pgconn.PgError{Code: "23503"},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"error":"A database error occurred."}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			recorder := httptest.NewRecorder()
+
+			HandleDBError(context.Background(), recorder, tt.err, "Category not found.")
+
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+			assert.JSONEq(t, tt.expectedBody, recorder.Body.String())
+		})
+	}
+}
@@ -0,0 +1,11 @@
+-- +goose Up
+ALTER TABLE episodes
+    ADD COLUMN source_type TEXT,
+    ADD COLUMN external_id TEXT,
+    ADD CONSTRAINT uq_episodes_source UNIQUE (series_id, source_type, external_id);
+
+-- +goose Down
+ALTER TABLE episodes
+    DROP CONSTRAINT IF EXISTS uq_episodes_source,
+    DROP COLUMN IF EXISTS external_id,
+    DROP COLUMN IF EXISTS source_type;
@@ -0,0 +1,19 @@
+-- +goose Up
+CREATE TABLE series_subscriptions (
+    series_id UUID PRIMARY KEY REFERENCES series(id) ON DELETE CASCADE,
+    source_type TEXT NOT NULL,
+    source_url TEXT NOT NULL,
+    interval_seconds INT NOT NULL CHECK (interval_seconds > 0),
+    etag TEXT,
+    last_modified TEXT,
+    next_sync_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_synced_at TIMESTAMPTZ,
+    last_job_id UUID REFERENCES import_jobs(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_series_subscriptions_next_sync ON series_subscriptions(next_sync_at);
+
+-- +goose Down
+DROP TABLE IF EXISTS series_subscriptions;
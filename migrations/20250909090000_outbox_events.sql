@@ -0,0 +1,16 @@
+-- +goose Up
+CREATE TABLE outbox_events (
+    id BIGSERIAL PRIMARY KEY,
+    task_type TEXT NOT NULL,
+    payload JSONB NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    sent_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_outbox_events_pending ON outbox_events(id) WHERE sent_at IS NULL;
+CREATE INDEX idx_outbox_events_sent_at ON outbox_events(sent_at) WHERE sent_at IS NOT NULL;
+
+-- +goose Down
+DROP TABLE IF EXISTS outbox_events;
@@ -0,0 +1,26 @@
+-- +goose Up
+ALTER TABLE episode_assets ADD COLUMN deleted_at TIMESTAMPTZ;
+
+CREATE TABLE deletion_jobs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    series_id UUID REFERENCES series(id) ON DELETE CASCADE NOT NULL,
+    requested_by TEXT NOT NULL,
+    delete_objects BOOLEAN NOT NULL DEFAULT FALSE,
+    status TEXT NOT NULL DEFAULT 'queued' CHECK (status IN ('queued', 'running', 'succeeded', 'failed')),
+    episode_count INT NOT NULL DEFAULT 0,
+    asset_count INT NOT NULL DEFAULT 0,
+    object_count INT NOT NULL DEFAULT 0,
+    errors JSONB NOT NULL DEFAULT '[]',
+    started_at TIMESTAMPTZ,
+    finished_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_deletion_jobs_series ON deletion_jobs(series_id, created_at DESC);
+CREATE INDEX idx_episodes_series_deleted_at ON episodes(series_id, deleted_at) WHERE deleted_at IS NOT NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_episodes_series_deleted_at;
+DROP TABLE IF EXISTS deletion_jobs;
+ALTER TABLE episode_assets DROP COLUMN IF EXISTS deleted_at;
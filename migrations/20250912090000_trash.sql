@@ -0,0 +1,19 @@
+-- +goose Up
+ALTER TABLE audit_events DROP CONSTRAINT audit_events_action_check;
+ALTER TABLE audit_events ADD CONSTRAINT audit_events_action_check
+    CHECK (action IN ('create', 'update', 'delete', 'restore', 'purge'));
+
+CREATE INDEX idx_categories_deleted_at ON categories(deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX idx_series_deleted_at ON series(deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX idx_episodes_deleted_at ON episodes(deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX idx_assets_deleted_at ON episode_assets(deleted_at) WHERE deleted_at IS NOT NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_assets_deleted_at;
+DROP INDEX IF EXISTS idx_episodes_deleted_at;
+DROP INDEX IF EXISTS idx_series_deleted_at;
+DROP INDEX IF EXISTS idx_categories_deleted_at;
+
+ALTER TABLE audit_events DROP CONSTRAINT audit_events_action_check;
+ALTER TABLE audit_events ADD CONSTRAINT audit_events_action_check
+    CHECK (action IN ('create', 'update', 'delete'));
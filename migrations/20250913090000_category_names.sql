@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE categories ADD COLUMN name VARCHAR(100);
+UPDATE categories SET name = slug;
+ALTER TABLE categories ALTER COLUMN name SET NOT NULL;
+
+-- +goose Down
+ALTER TABLE categories DROP COLUMN IF EXISTS name;
@@ -0,0 +1,59 @@
+-- +goose Up
+ALTER TABLE series
+    ADD COLUMN slug VARCHAR(100),
+    ADD COLUMN previous_slugs TEXT[] NOT NULL DEFAULT '{}';
+ALTER TABLE episodes
+    ADD COLUMN slug VARCHAR(100),
+    ADD COLUMN previous_slugs TEXT[] NOT NULL DEFAULT '{}';
+
+-- Existing rows get an ASCII slug of their title. Titles that share a slug
+-- get the start of their id appended, the application transliterates and
+-- numbers slugs from now on.
+WITH base AS (
+    SELECT id, created_at,
+           COALESCE(NULLIF(trim(BOTH '-' FROM left(regexp_replace(lower(title), '[^a-z0-9]+', '-', 'g'), 90)), ''), 'series') AS slug
+    FROM series
+), numbered AS (
+    SELECT id, slug, row_number() OVER (PARTITION BY slug ORDER BY created_at, id) AS n
+    FROM base
+)
+UPDATE series s
+SET slug = CASE WHEN n.n = 1 THEN n.slug ELSE n.slug || '-' || left(s.id::text, 8) END
+FROM numbered n
+WHERE n.id = s.id;
+
+WITH base AS (
+    SELECT id, series_id, created_at,
+           COALESCE(NULLIF(trim(BOTH '-' FROM left(regexp_replace(lower(title), '[^a-z0-9]+', '-', 'g'), 90)), ''), 'episode') AS slug
+    FROM episodes
+), numbered AS (
+    SELECT id, slug, row_number() OVER (PARTITION BY series_id, slug ORDER BY created_at, id) AS n
+    FROM base
+)
+UPDATE episodes e
+SET slug = CASE WHEN n.n = 1 THEN n.slug ELSE n.slug || '-' || left(e.id::text, 8) END
+FROM numbered n
+WHERE n.id = e.id;
+
+ALTER TABLE series ALTER COLUMN slug SET NOT NULL;
+ALTER TABLE episodes ALTER COLUMN slug SET NOT NULL;
+
+-- deleted rows keep their slug, so restoring them never clashes
+ALTER TABLE series ADD CONSTRAINT uq_series_slug UNIQUE (slug);
+ALTER TABLE episodes ADD CONSTRAINT uq_episodes_slug UNIQUE (series_id, slug);
+
+CREATE INDEX idx_series_previous_slugs ON series USING GIN (previous_slugs);
+CREATE INDEX idx_episodes_previous_slugs ON episodes USING GIN (previous_slugs);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_episodes_previous_slugs;
+DROP INDEX IF EXISTS idx_series_previous_slugs;
+
+ALTER TABLE episodes
+    DROP CONSTRAINT IF EXISTS uq_episodes_slug,
+    DROP COLUMN IF EXISTS previous_slugs,
+    DROP COLUMN IF EXISTS slug;
+ALTER TABLE series
+    DROP CONSTRAINT IF EXISTS uq_series_slug,
+    DROP COLUMN IF EXISTS previous_slugs,
+    DROP COLUMN IF EXISTS slug;
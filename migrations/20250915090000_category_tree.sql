@@ -0,0 +1,12 @@
+-- +goose Up
+ALTER TABLE categories
+    ADD COLUMN parent_id UUID REFERENCES categories(id),
+    ADD CONSTRAINT chk_categories_parent CHECK (parent_id <> id);
+
+CREATE INDEX idx_categories_parent_id ON categories(parent_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_categories_parent_id;
+ALTER TABLE categories
+    DROP CONSTRAINT IF EXISTS chk_categories_parent,
+    DROP COLUMN IF EXISTS parent_id;
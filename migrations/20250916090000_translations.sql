@@ -0,0 +1,37 @@
+-- +goose Up
+-- Locales are BCP 47 tags in canonical form, such as ar or en-GB. The text
+-- of a series itself is in its language, that of an episode in the language
+-- of its series.
+CREATE TABLE series_translations (
+    series_id UUID NOT NULL REFERENCES series(id) ON DELETE CASCADE,
+    locale VARCHAR(35) NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    description TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (series_id, locale)
+);
+
+CREATE TABLE episode_translations (
+    episode_id UUID NOT NULL REFERENCES episodes(id) ON DELETE CASCADE,
+    locale VARCHAR(35) NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    description TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (episode_id, locale)
+);
+
+CREATE TABLE category_translations (
+    category_id UUID NOT NULL REFERENCES categories(id) ON DELETE CASCADE,
+    locale VARCHAR(35) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (category_id, locale)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS category_translations;
+DROP TABLE IF EXISTS episode_translations;
+DROP TABLE IF EXISTS series_translations;
@@ -0,0 +1,24 @@
+-- +goose Up
+ALTER TABLE episodes
+    ADD COLUMN status TEXT NOT NULL DEFAULT 'draft'
+    CHECK (status IN ('draft', 'scheduled', 'published', 'unpublished'));
+
+-- Existing episodes were searchable already. Those with a future publish
+-- date are scheduled from now on, the others stay published.
+UPDATE episodes
+SET status = CASE WHEN publish_date > NOW() THEN 'scheduled' ELSE 'published' END;
+
+CREATE INDEX idx_episodes_scheduled ON episodes(publish_date)
+    WHERE status = 'scheduled' AND deleted_at IS NULL;
+
+ALTER TABLE audit_events DROP CONSTRAINT audit_events_action_check;
+ALTER TABLE audit_events ADD CONSTRAINT audit_events_action_check
+    CHECK (action IN ('create', 'update', 'delete', 'restore', 'purge', 'publish', 'unpublish'));
+
+-- +goose Down
+ALTER TABLE audit_events DROP CONSTRAINT audit_events_action_check;
+ALTER TABLE audit_events ADD CONSTRAINT audit_events_action_check
+    CHECK (action IN ('create', 'update', 'delete', 'restore', 'purge'));
+
+DROP INDEX IF EXISTS idx_episodes_scheduled;
+ALTER TABLE episodes DROP COLUMN IF EXISTS status;
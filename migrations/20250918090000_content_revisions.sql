@@ -0,0 +1,37 @@
+-- +goose Up
+CREATE TABLE content_revisions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    entity_type TEXT NOT NULL CHECK (entity_type IN ('series', 'episode')),
+    entity_id UUID NOT NULL,
+    revision INT NOT NULL CHECK (revision > 0),
+    author TEXT NOT NULL,
+    snapshot JSONB NOT NULL,
+    rollback_of INT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (entity_type, entity_id, revision)
+);
+
+-- +goose StatementBegin
+CREATE FUNCTION content_revisions_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'content_revisions is append-only';
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER trg_content_revisions_append_only
+BEFORE UPDATE OR DELETE ON content_revisions
+FOR EACH ROW EXECUTE FUNCTION content_revisions_append_only();
+
+-- Existing series and episodes start with their current state as revision 1.
+INSERT INTO content_revisions (entity_type, entity_id, revision, author, snapshot, created_at)
+SELECT 'series', s.id, 1, 'system', to_jsonb(s), s.updated_at
+FROM series s;
+
+INSERT INTO content_revisions (entity_type, entity_id, revision, author, snapshot, created_at)
+SELECT 'episode', e.id, 1, 'system', to_jsonb(e), e.updated_at
+FROM episodes e;
+
+-- +goose Down
+DROP TABLE IF EXISTS content_revisions;
+DROP FUNCTION IF EXISTS content_revisions_append_only();
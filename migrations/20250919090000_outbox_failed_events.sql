@@ -0,0 +1,13 @@
+-- +goose Up
+ALTER TABLE outbox_events ADD COLUMN failed_at TIMESTAMPTZ;
+
+-- Events that kept failing are parked and no longer claimed by the relay.
+DROP INDEX IF EXISTS idx_outbox_events_pending;
+CREATE INDEX idx_outbox_events_pending ON outbox_events(id)
+    WHERE sent_at IS NULL AND failed_at IS NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_outbox_events_pending;
+CREATE INDEX idx_outbox_events_pending ON outbox_events(id) WHERE sent_at IS NULL;
+
+ALTER TABLE outbox_events DROP COLUMN IF EXISTS failed_at;
@@ -0,0 +1,26 @@
+package v1
+
+import (
+	"encoding/json"
+	"th-application-technical-assignment/pkg/util"
+	"time"
+)
+
+type PaginatedAuditEventResponse = util.PaginatedResponse[AuditEventResponse]
+
+type AuditEventResponse struct {
+	ID         string                      `json:"id"`
+	Actor      string                      `json:"actor"`
+	Action     string                      `json:"action" enums:"create,update,delete,restore,purge,publish,unpublish"`
+	EntityType string                      `json:"entity_type" enums:"series,episode,category,asset"`
+	EntityID   string                      `json:"entity_id"`
+	Changes    map[string]AuditFieldChange `json:"changes"`
+	CreatedAt  time.Time                   `json:"created_at"`
+}
+
+// AuditFieldChange holds the JSON values of a field before and after a
+// change. Before is null for creations and After is null for deletions.
+type AuditFieldChange struct {
+	Before json.RawMessage `json:"before" swaggertype:"object"`
+	After  json.RawMessage `json:"after" swaggertype:"object"`
+}
@@ -5,14 +5,49 @@ import "th-application-technical-assignment/pkg/util"
 type PaginatedCategoryResponse = util.PaginatedResponse[CategoryResponse]
 
 type CategoryResponse struct {
-	ID   string `json:"id"`
-	Slug string `json:"slug"`
+	ID       string  `json:"id"`
+	Name     string  `json:"name"`
+	Slug     string  `json:"slug"`
+	ParentID *string `json:"parent_id,omitempty"`
+	// Locale is set when the name is the translation into this locale.
+	Locale *string `json:"locale,omitempty"`
 }
 
 type CreateCategoryRequest struct {
-	Name string `json:"name" validate:"required,min=1,max=100"`
+	Name     string  `json:"name" validate:"required,min=1,max=100"`
+	ParentID *string `json:"parent_id,omitempty" validate:"omitempty,uuid"`
 }
 
 type UpdateCategoryRequest struct {
 	Name string `json:"name" validate:"required,min=1,max=100"`
 }
+
+// MoveCategoryRequest moves a category below another one. A missing or null
+// ParentID makes it a root category.
+type MoveCategoryRequest struct {
+	ParentID *string `json:"parent_id" validate:"omitempty,uuid"`
+}
+
+// CategoryTreeNode is a live category with the live categories below it,
+// both ordered by name.
+type CategoryTreeNode struct {
+	ID       string             `json:"id"`
+	Name     string             `json:"name"`
+	Slug     string             `json:"slug"`
+	Locale   *string            `json:"locale,omitempty"`
+	Children []CategoryTreeNode `json:"children"`
+}
+
+// CategoryInUseResponse is returned when a category cannot be deleted
+// because live series still belong to it. Series lists at most the first
+// dependent series by title, SeriesCount counts all of them.
+type CategoryInUseResponse struct {
+	Error       string            `json:"error"`
+	SeriesCount int64             `json:"series_count"`
+	Series      []DependentSeries `json:"series"`
+}
+
+type DependentSeries struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+}
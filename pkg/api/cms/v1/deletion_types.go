@@ -0,0 +1,25 @@
+package v1
+
+import "time"
+
+type DeletionJobResponse struct {
+	ID            string          `json:"id"`
+	SeriesID      string          `json:"series_id"`
+	RequestedBy   string          `json:"requested_by"`
+	DeleteObjects bool            `json:"delete_objects"`
+	Status        string          `json:"status" enums:"queued,running,succeeded,failed"`
+	EpisodeCount  int32           `json:"episode_count"`
+	AssetCount    int32           `json:"asset_count"`
+	ObjectCount   int32           `json:"object_count"`
+	Errors        []DeletionError `json:"errors"`
+	StartedAt     *time.Time      `json:"started_at,omitempty"`
+	FinishedAt    *time.Time      `json:"finished_at,omitempty"`
+	CreatedAt     time.Time       `json:"created_at"`
+	UpdatedAt     time.Time       `json:"updated_at"`
+}
+
+type DeletionError struct {
+	AssetID string `json:"asset_id,omitempty"`
+	Key     string `json:"key,omitempty"`
+	Error   string `json:"error"`
+}
@@ -11,12 +11,17 @@ type EpisodeResponse struct {
 	ID              string     `json:"id"`
 	SeriesID        string     `json:"series_id"`
 	Title           string     `json:"title"`
+	Slug            string     `json:"slug"`
 	Description     *string    `json:"description,omitempty"`
 	DurationSeconds *int32     `json:"duration_seconds,omitempty"`
 	PublishDate     *time.Time `json:"publish_date,omitempty"`
+	Status          string     `json:"status" enums:"draft,scheduled,published,unpublished"`
 	CreatedAt       time.Time  `json:"created_at"`
 	UpdatedAt       time.Time  `json:"updated_at"`
 	Assets          []EpisodeAssetResponse `json:"assets"`
+	// Locale is set when the title and description are the translation
+	// into this locale.
+	Locale *string `json:"locale,omitempty"`
 }
 
 type CreateEpisodeRequest struct {
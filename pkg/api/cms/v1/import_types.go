@@ -1,8 +1,36 @@
 package v1
 
+import (
+	"th-application-technical-assignment/pkg/util"
+	"time"
+)
+
 type ImportRequest struct {
 	SourceType string `json:"source_type" validate:"required,oneof=youtube spotify rss vimeo"`
 	SourceURL  string `json:"source_url" validate:"required"`
 	SeriesID   string `json:"series_id" validate:"required,uuid"`
 }
 
+type PaginatedImportJobResponse = util.PaginatedResponse[ImportJobResponse]
+
+type ImportJobResponse struct {
+	ID           string            `json:"id"`
+	SeriesID     string            `json:"series_id"`
+	SourceType   string            `json:"source_type"`
+	SourceURL    string            `json:"source_url"`
+	Status       string            `json:"status" enums:"queued,running,succeeded,failed"`
+	CreatedCount int32             `json:"created_count"`
+	UpdatedCount int32             `json:"updated_count"`
+	SkippedCount int32             `json:"skipped_count"`
+	Errors       []ImportItemError `json:"errors"`
+	StartedAt    *time.Time        `json:"started_at,omitempty"`
+	FinishedAt   *time.Time        `json:"finished_at,omitempty"`
+	CreatedAt    time.Time         `json:"created_at"`
+	UpdatedAt    time.Time         `json:"updated_at"`
+}
+
+type ImportItemError struct {
+	ExternalID string `json:"external_id,omitempty"`
+	Title      string `json:"title,omitempty"`
+	Error      string `json:"error"`
+}
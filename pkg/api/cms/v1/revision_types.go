@@ -0,0 +1,33 @@
+package v1
+
+import (
+	"encoding/json"
+	"th-application-technical-assignment/pkg/util"
+	"time"
+)
+
+type PaginatedRevisionResponse = util.PaginatedResponse[RevisionResponse]
+
+// RevisionResponse is a stored version of a series or an episode. Snapshot
+// holds the whole row as it was after the change.
+type RevisionResponse struct {
+	ID         string `json:"id"`
+	EntityType string `json:"entity_type" enums:"series,episode"`
+	EntityID   string `json:"entity_id"`
+	Revision   int32  `json:"revision"`
+	Author     string `json:"author"`
+	// RollbackOf is the revision a rollback restored.
+	RollbackOf *int32          `json:"rollback_of,omitempty"`
+	Snapshot   json.RawMessage `json:"snapshot" swaggertype:"object"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+// RevisionDiffResponse lists the fields that differ between two revisions
+// of an entity, Before holding the value in From and After the one in To.
+type RevisionDiffResponse struct {
+	EntityType string                      `json:"entity_type" enums:"series,episode"`
+	EntityID   string                      `json:"entity_id"`
+	From       int32                       `json:"from"`
+	To         int32                       `json:"to"`
+	Changes    map[string]AuditFieldChange `json:"changes"`
+}
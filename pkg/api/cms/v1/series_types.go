@@ -10,12 +10,16 @@ type PaginatedSeriesResponse = util.PaginatedResponse[SeriesResponse]
 type SeriesResponse struct {
 	ID          string    `json:"id"`
 	Title       string    `json:"title"`
+	Slug        string    `json:"slug"`
 	Description *string   `json:"description,omitempty"`
 	CategoryID  string    `json:"category_id"`
 	Language    *string   `json:"language,omitempty"`
 	Type        string    `json:"type"`
 	CreatedAt   time.Time `json:"createdAt"`
 	UpdatedAt   time.Time `json:"updatedAt"`
+	// Locale is set when the title and description are the translation
+	// into this locale.
+	Locale *string `json:"locale,omitempty"`
 }
 
 
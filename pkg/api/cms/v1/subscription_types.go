@@ -0,0 +1,23 @@
+package v1
+
+import "time"
+
+type SubscriptionRequest struct {
+	SourceType      string `json:"source_type" validate:"required,oneof=youtube spotify rss vimeo"`
+	SourceURL       string `json:"source_url" validate:"required,url"`
+	IntervalSeconds int32  `json:"interval_seconds" validate:"required,min=300,max=604800"`
+}
+
+type SubscriptionResponse struct {
+	SeriesID        string             `json:"series_id"`
+	SourceType      string             `json:"source_type"`
+	SourceURL       string             `json:"source_url"`
+	IntervalSeconds int32              `json:"interval_seconds"`
+	ETag            *string            `json:"etag,omitempty"`
+	LastModified    *string            `json:"last_modified,omitempty"`
+	NextSyncAt      time.Time          `json:"next_sync_at"`
+	LastSyncedAt    *time.Time         `json:"last_synced_at,omitempty"`
+	LastSync        *ImportJobResponse `json:"last_sync,omitempty"`
+	CreatedAt       time.Time          `json:"created_at"`
+	UpdatedAt       time.Time          `json:"updated_at"`
+}
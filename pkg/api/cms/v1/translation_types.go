@@ -0,0 +1,41 @@
+package v1
+
+import "time"
+
+// SeriesTranslationRequest sets the title and description of a series in
+// the locale of the request path.
+type SeriesTranslationRequest struct {
+	Title       string  `json:"title" validate:"required,min=1,max=255"`
+	Description *string `json:"description,omitempty" validate:"omitempty,max=1000"`
+}
+
+// EpisodeTranslationRequest sets the title and description of an episode in
+// the locale of the request path.
+type EpisodeTranslationRequest struct {
+	Title       string  `json:"title" validate:"required,min=1,max=255"`
+	Description *string `json:"description,omitempty" validate:"omitempty,max=2000"`
+}
+
+// CategoryTranslationRequest sets the name of a category in the locale of
+// the request path.
+type CategoryTranslationRequest struct {
+	Name string `json:"name" validate:"required,min=1,max=100"`
+}
+
+// TranslationResponse is the title and description of a series or an
+// episode in one locale.
+type TranslationResponse struct {
+	Locale      string    `json:"locale"`
+	Title       string    `json:"title"`
+	Description *string   `json:"description,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// CategoryTranslationResponse is the name of a category in one locale.
+type CategoryTranslationResponse struct {
+	Locale    string    `json:"locale"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -0,0 +1,18 @@
+package v1
+
+import (
+	"th-application-technical-assignment/pkg/util"
+	"time"
+)
+
+type PaginatedTrashItemResponse = util.PaginatedResponse[TrashItemResponse]
+
+// TrashItemResponse is a deleted category, series or episode. Title is the
+// slug of a category.
+type TrashItemResponse struct {
+	Type      string    `json:"type" enums:"category,series,episode"`
+	ID        string    `json:"id"`
+	Title     string    `json:"title"`
+	SeriesID  *string   `json:"series_id,omitempty"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
@@ -5,8 +5,11 @@ type SearchSeriesRequest struct {
 	Page       int     `json:"page" validate:"min=1"`
 	PageSize   int     `json:"page_size" validate:"min=1,max=100"`
 	CategoryID *string `json:"category_id,omitempty" validate:"omitempty,uuid"`
-	Type       *string `json:"type,omitempty" validate:"omitempty,oneof=documentary podcast blog"`
-	Language   *string `json:"language,omitempty"`
+	// IncludeDescendants also matches series of the categories below
+	// CategoryID.
+	IncludeDescendants bool    `json:"include_descendants,omitempty"`
+	Type               *string `json:"type,omitempty" validate:"omitempty,oneof=documentary podcast blog"`
+	Language           *string `json:"language,omitempty"`
 }
 
 type SearchEpisodesRequest struct {
@@ -0,0 +1,152 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"th-application-technical-assignment/sqlc"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// Actions recorded in audit_events.action.
+const (
+	ActionCreate  = "create"
+	ActionUpdate  = "update"
+	ActionDelete  = "delete"
+	ActionRestore = "restore"
+	ActionPurge   = "purge"
+
+	// Publishing changes the status of an episode, see package publishing.
+	ActionPublish   = "publish"
+	ActionUnpublish = "unpublish"
+)
+
+// Entity types recorded in audit_events.entity_type.
+const (
+	EntitySeries   = "series"
+	EntityEpisode  = "episode"
+	EntityCategory = "category"
+	EntityAsset    = "asset"
+
+	// Translations are recorded with the id of the series, episode or
+	// category they translate, so they show up in its history.
+	EntitySeriesTranslation   = "series_translation"
+	EntityEpisodeTranslation  = "episode_translation"
+	EntityCategoryTranslation = "category_translation"
+)
+
+// UnknownActor is recorded for changes made without an authenticated subject.
+const UnknownActor = "unknown"
+
+// SystemActor is recorded for changes made by background jobs on their own,
+// such as purging expired trash.
+const SystemActor = "system"
+
+// ignoredFields change on every write and would only add noise to a diff.
+var ignoredFields = map[string]bool{
+	"created_at": true,
+	"updated_at": true,
+}
+
+// Event is a change to a single entity. Before is nil for creations and
+// After is nil for deletions.
+type Event struct {
+	Actor      string
+	Action     string
+	EntityType string
+	EntityID   uuid.UUID
+	Before     any
+	After      any
+}
+
+// FieldChange is the value of a field before and after a change.
+type FieldChange struct {
+	Before any `json:"before"`
+	After  any `json:"after"`
+}
+
+// Record stores the event with q. Call it with the queries of the
+// transaction that makes the change, so the change and its audit row are
+// committed together.
+func Record(ctx context.Context, q sqlc.Querier, e Event) error {
+	changes, err := Diff(e.Before, e.After)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(changes)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal audit changes")
+	}
+
+	actor := e.Actor
+	if actor == "" {
+		actor = UnknownActor
+	}
+
+	params := sqlc.CreateAuditEventParams{
+		Actor:      actor,
+		Action:     e.Action,
+		EntityType: e.EntityType,
+		EntityID:   e.EntityID,
+		Changes:    data,
+	}
+	if err := q.CreateAuditEvent(ctx, params); err != nil {
+		return errors.Wrap(err, "failed to record audit event")
+	}
+
+	return nil
+}
+
+// Diff compares the JSON representations of two values field by field and
+// returns the fields that differ. A nil value has no fields, so diffing
+// against nil lists every field of the other value.
+func Diff(before, after any) (map[string]FieldChange, error) {
+	b, err := fields(before)
+	if err != nil {
+		return nil, err
+	}
+	a, err := fields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := map[string]FieldChange{}
+	for k, v := range b {
+		if ignoredFields[k] {
+			continue
+		}
+		if !reflect.DeepEqual(v, a[k]) {
+			changes[k] = FieldChange{Before: v, After: a[k]}
+		}
+	}
+	for k, v := range a {
+		if ignoredFields[k] {
+			continue
+		}
+		if _, ok := b[k]; !ok && v != nil {
+			changes[k] = FieldChange{Before: nil, After: v}
+		}
+	}
+
+	return changes, nil
+}
+
+func fields(v any) (map[string]any, error) {
+	if v == nil {
+		return map[string]any{}, nil
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal audited entity")
+	}
+
+	m := map[string]any{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, errors.Wrap(err, "audited entity is not an object")
+	}
+	return m, nil
+}
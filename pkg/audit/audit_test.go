@@ -0,0 +1,147 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"th-application-technical-assignment/pkg/database"
+	"th-application-technical-assignment/sqlc"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	id := uuid.New()
+	before := sqlc.Category{ID: id, Name: "Technology", Slug: "technology", CreatedAt: time.Now()}
+	after := sqlc.Category{ID: id, Name: "Technology", Slug: "tech", CreatedAt: time.Now(), UpdatedAt: time.Now()}
+
+	tests := []struct {
+		name     string
+		before   any
+		after    any
+		expected map[string]FieldChange
+	}{
+		{
+			name:   "update lists changed fields only",
+			before: before,
+			after:  after,
+			expected: map[string]FieldChange{
+				"slug": {Before: "technology", After: "tech"},
+			},
+		},
+		{
+			name:  "create lists every set field",
+			after: before,
+			expected: map[string]FieldChange{
+				"id":   {Before: nil, After: id.String()},
+				"name": {Before: nil, After: "Technology"},
+				"slug": {Before: nil, After: "technology"},
+			},
+		},
+		{
+			name:   "delete lists every set field",
+			before: before,
+			expected: map[string]FieldChange{
+				"id":   {Before: id.String(), After: nil},
+				"name": {Before: "Technology", After: nil},
+				"slug": {Before: "technology", After: nil},
+			},
+		},
+		{
+			name:     "no changes",
+			before:   before,
+			after:    before,
+			expected: map[string]FieldChange{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			changes, err := Diff(tt.before, tt.after)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, changes)
+		})
+	}
+}
+
+func TestDiff_NotAnObject(t *testing.T) {
+	t.Parallel()
+
+	_, err := Diff("technology", nil)
+	assert.Error(t, err)
+}
+
+func TestRecord(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		actor         string
+		dbError       error
+		expectedActor string
+		expectError   bool
+	}{
+		{
+			name:          "records the actor",
+			actor:         "user-1",
+			expectedActor: "user-1",
+		},
+		{
+			name:          "missing actor",
+			expectedActor: UnknownActor,
+		},
+		{
+			name:          "database error",
+			actor:         "user-1",
+			dbError:       assert.AnError,
+			expectedActor: "user-1",
+			expectError:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockQueries := new(database.MockQuerier)
+			id := uuid.New()
+
+			mockQueries.On("CreateAuditEvent", mock.Anything, mock.MatchedBy(func(params sqlc.CreateAuditEventParams) bool {
+				var changes map[string]FieldChange
+				if err := json.Unmarshal(params.Changes, &changes); err != nil {
+					return false
+				}
+				return params.Actor == tt.expectedActor &&
+					params.Action == ActionUpdate &&
+					params.EntityType == EntityCategory &&
+					params.EntityID == id &&
+					len(changes) == 1 &&
+					changes["slug"] == FieldChange{Before: "technology", After: "tech"}
+			})).Return(tt.dbError)
+
+			err := Record(context.Background(), mockQueries, Event{
+				Actor:      tt.actor,
+				Action:     ActionUpdate,
+				EntityType: EntityCategory,
+				EntityID:   id,
+				Before:     sqlc.Category{ID: id, Slug: "technology"},
+				After:      sqlc.Category{ID: id, Slug: "tech"},
+			})
+
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			mockQueries.AssertExpectations(t)
+		})
+	}
+}
@@ -1,8 +1,17 @@
 package auth
 
+import "time"
+
+// Config selects the keys tokens are verified with. KeysURL takes precedence
+// over VerificationKey, which takes precedence over the shared SigningKey.
+// SigningAlg only applies to SigningKey; asymmetric keys carry their own
+// algorithm or have it inferred from the key type.
 type Config struct {
-    SigningKey string `env:"SIGNING_KEY" envDefault:"secret"`
-    SigningAlg string `env:"SIGNING_ALG" envDefault:"HS256"`
-    VerificationKey string `env:"VERIFICATION_KEY"`
+	SigningKey      string        `env:"SIGNING_KEY" envDefault:"secret"`
+	SigningAlg      string        `env:"SIGNING_ALG" envDefault:"HS256"`
+	VerificationKey string        `env:"VERIFICATION_KEY"`
+	KeysURL         string        `env:"KEYS_URL"`
+	KeysRefresh     time.Duration `env:"KEYS_REFRESH" envDefault:"15m"`
+	Issuer          string        `env:"ISSUER"`
+	Audience        string        `env:"AUDIENCE"`
 }
-
@@ -0,0 +1,231 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/pkg/errors"
+)
+
+const (
+	maxKeysSize = 1 << 20
+
+	// minKeysRefresh limits how often a token signed with an unknown key ID
+	// can make the key set reload.
+	minKeysRefresh = 30 * time.Second
+)
+
+// KeySet holds the public keys tokens are verified with. Keys loaded from a
+// file or URL are reloaded periodically and whenever a token names a key ID
+// the set does not know yet, so rotated keys are picked up without a restart.
+type KeySet struct {
+	source     string
+	client     *http.Client
+	minRefresh time.Duration
+
+	mu          sync.RWMutex
+	set         jwk.Set
+	lastRefresh time.Time
+	// lastAttempt is when a token with an unknown key ID last made the set
+	// reload, successful or not.
+	lastAttempt time.Time
+}
+
+// NewKeySet loads the keys at source, a file path, a file:// URL or an
+// http(s) URL, holding either PEM encoded public keys or a JWKS document.
+func NewKeySet(ctx context.Context, source string, client *http.Client) (*KeySet, error) {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	ks := &KeySet{source: source, client: client, minRefresh: minKeysRefresh}
+	if err := ks.Refresh(ctx); err != nil {
+		return nil, err
+	}
+	return ks, nil
+}
+
+// StaticKeySet wraps keys that are never reloaded.
+func StaticKeySet(set jwk.Set) *KeySet {
+	return &KeySet{set: set}
+}
+
+// HMACKeySet returns a set holding a single shared secret for alg.
+func HMACKeySet(secret []byte, alg string) (*KeySet, error) {
+	key, err := jwk.FromRaw(secret)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid signing key")
+	}
+	if err := key.Set(jwk.AlgorithmKey, jwa.SignatureAlgorithm(alg)); err != nil {
+		return nil, errors.Wrap(err, "invalid signing algorithm")
+	}
+
+	set := jwk.NewSet()
+	if err := set.AddKey(key); err != nil {
+		return nil, errors.Wrap(err, "failed to add signing key")
+	}
+	return StaticKeySet(set), nil
+}
+
+// Keys returns the current keys.
+func (ks *KeySet) Keys() jwk.Set {
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+	return ks.set
+}
+
+// Has reports whether the set holds a key with the given key ID.
+func (ks *KeySet) Has(kid string) bool {
+	_, ok := ks.Keys().LookupKeyID(kid)
+	return ok
+}
+
+// Anonymous reports whether the set holds keys without a key ID, as PEM
+// encoded keys are. Tokens can then only be matched by trying every key.
+func (ks *KeySet) Anonymous() bool {
+	set := ks.Keys()
+	for i := range set.Len() {
+		if key, _ := set.Key(i); key.KeyID() == "" {
+			return true
+		}
+	}
+	return false
+}
+
+// Refresh reloads the keys from their source. On failure the previous keys
+// stay in use.
+func (ks *KeySet) Refresh(ctx context.Context) error {
+	if ks.source == "" {
+		return nil
+	}
+
+	data, err := ks.fetch(ctx)
+	if err != nil {
+		return err
+	}
+
+	set, err := ParseKeys(data)
+	if err != nil {
+		return err
+	}
+
+	ks.mu.Lock()
+	ks.set = set
+	ks.lastRefresh = time.Now()
+	ks.mu.Unlock()
+
+	return nil
+}
+
+// refreshUnknown reloads the keys after a token named a key ID the set does
+// not hold, unless the set was refreshed or tried recently. The attempt is
+// claimed before fetching, so concurrent tokens and a failing key source
+// cause at most one fetch per minRefresh.
+func (ks *KeySet) refreshUnknown(ctx context.Context, kid string) {
+	if ks.source == "" {
+		return
+	}
+
+	ks.mu.Lock()
+	now := time.Now()
+	if now.Sub(ks.lastRefresh) < ks.minRefresh || now.Sub(ks.lastAttempt) < ks.minRefresh {
+		ks.mu.Unlock()
+		return
+	}
+	ks.lastAttempt = now
+	ks.mu.Unlock()
+
+	if err := ks.Refresh(ctx); err != nil {
+		slog.WarnContext(ctx, "failed to refresh verification keys", "err", err, "kid", kid)
+	}
+}
+
+// Run reloads the keys every interval until ctx is done.
+func (ks *KeySet) Run(ctx context.Context, interval time.Duration) {
+	if ks.source == "" || interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := ks.Refresh(ctx); err != nil {
+				slog.WarnContext(ctx, "failed to refresh verification keys", "err", err, "source", ks.source)
+			}
+		}
+	}
+}
+
+func (ks *KeySet) fetch(ctx context.Context) ([]byte, error) {
+	if !strings.HasPrefix(ks.source, "http://") && !strings.HasPrefix(ks.source, "https://") {
+		data, err := os.ReadFile(strings.TrimPrefix(ks.source, "file://"))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read keys")
+		}
+		return data, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ks.source, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build keys request")
+	}
+	req.Header.Set("Accept", "application/jwk-set+json, application/json, application/x-pem-file")
+
+	res, err := ks.client.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to fetch keys")
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("failed to fetch keys: unexpected status %d", res.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(res.Body, maxKeysSize))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read keys")
+	}
+	return data, nil
+}
+
+// ParseKeys parses a JWKS document or one or more PEM encoded keys into a
+// set of public keys.
+func ParseKeys(data []byte) (jwk.Set, error) {
+	data = bytes.TrimSpace(data)
+
+	var set jwk.Set
+	var err error
+	if bytes.HasPrefix(data, []byte("{")) {
+		set, err = jwk.Parse(data)
+	} else {
+		set, err = jwk.Parse(data, jwk.WithPEM(true))
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse keys")
+	}
+
+	set, err = jwk.PublicSetOf(set)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get public keys")
+	}
+
+	if set.Len() == 0 {
+		return nil, errors.New("no keys found")
+	}
+
+	return set, nil
+}
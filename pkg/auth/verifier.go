@@ -0,0 +1,119 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/jwtauth/v5"
+	"github.com/lestrrat-go/jwx/v2/jws"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+	"github.com/pkg/errors"
+)
+
+const acceptableSkew = 30 * time.Second
+
+// Verifier checks the signature and claims of bearer tokens against a
+// KeySet. The key is selected by the "kid" header of the token; tokens
+// without one are accepted when the set holds a single key or keys without
+// an ID.
+type Verifier struct {
+	keys     *KeySet
+	validate []jwt.ValidateOption
+	refresh  time.Duration
+}
+
+func NewVerifier(ctx context.Context, cfg *Config) (*Verifier, error) {
+	if cfg == nil {
+		return nil, errors.New("auth config is nil")
+	}
+
+	var keys *KeySet
+	var err error
+	switch {
+	case cfg.KeysURL != "":
+		keys, err = NewKeySet(ctx, cfg.KeysURL, nil)
+	case cfg.VerificationKey != "":
+		set, parseErr := ParseKeys([]byte(cfg.VerificationKey))
+		keys, err = StaticKeySet(set), parseErr
+	default:
+		keys, err = HMACKeySet([]byte(cfg.SigningKey), cfg.SigningAlg)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load verification keys")
+	}
+
+	validate := []jwt.ValidateOption{jwt.WithAcceptableSkew(acceptableSkew)}
+	if cfg.Issuer != "" {
+		validate = append(validate, jwt.WithIssuer(cfg.Issuer))
+	}
+	if cfg.Audience != "" {
+		validate = append(validate, jwt.WithAudience(cfg.Audience))
+	}
+
+	return &Verifier{keys: keys, validate: validate, refresh: cfg.KeysRefresh}, nil
+}
+
+// Start reloads keys loaded from a file or URL in the background until ctx
+// is done.
+func (v *Verifier) Start(ctx context.Context) {
+	go v.keys.Run(ctx, v.refresh)
+}
+
+// Verify parses a token and checks its signature, lifetime, issuer and
+// audience.
+func (v *Verifier) Verify(ctx context.Context, tokenString string) (jwt.Token, error) {
+	var kid string
+	if msg, err := jws.ParseString(tokenString); err == nil && len(msg.Signatures()) > 0 {
+		kid = msg.Signatures()[0].ProtectedHeaders().KeyID()
+	}
+	if kid != "" && !v.keys.Has(kid) {
+		v.keys.refreshUnknown(ctx, kid)
+	}
+
+	keySetOpts := []any{jws.WithInferAlgorithmFromKey(true), jws.WithUseDefault(true)}
+	if (kid == "" || !v.keys.Has(kid)) && v.keys.Anonymous() {
+		keySetOpts = append(keySetOpts, jws.WithRequireKid(false))
+	}
+
+	token, err := jwt.ParseString(tokenString,
+		jwt.WithKeySet(v.keys.Keys(), keySetOpts...),
+		jwt.WithValidate(false),
+	)
+	if err != nil {
+		return nil, jwtauth.ErrUnauthorized
+	}
+
+	if err := jwt.Validate(token, v.validate...); err != nil {
+		return token, jwtauth.ErrorReason(err)
+	}
+
+	return token, nil
+}
+
+// Verifier is a drop-in replacement for jwtauth.Verifier: it stores the
+// verified token, or the reason it was rejected, in the request context for
+// jwtauth.FromContext.
+func (v *Verifier) Verifier(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		var token jwt.Token
+		var err error
+		if tokenString := findToken(r); tokenString == "" {
+			err = jwtauth.ErrNoTokenFound
+		} else {
+			token, err = v.Verify(ctx, tokenString)
+		}
+
+		ctx = jwtauth.NewContext(ctx, token, err)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func findToken(r *http.Request) string {
+	if t := jwtauth.TokenFromHeader(r); t != "" {
+		return t
+	}
+	return jwtauth.TokenFromCookie(r)
+}
@@ -0,0 +1,356 @@
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRSAKey(t *testing.T, kid string) jwk.Key {
+	t.Helper()
+
+	raw, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	key, err := jwk.FromRaw(raw)
+	require.NoError(t, err)
+	require.NoError(t, key.Set(jwk.KeyIDKey, kid))
+	require.NoError(t, key.Set(jwk.AlgorithmKey, jwa.RS256))
+	return key
+}
+
+func signToken(t *testing.T, alg jwa.SignatureAlgorithm, key jwk.Key, claims map[string]any) string {
+	t.Helper()
+
+	tok := jwt.New()
+	require.NoError(t, tok.Set(jwt.SubjectKey, "user-1"))
+	require.NoError(t, tok.Set(jwt.ExpirationKey, time.Now().Add(time.Hour)))
+	for k, v := range claims {
+		require.NoError(t, tok.Set(k, v))
+	}
+
+	signed, err := jwt.Sign(tok, jwt.WithKey(alg, key))
+	require.NoError(t, err)
+	return string(signed)
+}
+
+// jwksServer serves the public part of the keys it currently holds.
+type jwksServer struct {
+	mu   sync.Mutex
+	keys []jwk.Key
+}
+
+func (s *jwksServer) setKeys(keys ...jwk.Key) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keys = keys
+}
+
+func (s *jwksServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	set := jwk.NewSet()
+	for _, k := range s.keys {
+		set.AddKey(k)
+	}
+	public, _ := jwk.PublicSetOf(set)
+	w.Header().Set("Content-Type", "application/jwk-set+json")
+	json.NewEncoder(w).Encode(public)
+}
+
+func TestVerifier_JWKS(t *testing.T) {
+	t.Parallel()
+
+	current := newRSAKey(t, "key-1")
+	jwks := &jwksServer{}
+	jwks.setKeys(current)
+	srv := httptest.NewServer(jwks)
+	t.Cleanup(srv.Close)
+
+	v, err := NewVerifier(context.Background(), &Config{
+		KeysURL:  srv.URL,
+		Issuer:   "https://idp.example.com",
+		Audience: "cms",
+	})
+	require.NoError(t, err)
+
+	valid := map[string]any{jwt.IssuerKey: "https://idp.example.com", jwt.AudienceKey: "cms"}
+
+	tests := []struct {
+		name        string
+		token       string
+		expectError bool
+	}{
+		{
+			name:  "valid token",
+			token: signToken(t, jwa.RS256, current, valid),
+		},
+		{
+			name:        "wrong issuer",
+			token:       signToken(t, jwa.RS256, current, map[string]any{jwt.IssuerKey: "https://evil.example.com", jwt.AudienceKey: "cms"}),
+			expectError: true,
+		},
+		{
+			name:        "wrong audience",
+			token:       signToken(t, jwa.RS256, current, map[string]any{jwt.IssuerKey: "https://idp.example.com", jwt.AudienceKey: "discovery"}),
+			expectError: true,
+		},
+		{
+			name:        "expired",
+			token:       signToken(t, jwa.RS256, current, map[string]any{jwt.IssuerKey: "https://idp.example.com", jwt.AudienceKey: "cms", jwt.ExpirationKey: time.Now().Add(-time.Hour)}),
+			expectError: true,
+		},
+		{
+			name:        "unknown key",
+			token:       signToken(t, jwa.RS256, newRSAKey(t, "key-x"), valid),
+			expectError: true,
+		},
+		{
+			name:        "signed by another key with the same kid",
+			token:       signToken(t, jwa.RS256, newRSAKey(t, "key-1"), valid),
+			expectError: true,
+		},
+		{
+			name:        "malformed",
+			token:       "not-a-token",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			token, err := v.Verify(context.Background(), tt.token)
+
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, "user-1", token.Subject())
+			}
+		})
+	}
+}
+
+func TestVerifier_KeyRotation(t *testing.T) {
+	t.Parallel()
+
+	oldKey := newRSAKey(t, "key-1")
+	newKey := newRSAKey(t, "key-2")
+
+	jwks := &jwksServer{}
+	jwks.setKeys(oldKey)
+	srv := httptest.NewServer(jwks)
+	t.Cleanup(srv.Close)
+
+	v, err := NewVerifier(context.Background(), &Config{KeysURL: srv.URL})
+	require.NoError(t, err)
+	v.keys.minRefresh = 0
+
+	_, err = v.Verify(context.Background(), signToken(t, jwa.RS256, oldKey, nil))
+	require.NoError(t, err)
+
+	// the provider publishes the new key next to the old one, then drops the old one
+	jwks.setKeys(oldKey, newKey)
+	_, err = v.Verify(context.Background(), signToken(t, jwa.RS256, newKey, nil))
+	require.NoError(t, err)
+
+	jwks.setKeys(newKey)
+	require.NoError(t, v.keys.Refresh(context.Background()))
+	_, err = v.Verify(context.Background(), signToken(t, jwa.RS256, oldKey, nil))
+	assert.Error(t, err)
+}
+
+func TestVerifier_RefreshRateLimited(t *testing.T) {
+	t.Parallel()
+
+	var requests int
+	var mu sync.Mutex
+	key := newRSAKey(t, "key-1")
+	jwks := &jwksServer{}
+	jwks.setKeys(key)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests++
+		mu.Unlock()
+		jwks.ServeHTTP(w, r)
+	}))
+	t.Cleanup(srv.Close)
+
+	v, err := NewVerifier(context.Background(), &Config{KeysURL: srv.URL})
+	require.NoError(t, err)
+
+	for range 5 {
+		_, err := v.Verify(context.Background(), signToken(t, jwa.RS256, newRSAKey(t, "unknown"), nil))
+		assert.Error(t, err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, 1, requests)
+}
+
+func TestVerifier_FailedRefreshRateLimited(t *testing.T) {
+	t.Parallel()
+
+	var requests int
+	var failing bool
+	var mu sync.Mutex
+	key := newRSAKey(t, "key-1")
+	jwks := &jwksServer{}
+	jwks.setKeys(key)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests++
+		fail := failing
+		mu.Unlock()
+		if fail {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		jwks.ServeHTTP(w, r)
+	}))
+	t.Cleanup(srv.Close)
+
+	v, err := NewVerifier(context.Background(), &Config{KeysURL: srv.URL})
+	require.NoError(t, err)
+
+	// the last refresh is long ago and the key server is down
+	mu.Lock()
+	failing = true
+	mu.Unlock()
+	v.keys.mu.Lock()
+	v.keys.lastRefresh = time.Time{}
+	v.keys.mu.Unlock()
+
+	token := signToken(t, jwa.RS256, newRSAKey(t, "forged"), nil)
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := v.Verify(context.Background(), token)
+			assert.Error(t, err)
+		}()
+	}
+	wg.Wait()
+	for range 5 {
+		_, err := v.Verify(context.Background(), token)
+		assert.Error(t, err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, 2, requests)
+}
+
+func TestVerifier_PEMFile(t *testing.T) {
+	t.Parallel()
+
+	raw, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	private, err := jwk.FromRaw(raw)
+	require.NoError(t, err)
+
+	public, err := private.PublicKey()
+	require.NoError(t, err)
+	pem, err := jwk.Pem(public)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "public.pem")
+	require.NoError(t, os.WriteFile(path, pem, 0o600))
+
+	for _, source := range []string{path, "file://" + path} {
+		v, err := NewVerifier(context.Background(), &Config{KeysURL: source})
+		require.NoError(t, err)
+
+		token, err := v.Verify(context.Background(), signToken(t, jwa.ES256, private, nil))
+		require.NoError(t, err)
+		assert.Equal(t, "user-1", token.Subject())
+
+		_, err = v.Verify(context.Background(), signToken(t, jwa.RS256, newRSAKey(t, ""), nil))
+		assert.Error(t, err)
+	}
+}
+
+func TestVerifier_InlineVerificationKey(t *testing.T) {
+	t.Parallel()
+
+	private := newRSAKey(t, "key-1")
+	public, err := private.PublicKey()
+	require.NoError(t, err)
+	pem, err := jwk.Pem(public)
+	require.NoError(t, err)
+
+	v, err := NewVerifier(context.Background(), &Config{VerificationKey: string(pem), SigningKey: "secret", SigningAlg: "HS256"})
+	require.NoError(t, err)
+
+	_, err = v.Verify(context.Background(), signToken(t, jwa.RS256, private, nil))
+	require.NoError(t, err)
+
+	// the shared secret is not accepted once public keys are configured
+	secret, err := jwk.FromRaw([]byte("secret"))
+	require.NoError(t, err)
+	_, err = v.Verify(context.Background(), signToken(t, jwa.HS256, secret, nil))
+	assert.Error(t, err)
+}
+
+func TestVerifier_HMAC(t *testing.T) {
+	t.Parallel()
+
+	v, err := NewVerifier(context.Background(), &Config{SigningKey: "secret", SigningAlg: "HS256"})
+	require.NoError(t, err)
+
+	secret, err := jwk.FromRaw([]byte("secret"))
+	require.NoError(t, err)
+	_, err = v.Verify(context.Background(), signToken(t, jwa.HS256, secret, nil))
+	require.NoError(t, err)
+
+	other, err := jwk.FromRaw([]byte("other"))
+	require.NoError(t, err)
+	_, err = v.Verify(context.Background(), signToken(t, jwa.HS256, other, nil))
+	assert.Error(t, err)
+}
+
+func TestNewVerifier_InvalidKeys(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.NotFoundHandler())
+	t.Cleanup(srv.Close)
+
+	tests := []struct {
+		name string
+		cfg  Config
+	}{
+		{name: "unreachable keys url", cfg: Config{KeysURL: srv.URL}},
+		{name: "missing keys file", cfg: Config{KeysURL: filepath.Join(t.TempDir(), "missing.pem")}},
+		{name: "garbage verification key", cfg: Config{VerificationKey: "not a key"}},
+		{name: "empty key set", cfg: Config{VerificationKey: `{"keys":[]}`}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := NewVerifier(context.Background(), &tt.cfg)
+			assert.Error(t, err)
+		})
+	}
+}
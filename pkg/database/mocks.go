@@ -3,6 +3,7 @@ package database
 import (
 	"context"
 	"th-application-technical-assignment/sqlc"
+	"time"
 
 	"github.com/stretchr/testify/mock"
     "github.com/google/uuid"
@@ -27,9 +28,9 @@ func (m *MockQuerier) UpdateEpisode(ctx context.Context, params sqlc.UpdateEpiso
 	return args.Get(0).(sqlc.Episode), args.Error(1)
 }
 
-func (m *MockQuerier) DeleteEpisode(ctx context.Context, id uuid.UUID) error {
+func (m *MockQuerier) DeleteEpisode(ctx context.Context, id uuid.UUID) (*time.Time, error) {
 	args := m.Called(ctx, id)
-	return args.Error(0)
+	return args.Get(0).(*time.Time), args.Error(1)
 }
 
 func (m *MockQuerier) CountEpisodesBySeries(ctx context.Context, seriesID uuid.UUID) (int64, error) {
@@ -52,11 +53,36 @@ func (m *MockQuerier) GetEpisodeWithAssets(ctx context.Context, id uuid.UUID) ([
 	return args.Get(0).([]sqlc.GetEpisodeWithAssetsRow), args.Error(1)
 }
 
+func (m *MockQuerier) GetEpisodeBySource(ctx context.Context, params sqlc.GetEpisodeBySourceParams) (sqlc.Episode, error) {
+	args := m.Called(ctx, params)
+	return args.Get(0).(sqlc.Episode), args.Error(1)
+}
+
+func (m *MockQuerier) UpsertImportedEpisode(ctx context.Context, params sqlc.UpsertImportedEpisodeParams) (sqlc.UpsertImportedEpisodeRow, error) {
+	args := m.Called(ctx, params)
+	return args.Get(0).(sqlc.UpsertImportedEpisodeRow), args.Error(1)
+}
+
 func (m *MockQuerier) ListEpisodesWithAssetsBySeriesPaginated(ctx context.Context, params sqlc.ListEpisodesWithAssetsBySeriesPaginatedParams) ([]sqlc.ListEpisodesWithAssetsBySeriesPaginatedRow, error) {
 	args := m.Called(ctx, params)
 	return args.Get(0).([]sqlc.ListEpisodesWithAssetsBySeriesPaginatedRow), args.Error(1)
 }
 
+func (m *MockQuerier) GetEpisodeBySlug(ctx context.Context, params sqlc.GetEpisodeBySlugParams) (sqlc.Episode, error) {
+	args := m.Called(ctx, params)
+	return args.Get(0).(sqlc.Episode), args.Error(1)
+}
+
+func (m *MockQuerier) GetEpisodeByPreviousSlug(ctx context.Context, params sqlc.GetEpisodeByPreviousSlugParams) (sqlc.Episode, error) {
+	args := m.Called(ctx, params)
+	return args.Get(0).(sqlc.Episode), args.Error(1)
+}
+
+func (m *MockQuerier) ListEpisodeSlugs(ctx context.Context, params sqlc.ListEpisodeSlugsParams) ([]string, error) {
+	args := m.Called(ctx, params)
+	return args.Get(0).([]string), args.Error(1)
+}
+
 // Asset operations
 func (m *MockQuerier) CreateAsset(ctx context.Context, params sqlc.CreateAssetParams) (sqlc.EpisodeAsset, error) {
 	args := m.Called(ctx, params)
@@ -99,9 +125,9 @@ func (m *MockQuerier) UpdateSeries(ctx context.Context, params sqlc.UpdateSeries
 	return args.Get(0).(sqlc.Series), args.Error(1)
 }
 
-func (m *MockQuerier) DeleteSeries(ctx context.Context, id uuid.UUID) error {
+func (m *MockQuerier) DeleteSeries(ctx context.Context, id uuid.UUID) (*time.Time, error) {
 	args := m.Called(ctx, id)
-	return args.Error(0)
+	return args.Get(0).(*time.Time), args.Error(1)
 }
 
 func (m *MockQuerier) CountSeries(ctx context.Context) (int64, error) {
@@ -119,9 +145,29 @@ func (m *MockQuerier) ListSeriesPaginated(ctx context.Context, params sqlc.ListS
 	return args.Get(0).([]sqlc.Series), args.Error(1)
 }
 
-// Category operations
-func (m *MockQuerier) CreateCategory(ctx context.Context, slug string) (sqlc.Category, error) {
+func (m *MockQuerier) GetSeriesBySlug(ctx context.Context, slug string) (sqlc.Series, error) {
+	args := m.Called(ctx, slug)
+	return args.Get(0).(sqlc.Series), args.Error(1)
+}
+
+func (m *MockQuerier) GetSeriesByPreviousSlug(ctx context.Context, slug string) (sqlc.Series, error) {
+	args := m.Called(ctx, slug)
+	return args.Get(0).(sqlc.Series), args.Error(1)
+}
+
+func (m *MockQuerier) ListSeriesSlugs(ctx context.Context, slug string) ([]string, error) {
 	args := m.Called(ctx, slug)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockQuerier) ListSeriesLanguages(ctx context.Context, ids []uuid.UUID) ([]sqlc.ListSeriesLanguagesRow, error) {
+	args := m.Called(ctx, ids)
+	return args.Get(0).([]sqlc.ListSeriesLanguagesRow), args.Error(1)
+}
+
+// Category operations
+func (m *MockQuerier) CreateCategory(ctx context.Context, params sqlc.CreateCategoryParams) (sqlc.Category, error) {
+	args := m.Called(ctx, params)
 	return args.Get(0).(sqlc.Category), args.Error(1)
 }
 
@@ -130,6 +176,16 @@ func (m *MockQuerier) GetCategory(ctx context.Context, id uuid.UUID) (sqlc.Categ
 	return args.Get(0).(sqlc.Category), args.Error(1)
 }
 
+func (m *MockQuerier) GetCategoryForUpdate(ctx context.Context, id uuid.UUID) (sqlc.Category, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(sqlc.Category), args.Error(1)
+}
+
+func (m *MockQuerier) GetCategoryForShare(ctx context.Context, id uuid.UUID) (sqlc.Category, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(sqlc.Category), args.Error(1)
+}
+
 func (m *MockQuerier) UpdateCategory(ctx context.Context, params sqlc.UpdateCategoryParams) (sqlc.Category, error) {
 	args := m.Called(ctx, params)
 	return args.Get(0).(sqlc.Category), args.Error(1)
@@ -154,3 +210,424 @@ func (m *MockQuerier) ListCategoriesPaginated(ctx context.Context, params sqlc.L
 	args := m.Called(ctx, params)
 	return args.Get(0).([]sqlc.Category), args.Error(1)
 }
+
+func (m *MockQuerier) ListCategorySlugs(ctx context.Context, slug string) ([]string, error) {
+	args := m.Called(ctx, slug)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockQuerier) CountSeriesByCategory(ctx context.Context, categoryID uuid.UUID) (int64, error) {
+	args := m.Called(ctx, categoryID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockQuerier) ListSeriesByCategory(ctx context.Context, arg sqlc.ListSeriesByCategoryParams) ([]sqlc.Series, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).([]sqlc.Series), args.Error(1)
+}
+
+func (m *MockQuerier) ReassignSeriesCategory(ctx context.Context, arg sqlc.ReassignSeriesCategoryParams) ([]sqlc.Series, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).([]sqlc.Series), args.Error(1)
+}
+
+func (m *MockQuerier) MoveCategory(ctx context.Context, arg sqlc.MoveCategoryParams) (sqlc.Category, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).(sqlc.Category), args.Error(1)
+}
+
+func (m *MockQuerier) ListCategoryDescendantIDs(ctx context.Context, id uuid.UUID) ([]uuid.UUID, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+func (m *MockQuerier) ListCategoryPath(ctx context.Context, id uuid.UUID) ([]uuid.UUID, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+func (m *MockQuerier) ListCategoryPaths(ctx context.Context) ([]sqlc.ListCategoryPathsRow, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]sqlc.ListCategoryPathsRow), args.Error(1)
+}
+
+func (m *MockQuerier) CountChildCategories(ctx context.Context, parentID *uuid.UUID) (int64, error) {
+	args := m.Called(ctx, parentID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockQuerier) TouchSeriesByCategories(ctx context.Context, categoryIds []uuid.UUID) ([]sqlc.Series, error) {
+	args := m.Called(ctx, categoryIds)
+	return args.Get(0).([]sqlc.Series), args.Error(1)
+}
+
+// Import job operations
+func (m *MockQuerier) CreateImportJob(ctx context.Context, params sqlc.CreateImportJobParams) (sqlc.ImportJob, error) {
+	args := m.Called(ctx, params)
+	return args.Get(0).(sqlc.ImportJob), args.Error(1)
+}
+
+func (m *MockQuerier) GetImportJob(ctx context.Context, id uuid.UUID) (sqlc.ImportJob, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(sqlc.ImportJob), args.Error(1)
+}
+
+func (m *MockQuerier) CountImportJobsBySeries(ctx context.Context, seriesID uuid.UUID) (int64, error) {
+	args := m.Called(ctx, seriesID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockQuerier) ListImportJobsBySeriesPaginated(ctx context.Context, params sqlc.ListImportJobsBySeriesPaginatedParams) ([]sqlc.ImportJob, error) {
+	args := m.Called(ctx, params)
+	return args.Get(0).([]sqlc.ImportJob), args.Error(1)
+}
+
+func (m *MockQuerier) StartImportJob(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockQuerier) UpdateImportJobProgress(ctx context.Context, params sqlc.UpdateImportJobProgressParams) error {
+	args := m.Called(ctx, params)
+	return args.Error(0)
+}
+
+func (m *MockQuerier) FinishImportJob(ctx context.Context, params sqlc.FinishImportJobParams) error {
+	args := m.Called(ctx, params)
+	return args.Error(0)
+}
+
+// Series subscription operations
+func (m *MockQuerier) UpsertSeriesSubscription(ctx context.Context, params sqlc.UpsertSeriesSubscriptionParams) (sqlc.SeriesSubscription, error) {
+	args := m.Called(ctx, params)
+	return args.Get(0).(sqlc.SeriesSubscription), args.Error(1)
+}
+
+func (m *MockQuerier) GetSeriesSubscription(ctx context.Context, seriesID uuid.UUID) (sqlc.SeriesSubscription, error) {
+	args := m.Called(ctx, seriesID)
+	return args.Get(0).(sqlc.SeriesSubscription), args.Error(1)
+}
+
+func (m *MockQuerier) DeleteSeriesSubscription(ctx context.Context, seriesID uuid.UUID) error {
+	args := m.Called(ctx, seriesID)
+	return args.Error(0)
+}
+
+func (m *MockQuerier) ClaimDueSubscriptions(ctx context.Context, limit int32) ([]sqlc.SeriesSubscription, error) {
+	args := m.Called(ctx, limit)
+	return args.Get(0).([]sqlc.SeriesSubscription), args.Error(1)
+}
+
+func (m *MockQuerier) SetSubscriptionLastJob(ctx context.Context, params sqlc.SetSubscriptionLastJobParams) error {
+	args := m.Called(ctx, params)
+	return args.Error(0)
+}
+
+func (m *MockQuerier) UpdateSubscriptionSync(ctx context.Context, params sqlc.UpdateSubscriptionSyncParams) error {
+	args := m.Called(ctx, params)
+	return args.Error(0)
+}
+
+// Audit operations
+func (m *MockQuerier) CreateAuditEvent(ctx context.Context, params sqlc.CreateAuditEventParams) error {
+	args := m.Called(ctx, params)
+	return args.Error(0)
+}
+
+func (m *MockQuerier) CountAuditEventsByEntity(ctx context.Context, entityID uuid.UUID) (int64, error) {
+	args := m.Called(ctx, entityID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockQuerier) ListAuditEventsByEntityPaginated(ctx context.Context, params sqlc.ListAuditEventsByEntityPaginatedParams) ([]sqlc.AuditEvent, error) {
+	args := m.Called(ctx, params)
+	return args.Get(0).([]sqlc.AuditEvent), args.Error(1)
+}
+
+// Outbox operations
+func (m *MockQuerier) CreateOutboxEvent(ctx context.Context, params sqlc.CreateOutboxEventParams) error {
+	args := m.Called(ctx, params)
+	return args.Error(0)
+}
+
+func (m *MockQuerier) TryLockOutboxRelay(ctx context.Context) (bool, error) {
+	args := m.Called(ctx)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockQuerier) ClaimOutboxEvents(ctx context.Context, limit int32) ([]sqlc.OutboxEvent, error) {
+	args := m.Called(ctx, limit)
+	return args.Get(0).([]sqlc.OutboxEvent), args.Error(1)
+}
+
+func (m *MockQuerier) MarkOutboxEventsSent(ctx context.Context, ids []int64) error {
+	args := m.Called(ctx, ids)
+	return args.Error(0)
+}
+
+func (m *MockQuerier) RecordOutboxEventFailure(ctx context.Context, params sqlc.RecordOutboxEventFailureParams) (*time.Time, error) {
+	args := m.Called(ctx, params)
+	return args.Get(0).(*time.Time), args.Error(1)
+}
+
+func (m *MockQuerier) GetOutboxBacklog(ctx context.Context) (sqlc.GetOutboxBacklogRow, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(sqlc.GetOutboxBacklogRow), args.Error(1)
+}
+
+func (m *MockQuerier) DeleteSentOutboxEvents(ctx context.Context, before time.Time) (int64, error) {
+	args := m.Called(ctx, before)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// Reindex operations
+func (m *MockQuerier) ListSeriesAfter(ctx context.Context, arg sqlc.ListSeriesAfterParams) ([]sqlc.Series, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).([]sqlc.Series), args.Error(1)
+}
+
+func (m *MockQuerier) ListEpisodesAfter(ctx context.Context, arg sqlc.ListEpisodesAfterParams) ([]sqlc.Episode, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).([]sqlc.Episode), args.Error(1)
+}
+
+func (m *MockQuerier) ListAssetsByEpisodes(ctx context.Context, episodeIds []uuid.UUID) ([]sqlc.EpisodeAsset, error) {
+	args := m.Called(ctx, episodeIds)
+	return args.Get(0).([]sqlc.EpisodeAsset), args.Error(1)
+}
+
+func (m *MockQuerier) ListSeriesChangedSince(ctx context.Context, arg sqlc.ListSeriesChangedSinceParams) ([]sqlc.Series, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).([]sqlc.Series), args.Error(1)
+}
+
+func (m *MockQuerier) ListEpisodesChangedSince(ctx context.Context, arg sqlc.ListEpisodesChangedSinceParams) ([]sqlc.ListEpisodesChangedSinceRow, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).([]sqlc.ListEpisodesChangedSinceRow), args.Error(1)
+}
+
+// Deletion job operations
+func (m *MockQuerier) CreateDeletionJob(ctx context.Context, arg sqlc.CreateDeletionJobParams) (sqlc.DeletionJob, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).(sqlc.DeletionJob), args.Error(1)
+}
+
+func (m *MockQuerier) GetDeletionJob(ctx context.Context, id uuid.UUID) (sqlc.DeletionJob, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(sqlc.DeletionJob), args.Error(1)
+}
+
+func (m *MockQuerier) StartDeletionJob(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockQuerier) FinishDeletionJob(ctx context.Context, arg sqlc.FinishDeletionJobParams) error {
+	args := m.Called(ctx, arg)
+	return args.Error(0)
+}
+
+func (m *MockQuerier) GetDeletedSeries(ctx context.Context, id uuid.UUID) (sqlc.Series, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(sqlc.Series), args.Error(1)
+}
+
+func (m *MockQuerier) DeleteEpisodesBySeries(ctx context.Context, arg sqlc.DeleteEpisodesBySeriesParams) ([]sqlc.Episode, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).([]sqlc.Episode), args.Error(1)
+}
+
+func (m *MockQuerier) DeleteAssetsByEpisodes(ctx context.Context, arg sqlc.DeleteAssetsByEpisodesParams) ([]sqlc.EpisodeAsset, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).([]sqlc.EpisodeAsset), args.Error(1)
+}
+
+func (m *MockQuerier) CountEpisodesDeletedWithSeries(ctx context.Context, arg sqlc.CountEpisodesDeletedWithSeriesParams) (int64, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockQuerier) ListAssetsDeletedWithSeries(ctx context.Context, arg sqlc.ListAssetsDeletedWithSeriesParams) ([]sqlc.EpisodeAsset, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).([]sqlc.EpisodeAsset), args.Error(1)
+}
+
+// Trash operations
+
+func (m *MockQuerier) CountTrash(ctx context.Context, entityType *string) (int64, error) {
+	args := m.Called(ctx, entityType)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockQuerier) ListTrashPaginated(ctx context.Context, arg sqlc.ListTrashPaginatedParams) ([]sqlc.ListTrashPaginatedRow, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).([]sqlc.ListTrashPaginatedRow), args.Error(1)
+}
+
+func (m *MockQuerier) GetDeletedCategory(ctx context.Context, id uuid.UUID) (sqlc.Category, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(sqlc.Category), args.Error(1)
+}
+
+func (m *MockQuerier) RestoreCategory(ctx context.Context, id uuid.UUID) (sqlc.Category, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(sqlc.Category), args.Error(1)
+}
+
+func (m *MockQuerier) RestoreSeries(ctx context.Context, id uuid.UUID) (sqlc.Series, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(sqlc.Series), args.Error(1)
+}
+
+func (m *MockQuerier) GetDeletedEpisode(ctx context.Context, id uuid.UUID) (sqlc.Episode, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(sqlc.Episode), args.Error(1)
+}
+
+func (m *MockQuerier) RestoreEpisode(ctx context.Context, id uuid.UUID) (sqlc.Episode, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(sqlc.Episode), args.Error(1)
+}
+
+func (m *MockQuerier) RestoreEpisodesBySeries(ctx context.Context, arg sqlc.RestoreEpisodesBySeriesParams) ([]sqlc.Episode, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).([]sqlc.Episode), args.Error(1)
+}
+
+func (m *MockQuerier) RestoreAssetsByEpisodes(ctx context.Context, arg sqlc.RestoreAssetsByEpisodesParams) ([]sqlc.EpisodeAsset, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).([]sqlc.EpisodeAsset), args.Error(1)
+}
+
+func (m *MockQuerier) ListPurgeableAssets(ctx context.Context, arg sqlc.ListPurgeableAssetsParams) ([]sqlc.EpisodeAsset, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).([]sqlc.EpisodeAsset), args.Error(1)
+}
+
+func (m *MockQuerier) PurgeAssets(ctx context.Context, ids []uuid.UUID) (int64, error) {
+	args := m.Called(ctx, ids)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockQuerier) PurgeEpisodes(ctx context.Context, arg sqlc.PurgeEpisodesParams) ([]uuid.UUID, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+func (m *MockQuerier) PurgeSeries(ctx context.Context, arg sqlc.PurgeSeriesParams) ([]uuid.UUID, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+func (m *MockQuerier) PurgeCategories(ctx context.Context, arg sqlc.PurgeCategoriesParams) ([]uuid.UUID, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+// Translation operations
+func (m *MockQuerier) TouchSeries(ctx context.Context, id uuid.UUID) (sqlc.Series, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(sqlc.Series), args.Error(1)
+}
+
+func (m *MockQuerier) TouchEpisode(ctx context.Context, id uuid.UUID) (sqlc.Episode, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(sqlc.Episode), args.Error(1)
+}
+
+func (m *MockQuerier) GetSeriesTranslation(ctx context.Context, arg sqlc.GetSeriesTranslationParams) (sqlc.SeriesTranslation, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).(sqlc.SeriesTranslation), args.Error(1)
+}
+
+func (m *MockQuerier) ListSeriesTranslations(ctx context.Context, seriesIds []uuid.UUID) ([]sqlc.SeriesTranslation, error) {
+	args := m.Called(ctx, seriesIds)
+	return args.Get(0).([]sqlc.SeriesTranslation), args.Error(1)
+}
+
+func (m *MockQuerier) UpsertSeriesTranslation(ctx context.Context, arg sqlc.UpsertSeriesTranslationParams) (sqlc.SeriesTranslation, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).(sqlc.SeriesTranslation), args.Error(1)
+}
+
+func (m *MockQuerier) DeleteSeriesTranslation(ctx context.Context, arg sqlc.DeleteSeriesTranslationParams) (sqlc.SeriesTranslation, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).(sqlc.SeriesTranslation), args.Error(1)
+}
+
+func (m *MockQuerier) GetEpisodeTranslation(ctx context.Context, arg sqlc.GetEpisodeTranslationParams) (sqlc.EpisodeTranslation, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).(sqlc.EpisodeTranslation), args.Error(1)
+}
+
+func (m *MockQuerier) ListEpisodeTranslations(ctx context.Context, episodeIds []uuid.UUID) ([]sqlc.EpisodeTranslation, error) {
+	args := m.Called(ctx, episodeIds)
+	return args.Get(0).([]sqlc.EpisodeTranslation), args.Error(1)
+}
+
+func (m *MockQuerier) UpsertEpisodeTranslation(ctx context.Context, arg sqlc.UpsertEpisodeTranslationParams) (sqlc.EpisodeTranslation, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).(sqlc.EpisodeTranslation), args.Error(1)
+}
+
+func (m *MockQuerier) DeleteEpisodeTranslation(ctx context.Context, arg sqlc.DeleteEpisodeTranslationParams) (sqlc.EpisodeTranslation, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).(sqlc.EpisodeTranslation), args.Error(1)
+}
+
+func (m *MockQuerier) GetCategoryTranslation(ctx context.Context, arg sqlc.GetCategoryTranslationParams) (sqlc.CategoryTranslation, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).(sqlc.CategoryTranslation), args.Error(1)
+}
+
+func (m *MockQuerier) ListCategoryTranslations(ctx context.Context, categoryIds []uuid.UUID) ([]sqlc.CategoryTranslation, error) {
+	args := m.Called(ctx, categoryIds)
+	return args.Get(0).([]sqlc.CategoryTranslation), args.Error(1)
+}
+
+func (m *MockQuerier) UpsertCategoryTranslation(ctx context.Context, arg sqlc.UpsertCategoryTranslationParams) (sqlc.CategoryTranslation, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).(sqlc.CategoryTranslation), args.Error(1)
+}
+
+func (m *MockQuerier) DeleteCategoryTranslation(ctx context.Context, arg sqlc.DeleteCategoryTranslationParams) (sqlc.CategoryTranslation, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).(sqlc.CategoryTranslation), args.Error(1)
+}
+
+// Publishing operations
+func (m *MockQuerier) SetEpisodeStatus(ctx context.Context, arg sqlc.SetEpisodeStatusParams) (sqlc.Episode, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).(sqlc.Episode), args.Error(1)
+}
+
+func (m *MockQuerier) PublishDueEpisodes(ctx context.Context, arg sqlc.PublishDueEpisodesParams) ([]sqlc.Episode, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).([]sqlc.Episode), args.Error(1)
+}
+
+// Revision operations
+func (m *MockQuerier) CreateSeriesRevision(ctx context.Context, arg sqlc.CreateSeriesRevisionParams) (sqlc.ContentRevision, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).(sqlc.ContentRevision), args.Error(1)
+}
+
+func (m *MockQuerier) CreateEpisodeRevision(ctx context.Context, arg sqlc.CreateEpisodeRevisionParams) (sqlc.ContentRevision, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).(sqlc.ContentRevision), args.Error(1)
+}
+
+func (m *MockQuerier) GetContentRevision(ctx context.Context, arg sqlc.GetContentRevisionParams) (sqlc.ContentRevision, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).(sqlc.ContentRevision), args.Error(1)
+}
+
+func (m *MockQuerier) CountContentRevisions(ctx context.Context, arg sqlc.CountContentRevisionsParams) (int64, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockQuerier) ListContentRevisionsPaginated(ctx context.Context, arg sqlc.ListContentRevisionsPaginatedParams) ([]sqlc.ContentRevision, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).([]sqlc.ContentRevision), args.Error(1)
+}
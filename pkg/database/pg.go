@@ -7,6 +7,7 @@ import (
 	"net/url"
 	"th-application-technical-assignment/sqlc"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/pkg/errors"
 )
@@ -42,6 +43,10 @@ func (p *PgPool) DBTX() sqlc.DBTX {
 	return p.pool
 }
 
+func (p *PgPool) BeginTx(ctx context.Context, opts pgx.TxOptions) (pgx.Tx, error) {
+	return p.pool.BeginTx(ctx, opts)
+}
+
 func (p *PgPool) Close(ctx context.Context) {
 	slog.InfoContext(ctx, "closing pg pool")
 	p.pool.Close()
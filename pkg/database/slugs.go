@@ -0,0 +1,66 @@
+package database
+
+import (
+	"context"
+	"slices"
+	"th-application-technical-assignment/pkg/util"
+	"th-application-technical-assignment/sqlc"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// Slugs used for titles that do not produce one, such as "!!!".
+const (
+	fallbackSeriesSlug  = "series"
+	fallbackEpisodeSlug = "episode"
+)
+
+// SeriesSlug builds the slug of a series from its title. A slug already taken
+// by another series, deleted ones included, gets the first free numbered
+// suffix. current is the slug of the series being renamed, which it may keep.
+func SeriesSlug(ctx context.Context, q sqlc.Querier, title, current string) (string, error) {
+	slug, err := titleSlug(title, fallbackSeriesSlug)
+	if err != nil || slug == current {
+		return slug, err
+	}
+
+	taken, err := q.ListSeriesSlugs(ctx, slug)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to list series slugs")
+	}
+	return uniqueSlug(slug, taken, current), nil
+}
+
+// EpisodeSlug builds the slug of an episode from its title. Episode slugs are
+// unique within their series only.
+func EpisodeSlug(ctx context.Context, q sqlc.Querier, seriesID uuid.UUID, title, current string) (string, error) {
+	slug, err := titleSlug(title, fallbackEpisodeSlug)
+	if err != nil || slug == current {
+		return slug, err
+	}
+
+	taken, err := q.ListEpisodeSlugs(ctx, sqlc.ListEpisodeSlugsParams{
+		SeriesID: seriesID,
+		Slug:     slug,
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "failed to list episode slugs")
+	}
+	return uniqueSlug(slug, taken, current), nil
+}
+
+func titleSlug(title, fallback string) (string, error) {
+	slug, err := util.CreateSlug(title)
+	if errors.Is(err, util.ErrEmptySlug) {
+		return fallback, nil
+	}
+	return slug, err
+}
+
+func uniqueSlug(slug string, taken []string, current string) string {
+	if current != "" {
+		taken = slices.DeleteFunc(taken, func(s string) bool { return s == current })
+	}
+	return util.UniqueSlug(slug, taken)
+}
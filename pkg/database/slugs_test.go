@@ -0,0 +1,97 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"th-application-technical-assignment/sqlc"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSeriesSlug(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		title        string
+		current      string
+		taken        []string
+		expectedSlug string
+		expectLookup bool
+	}{
+		{
+			name:         "free slug",
+			title:        "Tech Talk",
+			taken:        []string{},
+			expectedSlug: "tech-talk",
+			expectLookup: true,
+		},
+		{
+			name:         "taken slug gets a suffix",
+			title:        "Tech Talk",
+			taken:        []string{"tech-talk", "tech-talk-2"},
+			expectedSlug: "tech-talk-3",
+			expectLookup: true,
+		},
+		{
+			name:         "renamed series keeps its own numbered slug",
+			title:        "Tech Talk!",
+			current:      "tech-talk-2",
+			taken:        []string{"tech-talk", "tech-talk-2"},
+			expectedSlug: "tech-talk-2",
+			expectLookup: true,
+		},
+		{
+			name:         "same slug as before",
+			title:        "TECH TALK",
+			current:      "tech-talk",
+			expectedSlug: "tech-talk",
+		},
+		{
+			name:         "title without letters or digits",
+			title:        "!!!",
+			taken:        []string{"series"},
+			expectedSlug: "series-2",
+			expectLookup: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockQueries := new(MockQuerier)
+			if tt.expectLookup {
+				mockQueries.On("ListSeriesSlugs", mock.Anything, mock.Anything).Return(tt.taken, nil)
+			}
+
+			slug, err := SeriesSlug(context.Background(), mockQueries, tt.title, tt.current)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedSlug, slug)
+
+			mockQueries.AssertExpectations(t)
+		})
+	}
+}
+
+func TestEpisodeSlug(t *testing.T) {
+	t.Parallel()
+
+	seriesID := uuid.New()
+	failingID := uuid.New()
+	mockQueries := new(MockQuerier)
+	mockQueries.On("ListEpisodeSlugs", mock.Anything, sqlc.ListEpisodeSlugsParams{SeriesID: seriesID, Slug: "pilot"}).
+		Return([]string{"pilot"}, nil)
+	mockQueries.On("ListEpisodeSlugs", mock.Anything, sqlc.ListEpisodeSlugsParams{SeriesID: failingID, Slug: "pilot"}).
+		Return([]string(nil), assert.AnError)
+
+	slug, err := EpisodeSlug(context.Background(), mockQueries, seriesID, "Pilot", "")
+	require.NoError(t, err)
+	assert.Equal(t, "pilot-2", slug)
+
+	_, err = EpisodeSlug(context.Background(), mockQueries, failingID, "Pilot", "")
+	assert.ErrorIs(t, err, assert.AnError)
+}
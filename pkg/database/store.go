@@ -3,10 +3,26 @@ package database
 import (
 	"context"
 	"th-application-technical-assignment/sqlc"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pkg/errors"
+)
+
+const (
+	// serialization_failure and deadlock_detected, both safe to retry
+	pgSerializationFailure = "40001"
+	pgDeadlockDetected     = "40P01"
+	pgUniqueViolation      = "23505"
+
+	defaultTxAttempts = 3
+	txRetryBackoff    = 20 * time.Millisecond
 )
 
 type Connection interface {
 	DBTX() sqlc.DBTX
+	BeginTx(ctx context.Context, opts pgx.TxOptions) (pgx.Tx, error)
 	Close(ctx context.Context)
 }
 
@@ -15,10 +31,90 @@ type Store struct {
 	Queries sqlc.Querier
 }
 
+// TxOptions configures a transaction run by WithTxOptions. The zero value
+// uses the server default isolation level and the default number of
+// attempts.
+type TxOptions struct {
+	IsoLevel    pgx.TxIsoLevel
+	AccessMode  pgx.TxAccessMode
+	MaxAttempts int
+}
+
 func New(ctx context.Context, conn Connection) *Store {
 	return &Store{conn: conn, Queries: sqlc.New(conn.DBTX())}
 }
 
+// WithTx runs fn in a transaction with the default options.
+func (s *Store) WithTx(ctx context.Context, fn func(q sqlc.Querier) error) error {
+	return s.WithTxOptions(ctx, TxOptions{}, fn)
+}
+
+// WithTxOptions runs fn with queries bound to a transaction, which is
+// committed when fn returns nil and rolled back otherwise. When the
+// transaction fails with a serialization failure or a deadlock it is retried
+// from the start, so fn must not have side effects outside the database
+// other than assigning its results.
+//
+// A store without a connection, as built in tests, runs fn with its queries
+// directly.
+func (s *Store) WithTxOptions(ctx context.Context, opts TxOptions, fn func(q sqlc.Querier) error) error {
+	attempts := opts.MaxAttempts
+	if attempts <= 0 {
+		attempts = defaultTxAttempts
+	}
+
+	var err error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		err = s.runTx(ctx, opts, fn)
+		if err == nil || !IsRetryable(err) || attempt == attempts {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return errors.Wrap(ctx.Err(), "transaction retry")
+		case <-time.After(time.Duration(attempt) * txRetryBackoff):
+		}
+	}
+
+	return err
+}
+
+func (s *Store) runTx(ctx context.Context, opts TxOptions, fn func(q sqlc.Querier) error) error {
+	if s.conn == nil {
+		return fn(s.Queries)
+	}
+
+	tx, err := s.conn.BeginTx(ctx, pgx.TxOptions{IsoLevel: opts.IsoLevel, AccessMode: opts.AccessMode})
+	if err != nil {
+		return errors.Wrap(err, "begin transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	if err := fn(sqlc.New(tx)); err != nil {
+		return err
+	}
+
+	return errors.Wrap(tx.Commit(ctx), "commit transaction")
+}
+
+// IsRetryable reports whether err is a serialization failure or a deadlock,
+// after which the whole transaction can be run again.
+func IsRetryable(err error) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+	return pgErr.Code == pgSerializationFailure || pgErr.Code == pgDeadlockDetected
+}
+
+// IsUniqueViolation reports whether err is a violation of a unique
+// constraint, such as a slug that is already taken.
+func IsUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation
+}
+
 func (s *Store) Close(ctx context.Context) {
 	s.conn.Close(ctx)
 }
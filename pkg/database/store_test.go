@@ -0,0 +1,92 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"th-application-technical-assignment/sqlc"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStore_WithTxOptions(t *testing.T) {
+	t.Parallel()
+
+	serialization := &pgconn.PgError{Code: pgSerializationFailure}
+	deadlock := &pgconn.PgError{Code: pgDeadlockDetected}
+	uniqueViolation := &pgconn.PgError{Code: "23505"}
+
+	tests := []struct {
+		name          string
+		maxAttempts   int
+		errs          []error
+		expectedCalls int
+		expectError   bool
+	}{
+		{
+			name:          "succeeds first time",
+			errs:          []error{nil},
+			expectedCalls: 1,
+		},
+		{
+			name:          "retries serialization failures",
+			errs:          []error{serialization, errors.Wrap(deadlock, "failed to create asset"), nil},
+			expectedCalls: 3,
+		},
+		{
+			name:          "gives up after max attempts",
+			maxAttempts:   2,
+			errs:          []error{serialization, serialization, nil},
+			expectedCalls: 2,
+			expectError:   true,
+		},
+		{
+			name:          "does not retry other errors",
+			errs:          []error{uniqueViolation, nil},
+			expectedCalls: 1,
+			expectError:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockQueries := new(MockQuerier)
+			store := &Store{Queries: mockQueries}
+
+			calls := 0
+			err := store.WithTxOptions(context.Background(), TxOptions{MaxAttempts: tt.maxAttempts}, func(q sqlc.Querier) error {
+				assert.Same(t, mockQueries, q)
+				err := tt.errs[calls]
+				calls++
+				return err
+			})
+
+			assert.Equal(t, tt.expectedCalls, calls)
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestStore_WithTxOptions_ContextCanceled(t *testing.T) {
+	t.Parallel()
+
+	store := &Store{Queries: new(MockQuerier)}
+	ctx, cancel := context.WithCancel(context.Background())
+
+	calls := 0
+	err := store.WithTx(ctx, func(q sqlc.Querier) error {
+		calls++
+		cancel()
+		return &pgconn.PgError{Code: pgSerializationFailure}
+	})
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, calls)
+}
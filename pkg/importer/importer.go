@@ -2,18 +2,53 @@ package importer
 
 import (
 	"context"
+	"net/url"
+	"strings"
 	"th-application-technical-assignment/sqlc"
 
 	"github.com/google/uuid"
 	"github.com/pkg/errors"
 )
 
+// Item is a single episode produced by a source together with its assets.
+// Importers set Episode.ExternalID to the identifier the source uses for the
+// item (a feed GUID, a video ID) so that re-imports update it in place.
+type Item struct {
+	Episode sqlc.Episode
+	Assets  []sqlc.EpisodeAsset
+}
+
+// FetchRequest identifies the page of a source to fetch. Cursor is empty
+// for the first page and otherwise the NextCursor of the previous page.
+// ETag and LastModified are the validators of a previous fetch of the first
+// page; importers that support it use them to make a conditional request.
+type FetchRequest struct {
+	URL          string
+	SeriesID     string
+	Cursor       string
+	ETag         string
+	LastModified string
+}
+
+// Page is a batch of items read from a source. NextCursor is empty on the
+// last page. NotModified is set, with no items, when the source reports
+// that the first page is unchanged since the validators in the request.
+// ETag and LastModified carry the validators of the fetched page.
+type Page struct {
+	Items        []Item
+	NextCursor   string
+	NotModified  bool
+	ETag         string
+	LastModified string
+}
+
 type Importer interface {
-	FetchEpisode(ctx context.Context, url, seriesID string) (*sqlc.Episode, *sqlc.EpisodeAsset, error)
+	FetchPage(ctx context.Context, req FetchRequest) (*Page, error)
 }
 
 var importers = map[string]Importer{
 	"youtube": NewYouTubeImporter(),
+	"rss":     NewRSSImporter(nil),
 }
 
 func GetImporter(source string) (Importer, error) {
@@ -31,26 +66,51 @@ func NewYouTubeImporter() Importer {
 	return &YouTubeImporter{}
 }
 
-func (i *YouTubeImporter) FetchEpisode(ctx context.Context, url, seriesID string) (*sqlc.Episode, *sqlc.EpisodeAsset, error) {
+func (i *YouTubeImporter) FetchPage(ctx context.Context, req FetchRequest) (*Page, error) {
 	// youtube import logic, skipped
-	seriesUuid, err := uuid.Parse(seriesID)
+	seriesUuid, err := uuid.Parse(req.SeriesID)
 	if err != nil {
-		return nil, nil, errors.Wrap(err, "invalid series ID")
+		return nil, errors.Wrap(err, "invalid series ID")
 	}
 
+	url := req.URL
 	episodeID := uuid.New()
-	ep := &sqlc.Episode{
+	ep := sqlc.Episode{
 		ID:       episodeID,
 		SeriesID: seriesUuid,
 		Title:    "YouTube Import",
 	}
+	if videoID := youTubeVideoID(url); videoID != "" {
+		ep.ExternalID = &videoID
+	}
 
-	asset := &sqlc.EpisodeAsset{
+	asset := sqlc.EpisodeAsset{
 		EpisodeID: episodeID,
 		AssetType: "video",
 		MimeType:  "video/mp4",
 		Url:       &url,
 	}
 
-	return ep, asset, nil
+	return &Page{Items: []Item{{Episode: ep, Assets: []sqlc.EpisodeAsset{asset}}}}, nil
+}
+
+// youTubeVideoID extracts the video ID from watch and youtu.be URLs, falling
+// back to the raw URL when it has neither form.
+func youTubeVideoID(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+
+	if id := u.Query().Get("v"); id != "" {
+		return id
+	}
+
+	if strings.TrimPrefix(u.Hostname(), "www.") == "youtu.be" {
+		if id := strings.Trim(u.Path, "/"); id != "" {
+			return id
+		}
+	}
+
+	return rawURL
 }
@@ -25,6 +25,12 @@ func TestGetImporter(t *testing.T) {
 			expectError:  false,
 			expectedType: "*importer.YouTubeImporter",
 		},
+		{
+			name:         "successful rss importer retrieval",
+			source:       "rss",
+			expectError:  false,
+			expectedType: "*importer.RSSImporter",
+		},
 		{
 			name:         "unknown importer source",
 			source:       "unknown",
@@ -67,7 +73,7 @@ func TestGetImporter(t *testing.T) {
 	}
 }
 
-func TestYouTubeImporter_FetchEpisode(t *testing.T) {
+func TestYouTubeImporter_FetchPage(t *testing.T) {
 	t.Parallel()
 
 	tests := []struct {
@@ -118,19 +124,23 @@ func TestYouTubeImporter_FetchEpisode(t *testing.T) {
 			imp := NewYouTubeImporter()
 			ctx := context.Background()
 
-			episode, asset, err := imp.FetchEpisode(ctx, tt.url, tt.seriesID)
+			page, err := imp.FetchPage(ctx, FetchRequest{URL: tt.url, SeriesID: tt.seriesID})
 
 			if tt.expectError {
 				assert.Error(t, err)
-				assert.Nil(t, episode)
-				assert.Nil(t, asset)
+				assert.Nil(t, page)
 				if tt.errorContains != "" {
 					assert.Contains(t, err.Error(), tt.errorContains)
 				}
 			} else {
 				assert.NoError(t, err)
-				assert.NotNil(t, episode)
-				assert.NotNil(t, asset)
+				require.NotNil(t, page)
+				assert.Empty(t, page.NextCursor)
+				require.Len(t, page.Items, 1)
+				require.Len(t, page.Items[0].Assets, 1)
+
+				episode := page.Items[0].Episode
+				asset := page.Items[0].Assets[0]
 
 				assert.NotEqual(t, uuid.Nil, episode.ID)
 				assert.Equal(t, "YouTube Import", episode.Title)
@@ -138,6 +148,12 @@ func TestYouTubeImporter_FetchEpisode(t *testing.T) {
 				expectedSeriesID, parseErr := uuid.Parse(tt.seriesID)
 				require.NoError(t, parseErr)
 				assert.Equal(t, expectedSeriesID, episode.SeriesID)
+				if tt.url != "" {
+					require.NotNil(t, episode.ExternalID)
+					assert.Equal(t, "test123", *episode.ExternalID)
+				} else {
+					assert.Nil(t, episode.ExternalID)
+				}
 
 				assert.NotEqual(t, uuid.Nil, asset.EpisodeID)
 				assert.Equal(t, episode.ID, asset.EpisodeID)
@@ -150,7 +166,7 @@ func TestYouTubeImporter_FetchEpisode(t *testing.T) {
 	}
 }
 
-func TestYouTubeImporter_FetchEpisode_EdgeCases(t *testing.T) {
+func TestYouTubeImporter_FetchPage_EdgeCases(t *testing.T) {
 	t.Parallel()
 
 	imp := NewYouTubeImporter()
@@ -160,26 +176,48 @@ func TestYouTubeImporter_FetchEpisode_EdgeCases(t *testing.T) {
 	t.Run("very long URL", func(t *testing.T) {
 		t.Parallel()
 		longURL := "https://youtube.com/watch?v=" + string(make([]byte, 1000))
-		episode, asset, err := imp.FetchEpisode(ctx, longURL, seriesID)
+		page, err := imp.FetchPage(ctx, FetchRequest{URL: longURL, SeriesID: seriesID})
 
-		assert.NoError(t, err)
-		assert.NotNil(t, episode)
-		assert.NotNil(t, asset)
-		assert.Equal(t, longURL, *asset.Url)
+		require.NoError(t, err)
+		require.Len(t, page.Items, 1)
+		require.Len(t, page.Items[0].Assets, 1)
+		assert.Equal(t, longURL, *page.Items[0].Assets[0].Url)
 	})
 
 	t.Run("URL with special characters", func(t *testing.T) {
 		t.Parallel()
 		specialURL := "https://youtube.com/watch?v=test&param=value#fragment"
-		episode, asset, err := imp.FetchEpisode(ctx, specialURL, seriesID)
+		page, err := imp.FetchPage(ctx, FetchRequest{URL: specialURL, SeriesID: seriesID})
 
-		assert.NoError(t, err)
-		assert.NotNil(t, episode)
-		assert.NotNil(t, asset)
-		assert.Equal(t, specialURL, *asset.Url)
+		require.NoError(t, err)
+		require.Len(t, page.Items, 1)
+		require.Len(t, page.Items[0].Assets, 1)
+		assert.Equal(t, specialURL, *page.Items[0].Assets[0].Url)
 	})
 }
 
+func TestYouTubeVideoID(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		url      string
+		expected string
+	}{
+		{"https://youtube.com/watch?v=test123", "test123"},
+		{"https://www.youtube.com/watch?v=abc&list=PL1", "abc"},
+		{"https://youtu.be/xyz789", "xyz789"},
+		{"https://youtube.com/playlist?list=PL1", "https://youtube.com/playlist?list=PL1"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.expected, youTubeVideoID(tt.url))
+		})
+	}
+}
+
 var _ Importer = (*YouTubeImporter)(nil)
 
 func TestImportersMap(t *testing.T) {
@@ -0,0 +1,398 @@
+package importer
+
+import (
+	"context"
+	"encoding/xml"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"th-application-technical-assignment/sqlc"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"golang.org/x/text/encoding/htmlindex"
+)
+
+const (
+	atomNamespace = "http://www.w3.org/2005/Atom"
+
+	maxFeedSize    = 32 << 20
+	maxTitleLength = 255
+)
+
+var feedDateLayouts = []string{
+	time.RFC1123Z,
+	time.RFC1123,
+	time.RFC3339,
+	time.RFC822Z,
+	time.RFC822,
+	"Mon, 2 Jan 2006 15:04:05 -0700",
+	"Mon, 2 Jan 2006 15:04:05 MST",
+	"2 Jan 2006 15:04:05 -0700",
+	"2006-01-02T15:04:05",
+	"2006-01-02",
+}
+
+// RSSImporter imports podcast episodes from RSS 2.0 and Atom feeds,
+// including the iTunes podcast extensions. Paged feeds (RFC 5005) are
+// followed through their rel="next" links, which are used as the cursor.
+type RSSImporter struct {
+	client *http.Client
+}
+
+func NewRSSImporter(client *http.Client) Importer {
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+	return &RSSImporter{client: client}
+}
+
+func (i *RSSImporter) FetchPage(ctx context.Context, req FetchRequest) (*Page, error) {
+	seriesUuid, err := uuid.Parse(req.SeriesID)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid series ID")
+	}
+
+	url := req.URL
+	if req.Cursor != "" {
+		url = req.Cursor
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build feed request")
+	}
+	httpReq.Header.Set("Accept", "application/rss+xml, application/atom+xml, application/xml;q=0.9, text/xml;q=0.8")
+	if req.Cursor == "" {
+		if req.ETag != "" {
+			httpReq.Header.Set("If-None-Match", req.ETag)
+		}
+		if req.LastModified != "" {
+			httpReq.Header.Set("If-Modified-Since", req.LastModified)
+		}
+	}
+
+	res, err := i.client.Do(httpReq)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to fetch feed")
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotModified {
+		return &Page{NotModified: true, ETag: req.ETag, LastModified: req.LastModified}, nil
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("failed to fetch feed: unexpected status %d", res.StatusCode)
+	}
+
+	page, err := parseFeed(io.LimitReader(res.Body, maxFeedSize), seriesUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	if page.NextCursor != "" {
+		next, err := res.Request.URL.Parse(page.NextCursor)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid next page link")
+		}
+		page.NextCursor = next.String()
+	}
+	page.ETag = res.Header.Get("ETag")
+	page.LastModified = res.Header.Get("Last-Modified")
+
+	return page, nil
+}
+
+type rssDocument struct {
+	Channel struct {
+		Links []atomLink `xml:"http://www.w3.org/2005/Atom link"`
+		Items []rssItem  `xml:"item"`
+	} `xml:"channel"`
+}
+
+type rssItem struct {
+	Title       string          `xml:"title"`
+	Link        string          `xml:"link"`
+	Description string          `xml:"description"`
+	Summary     string          `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd summary"`
+	Duration    string          `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd duration"`
+	PubDate     string          `xml:"pubDate"`
+	GUID        string          `xml:"guid"`
+	Enclosures  []feedEnclosure `xml:"enclosure"`
+	Image       *itunesImage    `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd image"`
+}
+
+type itunesImage struct {
+	Href string `xml:"href,attr"`
+}
+
+type feedEnclosure struct {
+	URL    string `xml:"url,attr"`
+	Type   string `xml:"type,attr"`
+	Length string `xml:"length,attr"`
+}
+
+type atomDocument struct {
+	Links   []atomLink  `xml:"http://www.w3.org/2005/Atom link"`
+	Entries []atomEntry `xml:"http://www.w3.org/2005/Atom entry"`
+}
+
+type atomEntry struct {
+	ID        string     `xml:"http://www.w3.org/2005/Atom id"`
+	Title     string     `xml:"http://www.w3.org/2005/Atom title"`
+	Summary   string     `xml:"http://www.w3.org/2005/Atom summary"`
+	Content   string     `xml:"http://www.w3.org/2005/Atom content"`
+	Published string     `xml:"http://www.w3.org/2005/Atom published"`
+	Updated   string     `xml:"http://www.w3.org/2005/Atom updated"`
+	Duration  string     `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd duration"`
+	Links     []atomLink `xml:"http://www.w3.org/2005/Atom link"`
+}
+
+type atomLink struct {
+	Href   string `xml:"href,attr"`
+	Rel    string `xml:"rel,attr"`
+	Type   string `xml:"type,attr"`
+	Length string `xml:"length,attr"`
+}
+
+func parseFeed(r io.Reader, seriesID uuid.UUID) (*Page, error) {
+	dec := xml.NewDecoder(r)
+	dec.Strict = false
+	dec.CharsetReader = func(label string, input io.Reader) (io.Reader, error) {
+		// labels are resolved like browsers do, so iso-8859-1 decodes as
+		// windows-1252. Unknown labels are read as they are, feeds in the
+		// wild often declare charsets that do not exist
+		enc, err := htmlindex.Get(label)
+		if err != nil {
+			return input, nil
+		}
+		return enc.NewDecoder().Reader(input), nil
+	}
+
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			if err == io.EOF {
+				return nil, errors.New("empty feed document")
+			}
+			return nil, errors.Wrap(err, "failed to parse feed")
+		}
+
+		start, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		switch {
+		case start.Name.Local == "rss":
+			var doc rssDocument
+			if err := dec.DecodeElement(&doc, &start); err != nil {
+				return nil, errors.Wrap(err, "failed to decode rss feed")
+			}
+			return &Page{Items: rssItems(doc, seriesID), NextCursor: nextLink(doc.Channel.Links)}, nil
+		case start.Name.Local == "feed" && start.Name.Space == atomNamespace:
+			var doc atomDocument
+			if err := dec.DecodeElement(&doc, &start); err != nil {
+				return nil, errors.Wrap(err, "failed to decode atom feed")
+			}
+			return &Page{Items: atomItems(doc, seriesID), NextCursor: nextLink(doc.Links)}, nil
+		default:
+			return nil, errors.Errorf("unsupported feed format %q", start.Name.Local)
+		}
+	}
+}
+
+func rssItems(doc rssDocument, seriesID uuid.UUID) []Item {
+	items := make([]Item, 0, len(doc.Channel.Items))
+	for _, entry := range doc.Channel.Items {
+		description := firstNonEmpty(entry.Description, entry.Summary)
+		item := newFeedItem(seriesID, entry.Title, description, entry.Duration, entry.PubDate)
+		item.setExternalID(entry.GUID, firstEnclosureURL(entry.Enclosures), entry.Link)
+		for _, enc := range entry.Enclosures {
+			item.addEnclosure(enc.URL, enc.Type, enc.Length)
+		}
+		if entry.Image != nil {
+			item.addEnclosure(entry.Image.Href, imageMimeType(entry.Image.Href), "")
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+func atomItems(doc atomDocument, seriesID uuid.UUID) []Item {
+	items := make([]Item, 0, len(doc.Entries))
+	for _, entry := range doc.Entries {
+		description := firstNonEmpty(entry.Summary, entry.Content)
+		published := firstNonEmpty(entry.Published, entry.Updated)
+		item := newFeedItem(seriesID, entry.Title, description, entry.Duration, published)
+		item.setExternalID(entry.ID, alternateLink(entry.Links))
+		for _, l := range entry.Links {
+			if l.Rel == "enclosure" {
+				item.addEnclosure(l.Href, l.Type, l.Length)
+			}
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+func nextLink(links []atomLink) string {
+	for _, l := range links {
+		if l.Rel == "next" {
+			return strings.TrimSpace(l.Href)
+		}
+	}
+	return ""
+}
+
+func alternateLink(links []atomLink) string {
+	for _, l := range links {
+		if l.Rel == "" || l.Rel == "alternate" {
+			return l.Href
+		}
+	}
+	return ""
+}
+
+func firstEnclosureURL(enclosures []feedEnclosure) string {
+	if len(enclosures) == 0 {
+		return ""
+	}
+	return enclosures[0].URL
+}
+
+func newFeedItem(seriesID uuid.UUID, title, description, duration, published string) Item {
+	ep := sqlc.Episode{
+		ID:       uuid.New(),
+		SeriesID: seriesID,
+		Title:    truncate(strings.TrimSpace(title), maxTitleLength),
+	}
+
+	if d := strings.TrimSpace(description); d != "" {
+		ep.Description = &d
+	}
+
+	if secs, ok := parseDuration(duration); ok {
+		ep.DurationSeconds = &secs
+	}
+
+	if t, ok := parseFeedDate(published); ok {
+		ep.PublishDate = &t
+	}
+
+	return Item{Episode: ep, Assets: []sqlc.EpisodeAsset{}}
+}
+
+// setExternalID uses the first non-empty candidate as the item's identity in
+// the feed. Items without any stable identifier are imported as new episodes
+// every time.
+func (i *Item) setExternalID(candidates ...string) {
+	if id := strings.TrimSpace(firstNonEmpty(candidates...)); id != "" {
+		i.Episode.ExternalID = &id
+	}
+}
+
+func (i *Item) addEnclosure(url, mimeType, length string) {
+	url = strings.TrimSpace(url)
+	if url == "" {
+		return
+	}
+
+	mimeType = strings.TrimSpace(mimeType)
+	if mimeType == "" {
+		mimeType = "audio/mpeg"
+	}
+
+	asset := sqlc.EpisodeAsset{
+		EpisodeID: i.Episode.ID,
+		AssetType: assetTypeFromMime(mimeType),
+		MimeType:  mimeType,
+		Url:       &url,
+	}
+
+	if size, err := strconv.ParseInt(strings.TrimSpace(length), 10, 64); err == nil && size > 0 {
+		asset.SizeBytes = &size
+	}
+
+	i.Assets = append(i.Assets, asset)
+}
+
+func imageMimeType(url string) string {
+	if t := mime.TypeByExtension(path.Ext(strings.SplitN(url, "?", 2)[0])); strings.HasPrefix(t, "image/") {
+		return t
+	}
+	return "image/jpeg"
+}
+
+func assetTypeFromMime(mimeType string) string {
+	switch {
+	case strings.HasPrefix(mimeType, "video/"):
+		return "video"
+	case strings.HasPrefix(mimeType, "image/"):
+		return "thumbnail"
+	default:
+		return "audio"
+	}
+}
+
+// parseDuration accepts the itunes:duration formats: plain seconds,
+// MM:SS and HH:MM:SS.
+func parseDuration(s string) (int32, bool) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, false
+	}
+
+	parts := strings.Split(s, ":")
+	if len(parts) > 3 {
+		return 0, false
+	}
+
+	var total float64
+	for _, p := range parts {
+		n, err := strconv.ParseFloat(p, 64)
+		if err != nil || n < 0 {
+			return 0, false
+		}
+		total = total*60 + n
+	}
+
+	return int32(total), true
+}
+
+func parseFeedDate(s string) (time.Time, bool) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}, false
+	}
+
+	for _, layout := range feedDateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if strings.TrimSpace(v) != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+func truncate(s string, max int) string {
+	if utf8.RuneCountInString(s) <= max {
+		return s
+	}
+	return string([]rune(s)[:max])
+}
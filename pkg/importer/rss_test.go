@@ -0,0 +1,334 @@
+package importer
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newFeedServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.Handle("/feeds/", http.StripPrefix("/feeds/", http.FileServer(http.Dir("testdata"))))
+	mux.HandleFunc("/broken", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("<rss><channel><item><title>unterminated"))
+	})
+	mux.HandleFunc("/html", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("<html><body>not a feed</body></html>"))
+	})
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestRSSImporter_FetchPage_RSS(t *testing.T) {
+	t.Parallel()
+
+	srv := newFeedServer(t)
+	seriesID := uuid.New()
+
+	imp := NewRSSImporter(srv.Client())
+	page, err := imp.FetchPage(context.Background(), FetchRequest{URL: srv.URL + "/feeds/podcast.rss.xml", SeriesID: seriesID.String()})
+	require.NoError(t, err)
+	assert.Empty(t, page.NextCursor)
+	require.Len(t, page.Items, 3)
+
+	first := page.Items[0]
+	assert.Equal(t, seriesID, first.Episode.SeriesID)
+	assert.Equal(t, "Episode 2: Black Holes", first.Episode.Title)
+	require.NotNil(t, first.Episode.ExternalID)
+	assert.Equal(t, "dsw-0002", *first.Episode.ExternalID)
+	require.NotNil(t, first.Episode.Description)
+	assert.Equal(t, "What happens beyond the event horizon.", *first.Episode.Description)
+	require.NotNil(t, first.Episode.DurationSeconds)
+	assert.Equal(t, int32(3723), *first.Episode.DurationSeconds)
+	require.NotNil(t, first.Episode.PublishDate)
+	assert.True(t, first.Episode.PublishDate.Equal(time.Date(2025, 8, 12, 8, 0, 0, 0, time.UTC)))
+
+	require.Len(t, first.Assets, 1)
+	asset := first.Assets[0]
+	assert.Equal(t, first.Episode.ID, asset.EpisodeID)
+	assert.Equal(t, "audio", asset.AssetType)
+	assert.Equal(t, "audio/mpeg", asset.MimeType)
+	require.NotNil(t, asset.Url)
+	assert.Equal(t, "https://cdn.example.com/dsw/ep2.mp3", *asset.Url)
+	require.NotNil(t, asset.SizeBytes)
+	assert.Equal(t, int64(48213504), *asset.SizeBytes)
+
+	second := page.Items[1]
+	require.NotNil(t, second.Episode.Description)
+	assert.Equal(t, "How it all started.", *second.Episode.Description)
+	assert.Equal(t, int32(1830), *second.Episode.DurationSeconds)
+	require.Len(t, second.Assets, 1)
+	assert.Equal(t, "video", second.Assets[0].AssetType)
+	assert.Nil(t, second.Assets[0].SizeBytes)
+
+	trailer := page.Items[2]
+	assert.Nil(t, trailer.Episode.ExternalID)
+	assert.Nil(t, trailer.Episode.Description)
+	assert.Nil(t, trailer.Episode.PublishDate)
+	assert.Equal(t, int32(150), *trailer.Episode.DurationSeconds)
+	assert.Empty(t, trailer.Assets)
+}
+
+func TestRSSImporter_FetchPage_Atom(t *testing.T) {
+	t.Parallel()
+
+	srv := newFeedServer(t)
+	seriesID := uuid.New()
+
+	imp := NewRSSImporter(srv.Client())
+	page, err := imp.FetchPage(context.Background(), FetchRequest{URL: srv.URL + "/feeds/podcast.atom.xml", SeriesID: seriesID.String()})
+	require.NoError(t, err)
+	require.Len(t, page.Items, 2)
+
+	first := page.Items[0]
+	assert.Equal(t, "Desert Recordings", first.Episode.Title)
+	require.NotNil(t, first.Episode.ExternalID)
+	assert.Equal(t, "urn:uuid:1225c695-cfb8-4ebb-aaaa-80da344efa6a", *first.Episode.ExternalID)
+	assert.Equal(t, "Sounds from the Empty Quarter.", *first.Episode.Description)
+	assert.Equal(t, int32(2700), *first.Episode.DurationSeconds)
+	assert.True(t, first.Episode.PublishDate.Equal(time.Date(2025, 8, 20, 18, 30, 2, 0, time.UTC)))
+	require.Len(t, first.Assets, 1)
+	assert.Equal(t, "audio/mp4", first.Assets[0].MimeType)
+	assert.Equal(t, "https://cdn.example.com/fn/desert.m4a", *first.Assets[0].Url)
+	assert.Equal(t, int64(1337), *first.Assets[0].SizeBytes)
+
+	second := page.Items[1]
+	assert.Equal(t, "Gulls and terns at dawn.", *second.Episode.Description)
+	assert.True(t, second.Episode.PublishDate.Equal(time.Date(2025, 8, 13, 6, 0, 0, 0, time.UTC)))
+	assert.Empty(t, second.Assets)
+}
+
+func TestRSSImporter_FetchPage_FollowsNextLinks(t *testing.T) {
+	t.Parallel()
+
+	srv := newFeedServer(t)
+	imp := NewRSSImporter(srv.Client())
+	req := FetchRequest{URL: srv.URL + "/feeds/paged-1.rss.xml", SeriesID: uuid.New().String()}
+
+	first, err := imp.FetchPage(context.Background(), req)
+	require.NoError(t, err)
+	require.Len(t, first.Items, 2)
+	assert.Equal(t, srv.URL+"/feeds/paged-2.rss.xml", first.NextCursor)
+
+	require.NotNil(t, first.Items[1].Episode.ExternalID)
+	assert.Equal(t, "https://cdn.example.com/archive/2.mp3", *first.Items[1].Episode.ExternalID)
+
+	require.Len(t, first.Items[0].Assets, 2)
+	assert.Equal(t, "audio", first.Items[0].Assets[0].AssetType)
+	assert.Equal(t, "thumbnail", first.Items[0].Assets[1].AssetType)
+	assert.Equal(t, "image/png", first.Items[0].Assets[1].MimeType)
+
+	req.Cursor = first.NextCursor
+	second, err := imp.FetchPage(context.Background(), req)
+	require.NoError(t, err)
+	require.Len(t, second.Items, 1)
+	assert.Equal(t, "Archive 1", second.Items[0].Episode.Title)
+	assert.Empty(t, second.NextCursor)
+}
+
+func TestRSSImporter_FetchPage_Conditional(t *testing.T) {
+	t.Parallel()
+
+	const etag = `"v1"`
+	feed, err := os.ReadFile("testdata/podcast.rss.xml")
+	require.NoError(t, err)
+
+	var conditional []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conditional = append(conditional, r.Header.Get("If-None-Match"))
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		w.Header().Set("Last-Modified", "Tue, 12 Aug 2025 08:00:00 GMT")
+		w.Write(feed)
+	}))
+	t.Cleanup(srv.Close)
+
+	imp := NewRSSImporter(srv.Client())
+	req := FetchRequest{URL: srv.URL, SeriesID: uuid.New().String()}
+
+	fresh, err := imp.FetchPage(context.Background(), req)
+	require.NoError(t, err)
+	assert.False(t, fresh.NotModified)
+	assert.Len(t, fresh.Items, 3)
+	assert.Equal(t, etag, fresh.ETag)
+	assert.Equal(t, "Tue, 12 Aug 2025 08:00:00 GMT", fresh.LastModified)
+
+	req.ETag = fresh.ETag
+	req.LastModified = fresh.LastModified
+	cached, err := imp.FetchPage(context.Background(), req)
+	require.NoError(t, err)
+	assert.True(t, cached.NotModified)
+	assert.Empty(t, cached.Items)
+	assert.Equal(t, etag, cached.ETag)
+	assert.Equal(t, fresh.LastModified, cached.LastModified)
+
+	req.Cursor = srv.URL + "/?page=2"
+	next, err := imp.FetchPage(context.Background(), req)
+	require.NoError(t, err)
+	assert.False(t, next.NotModified)
+
+	assert.Equal(t, []string{"", etag, ""}, conditional)
+}
+
+func TestRSSImporter_FetchPage(t *testing.T) {
+	t.Parallel()
+
+	srv := newFeedServer(t)
+
+	tests := []struct {
+		name          string
+		path          string
+		seriesID      string
+		expectError   bool
+		errorContains string
+		expectedItems int
+	}{
+		{
+			name:          "rss feed",
+			path:          "/feeds/podcast.rss.xml",
+			seriesID:      uuid.New().String(),
+			expectedItems: 3,
+		},
+		{
+			name:          "atom feed",
+			path:          "/feeds/podcast.atom.xml",
+			seriesID:      uuid.New().String(),
+			expectedItems: 2,
+		},
+		{
+			name:          "invalid series ID",
+			path:          "/feeds/podcast.rss.xml",
+			seriesID:      "invalid-uuid",
+			expectError:   true,
+			errorContains: "invalid series ID",
+		},
+		{
+			name:          "feed not found",
+			path:          "/feeds/missing.xml",
+			seriesID:      uuid.New().String(),
+			expectError:   true,
+			errorContains: "unexpected status 404",
+		},
+		{
+			name:          "malformed feed",
+			path:          "/broken",
+			seriesID:      uuid.New().String(),
+			expectError:   true,
+			errorContains: "failed to decode rss feed",
+		},
+		{
+			name:          "not a feed",
+			path:          "/html",
+			seriesID:      uuid.New().String(),
+			expectError:   true,
+			errorContains: "unsupported feed format",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			imp := NewRSSImporter(srv.Client())
+			page, err := imp.FetchPage(context.Background(), FetchRequest{URL: srv.URL + tt.path, SeriesID: tt.seriesID})
+
+			if tt.expectError {
+				assert.Error(t, err)
+				assert.Nil(t, page)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Len(t, page.Items, tt.expectedItems)
+			for _, item := range page.Items {
+				assert.NotEqual(t, uuid.Nil, item.Episode.ID)
+				assert.NotEmpty(t, item.Episode.Title)
+			}
+		})
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input    string
+		expected int32
+		ok       bool
+	}{
+		{"3600", 3600, true},
+		{"59:59", 3599, true},
+		{"1:00:00", 3600, true},
+		{" 12:05 ", 725, true},
+		{"90.5", 90, true},
+		{"", 0, false},
+		{"1:2:3:4", 0, false},
+		{"abc", 0, false},
+		{"-5", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := parseDuration(tt.input)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestParseFeed_TruncatesLongTitles(t *testing.T) {
+	t.Parallel()
+
+	feed := `<rss><channel><item><title>` + strings.Repeat("a", 300) + `</title></item></channel></rss>`
+	page, err := parseFeed(strings.NewReader(feed), uuid.New())
+	require.NoError(t, err)
+	require.Len(t, page.Items, 1)
+	assert.Len(t, page.Items[0].Episode.Title, maxTitleLength)
+}
+
+var _ Importer = (*RSSImporter)(nil)
+
+func TestRSSImporter_FetchPage_Latin1(t *testing.T) {
+	t.Parallel()
+
+	srv := newFeedServer(t)
+	imp := NewRSSImporter(srv.Client())
+	page, err := imp.FetchPage(context.Background(), FetchRequest{URL: srv.URL + "/feeds/latin1.rss.xml", SeriesID: uuid.New().String()})
+	require.NoError(t, err)
+	require.Len(t, page.Items, 1)
+
+	ep := page.Items[0].Episode
+	assert.Equal(t, "Épisode 1 : L'été à Montréal", ep.Title)
+	require.NotNil(t, ep.Description)
+	assert.Equal(t, "Crêpes, café et « bonne humeur ».", *ep.Description)
+	assert.True(t, utf8.ValidString(ep.Title))
+}
+
+func TestParseFeed_UnknownCharset(t *testing.T) {
+	t.Parallel()
+
+	feed := `<?xml version="1.0" encoding="x-made-up"?><rss><channel><item><title>Café</title></item></channel></rss>`
+	page, err := parseFeed(strings.NewReader(feed), uuid.New())
+	require.NoError(t, err)
+	require.Len(t, page.Items, 1)
+	assert.Equal(t, "Café", page.Items[0].Episode.Title)
+}
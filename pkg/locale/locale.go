@@ -0,0 +1,91 @@
+// Package locale handles the locales of translated content and picks the
+// translation that suits the languages a client accepts.
+package locale
+
+import (
+	"github.com/pkg/errors"
+	"golang.org/x/text/language"
+)
+
+// Normalize returns locale as a canonical BCP 47 tag, such as en-GB for
+// en_gb. Locales are stored in this form, so each language has one key.
+func Normalize(locale string) (string, error) {
+	tag, err := language.Parse(locale)
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid locale %q", locale)
+	}
+	if tag == language.Und {
+		return "", errors.Errorf("invalid locale %q", locale)
+	}
+	return tag.String(), nil
+}
+
+// Language returns the language subtag of locale, such as en for en-GB.
+// Translations into variants of a language are searched with the same
+// analyzer. It returns an empty string when locale names no known language.
+func Language(locale string) string {
+	tag, err := language.Parse(locale)
+	if err != nil || tag == language.Und {
+		return ""
+	}
+	base, _ := tag.Base()
+	return base.String()
+}
+
+// Preferred parses an Accept-Language header into the languages it lists,
+// most preferred first. A missing or invalid header lists none.
+func Preferred(header string) []language.Tag {
+	if header == "" {
+		return nil
+	}
+	tags, _, err := language.ParseAcceptLanguage(header)
+	if err != nil {
+		return nil
+	}
+	return tags
+}
+
+// Match returns the index of the locale in locales that serves a client
+// preferring the languages in preferred best, provided it serves them better
+// than the text itself, which is in the language base. A nil base means the
+// language of the text is unknown, any locale the client accepts is then
+// better. ok is false when the text should be used as it is.
+func Match(preferred []language.Tag, base *string, locales []string) (index int, ok bool) {
+	if len(preferred) == 0 || len(locales) == 0 {
+		return 0, false
+	}
+
+	// the first supported tag is the fallback of the matcher
+	supported := make([]language.Tag, 0, len(locales)+1)
+	supported = append(supported, language.Und)
+	if base != nil {
+		if tag, err := language.Parse(*base); err == nil {
+			supported[0] = tag
+		}
+	}
+	for _, l := range locales {
+		supported = append(supported, language.Make(l))
+	}
+
+	_, i, confidence := language.NewMatcher(supported).Match(preferred...)
+	if i == 0 || confidence < language.High {
+		return 0, false
+	}
+	return i - 1, true
+}
+
+// Pick returns the translation Match picks among translations, of which
+// locale returns the locale.
+func Pick[T any](preferred []language.Tag, base *string, translations []T, locale func(T) string) (T, bool) {
+	locales := make([]string, len(translations))
+	for i, t := range translations {
+		locales[i] = locale(t)
+	}
+
+	i, ok := Match(preferred, base, locales)
+	if !ok {
+		var zero T
+		return zero, false
+	}
+	return translations[i], true
+}
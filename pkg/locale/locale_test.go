@@ -0,0 +1,148 @@
+package locale
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalize(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		locale   string
+		expected string
+		wantErr  bool
+	}{
+		{name: "language", locale: "ar", expected: "ar"},
+		{name: "region", locale: "en-GB", expected: "en-GB"},
+		{name: "lower case with underscore", locale: "en_gb", expected: "en-GB"},
+		{name: "script", locale: "zh-hant", expected: "zh-Hant"},
+		{name: "undetermined", locale: "und", wantErr: true},
+		{name: "invalid", locale: "not a locale", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			locale, err := Normalize(tt.locale)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, locale)
+		})
+	}
+}
+
+func TestLanguage(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "en", Language("en-GB"))
+	assert.Equal(t, "ar", Language("ar"))
+	assert.Equal(t, "pt", Language("pt-BR"))
+	assert.Equal(t, "en", Language("en_us"))
+	assert.Empty(t, Language("English"))
+	assert.Empty(t, Language(""))
+}
+
+func TestMatch(t *testing.T) {
+	t.Parallel()
+
+	english := "en"
+	arabic := "ar"
+
+	tests := []struct {
+		name           string
+		acceptLanguage string
+		base           *string
+		locales        []string
+		expected       int
+		expectedOK     bool
+	}{
+		{
+			name:           "no header keeps the text",
+			acceptLanguage: "",
+			base:           &english,
+			locales:        []string{"ar"},
+		},
+		{
+			name:           "translation into the preferred language",
+			acceptLanguage: "ar",
+			base:           &english,
+			locales:        []string{"fr", "ar"},
+			expected:       1,
+			expectedOK:     true,
+		},
+		{
+			name:           "text in the preferred language wins over a translation",
+			acceptLanguage: "ar, en;q=0.5",
+			base:           &arabic,
+			locales:        []string{"en"},
+		},
+		{
+			name:           "second choice translation",
+			acceptLanguage: "de, ar;q=0.8",
+			base:           &english,
+			locales:        []string{"ar"},
+			expectedOK:     true,
+		},
+		{
+			name:           "regional translation serves the language",
+			acceptLanguage: "ar",
+			base:           &english,
+			locales:        []string{"ar-EG"},
+			expectedOK:     true,
+		},
+		{
+			name:           "no acceptable translation falls back to the text",
+			acceptLanguage: "de",
+			base:           &english,
+			locales:        []string{"ar"},
+		},
+		{
+			name:           "unknown language of the text",
+			acceptLanguage: "ar, en;q=0.5",
+			locales:        []string{"en"},
+			expectedOK:     true,
+		},
+		{
+			name:           "invalid header keeps the text",
+			acceptLanguage: "en;q=x;;",
+			base:           &arabic,
+			locales:        []string{"en"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			i, ok := Match(Preferred(tt.acceptLanguage), tt.base, tt.locales)
+			assert.Equal(t, tt.expectedOK, ok)
+			assert.Equal(t, tt.expected, i)
+		})
+	}
+}
+
+func TestPick(t *testing.T) {
+	t.Parallel()
+
+	type translation struct {
+		Locale string
+		Title  string
+	}
+	translations := []translation{{Locale: "ar", Title: "حديث التقنية"}, {Locale: "fr", Title: "Discussion technique"}}
+	english := "en"
+
+	picked, ok := Pick(Preferred("fr-CH, fr;q=0.9"), &english, translations, func(t translation) string { return t.Locale })
+	require.True(t, ok)
+	assert.Equal(t, "Discussion technique", picked.Title)
+
+	_, ok = Pick(Preferred("en-US"), &english, translations, func(t translation) string { return t.Locale })
+	assert.False(t, ok)
+}
@@ -0,0 +1,27 @@
+package mapping
+
+import (
+	"encoding/json"
+	"th-application-technical-assignment/pkg/api/cms/v1"
+	"th-application-technical-assignment/sqlc"
+)
+
+func AuditEvent(e sqlc.AuditEvent) v1.AuditEventResponse {
+	resp := v1.AuditEventResponse{
+		ID:         e.ID.String(),
+		Actor:      e.Actor,
+		Action:     e.Action,
+		EntityType: e.EntityType,
+		EntityID:   e.EntityID.String(),
+		Changes:    map[string]v1.AuditFieldChange{},
+		CreatedAt:  e.CreatedAt,
+	}
+
+	if len(e.Changes) > 0 {
+		if err := json.Unmarshal(e.Changes, &resp.Changes); err != nil || resp.Changes == nil {
+			resp.Changes = map[string]v1.AuditFieldChange{}
+		}
+	}
+
+	return resp
+}
@@ -1,14 +1,79 @@
 package mapping
 
 import (
+	"cmp"
+	"slices"
 	"th-application-technical-assignment/pkg/api/cms/v1"
 	"th-application-technical-assignment/sqlc"
+
+	"github.com/google/uuid"
 )
 
 func Category(c sqlc.Category) v1.CategoryResponse {
-	return v1.CategoryResponse{
+	resp := v1.CategoryResponse{
 		ID:   c.ID.String(),
+		Name: c.Name,
 		Slug: c.Slug,
 	}
+
+	if c.ParentID != nil {
+		parentID := c.ParentID.String()
+		resp.ParentID = &parentID
+	}
+
+	return resp
+}
+
+// CategoryTree nests categories below their parents, siblings ordered by
+// name. A category whose parent is not among categories becomes a root.
+// Categories with an entry in translations are named, and ordered, by it.
+func CategoryTree(categories []sqlc.Category, translations map[uuid.UUID]sqlc.CategoryTranslation) []v1.CategoryTreeNode {
+	categories = slices.Clone(categories)
+	for i, c := range categories {
+		if t, ok := translations[c.ID]; ok {
+			categories[i].Name = t.Name
+		}
+	}
+	slices.SortFunc(categories, func(a, b sqlc.Category) int {
+		return cmp.Or(cmp.Compare(a.Name, b.Name), cmp.Compare(a.ID.String(), b.ID.String()))
+	})
+
+	known := make(map[uuid.UUID]bool, len(categories))
+	for _, c := range categories {
+		known[c.ID] = true
+	}
+	children := make(map[uuid.UUID][]sqlc.Category)
+	var roots []sqlc.Category
+	for _, c := range categories {
+		if c.ParentID != nil && known[*c.ParentID] {
+			children[*c.ParentID] = append(children[*c.ParentID], c)
+		} else {
+			roots = append(roots, c)
+		}
+	}
+
+	var build func(cs []sqlc.Category) []v1.CategoryTreeNode
+	build = func(cs []sqlc.Category) []v1.CategoryTreeNode {
+		nodes := make([]v1.CategoryTreeNode, len(cs))
+		for i, c := range cs {
+			nodes[i] = v1.CategoryTreeNode{
+				ID:       c.ID.String(),
+				Name:     c.Name,
+				Slug:     c.Slug,
+				Children: build(children[c.ID]),
+			}
+			if t, ok := translations[c.ID]; ok {
+				nodes[i].Locale = &t.Locale
+			}
+		}
+		return nodes
+	}
+	return build(roots)
 }
 
+func DependentSeries(s sqlc.Series) v1.DependentSeries {
+	return v1.DependentSeries{
+		ID:    s.ID.String(),
+		Title: s.Title,
+	}
+}
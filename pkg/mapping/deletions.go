@@ -0,0 +1,33 @@
+package mapping
+
+import (
+	"encoding/json"
+	"th-application-technical-assignment/pkg/api/cms/v1"
+	"th-application-technical-assignment/sqlc"
+)
+
+func DeletionJob(j sqlc.DeletionJob) v1.DeletionJobResponse {
+	resp := v1.DeletionJobResponse{
+		ID:            j.ID.String(),
+		SeriesID:      j.SeriesID.String(),
+		RequestedBy:   j.RequestedBy,
+		DeleteObjects: j.DeleteObjects,
+		Status:        j.Status,
+		EpisodeCount:  j.EpisodeCount,
+		AssetCount:    j.AssetCount,
+		ObjectCount:   j.ObjectCount,
+		Errors:        []v1.DeletionError{},
+		StartedAt:     j.StartedAt,
+		FinishedAt:    j.FinishedAt,
+		CreatedAt:     j.CreatedAt,
+		UpdatedAt:     j.UpdatedAt,
+	}
+
+	if len(j.Errors) > 0 {
+		if err := json.Unmarshal(j.Errors, &resp.Errors); err != nil || resp.Errors == nil {
+			resp.Errors = []v1.DeletionError{}
+		}
+	}
+
+	return resp
+}
@@ -0,0 +1,75 @@
+package mapping
+
+import (
+	"th-application-technical-assignment/pkg/search"
+	"th-application-technical-assignment/sqlc"
+
+	"github.com/google/uuid"
+)
+
+// SeriesDocument builds the search document of a series. categoryPath lists
+// the category ids from the root category down to the category of the series.
+func SeriesDocument(s sqlc.Series, categoryPath []uuid.UUID, translations []sqlc.SeriesTranslation) search.SeriesDocument {
+	doc := search.SeriesDocument{
+		ID:            s.ID.String(),
+		Title:         s.Title,
+		Slug:          s.Slug,
+		PreviousSlugs: s.PreviousSlugs,
+		Description:   s.Description,
+		CategoryID:    s.CategoryID.String(),
+		Language:      s.Language,
+		Type:          s.SeriesType,
+		CreatedAt:     s.CreatedAt,
+		UpdatedAt:     s.UpdatedAt,
+	}
+
+	for _, id := range categoryPath {
+		doc.CategoryPath = append(doc.CategoryPath, id.String())
+	}
+
+	if s.Language != nil {
+		doc.AddText(*s.Language, s.Title, s.Description)
+	}
+	for _, t := range translations {
+		doc.AddTranslation(t.Locale, t.Title, t.Description)
+	}
+
+	return doc
+}
+
+// EpisodeDocument builds the search document of an episode. language is the
+// language of its series, which the episode shares.
+func EpisodeDocument(ep sqlc.Episode, language *string, assets []sqlc.EpisodeAsset, translations []sqlc.EpisodeTranslation) search.EpisodeDocument {
+	doc := search.EpisodeDocument{
+		ID:              ep.ID.String(),
+		SeriesID:        ep.SeriesID.String(),
+		Title:           ep.Title,
+		Slug:            ep.Slug,
+		PreviousSlugs:   ep.PreviousSlugs,
+		Description:     ep.Description,
+		DurationSeconds: ep.DurationSeconds,
+		PublishDate:     ep.PublishDate,
+		Language:        language,
+		CreatedAt:       ep.CreatedAt,
+		UpdatedAt:       ep.UpdatedAt,
+	}
+
+	for _, a := range assets {
+		doc.Assets = append(doc.Assets, search.AssetDocument{
+			ID:        a.ID.String(),
+			AssetType: a.AssetType,
+			MimeType:  a.MimeType,
+			SizeBytes: a.SizeBytes,
+			URL:       a.Url,
+		})
+	}
+
+	if language != nil {
+		doc.AddText(*language, ep.Title, ep.Description)
+	}
+	for _, t := range translations {
+		doc.AddTranslation(t.Locale, t.Title, t.Description)
+	}
+
+	return doc
+}
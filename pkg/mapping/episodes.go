@@ -13,8 +13,10 @@ func Episode(ep sqlc.Episode, assets []sqlc.EpisodeAsset) v1.EpisodeResponse {
 		SeriesID:        ep.SeriesID.String(),
 		Description:     ep.Description,
 		Title:           ep.Title,
+		Slug:            ep.Slug,
 		DurationSeconds: ep.DurationSeconds,
 		PublishDate:     ep.PublishDate,
+		Status:          ep.Status,
 		CreatedAt:       ep.CreatedAt,
 		UpdatedAt:       ep.UpdatedAt,
 	}
@@ -44,9 +46,11 @@ func EpisodesWithAssets(rows []sqlc.ListEpisodesWithAssetsBySeriesPaginatedRow)
 				ID:              row.EpisodeID.String(),
 				SeriesID:        row.SeriesID.String(),
 				Title:           row.Title,
+				Slug:            row.Slug,
 				Description:     row.Description,
 				DurationSeconds: row.DurationSeconds,
 				PublishDate:     row.PublishDate,
+				Status:          row.Status,
 				CreatedAt:       row.EpisodeCreatedAt,
 				UpdatedAt:       row.EpisodeUpdatedAt,
 				Assets:          []v1.EpisodeAssetResponse{},
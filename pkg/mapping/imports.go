@@ -0,0 +1,33 @@
+package mapping
+
+import (
+	"encoding/json"
+	"th-application-technical-assignment/pkg/api/cms/v1"
+	"th-application-technical-assignment/sqlc"
+)
+
+func ImportJob(j sqlc.ImportJob) v1.ImportJobResponse {
+	resp := v1.ImportJobResponse{
+		ID:           j.ID.String(),
+		SeriesID:     j.SeriesID.String(),
+		SourceType:   j.SourceType,
+		SourceURL:    j.SourceUrl,
+		Status:       j.Status,
+		CreatedCount: j.CreatedCount,
+		UpdatedCount: j.UpdatedCount,
+		SkippedCount: j.SkippedCount,
+		Errors:       []v1.ImportItemError{},
+		StartedAt:    j.StartedAt,
+		FinishedAt:   j.FinishedAt,
+		CreatedAt:    j.CreatedAt,
+		UpdatedAt:    j.UpdatedAt,
+	}
+
+	if len(j.Errors) > 0 {
+		if err := json.Unmarshal(j.Errors, &resp.Errors); err != nil || resp.Errors == nil {
+			resp.Errors = []v1.ImportItemError{}
+		}
+	}
+
+	return resp
+}
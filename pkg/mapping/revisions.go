@@ -0,0 +1,40 @@
+package mapping
+
+import (
+	"encoding/json"
+	"th-application-technical-assignment/pkg/api/cms/v1"
+	"th-application-technical-assignment/pkg/audit"
+	"th-application-technical-assignment/sqlc"
+)
+
+func Revision(r sqlc.ContentRevision) v1.RevisionResponse {
+	return v1.RevisionResponse{
+		ID:         r.ID.String(),
+		EntityType: r.EntityType,
+		EntityID:   r.EntityID.String(),
+		Revision:   r.Revision,
+		Author:     r.Author,
+		RollbackOf: r.RollbackOf,
+		Snapshot:   json.RawMessage(r.Snapshot),
+		CreatedAt:  r.CreatedAt,
+	}
+}
+
+func RevisionDiff(from, to sqlc.ContentRevision, changes map[string]audit.FieldChange) v1.RevisionDiffResponse {
+	resp := v1.RevisionDiffResponse{
+		EntityType: to.EntityType,
+		EntityID:   to.EntityID.String(),
+		From:       from.Revision,
+		To:         to.Revision,
+		Changes:    make(map[string]v1.AuditFieldChange, len(changes)),
+	}
+
+	for field, c := range changes {
+		// the values were decoded from JSON and encode again without error
+		before, _ := json.Marshal(c.Before)
+		after, _ := json.Marshal(c.After)
+		resp.Changes[field] = v1.AuditFieldChange{Before: before, After: after}
+	}
+
+	return resp
+}
@@ -9,6 +9,7 @@ func Series(s sqlc.Series) v1.SeriesResponse {
 	resp := v1.SeriesResponse{
 		ID:          s.ID.String(),
 		Title:       s.Title,
+		Slug:        s.Slug,
 		CategoryID:  s.CategoryID.String(),
 		Type:        s.SeriesType,
 		Description: s.Description,
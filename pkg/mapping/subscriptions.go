@@ -0,0 +1,30 @@
+package mapping
+
+import (
+	"th-application-technical-assignment/pkg/api/cms/v1"
+	"th-application-technical-assignment/sqlc"
+)
+
+// Subscription maps a series subscription together with the import job of
+// its last sync, if there was one.
+func Subscription(s sqlc.SeriesSubscription, lastJob *sqlc.ImportJob) v1.SubscriptionResponse {
+	resp := v1.SubscriptionResponse{
+		SeriesID:        s.SeriesID.String(),
+		SourceType:      s.SourceType,
+		SourceURL:       s.SourceUrl,
+		IntervalSeconds: s.IntervalSeconds,
+		ETag:            s.Etag,
+		LastModified:    s.LastModified,
+		NextSyncAt:      s.NextSyncAt,
+		LastSyncedAt:    s.LastSyncedAt,
+		CreatedAt:       s.CreatedAt,
+		UpdatedAt:       s.UpdatedAt,
+	}
+
+	if lastJob != nil {
+		job := ImportJob(*lastJob)
+		resp.LastSync = &job
+	}
+
+	return resp
+}
@@ -0,0 +1,60 @@
+package mapping
+
+import (
+	"th-application-technical-assignment/pkg/api/cms/v1"
+	"th-application-technical-assignment/sqlc"
+)
+
+func SeriesTranslation(t sqlc.SeriesTranslation) v1.TranslationResponse {
+	return v1.TranslationResponse{
+		Locale:      t.Locale,
+		Title:       t.Title,
+		Description: t.Description,
+		CreatedAt:   t.CreatedAt,
+		UpdatedAt:   t.UpdatedAt,
+	}
+}
+
+func EpisodeTranslation(t sqlc.EpisodeTranslation) v1.TranslationResponse {
+	return v1.TranslationResponse{
+		Locale:      t.Locale,
+		Title:       t.Title,
+		Description: t.Description,
+		CreatedAt:   t.CreatedAt,
+		UpdatedAt:   t.UpdatedAt,
+	}
+}
+
+func CategoryTranslation(t sqlc.CategoryTranslation) v1.CategoryTranslationResponse {
+	return v1.CategoryTranslationResponse{
+		Locale:    t.Locale,
+		Name:      t.Name,
+		CreatedAt: t.CreatedAt,
+		UpdatedAt: t.UpdatedAt,
+	}
+}
+
+// LocalizedSeries replaces the title and description of a series with the
+// translation t. Both come from the translation, a missing translated
+// description is not filled in from another language.
+func LocalizedSeries(resp v1.SeriesResponse, t sqlc.SeriesTranslation) v1.SeriesResponse {
+	resp.Title = t.Title
+	resp.Description = t.Description
+	resp.Locale = &t.Locale
+	return resp
+}
+
+// LocalizedEpisode replaces the title and description of an episode with
+// the translation t, like LocalizedSeries.
+func LocalizedEpisode(resp v1.EpisodeResponse, t sqlc.EpisodeTranslation) v1.EpisodeResponse {
+	resp.Title = t.Title
+	resp.Description = t.Description
+	resp.Locale = &t.Locale
+	return resp
+}
+
+func LocalizedCategory(resp v1.CategoryResponse, t sqlc.CategoryTranslation) v1.CategoryResponse {
+	resp.Name = t.Name
+	resp.Locale = &t.Locale
+	return resp
+}
@@ -0,0 +1,24 @@
+package mapping
+
+import (
+	"th-application-technical-assignment/pkg/api/cms/v1"
+	"th-application-technical-assignment/sqlc"
+)
+
+func TrashItem(t sqlc.ListTrashPaginatedRow) v1.TrashItemResponse {
+	resp := v1.TrashItemResponse{
+		Type:  t.EntityType,
+		ID:    t.ID.String(),
+		Title: t.Title,
+	}
+
+	if t.SeriesID != nil {
+		seriesID := t.SeriesID.String()
+		resp.SeriesID = &seriesID
+	}
+	if t.DeletedAt != nil {
+		resp.DeletedAt = *t.DeletedAt
+	}
+
+	return resp
+}
@@ -0,0 +1,53 @@
+// Package publishing defines the publishing states of episodes and the
+// transitions between them. Only published episodes are searchable.
+package publishing
+
+import (
+	"slices"
+	"time"
+)
+
+// Statuses stored in episodes.status.
+const (
+	StatusDraft       = "draft"
+	StatusScheduled   = "scheduled"
+	StatusPublished   = "published"
+	StatusUnpublished = "unpublished"
+)
+
+// transitions lists the statuses each status can change to. Unpublishing a
+// scheduled episode cancels the schedule and makes it a draft again.
+var transitions = map[string][]string{
+	StatusDraft:       {StatusScheduled, StatusPublished},
+	StatusScheduled:   {StatusPublished, StatusDraft},
+	StatusPublished:   {StatusUnpublished},
+	StatusUnpublished: {StatusScheduled, StatusPublished},
+}
+
+// CanTransition reports whether an episode may change from status from to
+// status to.
+func CanTransition(from, to string) bool {
+	return slices.Contains(transitions[from], to)
+}
+
+// PublishTarget returns the status publishing an episode leads to: scheduled
+// while its publish date lies in the future, published otherwise.
+func PublishTarget(publishDate *time.Time, now time.Time) string {
+	if publishDate != nil && publishDate.After(now) {
+		return StatusScheduled
+	}
+	return StatusPublished
+}
+
+// UnpublishTarget returns the status unpublishing an episode leads to, and
+// false when an episode in status cannot be unpublished.
+func UnpublishTarget(status string) (string, bool) {
+	switch status {
+	case StatusPublished:
+		return StatusUnpublished, true
+	case StatusScheduled:
+		return StatusDraft, true
+	default:
+		return "", false
+	}
+}
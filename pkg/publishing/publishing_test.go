@@ -0,0 +1,70 @@
+package publishing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanTransition(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		from     string
+		to       string
+		expected bool
+	}{
+		{from: StatusDraft, to: StatusScheduled, expected: true},
+		{from: StatusDraft, to: StatusPublished, expected: true},
+		{from: StatusDraft, to: StatusUnpublished},
+		{from: StatusScheduled, to: StatusPublished, expected: true},
+		{from: StatusScheduled, to: StatusDraft, expected: true},
+		{from: StatusScheduled, to: StatusUnpublished},
+		{from: StatusPublished, to: StatusUnpublished, expected: true},
+		{from: StatusPublished, to: StatusPublished},
+		{from: StatusPublished, to: StatusDraft},
+		{from: StatusUnpublished, to: StatusPublished, expected: true},
+		{from: StatusUnpublished, to: StatusScheduled, expected: true},
+		{from: StatusUnpublished, to: StatusDraft},
+		{from: "archived", to: StatusPublished},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.from+" to "+tt.to, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expected, CanTransition(tt.from, tt.to))
+		})
+	}
+}
+
+func TestPublishTarget(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 9, 17, 9, 0, 0, 0, time.UTC)
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+
+	assert.Equal(t, StatusPublished, PublishTarget(nil, now))
+	assert.Equal(t, StatusPublished, PublishTarget(&past, now))
+	assert.Equal(t, StatusPublished, PublishTarget(&now, now))
+	assert.Equal(t, StatusScheduled, PublishTarget(&future, now))
+}
+
+func TestUnpublishTarget(t *testing.T) {
+	t.Parallel()
+
+	status, ok := UnpublishTarget(StatusPublished)
+	assert.True(t, ok)
+	assert.Equal(t, StatusUnpublished, status)
+
+	status, ok = UnpublishTarget(StatusScheduled)
+	assert.True(t, ok)
+	assert.Equal(t, StatusDraft, status)
+
+	_, ok = UnpublishTarget(StatusDraft)
+	assert.False(t, ok)
+	_, ok = UnpublishTarget(StatusUnpublished)
+	assert.False(t, ok)
+}
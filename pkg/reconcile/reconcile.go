@@ -0,0 +1,337 @@
+package reconcile
+
+import (
+	"context"
+	"log/slog"
+	"th-application-technical-assignment/pkg/database"
+	"th-application-technical-assignment/pkg/search"
+	"th-application-technical-assignment/pkg/tasks"
+	"th-application-technical-assignment/sqlc"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+const (
+	DefaultBatchSize = 500
+	DefaultGrace     = 5 * time.Minute
+)
+
+type Config struct {
+	BatchSize int  `env:"BATCH_SIZE" envDefault:"500"`
+	Repair    bool `env:"REPAIR" envDefault:"false"`
+	// Interval repeats the check until the process is stopped. With the
+	// default of zero the check runs once.
+	Interval time.Duration `env:"INTERVAL" envDefault:"0s"`
+	// Grace ignores rows and documents changed this recently, whose
+	// indexing tasks may still be on their way.
+	Grace time.Duration `env:"GRACE" envDefault:"5m"`
+}
+
+// Reconciler compares the series and episodes in PostgreSQL with the
+// documents behind the search read aliases. Both sides are walked in id
+// order in batches and merged, so memory use does not grow with the size of
+// the catalog.
+//
+// A live row without a document is missing, a document whose updated_at
+// differs from its row is stale and a document without a live row is
+// orphaned. With repair enabled, index and delete tasks are enqueued for
+// them and applied by the indexer like any other change.
+type Reconciler struct {
+	store     *database.Store
+	indices   search.IndexManager
+	queue     tasks.TaskQueue
+	prefix    string
+	batchSize int
+	repair    bool
+	interval  time.Duration
+	grace     time.Duration
+	now       func() time.Time
+}
+
+// Result describes the differences found for one alias.
+type Result struct {
+	Alias     string
+	Rows      int
+	Documents int
+	Missing   []string
+	Stale     []string
+	Orphaned  []string
+	// Repaired counts the tasks enqueued to fix the differences.
+	Repaired int
+}
+
+// Consistent reports whether the index matched the database.
+func (r Result) Consistent() bool {
+	return len(r.Missing) == 0 && len(r.Stale) == 0 && len(r.Orphaned) == 0
+}
+
+func New(store *database.Store, indices search.IndexManager, queue tasks.TaskQueue, searchCfg *search.Config, cfg *Config) *Reconciler {
+	r := &Reconciler{
+		store:     store,
+		indices:   indices,
+		queue:     queue,
+		prefix:    searchCfg.IndexPrefix,
+		batchSize: DefaultBatchSize,
+		grace:     DefaultGrace,
+		now:       time.Now,
+	}
+
+	if cfg != nil {
+		if cfg.BatchSize > 0 {
+			r.batchSize = cfg.BatchSize
+		}
+		if cfg.Grace > 0 {
+			r.grace = cfg.Grace
+		}
+		r.repair = cfg.Repair
+		r.interval = cfg.Interval
+	}
+
+	return r
+}
+
+// Run checks the indices every interval until ctx is canceled. Without an
+// interval it checks once.
+func (r *Reconciler) Run(ctx context.Context) {
+	if r.interval <= 0 {
+		if _, err := r.Check(ctx); err != nil {
+			slog.WarnContext(ctx, "failed to reconcile search indices", "err", err)
+		}
+		return
+	}
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := r.Check(ctx); err != nil {
+			slog.WarnContext(ctx, "failed to reconcile search indices", "err", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// record is a live row as the search index should contain it.
+type record struct {
+	id        string
+	updatedAt time.Time
+	// index enqueues the task that indexes the row.
+	index func(ctx context.Context) error
+}
+
+// target is one index spec together with how to list and repair its rows.
+type target struct {
+	spec   search.IndexSpec
+	page   func(ctx context.Context, after uuid.UUID) ([]record, uuid.UUID, error)
+	// delete enqueues the task that removes a document, versioned with
+	// deletedAt.
+	delete func(ctx context.Context, id string, deletedAt time.Time) error
+}
+
+// Check compares the series index, then the episodes index, with the
+// database and logs a summary of each.
+func (r *Reconciler) Check(ctx context.Context) ([]Result, error) {
+	targets := []target{
+		{spec: search.SeriesIndex, page: r.seriesPage, delete: r.queue.EnqueueDeleteSeries},
+		{spec: search.EpisodesIndex, page: r.episodesPage, delete: r.queue.EnqueueDeleteEpisode},
+	}
+
+	results := make([]Result, 0, len(targets))
+	for _, t := range targets {
+		res, err := r.check(ctx, t)
+		if err != nil {
+			return results, errors.Wrapf(err, "failed to reconcile %s", t.spec.ReadAlias(r.prefix))
+		}
+
+		attrs := []any{"alias", res.Alias, "rows", res.Rows, "documents", res.Documents,
+			"missing", len(res.Missing), "stale", len(res.Stale), "orphaned", len(res.Orphaned), "repaired", res.Repaired}
+		if res.Consistent() {
+			slog.InfoContext(ctx, "search index is consistent", attrs...)
+		} else {
+			slog.WarnContext(ctx, "search index differs from database", attrs...)
+		}
+		results = append(results, res)
+	}
+
+	return results, nil
+}
+
+func (r *Reconciler) check(ctx context.Context, t target) (Result, error) {
+	alias := t.spec.ReadAlias(r.prefix)
+	res := Result{Alias: alias}
+	// changes after the cutoff may still be in the outbox or the queue
+	cutoff := r.now().Add(-r.grace)
+
+	after := uuid.Nil
+	rows := &cursor[record]{size: r.batchSize, fetch: func(ctx context.Context) ([]record, error) {
+		recs, last, err := t.page(ctx, after)
+		after = last
+		return recs, err
+	}}
+	lastDoc := ""
+	docs := &cursor[search.DocumentVersion]{size: r.batchSize, fetch: func(ctx context.Context) ([]search.DocumentVersion, error) {
+		page, err := r.indices.ScanDocuments(ctx, alias, lastDoc, r.batchSize)
+		if len(page) > 0 {
+			lastDoc = page[len(page)-1].ID
+		}
+		return page, err
+	}}
+
+	for {
+		row, hasRow, err := rows.peek(ctx)
+		if err != nil {
+			return res, errors.Wrap(err, "failed to list rows")
+		}
+		doc, hasDoc, err := docs.peek(ctx)
+		if err != nil {
+			return res, err
+		}
+
+		switch {
+		case !hasRow && !hasDoc:
+			return res, nil
+
+		case hasRow && (!hasDoc || row.id < doc.ID):
+			rows.pop()
+			res.Rows++
+			if row.updatedAt.After(cutoff) {
+				continue
+			}
+			res.Missing = append(res.Missing, row.id)
+			if err := r.fix(ctx, &res, row.index); err != nil {
+				return res, err
+			}
+
+		case hasDoc && (!hasRow || doc.ID < row.id):
+			docs.pop()
+			res.Documents++
+			if doc.UpdatedAt.After(cutoff) {
+				continue
+			}
+			res.Orphaned = append(res.Orphaned, doc.ID)
+			// versioned as the document itself, so one indexed again in the
+			// meantime is kept
+			if err := r.fix(ctx, &res, func(ctx context.Context) error { return t.delete(ctx, doc.ID, doc.UpdatedAt) }); err != nil {
+				return res, err
+			}
+
+		default:
+			rows.pop()
+			docs.pop()
+			res.Rows++
+			res.Documents++
+			if doc.UpdatedAt.Equal(row.updatedAt) || row.updatedAt.After(cutoff) {
+				continue
+			}
+			res.Stale = append(res.Stale, row.id)
+			if err := r.fix(ctx, &res, row.index); err != nil {
+				return res, err
+			}
+		}
+	}
+}
+
+func (r *Reconciler) fix(ctx context.Context, res *Result, enqueue func(ctx context.Context) error) error {
+	if !r.repair {
+		return nil
+	}
+	if err := enqueue(ctx); err != nil {
+		return errors.Wrap(err, "failed to enqueue repair task")
+	}
+	res.Repaired++
+	return nil
+}
+
+func (r *Reconciler) seriesPage(ctx context.Context, after uuid.UUID) ([]record, uuid.UUID, error) {
+	rows, err := r.store.Queries.ListSeriesAfter(ctx, sqlc.ListSeriesAfterParams{
+		ID:    after,
+		Limit: int32(r.batchSize),
+	})
+	if err != nil {
+		return nil, after, errors.Wrap(err, "failed to list series")
+	}
+
+	recs := make([]record, 0, len(rows))
+	for _, s := range rows {
+		recs = append(recs, record{
+			id:        s.ID.String(),
+			updatedAt: s.UpdatedAt,
+			index: func(ctx context.Context) error {
+				return r.queue.EnqueueIndexSeries(ctx, s)
+			},
+		})
+	}
+
+	if len(rows) > 0 {
+		after = rows[len(rows)-1].ID
+	}
+	return recs, after, nil
+}
+
+func (r *Reconciler) episodesPage(ctx context.Context, after uuid.UUID) ([]record, uuid.UUID, error) {
+	rows, err := r.store.Queries.ListEpisodesAfter(ctx, sqlc.ListEpisodesAfterParams{
+		ID:    after,
+		Limit: int32(r.batchSize),
+	})
+	if err != nil {
+		return nil, after, errors.Wrap(err, "failed to list episodes")
+	}
+
+	recs := make([]record, 0, len(rows))
+	for _, ep := range rows {
+		recs = append(recs, record{
+			id:        ep.ID.String(),
+			updatedAt: ep.UpdatedAt,
+			index: func(ctx context.Context) error {
+				assets, err := r.store.Queries.ListAssetsByEpisode(ctx, ep.ID)
+				if err != nil {
+					return errors.Wrap(err, "failed to list assets")
+				}
+				return r.queue.EnqueueIndexEpisode(ctx, ep, assets)
+			},
+		})
+	}
+
+	if len(rows) > 0 {
+		after = rows[len(rows)-1].ID
+	}
+	return recs, after, nil
+}
+
+// cursor walks a sequence that is fetched in batches of size. A batch
+// shorter than size ends the sequence.
+type cursor[T any] struct {
+	fetch func(ctx context.Context) ([]T, error)
+	size  int
+	buf   []T
+	done  bool
+}
+
+// peek returns the current element, fetching the next batch when needed.
+// It reports false at the end of the sequence.
+func (c *cursor[T]) peek(ctx context.Context) (T, bool, error) {
+	var zero T
+	for len(c.buf) == 0 {
+		if c.done {
+			return zero, false, nil
+		}
+		batch, err := c.fetch(ctx)
+		if err != nil {
+			return zero, false, err
+		}
+		c.buf = batch
+		c.done = len(batch) < c.size
+	}
+	return c.buf[0], true, nil
+}
+
+func (c *cursor[T]) pop() {
+	c.buf = c.buf[1:]
+}
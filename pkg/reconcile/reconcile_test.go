@@ -0,0 +1,165 @@
+package reconcile
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"th-application-technical-assignment/pkg/database"
+	"th-application-technical-assignment/pkg/search"
+	"th-application-technical-assignment/pkg/tasks"
+	"th-application-technical-assignment/sqlc"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReconciler_Check(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 9, 10, 12, 0, 0, 0, time.UTC)
+	old := now.Add(-time.Hour)
+	recent := now.Add(-time.Minute)
+
+	id := func(n string) uuid.UUID {
+		return uuid.MustParse("00000000-0000-0000-0000-00000000000" + n)
+	}
+	series := func(n string, updatedAt time.Time) sqlc.Series {
+		return sqlc.Series{ID: id(n), Title: "Series " + n, UpdatedAt: updatedAt}
+	}
+	doc := func(n string, updatedAt time.Time) search.DocumentVersion {
+		return search.DocumentVersion{ID: id(n).String(), UpdatedAt: updatedAt}
+	}
+
+	// 1 matches, 2 is missing, 3 is stale, 4 is missing but changed within
+	// the grace period, 5 is orphaned and 6 is orphaned but recent
+	rowPages := [][]sqlc.Series{
+		{series("1", old), series("2", old)},
+		{series("3", old), series("4", recent)},
+		{},
+	}
+	docPages := [][]search.DocumentVersion{
+		{doc("1", old), doc("3", old.Add(-time.Hour))},
+		{doc("5", old), doc("6", recent)},
+		{},
+	}
+
+	tests := []struct {
+		name           string
+		repair         bool
+		enqueueError   error
+		expectRepaired int
+		expectError    bool
+	}{
+		{
+			name: "reports differences",
+		},
+		{
+			name:           "enqueues repair tasks",
+			repair:         true,
+			expectRepaired: 3,
+		},
+		{
+			name:         "fails when a repair task cannot be written",
+			repair:       true,
+			enqueueError: errors.New("db down"),
+			expectError:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockQuerier := new(database.MockQuerier)
+			mockIndices := new(search.MockIndexManager)
+			mockQueue := new(tasks.MockQueue)
+
+			after := uuid.Nil
+			for _, page := range rowPages {
+				mockQuerier.On("ListSeriesAfter", mock.Anything, sqlc.ListSeriesAfterParams{ID: after, Limit: 2}).Return(page, nil).Maybe()
+				if len(page) > 0 {
+					after = page[len(page)-1].ID
+				}
+			}
+			lastDoc := ""
+			for _, page := range docPages {
+				mockIndices.On("ScanDocuments", mock.Anything, "th-series", lastDoc, 2).Return(page, nil).Maybe()
+				if len(page) > 0 {
+					lastDoc = page[len(page)-1].ID
+				}
+			}
+			mockQuerier.On("ListEpisodesAfter", mock.Anything, sqlc.ListEpisodesAfterParams{ID: uuid.Nil, Limit: 2}).Return([]sqlc.Episode{}, nil).Maybe()
+			mockIndices.On("ScanDocuments", mock.Anything, "th-episodes", "", 2).Return([]search.DocumentVersion{}, nil).Maybe()
+
+			if tt.repair {
+				mockQueue.On("EnqueueIndexSeries", mock.Anything, series("2", old)).Return(tt.enqueueError).Once()
+				if tt.enqueueError == nil {
+					mockQueue.On("EnqueueIndexSeries", mock.Anything, series("3", old)).Return(nil).Once()
+					mockQueue.On("EnqueueDeleteSeries", mock.Anything, id("5").String(), old).Return(nil).Once()
+				}
+			}
+
+			r := New(&database.Store{Queries: mockQuerier}, mockIndices, mockQueue, &search.Config{IndexPrefix: "th"}, &Config{
+				BatchSize: 2,
+				Repair:    tt.repair,
+			})
+			r.now = func() time.Time { return now }
+
+			results, err := r.Check(context.Background())
+
+			if tt.expectError {
+				assert.Error(t, err)
+				assert.Empty(t, results)
+			} else {
+				require.NoError(t, err)
+				require.Len(t, results, 2)
+
+				assert.Equal(t, Result{
+					Alias:     "th-series",
+					Rows:      4,
+					Documents: 4,
+					Missing:   []string{id("2").String()},
+					Stale:     []string{id("3").String()},
+					Orphaned:  []string{id("5").String()},
+					Repaired:  tt.expectRepaired,
+				}, results[0])
+				assert.False(t, results[0].Consistent())
+				assert.Equal(t, Result{Alias: "th-episodes"}, results[1])
+				assert.True(t, results[1].Consistent())
+			}
+			mockQueue.AssertExpectations(t)
+		})
+	}
+}
+
+func TestReconciler_Check_RepairsEpisodeWithAssets(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 9, 10, 12, 0, 0, 0, time.UTC)
+	episode := sqlc.Episode{ID: uuid.New(), SeriesID: uuid.New(), UpdatedAt: now.Add(-time.Hour)}
+	assets := []sqlc.EpisodeAsset{{ID: uuid.New(), EpisodeID: episode.ID}}
+
+	mockQuerier := new(database.MockQuerier)
+	mockIndices := new(search.MockIndexManager)
+	mockQueue := new(tasks.MockQueue)
+
+	mockQuerier.On("ListSeriesAfter", mock.Anything, mock.Anything).Return([]sqlc.Series{}, nil)
+	mockIndices.On("ScanDocuments", mock.Anything, "th-series", "", DefaultBatchSize).Return([]search.DocumentVersion{}, nil)
+	mockQuerier.On("ListEpisodesAfter", mock.Anything, mock.Anything).Return([]sqlc.Episode{episode}, nil)
+	mockIndices.On("ScanDocuments", mock.Anything, "th-episodes", "", DefaultBatchSize).Return([]search.DocumentVersion{}, nil)
+	mockQuerier.On("ListAssetsByEpisode", mock.Anything, episode.ID).Return(assets, nil)
+	mockQueue.On("EnqueueIndexEpisode", mock.Anything, episode, assets).Return(nil).Once()
+
+	r := New(&database.Store{Queries: mockQuerier}, mockIndices, mockQueue, &search.Config{IndexPrefix: "th"}, &Config{Repair: true})
+	r.now = func() time.Time { return now }
+
+	results, err := r.Check(context.Background())
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, []string{episode.ID.String()}, results[1].Missing)
+	assert.Equal(t, 1, results[1].Repaired)
+	mockQueue.AssertExpectations(t)
+}
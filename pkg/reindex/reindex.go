@@ -0,0 +1,455 @@
+package reindex
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"th-application-technical-assignment/pkg/database"
+	"th-application-technical-assignment/pkg/mapping"
+	"th-application-technical-assignment/pkg/search"
+	"th-application-technical-assignment/sqlc"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+const DefaultBatchSize = 500
+
+// catchUpMargin widens the catch-up window. Timestamps are taken by
+// Postgres at the start of a transaction, so a row committed just after the
+// window opened can carry an earlier time. Reindexing a few rows twice is
+// harmless.
+const catchUpMargin = time.Minute
+
+type Config struct {
+	BatchSize int  `env:"BATCH_SIZE" envDefault:"500"`
+	KeepOld   bool `env:"KEEP_OLD" envDefault:"false"`
+	// OnlyDrifted skips indices already built from the current mapping, which
+	// turns the command into a mapping migration that is safe to run on
+	// every deploy.
+	OnlyDrifted bool `env:"ONLY_DRIFTED" envDefault:"false"`
+}
+
+// Reindexer rebuilds the series and episodes indices from Postgres. Each
+// rebuild loads every live row into a new concrete index built from the
+// current mapping while the old one keeps serving, then atomically moves the
+// read and write aliases to it.
+//
+// Changes made while the load runs still reach the old index through the
+// write alias. They are copied over by a catch-up pass before the swap, and
+// a second pass after the swap picks up whatever was written to the old
+// index in between.
+type Reindexer struct {
+	store       *database.Store
+	indices     search.IndexManager
+	prefix      string
+	batchSize   int
+	keepOld     bool
+	onlyDrifted bool
+	now         func() time.Time
+}
+
+// Result describes a finished rebuild of one alias.
+type Result struct {
+	Alias     string
+	Index     string
+	Documents int
+	Previous  []string
+	// Skipped is set when the index was current and OnlyDrifted was asked.
+	Skipped bool
+}
+
+func New(store *database.Store, indices search.IndexManager, searchCfg *search.Config, cfg *Config) *Reindexer {
+	r := &Reindexer{
+		store:     store,
+		indices:   indices,
+		prefix:    searchCfg.IndexPrefix,
+		batchSize: DefaultBatchSize,
+		now:       time.Now,
+	}
+
+	if cfg != nil {
+		if cfg.BatchSize > 0 {
+			r.batchSize = cfg.BatchSize
+		}
+		r.keepOld = cfg.KeepOld
+		r.onlyDrifted = cfg.OnlyDrifted
+	}
+
+	return r
+}
+
+// target is one index spec together with how to fill it.
+type target struct {
+	spec search.IndexSpec
+	// page returns the bulk operations for the rows after the given id and
+	// the id to continue from. since is zero for the full load.
+	page func(ctx context.Context, since time.Time, after uuid.UUID) ([]search.BulkOperation, uuid.UUID, error)
+}
+
+// Run rebuilds the series index, then the episodes index.
+func (r *Reindexer) Run(ctx context.Context) ([]Result, error) {
+	targets := []target{
+		{spec: search.SeriesIndex, page: r.seriesPage},
+		{spec: search.EpisodesIndex, page: r.episodesPage},
+	}
+
+	results := make([]Result, 0, len(targets))
+	for _, t := range targets {
+		res, err := r.rebuild(ctx, t)
+		if err != nil {
+			return results, errors.Wrapf(err, "failed to rebuild %s", t.spec.ReadAlias(r.prefix))
+		}
+		results = append(results, res)
+	}
+
+	return results, nil
+}
+
+func (r *Reindexer) rebuild(ctx context.Context, t target) (Result, error) {
+	read, write := t.spec.ReadAlias(r.prefix), t.spec.WriteAlias(r.prefix)
+	res := Result{Alias: read}
+
+	if r.onlyDrifted {
+		current, err := r.current(ctx, t.spec)
+		if err != nil {
+			return res, err
+		}
+		if current != "" {
+			slog.InfoContext(ctx, "index is current", "index", current, "version", t.spec.Version)
+			return Result{Alias: read, Index: current, Skipped: true}, nil
+		}
+	}
+
+	started := r.now()
+	res.Index = t.spec.NewIndex(r.prefix, started)
+
+	body, err := t.spec.Body()
+	if err != nil {
+		return res, err
+	}
+	if err := r.indices.CreateIndex(ctx, res.Index, body); err != nil {
+		return res, err
+	}
+	slog.InfoContext(ctx, "created index", "index", res.Index, "alias", read, "version", t.spec.Version)
+
+	swapped := false
+	defer func() {
+		if swapped {
+			return
+		}
+		// never leave a half built index behind
+		if err := r.indices.DeleteIndex(context.WithoutCancel(ctx), res.Index); err != nil {
+			slog.WarnContext(ctx, "failed to delete unused index", "err", err, "index", res.Index)
+		}
+	}()
+
+	n, err := r.load(ctx, res.Index, t, time.Time{})
+	if err != nil {
+		return res, errors.Wrap(err, "failed to load documents")
+	}
+	res.Documents = n
+	slog.InfoContext(ctx, "loaded documents", "index", res.Index, "count", n)
+
+	checkpoint := r.now()
+	if _, err := r.load(ctx, res.Index, t, started.Add(-catchUpMargin)); err != nil {
+		return res, errors.Wrap(err, "failed to catch up")
+	}
+
+	if err := r.indices.RefreshIndex(ctx, res.Index); err != nil {
+		return res, err
+	}
+
+	readIndices, err := search.Resolve(ctx, r.indices, read)
+	if err != nil {
+		return res, err
+	}
+	writeIndices, err := r.indices.AliasIndices(ctx, write)
+	if err != nil {
+		return res, err
+	}
+
+	var actions []search.AliasAction
+	for _, p := range readIndices {
+		if p == read {
+			// a concrete index from before aliases were used frees the name
+			actions = append(actions, search.AliasAction{Type: "remove_index", Index: p})
+			continue
+		}
+		actions = append(actions, search.AliasAction{Type: "remove", Index: p, Alias: read})
+		res.Previous = append(res.Previous, p)
+	}
+	for _, p := range writeIndices {
+		if p == read {
+			continue
+		}
+		actions = append(actions, search.AliasAction{Type: "remove", Index: p, Alias: write})
+		if !slices.Contains(res.Previous, p) {
+			res.Previous = append(res.Previous, p)
+		}
+	}
+	actions = append(actions,
+		search.AliasAction{Type: "add", Index: res.Index, Alias: read},
+		search.AliasAction{Type: "add", Index: res.Index, Alias: write},
+	)
+
+	if err := r.indices.UpdateAliases(ctx, actions); err != nil {
+		return res, err
+	}
+	swapped = true
+	slog.InfoContext(ctx, "swapped aliases", "alias", read, "index", res.Index, "previous", readIndices)
+
+	// the swap succeeded, so failures from here on leave a serving index
+	// that is at most as stale as the indexing tasks it missed
+	if _, err := r.load(ctx, write, t, checkpoint.Add(-catchUpMargin)); err != nil {
+		return res, errors.Wrap(err, "failed to catch up after swap")
+	}
+
+	if !r.keepOld {
+		for _, p := range res.Previous {
+			if err := r.indices.DeleteIndex(ctx, p); err != nil {
+				slog.WarnContext(ctx, "failed to delete previous index", "err", err, "index", p)
+			}
+		}
+	}
+
+	return res, nil
+}
+
+// current returns the index behind both aliases of spec when it was built
+// from the current mapping, or an empty string.
+func (r *Reindexer) current(ctx context.Context, spec search.IndexSpec) (string, error) {
+	readIndices, err := search.Resolve(ctx, r.indices, spec.ReadAlias(r.prefix))
+	if err != nil {
+		return "", err
+	}
+	writeIndices, err := r.indices.AliasIndices(ctx, spec.WriteAlias(r.prefix))
+	if err != nil {
+		return "", err
+	}
+	if len(readIndices) != 1 || !slices.Equal(readIndices, writeIndices) {
+		return "", nil
+	}
+
+	meta, err := r.indices.GetIndexMeta(ctx, readIndices[0])
+	if err != nil {
+		return "", err
+	}
+	if meta != spec.Meta() {
+		return "", nil
+	}
+	return readIndices[0], nil
+}
+
+// load pages through the rows of t and writes them to index in bulk. It
+// returns the number of operations sent.
+func (r *Reindexer) load(ctx context.Context, index string, t target, since time.Time) (int, error) {
+	total := 0
+	after := uuid.Nil
+
+	for {
+		ops, last, err := t.page(ctx, since, after)
+		if err != nil {
+			return total, err
+		}
+		if err := r.indices.Bulk(ctx, index, ops); err != nil {
+			return total, err
+		}
+
+		total += len(ops)
+		if len(ops) < r.batchSize {
+			return total, nil
+		}
+		after = last
+	}
+}
+
+func (r *Reindexer) seriesPage(ctx context.Context, since time.Time, after uuid.UUID) ([]search.BulkOperation, uuid.UUID, error) {
+	var rows []sqlc.Series
+	var err error
+	if since.IsZero() {
+		rows, err = r.store.Queries.ListSeriesAfter(ctx, sqlc.ListSeriesAfterParams{
+			ID:    after,
+			Limit: int32(r.batchSize),
+		})
+	} else {
+		rows, err = r.store.Queries.ListSeriesChangedSince(ctx, sqlc.ListSeriesChangedSinceParams{
+			Since:    since,
+			AfterID:  after,
+			RowLimit: int32(r.batchSize),
+		})
+	}
+	if err != nil {
+		return nil, after, errors.Wrap(err, "failed to list series")
+	}
+
+	paths, err := r.categoryPaths(ctx)
+	if err != nil {
+		return nil, after, err
+	}
+
+	ids := make([]uuid.UUID, 0, len(rows))
+	for _, s := range rows {
+		if s.DeletedAt == nil {
+			ids = append(ids, s.ID)
+		}
+	}
+
+	translations := make(map[uuid.UUID][]sqlc.SeriesTranslation)
+	if len(ids) > 0 {
+		translationRows, err := r.store.Queries.ListSeriesTranslations(ctx, ids)
+		if err != nil {
+			return nil, after, errors.Wrap(err, "failed to list series translations")
+		}
+		for _, t := range translationRows {
+			translations[t.SeriesID] = append(translations[t.SeriesID], t)
+		}
+	}
+
+	ops := make([]search.BulkOperation, 0, len(rows))
+	for _, s := range rows {
+		op := search.BulkOperation{ID: s.ID.String(), Version: rowVersion(s.UpdatedAt, s.DeletedAt)}
+		if s.DeletedAt == nil {
+			op.Document, err = mapping.SeriesDocument(s, paths[s.CategoryID], translations[s.ID]).ToJSON()
+			if err != nil {
+				return nil, after, errors.Wrap(err, "failed to convert document to JSON")
+			}
+		}
+		ops = append(ops, op)
+	}
+
+	if len(rows) > 0 {
+		after = rows[len(rows)-1].ID
+	}
+	return ops, after, nil
+}
+
+// rowVersion is the external version of the document of a row, the time of
+// its soft delete for a deleted one. It matches the versions of the indexer,
+// so its tasks and the reindex can write to the same index in any order.
+func rowVersion(updatedAt time.Time, deletedAt *time.Time) int64 {
+	if deletedAt != nil && deletedAt.After(updatedAt) {
+		return search.ExternalVersion(*deletedAt)
+	}
+	return search.ExternalVersion(updatedAt)
+}
+
+// categoryPaths maps every category to the ids from its root category down
+// to it. Categories are few, so they are read again for every page.
+func (r *Reindexer) categoryPaths(ctx context.Context) (map[uuid.UUID][]uuid.UUID, error) {
+	rows, err := r.store.Queries.ListCategoryPaths(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list category paths")
+	}
+
+	paths := make(map[uuid.UUID][]uuid.UUID, len(rows))
+	for _, row := range rows {
+		paths[row.ID] = row.Path
+	}
+	return paths, nil
+}
+
+func (r *Reindexer) episodesPage(ctx context.Context, since time.Time, after uuid.UUID) ([]search.BulkOperation, uuid.UUID, error) {
+	var episodes []sqlc.Episode
+	removed := make(map[uuid.UUID]bool)
+
+	if since.IsZero() {
+		rows, err := r.store.Queries.ListEpisodesAfter(ctx, sqlc.ListEpisodesAfterParams{
+			ID:    after,
+			Limit: int32(r.batchSize),
+		})
+		if err != nil {
+			return nil, after, errors.Wrap(err, "failed to list episodes")
+		}
+		episodes = rows
+	} else {
+		rows, err := r.store.Queries.ListEpisodesChangedSince(ctx, sqlc.ListEpisodesChangedSinceParams{
+			Since:    since,
+			AfterID:  after,
+			RowLimit: int32(r.batchSize),
+		})
+		if err != nil {
+			return nil, after, errors.Wrap(err, "failed to list changed episodes")
+		}
+		for _, row := range rows {
+			episodes = append(episodes, sqlc.Episode{
+				ID:              row.ID,
+				SeriesID:        row.SeriesID,
+				Title:           row.Title,
+				Description:     row.Description,
+				DurationSeconds: row.DurationSeconds,
+				PublishDate:     row.PublishDate,
+				CreatedAt:       row.CreatedAt,
+				UpdatedAt:       row.UpdatedAt,
+				DeletedAt:       row.DeletedAt,
+				SourceType:      row.SourceType,
+				ExternalID:      row.ExternalID,
+				Slug:            row.Slug,
+				PreviousSlugs:   row.PreviousSlugs,
+			})
+			removed[row.ID] = row.Removed
+		}
+	}
+
+	if len(episodes) == 0 {
+		return nil, after, nil
+	}
+
+	ids := make([]uuid.UUID, 0, len(episodes))
+	for _, ep := range episodes {
+		if !removed[ep.ID] {
+			ids = append(ids, ep.ID)
+		}
+	}
+
+	assets := make(map[uuid.UUID][]sqlc.EpisodeAsset)
+	languages := make(map[uuid.UUID]*string)
+	translations := make(map[uuid.UUID][]sqlc.EpisodeTranslation)
+	if len(ids) > 0 {
+		rows, err := r.store.Queries.ListAssetsByEpisodes(ctx, ids)
+		if err != nil {
+			return nil, after, errors.Wrap(err, "failed to list assets")
+		}
+		for _, a := range rows {
+			assets[a.EpisodeID] = append(assets[a.EpisodeID], a)
+		}
+
+		var seriesIDs []uuid.UUID
+		for _, ep := range episodes {
+			if !removed[ep.ID] && !slices.Contains(seriesIDs, ep.SeriesID) {
+				seriesIDs = append(seriesIDs, ep.SeriesID)
+			}
+		}
+		languageRows, err := r.store.Queries.ListSeriesLanguages(ctx, seriesIDs)
+		if err != nil {
+			return nil, after, errors.Wrap(err, "failed to list series languages")
+		}
+		for _, row := range languageRows {
+			languages[row.ID] = row.Language
+		}
+
+		translationRows, err := r.store.Queries.ListEpisodeTranslations(ctx, ids)
+		if err != nil {
+			return nil, after, errors.Wrap(err, "failed to list episode translations")
+		}
+		for _, t := range translationRows {
+			translations[t.EpisodeID] = append(translations[t.EpisodeID], t)
+		}
+	}
+
+	ops := make([]search.BulkOperation, 0, len(episodes))
+	for _, ep := range episodes {
+		op := search.BulkOperation{ID: ep.ID.String(), Version: rowVersion(ep.UpdatedAt, ep.DeletedAt)}
+		if !removed[ep.ID] {
+			doc, err := mapping.EpisodeDocument(ep, languages[ep.SeriesID], assets[ep.ID], translations[ep.ID]).ToJSON()
+			if err != nil {
+				return nil, after, errors.Wrap(err, "failed to convert document to JSON")
+			}
+			op.Document = doc
+		}
+		ops = append(ops, op)
+	}
+
+	return ops, episodes[len(episodes)-1].ID, nil
+}
@@ -0,0 +1,288 @@
+package reindex
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"th-application-technical-assignment/pkg/database"
+	"th-application-technical-assignment/pkg/search"
+	"th-application-technical-assignment/sqlc"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func opIDs(ops []search.BulkOperation) []string {
+	ids := make([]string, len(ops))
+	for i, op := range ops {
+		ids[i] = op.ID
+		if op.Document == nil {
+			ids[i] = "-" + op.ID
+		}
+	}
+	return ids
+}
+
+func TestReindexer_Run(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2025, 9, 10, 12, 0, 0, 0, time.UTC)
+	seriesIndex := "th-series-v5-20250910120000"
+	episodesIndex := "th-episodes-v4-20250910120000"
+	oldIndex := "th-series-v1-20250101000000"
+
+	seriesBody, err := search.SeriesIndex.Body()
+	require.NoError(t, err)
+	episodesBody, err := search.EpisodesIndex.Body()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		aliasIndices   []string
+		legacyIndex    bool
+		keepOld        bool
+		loadError      error
+		expectRemovals []search.AliasAction
+		expectPrevious []string
+		expectDeleted  []string
+		expectError    bool
+	}{
+		{
+			name:           "replaces the concrete index created before aliases",
+			legacyIndex:    true,
+			expectRemovals: []search.AliasAction{{Type: "remove_index", Index: "th-series"}},
+		},
+		{
+			name:         "moves both aliases and deletes the old index",
+			aliasIndices: []string{oldIndex},
+			expectRemovals: []search.AliasAction{
+				{Type: "remove", Index: oldIndex, Alias: "th-series"},
+				{Type: "remove", Index: oldIndex, Alias: "th-series-write"},
+			},
+			expectPrevious: []string{oldIndex},
+			expectDeleted:  []string{oldIndex},
+		},
+		{
+			name:         "keeps the old index when asked to",
+			aliasIndices: []string{oldIndex},
+			keepOld:      true,
+			expectRemovals: []search.AliasAction{
+				{Type: "remove", Index: oldIndex, Alias: "th-series"},
+				{Type: "remove", Index: oldIndex, Alias: "th-series-write"},
+			},
+			expectPrevious: []string{oldIndex},
+		},
+		{
+			name:          "failed load drops the new index and keeps the aliases",
+			loadError:     errors.New("database error"),
+			expectDeleted: []string{seriesIndex},
+			expectError:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockQueries := new(database.MockQuerier)
+			mockIndices := new(search.MockIndexManager)
+
+			r := New(&database.Store{Queries: mockQueries}, mockIndices, &search.Config{IndexPrefix: "th"}, &Config{BatchSize: 2, KeepOld: tt.keepOld})
+			r.now = func() time.Time { return start }
+
+			root, child := uuid.New(), uuid.New()
+			live := []sqlc.Series{{ID: uuid.New(), Title: "First", CategoryID: child}, {ID: uuid.New(), Title: "Second"}}
+			deleted := sqlc.Series{ID: uuid.New(), DeletedAt: &start}
+
+			mockIndices.On("CreateIndex", mock.Anything, seriesIndex, seriesBody).Return(nil)
+			mockQueries.On("ListSeriesAfter", mock.Anything, sqlc.ListSeriesAfterParams{ID: uuid.Nil, Limit: 2}).Return(live, tt.loadError)
+
+			if tt.loadError == nil {
+				mockQueries.On("ListSeriesAfter", mock.Anything, sqlc.ListSeriesAfterParams{ID: live[1].ID, Limit: 2}).Return([]sqlc.Series{}, nil)
+				mockQueries.On("ListCategoryPaths", mock.Anything).Return([]sqlc.ListCategoryPathsRow{
+					{ID: root, Path: []uuid.UUID{root}},
+					{ID: child, Path: []uuid.UUID{root, child}},
+				}, nil)
+				mockQueries.On("ListSeriesTranslations", mock.Anything, []uuid.UUID{live[0].ID, live[1].ID}).Return([]sqlc.SeriesTranslation{
+					{SeriesID: live[0].ID, Locale: "ar", Title: "حديث التقنية"},
+				}, nil)
+				mockIndices.On("Bulk", mock.Anything, seriesIndex, mock.MatchedBy(func(ops []search.BulkOperation) bool {
+					if !assert.ObjectsAreEqual([]string{live[0].ID.String(), live[1].ID.String()}, opIDs(ops)) {
+						return false
+					}
+					var doc search.SeriesDocument
+					return json.Unmarshal(ops[0].Document, &doc) == nil &&
+						assert.ObjectsAreEqual([]string{root.String(), child.String()}, doc.CategoryPath) &&
+						assert.ObjectsAreEqual([]string{"حديث التقنية"}, doc.I18n["ar"].Title)
+				})).Return(nil).Once()
+				mockIndices.On("Bulk", mock.Anything, seriesIndex, []search.BulkOperation{}).Return(nil).Once()
+
+				// the deletion arrives during the load; it is applied before the swap
+				mockQueries.On("ListSeriesChangedSince", mock.Anything, sqlc.ListSeriesChangedSinceParams{
+					Since:    start.Add(-catchUpMargin),
+					AfterID:  uuid.Nil,
+					RowLimit: 2,
+				}).Return([]sqlc.Series{deleted}, nil).Once()
+				mockIndices.On("Bulk", mock.Anything, seriesIndex, mock.MatchedBy(func(ops []search.BulkOperation) bool {
+					return assert.ObjectsAreEqual([]string{"-" + deleted.ID.String()}, opIDs(ops))
+				})).Return(nil).Once()
+				// the second pass goes through the write alias after the swap
+				mockQueries.On("ListSeriesChangedSince", mock.Anything, mock.Anything).Return([]sqlc.Series{}, nil).Once()
+				mockIndices.On("Bulk", mock.Anything, "th-series-write", []search.BulkOperation{}).Return(nil).Once()
+
+				mockIndices.On("RefreshIndex", mock.Anything, seriesIndex).Return(nil)
+				mockIndices.On("AliasIndices", mock.Anything, "th-series").Return(tt.aliasIndices, nil)
+				if len(tt.aliasIndices) == 0 {
+					mockIndices.On("IndexExists", mock.Anything, "th-series").Return(tt.legacyIndex, nil)
+					mockIndices.On("AliasIndices", mock.Anything, "th-series-write").Return([]string{"th-series"}, nil)
+				} else {
+					mockIndices.On("AliasIndices", mock.Anything, "th-series-write").Return(tt.aliasIndices, nil)
+				}
+				mockIndices.On("UpdateAliases", mock.Anything, append(tt.expectRemovals,
+					search.AliasAction{Type: "add", Index: seriesIndex, Alias: "th-series"},
+					search.AliasAction{Type: "add", Index: seriesIndex, Alias: "th-series-write"},
+				)).Return(nil)
+
+				mockIndices.On("CreateIndex", mock.Anything, episodesIndex, episodesBody).Return(nil)
+				mockQueries.On("ListEpisodesAfter", mock.Anything, mock.Anything).Return([]sqlc.Episode{}, nil)
+				mockQueries.On("ListEpisodesChangedSince", mock.Anything, mock.Anything).Return([]sqlc.ListEpisodesChangedSinceRow{}, nil)
+				mockIndices.On("Bulk", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				mockIndices.On("RefreshIndex", mock.Anything, episodesIndex).Return(nil)
+				mockIndices.On("AliasIndices", mock.Anything, "th-episodes").Return([]string{}, nil)
+				mockIndices.On("AliasIndices", mock.Anything, "th-episodes-write").Return([]string{}, nil)
+				mockIndices.On("IndexExists", mock.Anything, "th-episodes").Return(false, nil)
+				mockIndices.On("UpdateAliases", mock.Anything, []search.AliasAction{
+					{Type: "add", Index: episodesIndex, Alias: "th-episodes"},
+					{Type: "add", Index: episodesIndex, Alias: "th-episodes-write"},
+				}).Return(nil)
+			}
+			for _, index := range tt.expectDeleted {
+				mockIndices.On("DeleteIndex", mock.Anything, index).Return(nil)
+			}
+
+			results, err := r.Run(context.Background())
+
+			if tt.expectError {
+				assert.Error(t, err)
+				assert.Empty(t, results)
+				mockIndices.AssertNotCalled(t, "UpdateAliases", mock.Anything, mock.Anything)
+			} else {
+				require.NoError(t, err)
+				require.Len(t, results, 2)
+				assert.Equal(t, Result{Alias: "th-series", Index: seriesIndex, Documents: 2, Previous: tt.expectPrevious}, results[0])
+				assert.Equal(t, Result{Alias: "th-episodes", Index: episodesIndex}, results[1])
+			}
+
+			mockQueries.AssertExpectations(t)
+			mockIndices.AssertExpectations(t)
+			if len(tt.expectDeleted) == 0 {
+				mockIndices.AssertNotCalled(t, "DeleteIndex", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestReindexer_Run_OnlyDrifted(t *testing.T) {
+	t.Parallel()
+
+	current := "th-series-v5-20250101000000"
+	drifted := "th-episodes-v0-20250101000000"
+	start := time.Date(2025, 9, 10, 12, 0, 0, 0, time.UTC)
+	episodesIndex := "th-episodes-v4-20250910120000"
+
+	mockQueries := new(database.MockQuerier)
+	mockIndices := new(search.MockIndexManager)
+
+	r := New(&database.Store{Queries: mockQueries}, mockIndices, &search.Config{IndexPrefix: "th"}, &Config{OnlyDrifted: true})
+	r.now = func() time.Time { return start }
+
+	mockIndices.On("AliasIndices", mock.Anything, "th-series").Return([]string{current}, nil)
+	mockIndices.On("AliasIndices", mock.Anything, "th-series-write").Return([]string{current}, nil)
+	mockIndices.On("GetIndexMeta", mock.Anything, current).Return(search.SeriesIndex.Meta(), nil)
+
+	mockIndices.On("AliasIndices", mock.Anything, "th-episodes").Return([]string{drifted}, nil)
+	mockIndices.On("AliasIndices", mock.Anything, "th-episodes-write").Return([]string{drifted}, nil)
+	mockIndices.On("GetIndexMeta", mock.Anything, drifted).Return(search.IndexMeta{}, nil)
+	mockIndices.On("CreateIndex", mock.Anything, episodesIndex, mock.Anything).Return(nil)
+	mockQueries.On("ListEpisodesAfter", mock.Anything, mock.Anything).Return([]sqlc.Episode{}, nil)
+	mockQueries.On("ListEpisodesChangedSince", mock.Anything, mock.Anything).Return([]sqlc.ListEpisodesChangedSinceRow{}, nil)
+	mockIndices.On("Bulk", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockIndices.On("RefreshIndex", mock.Anything, episodesIndex).Return(nil)
+	mockIndices.On("UpdateAliases", mock.Anything, mock.Anything).Return(nil)
+	mockIndices.On("DeleteIndex", mock.Anything, drifted).Return(nil)
+
+	results, err := r.Run(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, []Result{
+		{Alias: "th-series", Index: current, Skipped: true},
+		{Alias: "th-episodes", Index: episodesIndex, Previous: []string{drifted}},
+	}, results)
+
+	mockQueries.AssertExpectations(t)
+	mockIndices.AssertExpectations(t)
+	mockIndices.AssertNotCalled(t, "CreateIndex", mock.Anything, mock.MatchedBy(func(index string) bool {
+		return index != episodesIndex
+	}), mock.Anything)
+}
+
+func TestReindexer_episodesPage(t *testing.T) {
+	t.Parallel()
+
+	since := time.Date(2025, 9, 10, 12, 0, 0, 0, time.UTC)
+	url := "https://cdn.example.com/audio.mp3"
+	english := "en"
+
+	live := sqlc.ListEpisodesChangedSinceRow{ID: uuid.New(), SeriesID: uuid.New(), Title: "Live"}
+	bare := sqlc.ListEpisodesChangedSinceRow{ID: uuid.New(), SeriesID: uuid.New(), Title: "No assets"}
+	removed := sqlc.ListEpisodesChangedSinceRow{ID: uuid.New(), SeriesID: uuid.New(), Removed: true}
+
+	mockQueries := new(database.MockQuerier)
+	r := New(&database.Store{Queries: mockQueries}, new(search.MockIndexManager), &search.Config{IndexPrefix: "th"}, &Config{BatchSize: 3})
+
+	mockQueries.On("ListEpisodesChangedSince", mock.Anything, sqlc.ListEpisodesChangedSinceParams{
+		Since:    since,
+		AfterID:  uuid.Nil,
+		RowLimit: 3,
+	}).Return([]sqlc.ListEpisodesChangedSinceRow{live, bare, removed}, nil)
+	mockQueries.On("ListAssetsByEpisodes", mock.Anything, []uuid.UUID{live.ID, bare.ID}).Return([]sqlc.EpisodeAsset{
+		{ID: uuid.New(), EpisodeID: live.ID, AssetType: "audio", MimeType: "audio/mpeg", Url: &url},
+	}, nil)
+	mockQueries.On("ListSeriesLanguages", mock.Anything, []uuid.UUID{live.SeriesID, bare.SeriesID}).Return([]sqlc.ListSeriesLanguagesRow{
+		{ID: live.SeriesID, Language: &english},
+		{ID: bare.SeriesID},
+	}, nil)
+	mockQueries.On("ListEpisodeTranslations", mock.Anything, []uuid.UUID{live.ID, bare.ID}).Return([]sqlc.EpisodeTranslation{
+		{EpisodeID: live.ID, Locale: "fr", Title: "En direct"},
+	}, nil)
+
+	ops, last, err := r.episodesPage(context.Background(), since, uuid.Nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, removed.ID, last)
+	assert.Equal(t, []string{live.ID.String(), bare.ID.String(), "-" + removed.ID.String()}, opIDs(ops))
+
+	var doc search.EpisodeDocument
+	require.NoError(t, json.Unmarshal(ops[0].Document, &doc))
+	assert.Equal(t, "Live", doc.Title)
+	require.Len(t, doc.Assets, 1)
+	assert.Equal(t, url, *doc.Assets[0].URL)
+	assert.Equal(t, "En direct", doc.Translations["fr"].Title)
+	assert.Equal(t, []string{"En direct"}, doc.I18n["fr"].Title)
+	// the episode is analyzed in the language of its series
+	assert.Equal(t, "en", *doc.Language)
+	assert.Equal(t, []string{"Live"}, doc.I18n["en"].Title)
+
+	doc = search.EpisodeDocument{}
+	require.NoError(t, json.Unmarshal(ops[1].Document, &doc))
+	assert.Equal(t, "No assets", doc.Title)
+	assert.Empty(t, doc.Assets)
+	assert.Nil(t, doc.Language)
+	assert.Empty(t, doc.I18n)
+
+	mockQueries.AssertExpectations(t)
+}
@@ -0,0 +1,80 @@
+// Package revisions keeps the version history of series and episodes. Every
+// change stores the whole row as a new immutable revision, so earlier
+// versions can be compared and rolled back to.
+package revisions
+
+import (
+	"context"
+	"encoding/json"
+	"th-application-technical-assignment/pkg/audit"
+	"th-application-technical-assignment/sqlc"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// Revision is a change to a series or an episode. EntityType is
+// audit.EntitySeries or audit.EntityEpisode.
+type Revision struct {
+	Author     string
+	EntityType string
+	EntityID   uuid.UUID
+	// RollbackOf is the revision whose state the change restored, nil for
+	// ordinary edits.
+	RollbackOf *int32
+}
+
+// Record stores the current row of the entity as its next revision. Call it
+// with the queries of the transaction that makes the change, after the row
+// is written, so the row lock keeps concurrent revisions in order.
+//
+// The snapshot is built by the database, in the same format as the
+// revisions backfilled for existing rows, so diffs only show real changes.
+func Record(ctx context.Context, q sqlc.Querier, r Revision) (sqlc.ContentRevision, error) {
+	author := r.Author
+	if author == "" {
+		author = audit.UnknownActor
+	}
+
+	var (
+		rev sqlc.ContentRevision
+		err error
+	)
+	switch r.EntityType {
+	case audit.EntitySeries:
+		rev, err = q.CreateSeriesRevision(ctx, sqlc.CreateSeriesRevisionParams{
+			Author:     author,
+			RollbackOf: r.RollbackOf,
+			ID:         r.EntityID,
+		})
+	case audit.EntityEpisode:
+		rev, err = q.CreateEpisodeRevision(ctx, sqlc.CreateEpisodeRevisionParams{
+			Author:     author,
+			RollbackOf: r.RollbackOf,
+			ID:         r.EntityID,
+		})
+	default:
+		return sqlc.ContentRevision{}, errors.Errorf("revisions of %q are not kept", r.EntityType)
+	}
+	if err != nil {
+		return sqlc.ContentRevision{}, errors.Wrap(err, "failed to record revision")
+	}
+
+	return rev, nil
+}
+
+// Snapshot decodes the row stored in rev, a sqlc.Series or sqlc.Episode
+// depending on its entity type.
+func Snapshot[T any](rev sqlc.ContentRevision) (T, error) {
+	var v T
+	if err := json.Unmarshal(rev.Snapshot, &v); err != nil {
+		return v, errors.Wrapf(err, "failed to decode revision %d", rev.Revision)
+	}
+	return v, nil
+}
+
+// Diff returns the fields that differ between the snapshots of two
+// revisions, keyed by column name.
+func Diff(from, to sqlc.ContentRevision) (map[string]audit.FieldChange, error) {
+	return audit.Diff(json.RawMessage(from.Snapshot), json.RawMessage(to.Snapshot))
+}
@@ -0,0 +1,106 @@
+package revisions
+
+import (
+	"context"
+	"testing"
+	"th-application-technical-assignment/pkg/audit"
+	"th-application-technical-assignment/pkg/database"
+	"th-application-technical-assignment/sqlc"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecord(t *testing.T) {
+	t.Parallel()
+
+	id := uuid.New()
+	rollbackOf := int32(2)
+
+	tests := []struct {
+		name        string
+		revision    Revision
+		setupMock   func(*database.MockQuerier)
+		expectError bool
+	}{
+		{
+			name:     "series",
+			revision: Revision{Author: "user-1", EntityType: audit.EntitySeries, EntityID: id},
+			setupMock: func(m *database.MockQuerier) {
+				m.On("CreateSeriesRevision", mock.Anything, sqlc.CreateSeriesRevisionParams{Author: "user-1", ID: id}).
+					Return(sqlc.ContentRevision{EntityID: id, Revision: 3}, nil)
+			},
+		},
+		{
+			name:     "episode rollback without author",
+			revision: Revision{EntityType: audit.EntityEpisode, EntityID: id, RollbackOf: &rollbackOf},
+			setupMock: func(m *database.MockQuerier) {
+				m.On("CreateEpisodeRevision", mock.Anything, sqlc.CreateEpisodeRevisionParams{Author: audit.UnknownActor, RollbackOf: &rollbackOf, ID: id}).
+					Return(sqlc.ContentRevision{EntityID: id, Revision: 3}, nil)
+			},
+		},
+		{
+			name:        "unversioned entity type",
+			revision:    Revision{Author: "user-1", EntityType: audit.EntityCategory, EntityID: id},
+			setupMock:   func(m *database.MockQuerier) {},
+			expectError: true,
+		},
+		{
+			name:     "database error",
+			revision: Revision{Author: "user-1", EntityType: audit.EntitySeries, EntityID: id},
+			setupMock: func(m *database.MockQuerier) {
+				m.On("CreateSeriesRevision", mock.Anything, mock.Anything).Return(sqlc.ContentRevision{}, assert.AnError)
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockQueries := new(database.MockQuerier)
+			tt.setupMock(mockQueries)
+
+			rev, err := Record(context.Background(), mockQueries, tt.revision)
+
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, int32(3), rev.Revision)
+			}
+			mockQueries.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSnapshot(t *testing.T) {
+	t.Parallel()
+
+	rev := sqlc.ContentRevision{Snapshot: []byte(`{"title": "Pilot", "duration_seconds": 1800, "publish_date": "2025-09-18T09:00:00+00:00", "previous_slugs": null}`)}
+
+	ep, err := Snapshot[sqlc.Episode](rev)
+	require.NoError(t, err)
+	assert.Equal(t, "Pilot", ep.Title)
+	assert.Equal(t, int32(1800), *ep.DurationSeconds)
+	assert.Equal(t, 2025, ep.PublishDate.Year())
+
+	_, err = Snapshot[sqlc.Episode](sqlc.ContentRevision{Snapshot: []byte(`[]`)})
+	assert.Error(t, err)
+}
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	from := sqlc.ContentRevision{Snapshot: []byte(`{"title": "Pilot", "slug": "pilot", "updated_at": "2025-09-18T09:00:00+00:00"}`)}
+	to := sqlc.ContentRevision{Snapshot: []byte(`{"title": "Pilot", "slug": "the-pilot", "updated_at": "2025-09-19T09:00:00+00:00"}`)}
+
+	changes, err := Diff(from, to)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]audit.FieldChange{
+		"slug": {Before: "pilot", After: "the-pilot"},
+	}, changes)
+}
@@ -0,0 +1,275 @@
+package search
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/opensearch-project/opensearch-go/v2/opensearchapi"
+	"github.com/pkg/errors"
+)
+
+const (
+	DefaultBulkMaxActions = 1000
+	DefaultBulkMaxBytes   = 5 << 20
+)
+
+// BulkOperation is a single action of a bulk request. It indexes Document
+// under ID, or deletes the document with ID when Document is nil. Index
+// overrides the index of the request for this action.
+//
+// Version is the external version of the action, see ExternalVersion. An
+// action older than the stored document is ignored by OpenSearch. Zero
+// sends the action without a version.
+type BulkOperation struct {
+	Index    string
+	ID       string
+	Document []byte
+	Version  int64
+}
+
+// BulkDocument is a document to index with BulkIndex, or to delete by its ID
+// with BulkDelete. Version is the external version of the action.
+type BulkDocument struct {
+	ID       string
+	Document []byte
+	Version  int64
+}
+
+// ExternalVersion is the version of a document last changed at t, in
+// microseconds like the timestamps of PostgreSQL. Tasks for the same
+// document may be applied out of order, the version keeps an older one from
+// overwriting a newer document or bringing back a deleted one.
+func ExternalVersion(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixMicro()
+}
+
+// BulkItem is the outcome of one action of a bulk request.
+type BulkItem struct {
+	Index  string
+	ID     string
+	Action string
+	Status int
+	Error  *BulkItemError
+}
+
+type BulkItemError struct {
+	Type   string `json:"type"`
+	Reason string `json:"reason"`
+}
+
+// Failed reports whether the action did not take effect. Deleting a document
+// that does not exist is not a failure, and neither is an outdated action.
+func (i BulkItem) Failed() bool {
+	if i.Action == "delete" && i.Status == http.StatusNotFound {
+		return false
+	}
+	if i.Outdated() {
+		return false
+	}
+	return i.Error != nil || i.Status >= http.StatusBadRequest
+}
+
+// Outdated reports whether the action was ignored because the index holds
+// a newer version of the document already.
+func (i BulkItem) Outdated() bool {
+	return i.Status == http.StatusConflict && i.Error != nil && i.Error.Type == "version_conflict_engine_exception"
+}
+
+// Retryable reports whether a failed action may succeed when sent again,
+// because the cluster was overloaded or unavailable rather than the
+// document being rejected.
+func (i BulkItem) Retryable() bool {
+	return i.Status == http.StatusTooManyRequests || i.Status >= http.StatusInternalServerError
+}
+
+// BulkResponse holds the outcome of every action, in request order.
+type BulkResponse struct {
+	Items []BulkItem
+}
+
+func (r *BulkResponse) Failed() []BulkItem {
+	var failed []BulkItem
+	for _, item := range r.Items {
+		if item.Failed() {
+			failed = append(failed, item)
+		}
+	}
+	return failed
+}
+
+// Err summarizes the failed actions, or returns nil when all succeeded.
+func (r *BulkResponse) Err() error {
+	failed := r.Failed()
+	if len(failed) == 0 {
+		return nil
+	}
+
+	first := failed[0]
+	reason := http.StatusText(first.Status)
+	if first.Error != nil {
+		reason = fmt.Sprintf("%s: %s", first.Error.Type, first.Error.Reason)
+	}
+	return errors.Errorf("%d of %d bulk operations failed, first on document %s: %s",
+		len(failed), len(r.Items), first.ID, reason)
+}
+
+// BulkIndex indexes docs into index.
+func (c *OpenSearchClient) BulkIndex(ctx context.Context, index string, docs []BulkDocument) (*BulkResponse, error) {
+	ops := make([]BulkOperation, 0, len(docs))
+	for _, d := range docs {
+		if d.Document == nil {
+			return nil, errors.Errorf("document %s has no body", d.ID)
+		}
+		ops = append(ops, BulkOperation{ID: d.ID, Document: d.Document, Version: d.Version})
+	}
+	return c.bulk(ctx, index, ops)
+}
+
+// BulkDelete deletes the documents with the ids of docs from index. Their
+// Document is ignored.
+func (c *OpenSearchClient) BulkDelete(ctx context.Context, index string, docs []BulkDocument) (*BulkResponse, error) {
+	ops := make([]BulkOperation, 0, len(docs))
+	for _, d := range docs {
+		ops = append(ops, BulkOperation{ID: d.ID, Version: d.Version})
+	}
+	return c.bulk(ctx, index, ops)
+}
+
+// Bulk runs ops against index and fails when any of them failed.
+func (c *OpenSearchClient) Bulk(ctx context.Context, index string, ops []BulkOperation) error {
+	res, err := c.bulk(ctx, index, ops)
+	if err != nil {
+		return err
+	}
+	return res.Err()
+}
+
+// bulk sends ops in as few requests as the configured size limits allow.
+// An error is returned when a request fails as a whole; failures of single
+// actions are reported in the response.
+func (c *OpenSearchClient) bulk(ctx context.Context, index string, ops []BulkOperation) (*BulkResponse, error) {
+	res := &BulkResponse{Items: make([]BulkItem, 0, len(ops))}
+
+	maxActions, maxBytes := c.config.BulkMaxActions, c.config.BulkMaxBytes
+	if maxActions <= 0 {
+		maxActions = DefaultBulkMaxActions
+	}
+	if maxBytes <= 0 {
+		maxBytes = DefaultBulkMaxBytes
+	}
+
+	var body bytes.Buffer
+	pending := 0
+	flush := func() error {
+		if pending == 0 {
+			return nil
+		}
+		items, err := c.sendBulk(ctx, index, &body)
+		if err != nil {
+			return err
+		}
+		res.Items = append(res.Items, items...)
+		body.Reset()
+		pending = 0
+		return nil
+	}
+
+	for _, op := range ops {
+		line, err := encodeBulkOperation(op)
+		if err != nil {
+			return nil, err
+		}
+		if pending > 0 && (pending >= maxActions || body.Len()+len(line) > maxBytes) {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+		}
+		body.Write(line)
+		pending++
+	}
+
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func encodeBulkOperation(op BulkOperation) ([]byte, error) {
+	action := "index"
+	if op.Document == nil {
+		action = "delete"
+	}
+
+	meta := map[string]any{"_id": op.ID}
+	if op.Index != "" {
+		meta["_index"] = op.Index
+	}
+	if op.Version > 0 {
+		// equal versions are accepted, so a document can be indexed again
+		// after a change that did not touch its row
+		meta["version"] = op.Version
+		meta["version_type"] = "external_gte"
+	}
+
+	line, err := json.Marshal(map[string]any{action: meta})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encode bulk action")
+	}
+	line = append(line, '\n')
+	if op.Document != nil {
+		line = append(line, op.Document...)
+		line = append(line, '\n')
+	}
+	return line, nil
+}
+
+func (c *OpenSearchClient) sendBulk(ctx context.Context, index string, body *bytes.Buffer) ([]BulkItem, error) {
+	req := opensearchapi.BulkRequest{
+		Index:   index,
+		Body:    body,
+		Refresh: c.config.Refresh,
+	}
+
+	res, err := req.Do(ctx, c.client)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to run bulk request")
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, errors.Errorf("failed to run bulk request: %s", res.String())
+	}
+
+	var result struct {
+		Items []map[string]struct {
+			Index  string         `json:"_index"`
+			ID     string         `json:"_id"`
+			Status int            `json:"status"`
+			Error  *BulkItemError `json:"error"`
+		} `json:"items"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return nil, errors.Wrap(err, "failed to decode bulk response")
+	}
+
+	items := make([]BulkItem, 0, len(result.Items))
+	for _, item := range result.Items {
+		for action, r := range item {
+			items = append(items, BulkItem{
+				Index:  r.Index,
+				ID:     r.ID,
+				Action: action,
+				Status: r.Status,
+				Error:  r.Error,
+			})
+		}
+	}
+	return items, nil
+}
@@ -0,0 +1,175 @@
+package search
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// bulkServer answers bulk requests with the status configured per document
+// id, 201 for index and 200 for delete by default. Like OpenSearch, it
+// rejects an external_gte action older than the last one for its document
+// with 409.
+type bulkServer struct {
+	mu       sync.Mutex
+	statuses map[string]int
+	versions map[string]float64
+	requests [][]map[string]any
+	refresh  []string
+}
+
+func (s *bulkServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var lines []map[string]any
+	var items []map[string]any
+	scanner := bufio.NewScanner(r.Body)
+	for scanner.Scan() {
+		var line map[string]any
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		lines = append(lines, line)
+
+		for _, action := range []string{"index", "delete"} {
+			meta, ok := line[action].(map[string]any)
+			if !ok {
+				continue
+			}
+			id := meta["_id"].(string)
+			status := http.StatusOK
+			if action == "index" {
+				status = http.StatusCreated
+			}
+			if st, ok := s.statuses[id]; ok {
+				status = st
+			}
+			if version, ok := meta["version"].(float64); ok && meta["version_type"] == "external_gte" {
+				if s.versions == nil {
+					s.versions = make(map[string]float64)
+				}
+				if stored, ok := s.versions[id]; ok && version < stored {
+					status = http.StatusConflict
+				} else {
+					s.versions[id] = version
+				}
+			}
+			item := map[string]any{"_index": "idx", "_id": id, "status": status}
+			switch {
+			case status == http.StatusConflict:
+				item["error"] = map[string]string{"type": "version_conflict_engine_exception", "reason": "current version is higher"}
+			case status >= 400 && !(action == "delete" && status == http.StatusNotFound):
+				item["error"] = map[string]string{"type": "mapper_parsing_exception", "reason": "failed to parse"}
+			}
+			items = append(items, map[string]any{action: item})
+		}
+	}
+	s.requests = append(s.requests, lines)
+	s.refresh = append(s.refresh, r.URL.Query().Get("refresh"))
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]any{"errors": true, "items": items})
+}
+
+func TestOpenSearchClient_BulkIndex(t *testing.T) {
+	t.Parallel()
+
+	srv := &bulkServer{statuses: map[string]int{"bad": http.StatusBadRequest, "busy": http.StatusTooManyRequests}}
+	ts := httptest.NewServer(srv)
+	t.Cleanup(ts.Close)
+
+	client, err := NewClient(&Config{OpenSearchURL: ts.URL, Refresh: "wait_for", BulkMaxActions: 2})
+	require.NoError(t, err)
+
+	res, err := client.BulkIndex(context.Background(), "th-episodes-write", []BulkDocument{
+		{ID: "1", Document: []byte(`{"title":"one"}`)},
+		{ID: "bad", Document: []byte(`{"title":"bad"}`)},
+		{ID: "busy", Document: []byte(`{"title":"busy"}`)},
+	})
+	require.NoError(t, err)
+
+	// split after BulkMaxActions actions, each followed by its document
+	require.Len(t, srv.requests, 2)
+	assert.Len(t, srv.requests[0], 4)
+	assert.Len(t, srv.requests[1], 2)
+	assert.Equal(t, "one", srv.requests[0][1]["title"])
+	assert.Equal(t, []string{"wait_for", "wait_for"}, srv.refresh)
+
+	require.Len(t, res.Items, 3)
+	assert.False(t, res.Items[0].Failed())
+
+	failed := res.Failed()
+	require.Len(t, failed, 2)
+	assert.Equal(t, "bad", failed[0].ID)
+	assert.False(t, failed[0].Retryable())
+	assert.Equal(t, "mapper_parsing_exception", failed[0].Error.Type)
+	assert.Equal(t, "busy", failed[1].ID)
+	assert.True(t, failed[1].Retryable())
+	assert.EqualError(t, res.Err(), "2 of 3 bulk operations failed, first on document bad: mapper_parsing_exception: failed to parse")
+}
+
+func TestOpenSearchClient_BulkDelete(t *testing.T) {
+	t.Parallel()
+
+	srv := &bulkServer{statuses: map[string]int{"gone": http.StatusNotFound}}
+	ts := httptest.NewServer(srv)
+	t.Cleanup(ts.Close)
+
+	client, err := NewClient(&Config{OpenSearchURL: ts.URL})
+	require.NoError(t, err)
+
+	res, err := client.BulkDelete(context.Background(), "th-series-write", []BulkDocument{{ID: "1", Version: 1757505600000000}, {ID: "gone"}})
+	require.NoError(t, err)
+
+	require.Len(t, srv.requests, 1)
+	assert.Equal(t, map[string]any{"delete": map[string]any{"_id": "1", "version": float64(1757505600000000), "version_type": "external_gte"}}, srv.requests[0][0])
+	assert.Equal(t, map[string]any{"delete": map[string]any{"_id": "gone"}}, srv.requests[0][1])
+	assert.Len(t, res.Items, 2)
+	assert.Empty(t, res.Failed(), "deleting a missing document is not a failure")
+	assert.NoError(t, res.Err())
+}
+
+func TestOpenSearchClient_BulkOutdated(t *testing.T) {
+	t.Parallel()
+
+	srv := &bulkServer{}
+	ts := httptest.NewServer(srv)
+	t.Cleanup(ts.Close)
+
+	client, err := NewClient(&Config{OpenSearchURL: ts.URL})
+	require.NoError(t, err)
+
+	older := time.Date(2025, 9, 10, 12, 0, 0, 0, time.UTC)
+	newer := older.Add(time.Second)
+	ctx := context.Background()
+
+	// the newer change arrives first, the retried older batch after it
+	res, err := client.BulkIndex(ctx, "th-episodes-write", []BulkDocument{{ID: "1", Document: []byte(`{"title":"new"}`), Version: ExternalVersion(newer)}})
+	require.NoError(t, err)
+	require.NoError(t, res.Err())
+
+	res, err = client.BulkIndex(ctx, "th-episodes-write", []BulkDocument{{ID: "1", Document: []byte(`{"title":"old"}`), Version: ExternalVersion(older)}})
+	require.NoError(t, err)
+	require.Len(t, res.Items, 1)
+	assert.True(t, res.Items[0].Outdated())
+	assert.False(t, res.Items[0].Failed(), "an outdated action is not a failure")
+	assert.NoError(t, res.Err())
+
+	// an older delete does not remove the newer document either
+	res, err = client.BulkDelete(ctx, "th-episodes-write", []BulkDocument{{ID: "1", Version: ExternalVersion(older)}})
+	require.NoError(t, err)
+	assert.True(t, res.Items[0].Outdated())
+	assert.Equal(t, float64(ExternalVersion(newer)), srv.versions["1"])
+
+	assert.Zero(t, ExternalVersion(time.Time{}))
+}
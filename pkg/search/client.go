@@ -87,7 +87,7 @@ func (c *OpenSearchClient) IndexDocument(ctx context.Context, indexName string,
 		Index:      indexName,
 		DocumentID: documentID,
 		Body:       bytes.NewReader(documentJSON),
-		Refresh:    "true",
+		Refresh:    c.config.Refresh,
 	}
 
 	res, err := req.Do(ctx, c.client)
@@ -107,7 +107,7 @@ func (c *OpenSearchClient) DeleteDocument(ctx context.Context, indexName string,
 	req := opensearchapi.DeleteRequest{
 		Index:      indexName,
 		DocumentID: documentID,
-		Refresh:    "true",
+		Refresh:    c.config.Refresh,
 	}
 
 	res, err := req.Do(ctx, c.client)
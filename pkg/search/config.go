@@ -8,5 +8,11 @@ type Config struct {
 	OpenSearchPassword string        `env:"PASSWORD"`
     IndexPrefix        string        `env:"INDEX_PREFIX" envDefault:"th"`
 	RequestTimeout     time.Duration `env:"TIMEOUT" envDefault:"30s"`
+	// Refresh is the refresh policy of writes: "true" makes them visible to
+	// searches immediately at a high cost, "wait_for" waits for the next
+	// periodic refresh and "false" returns without waiting.
+	Refresh        string `env:"REFRESH" envDefault:"false"`
+	BulkMaxActions int    `env:"BULK_MAX_ACTIONS" envDefault:"1000"`
+	BulkMaxBytes   int    `env:"BULK_MAX_BYTES" envDefault:"5242880"`
 }
 
@@ -2,19 +2,24 @@ package search
 
 import (
 	"encoding/json"
+	"th-application-technical-assignment/pkg/locale"
 	"time"
 )
 
 type SeriesDocument struct {
-	ID          string    `json:"id"`
-	Title       string    `json:"title"`
-	Description *string   `json:"description,omitempty"`
-	CategoryID  string    `json:"category_id"`
-	Language    *string   `json:"language,omitempty"`
-	Type        string    `json:"type"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
-	IndexedAt   time.Time `json:"indexed_at"`
+	ID            string    `json:"id"`
+	Title         string    `json:"title"`
+	Slug          string    `json:"slug"`
+	PreviousSlugs []string  `json:"previous_slugs,omitempty"`
+	Description   *string   `json:"description,omitempty"`
+	CategoryID    string    `json:"category_id"`
+	CategoryPath  []string  `json:"category_path,omitempty"`
+	Language      *string   `json:"language,omitempty"`
+	Type          string    `json:"type"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+	IndexedAt     time.Time `json:"indexed_at"`
+	Localized
 }
 
 type AssetDocument struct {
@@ -29,13 +34,70 @@ type EpisodeDocument struct {
 	ID              string          `json:"id"`
 	SeriesID        string          `json:"series_id"`
 	Title           string          `json:"title"`
+	Slug            string          `json:"slug"`
+	PreviousSlugs   []string        `json:"previous_slugs,omitempty"`
 	Description     *string         `json:"description,omitempty"`
 	DurationSeconds *int32          `json:"duration_seconds,omitempty"`
 	PublishDate     *time.Time      `json:"publish_date,omitempty"`
+	Language        *string         `json:"language,omitempty"`
 	CreatedAt       time.Time       `json:"created_at"`
 	UpdatedAt       time.Time       `json:"updated_at"`
 	IndexedAt       time.Time       `json:"indexed_at"`
 	Assets          []AssetDocument `json:"assets"`
+	Localized
+}
+
+// Localized holds the translations of the title and description of a
+// document. Translations keeps them by locale, to be shown as they are, and
+// I18n by language, to be searched with the analyzer of the language. I18n
+// holds the title and description of the document itself as well when its
+// language is known.
+type Localized struct {
+	Translations map[string]TranslationDocument `json:"translations,omitempty"`
+	I18n         map[string]LanguageFields      `json:"i18n,omitempty"`
+}
+
+type TranslationDocument struct {
+	Title       string  `json:"title"`
+	Description *string `json:"description,omitempty"`
+}
+
+// LanguageFields are the titles and descriptions in one language, such as
+// those of translations into en-GB and en-US.
+type LanguageFields struct {
+	Title       []string `json:"title"`
+	Description []string `json:"description,omitempty"`
+}
+
+// AddText adds the title and description of the document, which are in
+// the language of loc, to the fields searched with the analyzer of that
+// language. Text in an unknown language is only searched in the title and
+// description fields.
+func (l *Localized) AddText(loc, title string, description *string) {
+	lang := locale.Language(loc)
+	if lang == "" {
+		return
+	}
+	if l.I18n == nil {
+		l.I18n = make(map[string]LanguageFields)
+	}
+
+	fields := l.I18n[lang]
+	fields.Title = append(fields.Title, title)
+	if description != nil {
+		fields.Description = append(fields.Description, *description)
+	}
+	l.I18n[lang] = fields
+}
+
+// AddTranslation adds the translation of the title and description into
+// loc.
+func (l *Localized) AddTranslation(loc, title string, description *string) {
+	if l.Translations == nil {
+		l.Translations = make(map[string]TranslationDocument)
+	}
+	l.Translations[loc] = TranslationDocument{Title: title, Description: description}
+	l.AddText(loc, title, description)
 }
 
 func (s SeriesDocument) ToJSON() ([]byte, error) {
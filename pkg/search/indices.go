@@ -0,0 +1,248 @@
+package search
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/opensearch-project/opensearch-go/v2/opensearchapi"
+	"github.com/pkg/errors"
+)
+
+func (c *OpenSearchClient) DeleteIndex(ctx context.Context, index string) error {
+	req := opensearchapi.IndicesDeleteRequest{
+		Index: []string{index},
+	}
+
+	res, err := req.Do(ctx, c.client)
+	if err != nil {
+		return errors.Wrap(err, "failed to delete index")
+	}
+	defer res.Body.Close()
+
+	if res.IsError() && res.StatusCode != http.StatusNotFound {
+		return errors.Errorf("failed to delete index: %s", res.String())
+	}
+
+	return nil
+}
+
+func (c *OpenSearchClient) RefreshIndex(ctx context.Context, index string) error {
+	req := opensearchapi.IndicesRefreshRequest{
+		Index: []string{index},
+	}
+
+	res, err := req.Do(ctx, c.client)
+	if err != nil {
+		return errors.Wrap(err, "failed to refresh index")
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return errors.Errorf("failed to refresh index: %s", res.String())
+	}
+
+	return nil
+}
+
+// AliasIndices returns the indices alias points to. It is empty when there
+// is no such alias, including when alias is the name of a concrete index.
+func (c *OpenSearchClient) AliasIndices(ctx context.Context, alias string) ([]string, error) {
+	req := opensearchapi.IndicesGetAliasRequest{
+		Name: []string{alias},
+	}
+
+	res, err := req.Do(ctx, c.client)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get alias")
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if res.IsError() {
+		return nil, errors.Errorf("failed to get alias: %s", res.String())
+	}
+
+	var result map[string]json.RawMessage
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return nil, errors.Wrap(err, "failed to decode alias response")
+	}
+
+	indices := make([]string, 0, len(result))
+	for index := range result {
+		indices = append(indices, index)
+	}
+	return indices, nil
+}
+
+// AliasAction is one step of an UpdateAliases request.
+type AliasAction struct {
+	// Type is "add", "remove" or "remove_index". remove_index deletes a
+	// concrete index, which frees its name for an alias.
+	Type  string
+	Index string
+	Alias string
+}
+
+// UpdateAliases applies actions in one atomic request, so searches never
+// see a missing or half built index.
+func (c *OpenSearchClient) UpdateAliases(ctx context.Context, actions []AliasAction) error {
+	steps := make([]map[string]any, 0, len(actions))
+	for _, a := range actions {
+		step := map[string]string{"index": a.Index}
+		if a.Alias != "" {
+			step["alias"] = a.Alias
+		}
+		steps = append(steps, map[string]any{a.Type: step})
+	}
+
+	body, err := json.Marshal(map[string]any{"actions": steps})
+	if err != nil {
+		return errors.Wrap(err, "failed to encode alias actions")
+	}
+
+	req := opensearchapi.IndicesUpdateAliasesRequest{
+		Body: bytes.NewReader(body),
+	}
+
+	res, err := req.Do(ctx, c.client)
+	if err != nil {
+		return errors.Wrap(err, "failed to update aliases")
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return errors.Errorf("failed to update aliases: %s", res.String())
+	}
+
+	return nil
+}
+
+// GetIndexMeta returns the _meta of the mapping of a concrete index. It is
+// zero for indices created before mappings were versioned.
+func (c *OpenSearchClient) GetIndexMeta(ctx context.Context, index string) (IndexMeta, error) {
+	req := opensearchapi.IndicesGetMappingRequest{
+		Index: []string{index},
+	}
+
+	res, err := req.Do(ctx, c.client)
+	if err != nil {
+		return IndexMeta{}, errors.Wrap(err, "failed to get mapping")
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return IndexMeta{}, errors.Errorf("failed to get mapping: %s", res.String())
+	}
+
+	var result map[string]struct {
+		Mappings struct {
+			Meta IndexMeta `json:"_meta"`
+		} `json:"mappings"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return IndexMeta{}, errors.Wrap(err, "failed to decode mapping response")
+	}
+
+	m, ok := result[index]
+	if !ok {
+		return IndexMeta{}, errors.Errorf("no mapping returned for index %s", index)
+	}
+	return m.Mappings.Meta, nil
+}
+
+// Resolve returns the concrete indices name refers to: the targets of an
+// alias, the index itself for a concrete index, or nothing.
+func Resolve(ctx context.Context, m IndexManager, name string) ([]string, error) {
+	indices, err := m.AliasIndices(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if len(indices) > 0 {
+		return indices, nil
+	}
+
+	exists, err := m.IndexExists(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return []string{name}, nil
+	}
+	return nil, nil
+}
+
+// IndexStatus is the state of the concrete index behind a write alias.
+type IndexStatus struct {
+	Index   string
+	Meta    IndexMeta
+	Created bool
+}
+
+// Current reports whether the index was built from spec as it is now.
+func (s IndexStatus) Current(spec IndexSpec) bool {
+	return s.Meta == spec.Meta()
+}
+
+// EnsureIndex makes sure the read and write aliases of spec exist. When
+// there is no index yet one is created from spec. An index without a write
+// alias, left by an older release, gets one. The returned status tells
+// whether the live index has drifted from spec; migrating it is the job of
+// the reindex command.
+func EnsureIndex(ctx context.Context, m IndexManager, prefix string, spec IndexSpec, now time.Time) (IndexStatus, error) {
+	read, write := spec.ReadAlias(prefix), spec.WriteAlias(prefix)
+
+	writeIndices, err := m.AliasIndices(ctx, write)
+	if err != nil {
+		return IndexStatus{}, err
+	}
+	if len(writeIndices) > 1 {
+		return IndexStatus{}, errors.Errorf("write alias %s points at %d indices", write, len(writeIndices))
+	}
+	if len(writeIndices) == 1 {
+		meta, err := m.GetIndexMeta(ctx, writeIndices[0])
+		if err != nil {
+			return IndexStatus{}, err
+		}
+		return IndexStatus{Index: writeIndices[0], Meta: meta}, nil
+	}
+
+	readIndices, err := Resolve(ctx, m, read)
+	if err != nil {
+		return IndexStatus{}, err
+	}
+
+	switch len(readIndices) {
+	case 0:
+		index := spec.NewIndex(prefix, now)
+		body, err := spec.Body()
+		if err != nil {
+			return IndexStatus{}, err
+		}
+		if err := m.CreateIndex(ctx, index, body); err != nil {
+			return IndexStatus{}, err
+		}
+		if err := m.UpdateAliases(ctx, []AliasAction{
+			{Type: "add", Index: index, Alias: read},
+			{Type: "add", Index: index, Alias: write},
+		}); err != nil {
+			return IndexStatus{}, err
+		}
+		return IndexStatus{Index: index, Meta: spec.Meta(), Created: true}, nil
+	case 1:
+		if err := m.UpdateAliases(ctx, []AliasAction{{Type: "add", Index: readIndices[0], Alias: write}}); err != nil {
+			return IndexStatus{}, err
+		}
+		meta, err := m.GetIndexMeta(ctx, readIndices[0])
+		if err != nil {
+			return IndexStatus{}, err
+		}
+		return IndexStatus{Index: readIndices[0], Meta: meta}, nil
+	default:
+		return IndexStatus{}, errors.Errorf("read alias %s points at %d indices", read, len(readIndices))
+	}
+}
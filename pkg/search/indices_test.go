@@ -0,0 +1,144 @@
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndexSpec(t *testing.T) {
+	t.Parallel()
+
+	spec := IndexSpec{Name: "episodes", Version: 3, Mapping: `{"mappings": {"properties": {"title": {"type": "text"}}}}`}
+	now := time.Date(2025, 9, 10, 12, 0, 0, 0, time.FixedZone("CEST", 2*60*60))
+
+	assert.Equal(t, "th-episodes", spec.ReadAlias("th"))
+	assert.Equal(t, "th-episodes-write", spec.WriteAlias("th"))
+	assert.Equal(t, "th-episodes-v3-20250910100000", spec.NewIndex("th", now))
+
+	body, err := spec.Body()
+	require.NoError(t, err)
+
+	var parsed struct {
+		Mappings struct {
+			Meta       IndexMeta      `json:"_meta"`
+			Properties map[string]any `json:"properties"`
+		} `json:"mappings"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(body), &parsed))
+	assert.Equal(t, spec.Meta(), parsed.Mappings.Meta)
+	assert.Equal(t, 3, parsed.Mappings.Meta.Version)
+	assert.Contains(t, parsed.Mappings.Properties, "title")
+
+	reformatted := spec
+	reformatted.Mapping = "{\n\t\"mappings\": {\"properties\": {\"title\": {\"type\": \"text\"}}}\n}"
+	assert.Equal(t, spec.Meta(), reformatted.Meta(), "whitespace does not change the checksum")
+
+	edited := spec
+	edited.Mapping = `{"mappings": {"properties": {"title": {"type": "keyword"}}}}`
+	assert.NotEqual(t, spec.Meta().Checksum, edited.Meta().Checksum)
+}
+
+func TestEnsureIndex(t *testing.T) {
+	t.Parallel()
+
+	spec := IndexSpec{Name: "series", Version: 2, Mapping: `{"mappings": {}}`}
+	now := time.Date(2025, 9, 10, 12, 0, 0, 0, time.UTC)
+	created := "th-series-v2-20250910120000"
+
+	tests := []struct {
+		name          string
+		writeIndices  []string
+		readIndices   []string
+		legacyIndex   bool
+		meta          IndexMeta
+		expectActions []AliasAction
+		expectStatus  IndexStatus
+		expectCurrent bool
+		expectError   bool
+	}{
+		{
+			name: "creates the first index with both aliases",
+			expectActions: []AliasAction{
+				{Type: "add", Index: created, Alias: "th-series"},
+				{Type: "add", Index: created, Alias: "th-series-write"},
+			},
+			expectStatus:  IndexStatus{Index: created, Meta: spec.Meta(), Created: true},
+			expectCurrent: true,
+		},
+		{
+			name:          "reports a current index",
+			writeIndices:  []string{"th-series-v2-20250101000000"},
+			meta:          spec.Meta(),
+			expectStatus:  IndexStatus{Index: "th-series-v2-20250101000000", Meta: spec.Meta()},
+			expectCurrent: true,
+		},
+		{
+			name:         "reports an index from an older version",
+			writeIndices: []string{"th-series-v1-20250101000000"},
+			meta:         IndexMeta{Version: 1, Checksum: "abc"},
+			expectStatus: IndexStatus{Index: "th-series-v1-20250101000000", Meta: IndexMeta{Version: 1, Checksum: "abc"}},
+		},
+		{
+			name:          "adds the write alias to a concrete index from before aliases",
+			legacyIndex:   true,
+			expectActions: []AliasAction{{Type: "add", Index: "th-series", Alias: "th-series-write"}},
+			expectStatus:  IndexStatus{Index: "th-series"},
+		},
+		{
+			name:          "adds the write alias next to an existing read alias",
+			readIndices:   []string{"th-series-20250101000000"},
+			expectActions: []AliasAction{{Type: "add", Index: "th-series-20250101000000", Alias: "th-series-write"}},
+			expectStatus:  IndexStatus{Index: "th-series-20250101000000"},
+		},
+		{
+			name:         "write alias on several indices",
+			writeIndices: []string{"a", "b"},
+			expectError:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			m := new(MockIndexManager)
+
+			m.On("AliasIndices", mock.Anything, "th-series-write").Return(tt.writeIndices, nil)
+			if len(tt.writeIndices) == 0 {
+				m.On("AliasIndices", mock.Anything, "th-series").Return(tt.readIndices, nil)
+				if len(tt.readIndices) == 0 {
+					m.On("IndexExists", mock.Anything, "th-series").Return(tt.legacyIndex, nil)
+				}
+			}
+			if len(tt.writeIndices) == 0 && len(tt.readIndices) == 0 && !tt.legacyIndex {
+				body, err := spec.Body()
+				require.NoError(t, err)
+				m.On("CreateIndex", mock.Anything, created, body).Return(nil)
+			}
+			if tt.expectActions != nil {
+				m.On("UpdateAliases", mock.Anything, tt.expectActions).Return(nil)
+			}
+			if !tt.expectError && !tt.expectStatus.Created {
+				m.On("GetIndexMeta", mock.Anything, tt.expectStatus.Index).Return(tt.meta, nil)
+			}
+
+			status, err := EnsureIndex(context.Background(), m, "th", spec, now)
+
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectStatus, status)
+				assert.Equal(t, tt.expectCurrent, status.Current(spec))
+			}
+
+			m.AssertExpectations(t)
+		})
+	}
+}
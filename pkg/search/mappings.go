@@ -1,77 +1,243 @@
 package search
 
-const SeriesMapping = `{
-	"mappings": {
-		"properties": {
-			"id": {"type": "keyword"},
-			"title": {
-				"type": "text",
-				"analyzer": "standard",
-				"fields": {
-					"keyword": {"type": "keyword"}
-				}
-			},
-			"description": {
-				"type": "text",
-				"analyzer": "standard"
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Analyzers defined in the index settings. folding splits text into words
+// and folds case, accents and other variants of characters with ICU, so
+// that cafe matches Café and أحمد matches أَحْمَد. titleNgram indexes the
+// leading 2 to 15 characters of every folded word, for partial matches.
+const (
+	foldingAnalyzer    = "folding"
+	titleNgramAnalyzer = "title_ngram"
+)
+
+// languageAnalyzers are the built-in analyzers with stemming and stopwords
+// by language subtag. The title and description of a document are searched
+// with the analyzer of the language of the document, and translations with
+// that of their own. Text in other languages uses foldingAnalyzer.
+var languageAnalyzers = map[string]string{
+	"ar": "arabic",
+	"de": "german",
+	"en": "english",
+	"es": "spanish",
+	"fa": "persian",
+	"fr": "french",
+	"hi": "hindi",
+	"id": "indonesian",
+	"it": "italian",
+	"nl": "dutch",
+	"pt": "portuguese",
+	"ru": "russian",
+	"tr": "turkish",
+}
+
+var (
+	SeriesMapping = indexMapping(map[string]any{
+		"category_id":   keyword(),
+		"category_path": keyword(),
+		"language":      keyword(),
+		"type":          keyword(),
+	})
+	EpisodeMapping = indexMapping(map[string]any{
+		"series_id":        keyword(),
+		"uploader_id":      keyword(),
+		"duration_seconds": map[string]any{"type": "integer"},
+		"publish_date":     date(),
+		"language":         keyword(),
+		"transcript_url":   keyword(),
+		"mime_type":        keyword(),
+		"size_bytes":       map[string]any{"type": "long"},
+	})
+)
+
+// indexMapping returns the create index body of a document type with the
+// fields all documents share and properties.
+func indexMapping(properties map[string]any) string {
+	fields := map[string]any{
+		"id": keyword(),
+		"title": map[string]any{
+			"type":     "text",
+			"analyzer": foldingAnalyzer,
+			"fields": map[string]any{
+				"keyword": keyword(),
+				"ngram": map[string]any{
+					"type":            "text",
+					"analyzer":        titleNgramAnalyzer,
+					"search_analyzer": foldingAnalyzer,
+				},
 			},
-			"category_id": {"type": "keyword"},
-			"language": {"type": "keyword"},
-			"type": {"type": "keyword"},
-			"created_at": {"type": "date"},
-			"updated_at": {"type": "date"},
-			"indexed_at": {"type": "date"}
-		}
-	},
-	"settings": {
-		"number_of_shards": 1,
-		"number_of_replicas": 0,
-		"analysis": {
-			"analyzer": {
-				"standard": {
-					"type": "standard"
-				}
-			}
-		}
+		},
+		"slug":           keyword(),
+		"previous_slugs": keyword(),
+		"description":    map[string]any{"type": "text", "analyzer": foldingAnalyzer},
+		"translations":   map[string]any{"type": "object", "enabled": false},
+		"i18n":           map[string]any{"type": "object"},
+		"created_at":     date(),
+		"updated_at":     date(),
+		"indexed_at":     date(),
+	}
+	for name, field := range properties {
+		fields[name] = field
 	}
-}`
-
-const EpisodeMapping = `{
-	"mappings": {
-		"properties": {
-			"id": {"type": "keyword"},
-			"series_id": {"type": "keyword"},
-			"uploader_id": {"type": "keyword"},
-			"title": {
-				"type": "text",
-				"analyzer": "standard",
-				"fields": {
-					"keyword": {"type": "keyword"}
-				}
+
+	body := map[string]any{
+		"mappings": map[string]any{
+			"dynamic_templates": i18nTemplates(),
+			"properties":        fields,
+		},
+		"settings": map[string]any{
+			"number_of_shards":   1,
+			"number_of_replicas": 0,
+			"analysis":           analysis(),
+		},
+	}
+
+	// maps of strings, numbers and booleans always encode
+	data, _ := json.MarshalIndent(body, "", "\t")
+	return string(data)
+}
+
+// i18nTemplates maps the fields i18n.<language>.title and
+// i18n.<language>.description to the analyzer of the language. Templates
+// apply in order, the last one catches the remaining languages.
+func i18nTemplates() []map[string]any {
+	languages := slices.Sorted(maps.Keys(languageAnalyzers))
+
+	templates := make([]map[string]any, 0, len(languages)+1)
+	for _, lang := range languages {
+		templates = append(templates, i18nTemplate(lang, languageAnalyzers[lang]))
+	}
+	return append(templates, i18nTemplate("*", foldingAnalyzer))
+}
+
+func i18nTemplate(lang, analyzer string) map[string]any {
+	name := "i18n_" + lang
+	if lang == "*" {
+		name = "i18n_other"
+	}
+
+	return map[string]any{
+		name: map[string]any{
+			"path_match": "i18n." + lang + ".*",
+			"mapping":    map[string]any{"type": "text", "analyzer": analyzer},
+		},
+	}
+}
+
+func analysis() map[string]any {
+	return map[string]any{
+		"filter": map[string]any{
+			"title_edge_ngram": map[string]any{
+				"type":     "edge_ngram",
+				"min_gram": 2,
+				"max_gram": 15,
+			},
+		},
+		"analyzer": map[string]any{
+			foldingAnalyzer: map[string]any{
+				"type":      "custom",
+				"tokenizer": "standard",
+				"filter":    []string{"icu_folding"},
 			},
-			"description": {
-				"type": "text",
-				"analyzer": "standard"
+			titleNgramAnalyzer: map[string]any{
+				"type":      "custom",
+				"tokenizer": "standard",
+				"filter":    []string{"icu_folding", "title_edge_ngram"},
 			},
-			"duration_seconds": {"type": "integer"},
-			"publish_date": {"type": "date"},
-			"transcript_url": {"type": "keyword"},
-			"mime_type": {"type": "keyword"},
-			"size_bytes": {"type": "long"},
-			"created_at": {"type": "date"},
-			"updated_at": {"type": "date"},
-			"indexed_at": {"type": "date"}
-		}
-	},
-	"settings": {
-		"number_of_shards": 1,
-		"number_of_replicas": 0,
-		"analysis": {
-			"analyzer": {
-				"standard": {
-					"type": "standard"
-				}
-			}
-		}
+		},
+	}
+}
+
+func keyword() map[string]any {
+	return map[string]any{"type": "keyword"}
+}
+
+func date() map[string]any {
+	return map[string]any{"type": "date"}
+}
+
+// IndexSpec describes one kind of search index. Readers use the alias
+// <prefix>-<name> and writers <prefix>-<name>-write. Both point at a
+// concrete index <prefix>-<name>-v<version>-<timestamp>, created from
+// Mapping.
+//
+// Bump Version whenever Mapping changes. The indexer reports indices built
+// from another version or from an edited mapping at startup, and the
+// reindex command migrates them.
+type IndexSpec struct {
+	Name    string
+	Version int
+	Mapping string
+}
+
+var (
+	SeriesIndex   = IndexSpec{Name: "series", Version: 5, Mapping: SeriesMapping}
+	EpisodesIndex = IndexSpec{Name: "episodes", Version: 4, Mapping: EpisodeMapping}
+)
+
+// IndexMeta is the version information stored in the _meta field of an
+// index mapping.
+type IndexMeta struct {
+	Version  int    `json:"version"`
+	Checksum string `json:"checksum"`
+}
+
+func (s IndexSpec) ReadAlias(prefix string) string {
+	return fmt.Sprintf("%s-%s", prefix, s.Name)
+}
+
+func (s IndexSpec) WriteAlias(prefix string) string {
+	return s.ReadAlias(prefix) + "-write"
+}
+
+// NewIndex returns the name of a new concrete index for the spec.
+func (s IndexSpec) NewIndex(prefix string, t time.Time) string {
+	return fmt.Sprintf("%s-v%d-%s", s.ReadAlias(prefix), s.Version, t.UTC().Format("20060102150405"))
+}
+
+// Meta returns the _meta an index created from the spec carries. The
+// checksum catches mapping edits that forgot to bump the version.
+func (s IndexSpec) Meta() IndexMeta {
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, []byte(s.Mapping)); err != nil {
+		compact.WriteString(s.Mapping)
+	}
+	sum := sha256.Sum256(compact.Bytes())
+
+	return IndexMeta{
+		Version:  s.Version,
+		Checksum: hex.EncodeToString(sum[:8]),
+	}
+}
+
+// Body returns the create index request body: Mapping with Meta added.
+func (s IndexSpec) Body() (string, error) {
+	var body map[string]any
+	if err := json.Unmarshal([]byte(s.Mapping), &body); err != nil {
+		return "", errors.Wrapf(err, "invalid %s mapping", s.Name)
+	}
+
+	mappings, _ := body["mappings"].(map[string]any)
+	if mappings == nil {
+		mappings = map[string]any{}
+		body["mappings"] = mappings
+	}
+	mappings["_meta"] = s.Meta()
+
+	out, err := json.Marshal(body)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to encode %s mapping", s.Name)
 	}
-}`
+	return string(out), nil
+}
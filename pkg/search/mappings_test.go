@@ -0,0 +1,140 @@
+package search
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// indexBody is the part of a create index body the mapping tests look at.
+type indexBody struct {
+	Mappings struct {
+		DynamicTemplates []map[string]struct {
+			PathMatch string         `json:"path_match"`
+			Mapping   map[string]any `json:"mapping"`
+		} `json:"dynamic_templates"`
+		Properties map[string]map[string]any `json:"properties"`
+	} `json:"mappings"`
+	Settings struct {
+		Analysis struct {
+			Filter   map[string]map[string]any `json:"filter"`
+			Analyzer map[string]struct {
+				Type      string   `json:"type"`
+				Tokenizer string   `json:"tokenizer"`
+				Filter    []string `json:"filter"`
+			} `json:"analyzer"`
+		} `json:"analysis"`
+	} `json:"settings"`
+}
+
+func TestMappings(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		mapping        string
+		expectedFields []string
+	}{
+		{
+			name:           "series",
+			mapping:        SeriesMapping,
+			expectedFields: []string{"category_id", "category_path", "language", "type"},
+		},
+		{
+			name:           "episodes",
+			mapping:        EpisodeMapping,
+			expectedFields: []string{"series_id", "language", "duration_seconds", "publish_date"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var body indexBody
+			require.NoError(t, json.Unmarshal([]byte(tt.mapping), &body))
+			properties := body.Mappings.Properties
+
+			for _, field := range append(tt.expectedFields, "id", "slug", "previous_slugs", "created_at", "updated_at", "indexed_at") {
+				assert.Contains(t, properties, field)
+			}
+
+			assert.Equal(t, map[string]any{
+				"type":     "text",
+				"analyzer": "folding",
+				"fields": map[string]any{
+					"keyword": map[string]any{"type": "keyword"},
+					"ngram": map[string]any{
+						"type":            "text",
+						"analyzer":        "title_ngram",
+						"search_analyzer": "folding",
+					},
+				},
+			}, properties["title"])
+			assert.Equal(t, map[string]any{"type": "text", "analyzer": "folding"}, properties["description"])
+			assert.Equal(t, map[string]any{"type": "object", "enabled": false}, properties["translations"])
+
+			analyzers := body.Settings.Analysis.Analyzer
+			require.Contains(t, analyzers, "folding")
+			assert.Equal(t, "standard", analyzers["folding"].Tokenizer)
+			assert.Equal(t, []string{"icu_folding"}, analyzers["folding"].Filter)
+			require.Contains(t, analyzers, "title_ngram")
+			assert.Equal(t, []string{"icu_folding", "title_edge_ngram"}, analyzers["title_ngram"].Filter)
+			assert.Equal(t, map[string]any{"type": "edge_ngram", "min_gram": float64(2), "max_gram": float64(15)},
+				body.Settings.Analysis.Filter["title_edge_ngram"])
+		})
+	}
+}
+
+func TestMappings_I18nTemplates(t *testing.T) {
+	t.Parallel()
+
+	var body indexBody
+	require.NoError(t, json.Unmarshal([]byte(SeriesMapping), &body))
+
+	templates := body.Mappings.DynamicTemplates
+	require.Len(t, templates, len(languageAnalyzers)+1)
+
+	analyzers := make(map[string]any)
+	for _, template := range templates {
+		require.Len(t, template, 1)
+		for _, tmpl := range template {
+			analyzers[tmpl.PathMatch] = tmpl.Mapping["analyzer"]
+		}
+	}
+	assert.Equal(t, "arabic", analyzers["i18n.ar.*"])
+	assert.Equal(t, "english", analyzers["i18n.en.*"])
+	assert.Equal(t, "french", analyzers["i18n.fr.*"])
+
+	// the catch-all template has to come last, templates apply in order
+	other, ok := templates[len(templates)-1]["i18n_other"]
+	require.True(t, ok)
+	assert.Equal(t, "i18n.*.*", other.PathMatch)
+	assert.Equal(t, "folding", other.Mapping["analyzer"])
+
+	// episodes are analyzed the same way
+	var episodes indexBody
+	require.NoError(t, json.Unmarshal([]byte(EpisodeMapping), &episodes))
+	assert.Equal(t, body.Mappings.DynamicTemplates, episodes.Mappings.DynamicTemplates)
+	assert.Equal(t, body.Settings, episodes.Settings)
+}
+
+func TestMappings_Stable(t *testing.T) {
+	t.Parallel()
+
+	// the checksum in the index _meta detects edited mappings, building the
+	// same mapping again must not change it
+	rebuilt := IndexSpec{Name: "series", Version: SeriesIndex.Version, Mapping: indexMapping(map[string]any{
+		"category_id":   keyword(),
+		"category_path": keyword(),
+		"language":      keyword(),
+		"type":          keyword(),
+	})}
+	assert.Equal(t, SeriesIndex.Meta(), rebuilt.Meta())
+
+	body, err := EpisodesIndex.Body()
+	require.NoError(t, err)
+	assert.True(t, json.Valid([]byte(body)))
+}
@@ -0,0 +1,100 @@
+package search
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockIndexManager struct {
+	mock.Mock
+}
+
+func (m *MockIndexManager) IndexExists(ctx context.Context, indexName string) (bool, error) {
+	args := m.Called(ctx, indexName)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockIndexManager) CreateIndex(ctx context.Context, indexName, mapping string) error {
+	args := m.Called(ctx, indexName, mapping)
+	return args.Error(0)
+}
+
+func (m *MockIndexManager) DeleteIndex(ctx context.Context, indexName string) error {
+	args := m.Called(ctx, indexName)
+	return args.Error(0)
+}
+
+func (m *MockIndexManager) RefreshIndex(ctx context.Context, indexName string) error {
+	args := m.Called(ctx, indexName)
+	return args.Error(0)
+}
+
+func (m *MockIndexManager) Bulk(ctx context.Context, indexName string, ops []BulkOperation) error {
+	args := m.Called(ctx, indexName, ops)
+	return args.Error(0)
+}
+
+func (m *MockIndexManager) AliasIndices(ctx context.Context, alias string) ([]string, error) {
+	args := m.Called(ctx, alias)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockIndexManager) UpdateAliases(ctx context.Context, actions []AliasAction) error {
+	args := m.Called(ctx, actions)
+	return args.Error(0)
+}
+
+func (m *MockIndexManager) GetIndexMeta(ctx context.Context, indexName string) (IndexMeta, error) {
+	args := m.Called(ctx, indexName)
+	return args.Get(0).(IndexMeta), args.Error(1)
+}
+
+func (m *MockIndexManager) ScanDocuments(ctx context.Context, indexName, after string, size int) ([]DocumentVersion, error) {
+	args := m.Called(ctx, indexName, after, size)
+	return args.Get(0).([]DocumentVersion), args.Error(1)
+}
+
+type MockSearcher struct {
+	mock.Mock
+}
+
+func (m *MockSearcher) SearchSeries(ctx context.Context, req SearchRequest) (*SearchResponse, error) {
+	args := m.Called(ctx, req)
+	return args.Get(0).(*SearchResponse), args.Error(1)
+}
+
+func (m *MockSearcher) SearchEpisodes(ctx context.Context, req SearchRequest) (*SearchResponse, error) {
+	args := m.Called(ctx, req)
+	return args.Get(0).(*SearchResponse), args.Error(1)
+}
+
+func (m *MockSearcher) IndexDocument(ctx context.Context, indexName, documentID string, documentJSON []byte) error {
+	args := m.Called(ctx, indexName, documentID, documentJSON)
+	return args.Error(0)
+}
+
+func (m *MockSearcher) DeleteDocument(ctx context.Context, indexName, documentID string) error {
+	args := m.Called(ctx, indexName, documentID)
+	return args.Error(0)
+}
+
+func (m *MockSearcher) BulkIndex(ctx context.Context, indexName string, docs []BulkDocument) (*BulkResponse, error) {
+	args := m.Called(ctx, indexName, docs)
+	return args.Get(0).(*BulkResponse), args.Error(1)
+}
+
+func (m *MockSearcher) BulkDelete(ctx context.Context, indexName string, docs []BulkDocument) (*BulkResponse, error) {
+	args := m.Called(ctx, indexName, docs)
+	return args.Get(0).(*BulkResponse), args.Error(1)
+}
+
+func (m *MockSearcher) IndexExists(ctx context.Context, indexName string) (bool, error) {
+	args := m.Called(ctx, indexName)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockSearcher) CreateIndex(ctx context.Context, indexName, mapping string) error {
+	args := m.Called(ctx, indexName, mapping)
+	return args.Error(0)
+}
@@ -0,0 +1,74 @@
+package search
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/opensearch-project/opensearch-go/v2/opensearchapi"
+	"github.com/pkg/errors"
+)
+
+// DocumentVersion identifies the version of an indexed document by the
+// updated_at of the row it was built from.
+type DocumentVersion struct {
+	ID        string    `json:"id"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ScanDocuments returns up to size documents of index ordered by id, starting
+// after the id after, or at the first document when after is empty. Only the
+// id and updated_at of each document are fetched.
+//
+// Document ids are canonical UUID strings, so this order matches the order
+// of the uuid primary keys in PostgreSQL.
+func (c *OpenSearchClient) ScanDocuments(ctx context.Context, index, after string, size int) ([]DocumentVersion, error) {
+	query := map[string]any{
+		"query":            map[string]any{"match_all": map[string]any{}},
+		"_source":          []string{"id", "updated_at"},
+		"sort":             []any{map[string]any{"id": "asc"}},
+		"track_total_hits": false,
+	}
+	if after != "" {
+		query["search_after"] = []string{after}
+	}
+
+	body, err := json.Marshal(query)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encode scan query")
+	}
+
+	req := opensearchapi.SearchRequest{
+		Index: []string{index},
+		Body:  bytes.NewReader(body),
+		Size:  &size,
+	}
+
+	res, err := req.Do(ctx, c.client)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to scan documents")
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, errors.Errorf("failed to scan documents: %s", res.String())
+	}
+
+	var result struct {
+		Hits struct {
+			Hits []struct {
+				Source DocumentVersion `json:"_source"`
+			} `json:"hits"`
+		} `json:"hits"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return nil, errors.Wrap(err, "failed to decode scan response")
+	}
+
+	docs := make([]DocumentVersion, 0, len(result.Hits.Hits))
+	for _, hit := range result.Hits.Hits {
+		docs = append(docs, hit.Source)
+	}
+	return docs, nil
+}
@@ -3,7 +3,6 @@ package search
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 	"strings"
 
 	"github.com/opensearch-project/opensearch-go/v2/opensearchapi"
@@ -25,12 +24,12 @@ type SearchResponse struct {
 }
 
 func (c *OpenSearchClient) SearchSeries(ctx context.Context, req SearchRequest) (*SearchResponse, error) {
-	index := fmt.Sprintf("%s-series", c.config.IndexPrefix)
+	index := SeriesIndex.ReadAlias(c.config.IndexPrefix)
 	return c.search(ctx, index, req)
 }
 
 func (c *OpenSearchClient) SearchEpisodes(ctx context.Context, req SearchRequest) (*SearchResponse, error) {
-	index := fmt.Sprintf("%s-episodes", c.config.IndexPrefix)
+	index := EpisodesIndex.ReadAlias(c.config.IndexPrefix)
 	return c.search(ctx, index, req)
 }
 
@@ -107,7 +106,8 @@ func buildSearchQuery(req SearchRequest) string {
 		must = append(must, map[string]any{
 			"multi_match": map[string]any{
 				"query":  req.Query,
-				"fields": []string{"title^2", "description"},
+				// title.ngram matches words from their first letters on, such as tech for technology
+				"fields": []string{"title^2", "title.ngram", "description", "i18n.*.title^2", "i18n.*.description"},
 				"type":   "best_fields",
 			},
 		})
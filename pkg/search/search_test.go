@@ -25,7 +25,7 @@ func TestBuildSearchQuery(t *testing.T) {
 				PageSize: 20,
 			},
 			expectedFields: []string{"query", "sort"},
-			shouldContain:  []string{"multi_match", "podcast series", "title^2", "description"},
+			shouldContain:  []string{"multi_match", "podcast series", "title^2", "title.ngram", "description", "i18n.*.title^2"},
 		},
 		{
 			name: "query with filters",
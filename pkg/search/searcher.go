@@ -9,6 +9,28 @@ type Searcher interface {
 	SearchEpisodes(ctx context.Context, req SearchRequest) (*SearchResponse, error)
 	IndexDocument(ctx context.Context, indexName string, documentID string, documentJSON []byte) error
 	DeleteDocument(ctx context.Context, indexName string, documentID string) error
+	BulkIndex(ctx context.Context, indexName string, docs []BulkDocument) (*BulkResponse, error)
+	BulkDelete(ctx context.Context, indexName string, docs []BulkDocument) (*BulkResponse, error)
 	IndexExists(ctx context.Context, indexName string) (bool, error)
 	CreateIndex(ctx context.Context, indexName string, mapping string) error
 }
+
+// IndexManager builds the concrete indices behind the series and episodes
+// aliases, moves the aliases between them and lists what they contain.
+type IndexManager interface {
+	IndexExists(ctx context.Context, indexName string) (bool, error)
+	CreateIndex(ctx context.Context, indexName string, mapping string) error
+	DeleteIndex(ctx context.Context, indexName string) error
+	RefreshIndex(ctx context.Context, indexName string) error
+	Bulk(ctx context.Context, indexName string, ops []BulkOperation) error
+	AliasIndices(ctx context.Context, alias string) ([]string, error)
+	UpdateAliases(ctx context.Context, actions []AliasAction) error
+	GetIndexMeta(ctx context.Context, indexName string) (IndexMeta, error)
+	ScanDocuments(ctx context.Context, indexName, after string, size int) ([]DocumentVersion, error)
+}
+
+// Client is the full OpenSearch client used by the indexer worker.
+type Client interface {
+	Searcher
+	IndexManager
+}
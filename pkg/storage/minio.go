@@ -16,6 +16,7 @@ type MinioClient interface {
 	BucketExists(ctx context.Context, bucketName string) (bool, error)
 	MakeBucket(ctx context.Context, bucketName string, opts minio.MakeBucketOptions) error
 	PresignedPutObject(ctx context.Context, bucketName, objectName string, expiration time.Duration) (*url.URL, error)
+	RemoveObject(ctx context.Context, bucketName, objectName string, opts minio.RemoveObjectOptions) error
 }
 
 type MinIOStorage struct {
@@ -65,6 +66,15 @@ func (m *MinIOStorage) GeneratePresignedPutURL(ctx context.Context, key string,
 	return m.client.PresignedPutObject(ctx, m.bucketName, key, expiry)
 }
 
+// RemoveObject deletes the object stored under key. Removing an object that
+// does not exist is not an error.
+func (m *MinIOStorage) RemoveObject(ctx context.Context, key string) error {
+	if err := m.client.RemoveObject(ctx, m.bucketName, key, minio.RemoveObjectOptions{}); err != nil {
+		return fmt.Errorf("failed to remove object: %w", err)
+	}
+	return nil
+}
+
 func (m *MinIOStorage) GenerateKey(seriesID, episodeID uuid.UUID, filename string) string {
 	ext := filepath.Ext(filename)
 	timestamp := time.Now().Unix()
@@ -30,6 +30,11 @@ func (m *MockMinioClient) PresignedPutObject(ctx context.Context, bucketName, ob
 	return args.Get(0).(*url.URL), args.Error(1)
 }
 
+func (m *MockMinioClient) RemoveObject(ctx context.Context, bucketName, objectName string, opts minio.RemoveObjectOptions) error {
+	args := m.Called(ctx, bucketName, objectName, opts)
+	return args.Error(0)
+}
+
 type TestClient struct {
 	client     MinioClient
 	bucketName string
@@ -117,3 +122,47 @@ func TestMinIOClient_EnsureBucket(t *testing.T) {
 		})
 	}
 }
+
+func TestMinIOClient_RemoveObject(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		removeErr   error
+		expectError bool
+	}{
+		{
+			name: "object removed",
+		},
+		{
+			name:        "removal fails",
+			removeErr:   assert.AnError,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockClient := new(MockMinioClient)
+			mockClient.On("RemoveObject", mock.Anything, "episodes", "episodes/a/b_1.mp3", minio.RemoveObjectOptions{}).
+				Return(tt.removeErr)
+
+			client := &MinIOStorage{
+				client:     mockClient,
+				bucketName: "episodes",
+			}
+
+			err := client.RemoveObject(context.Background(), "episodes/a/b_1.mp3")
+
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
@@ -11,6 +11,7 @@ import (
 type ObjectStorage interface {
 	EnsureBucket(ctx context.Context) error
 	GeneratePresignedPutURL(ctx context.Context, key string, expiry time.Duration) (*url.URL, error)
+	RemoveObject(ctx context.Context, key string) error
 	GenerateKey(seriesID, episodeID uuid.UUID, filename string) string
 	GetBucketName() string
 }
@@ -5,26 +5,49 @@ import (
 	"encoding/json"
 	"log/slog"
 	"th-application-technical-assignment/sqlc"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/hibiken/asynq"
 	"github.com/pkg/errors"
 )
 
 const (
-	TypeIndexSeries    = "search:index_series"
-	TypeIndexEpisode   = "search:index_episode"
-	TypeDeleteSeries   = "search:delete_series"
-	TypeDeleteEpisode  = "search:delete_episode"
-	TypeImportContent  = "import:content"
+	TypeIndexSeries         = "search:index_series"
+	TypeIndexEpisode        = "search:index_episode"
+	TypeIndexEpisodes       = "search:index_episodes"
+	TypeDeleteSeries        = "search:delete_series"
+	TypeDeleteEpisode       = "search:delete_episode"
+	TypeSearchBatch         = "search:batch"
+	TypeImportContent       = "import:content"
+	TypeSyncSubscriptions   = "import:sync_subscriptions"
+	TypeDeleteSeriesContent = "content:delete_series"
+	TypePurgeTrash          = "content:purge_trash"
+	TypePublishScheduled    = "content:publish_scheduled"
 )
 
+// SearchGroup is the asynq group search tasks are enqueued in. The indexer
+// aggregates the tasks of the group into TypeSearchBatch tasks and applies
+// each batch with bulk requests.
+const SearchGroup = "search"
+
+var groupedTypes = map[string]string{
+	TypeIndexSeries:   SearchGroup,
+	TypeIndexEpisode:  SearchGroup,
+	TypeIndexEpisodes: SearchGroup,
+	TypeDeleteSeries:  SearchGroup,
+	TypeDeleteEpisode: SearchGroup,
+}
+
 type TaskQueue interface {
     Enqueue(ctx context.Context, typename string, taskPayload any) error
     EnqueueIndexSeries(ctx context.Context, series sqlc.Series) error
     EnqueueIndexEpisode(ctx context.Context, episode sqlc.Episode, assets []sqlc.EpisodeAsset) error
-    EnqueueDeleteSeries(ctx context.Context, seriesID string) error
-    EnqueueDeleteEpisode(ctx context.Context, episodeID string) error
+    EnqueueIndexEpisodes(ctx context.Context, episodes []IndexEpisodePayload) error
+    EnqueueDeleteSeries(ctx context.Context, seriesID string, deletedAt time.Time) error
+    EnqueueDeleteEpisode(ctx context.Context, episodeID string, deletedAt time.Time) error
     EnqueueImportContent(ctx context.Context, payload ImportContentPayload) error
+    EnqueueDeleteSeriesContent(ctx context.Context, jobID string) error
     Close() error
 }
 
@@ -57,25 +80,69 @@ func (c *AsynqQueue) Close() error {
 
 type IndexSeriesPayload struct {
 	Series sqlc.Series `json:"series"`
+	// CategoryPath lists the category ids from the root category down to the
+	// category of the series, so that searches can include subcategories.
+	CategoryPath []uuid.UUID `json:"category_path,omitempty"`
+	// Translations are the translated titles and descriptions, searched
+	// with the analyzer of their language.
+	Translations []sqlc.SeriesTranslation `json:"translations,omitempty"`
 }
 
 type IndexEpisodePayload struct {
-	Episode sqlc.Episode       `json:"episode"`
+	Episode sqlc.Episode        `json:"episode"`
 	Assets  []sqlc.EpisodeAsset `json:"assets"`
+	// Language is the language of the series, the episode is in the same.
+	Language     *string                   `json:"language,omitempty"`
+	Translations []sqlc.EpisodeTranslation `json:"translations,omitempty"`
+}
+
+type IndexEpisodesPayload struct {
+	Episodes []IndexEpisodePayload `json:"episodes"`
+}
+
+// SearchBatchPayload is the payload of a TypeSearchBatch task.
+type SearchBatchPayload struct {
+	Tasks []BatchedTask `json:"tasks"`
+}
+
+type BatchedTask struct {
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload"`
 }
 
+// DeleteSeriesPayload removes a series from search. DeletedAt versions the
+// delete against other tasks of the series, it is zero in tasks enqueued
+// before deletes were versioned.
 type DeleteSeriesPayload struct {
-	SeriesID string `json:"series_id"`
+	SeriesID  string    `json:"series_id"`
+	DeletedAt time.Time `json:"deleted_at"`
 }
 
+// DeleteEpisodePayload removes an episode from search, versioned like
+// DeleteSeriesPayload.
 type DeleteEpisodePayload struct {
-	EpisodeID string `json:"episode_id"`
+	EpisodeID string    `json:"episode_id"`
+	DeletedAt time.Time `json:"deleted_at"`
 }
 
+// DeleteSeriesContentPayload names the deletion job whose series cascades
+// its soft delete to its episodes and assets.
+type DeleteSeriesContentPayload struct {
+	JobID string `json:"job_id"`
+}
+
+// ImportContentPayload describes an import of a source into a series.
+// Subscription is set for imports started by a series subscription, which
+// pass the validators of their last sync in ETag and LastModified and get
+// the new ones stored back once the import succeeds.
 type ImportContentPayload struct {
-	SourceType string `json:"source_type"`
-	SourceURL  string `json:"source_url"`
-	SeriesID   string `json:"series_id"`
+	JobID        string `json:"job_id,omitempty"`
+	SourceType   string `json:"source_type"`
+	SourceURL    string `json:"source_url"`
+	SeriesID     string `json:"series_id"`
+	Subscription bool   `json:"subscription,omitempty"`
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"last_modified,omitempty"`
 }
 
 func (c *AsynqQueue) Enqueue(ctx context.Context, typename string, taskPayload any) error {
@@ -84,8 +151,13 @@ func (c *AsynqQueue) Enqueue(ctx context.Context, typename string, taskPayload a
 		return errors.Wrap(err, "failed to marshal payload")
 	}
 
+	var opts []asynq.Option
+	if group, ok := groupedTypes[typename]; ok {
+		opts = append(opts, asynq.Group(group))
+	}
+
 	t := asynq.NewTask(typename, data)
-	_, err = c.client.EnqueueContext(ctx, t)
+	_, err = c.client.EnqueueContext(ctx, t, opts...)
 	if err != nil {
 		slog.ErrorContext(ctx, "failed to enqueue task", "err", err, "typename", typename)
 		return errors.Wrap(err, "failed to enqueue task")
@@ -105,16 +177,26 @@ func (c *AsynqQueue) EnqueueIndexEpisode(ctx context.Context, episode sqlc.Episo
 	return c.Enqueue(ctx, TypeIndexEpisode, payload)
 }
 
-func (c *AsynqQueue) EnqueueDeleteSeries(ctx context.Context, seriesID string) error {
-	payload := DeleteSeriesPayload{SeriesID: seriesID}
+func (c *AsynqQueue) EnqueueIndexEpisodes(ctx context.Context, episodes []IndexEpisodePayload) error {
+	payload := IndexEpisodesPayload{Episodes: episodes}
+	return c.Enqueue(ctx, TypeIndexEpisodes, payload)
+}
+
+func (c *AsynqQueue) EnqueueDeleteSeries(ctx context.Context, seriesID string, deletedAt time.Time) error {
+	payload := DeleteSeriesPayload{SeriesID: seriesID, DeletedAt: deletedAt}
 	return c.Enqueue(ctx, TypeDeleteSeries, payload)
 }
 
-func (c *AsynqQueue) EnqueueDeleteEpisode(ctx context.Context, episodeID string) error {
-	payload := DeleteEpisodePayload{EpisodeID: episodeID}
+func (c *AsynqQueue) EnqueueDeleteEpisode(ctx context.Context, episodeID string, deletedAt time.Time) error {
+	payload := DeleteEpisodePayload{EpisodeID: episodeID, DeletedAt: deletedAt}
 	return c.Enqueue(ctx, TypeDeleteEpisode, payload)
 }
 
 func (c *AsynqQueue) EnqueueImportContent(ctx context.Context, payload ImportContentPayload) error {
 	return c.Enqueue(ctx, TypeImportContent, payload)
 }
+
+func (c *AsynqQueue) EnqueueDeleteSeriesContent(ctx context.Context, jobID string) error {
+	payload := DeleteSeriesContentPayload{JobID: jobID}
+	return c.Enqueue(ctx, TypeDeleteSeriesContent, payload)
+}
@@ -6,6 +6,12 @@ type QueueConfig struct {
 	Concurrency   int           `env:"CONCURRENCY" envDefault:"10"`
 	RetryDelay    time.Duration `env:"RETRY_DELAY" envDefault:"5s"`
 	MaxRetry      int           `env:"MAX_RETRY" envDefault:"3"`
+	// Search tasks are aggregated into a batch once BatchMaxSize tasks are
+	// waiting, no task arrived for BatchGracePeriod (at least 1s) or the
+	// oldest task waited for BatchMaxDelay.
+	BatchMaxSize     int           `env:"BATCH_MAX_SIZE" envDefault:"500"`
+	BatchGracePeriod time.Duration `env:"BATCH_GRACE_PERIOD" envDefault:"1s"`
+	BatchMaxDelay    time.Duration `env:"BATCH_MAX_DELAY" envDefault:"5s"`
 }
 
 type RedisConfig struct {
@@ -14,3 +20,24 @@ type RedisConfig struct {
 	RedisDB       int           `env:"DB" envDefault:"0"`
 }
 
+type ImportConfig struct {
+	BatchSize     int    `env:"BATCH_SIZE" envDefault:"50"`
+	MaxPages      int    `env:"MAX_PAGES" envDefault:"100"`
+	SyncSchedule  string `env:"SYNC_SCHEDULE" envDefault:"@every 1m"`
+	SyncBatchSize int    `env:"SYNC_BATCH_SIZE" envDefault:"100"`
+}
+
+type TrashConfig struct {
+	// RetentionDays is how long deleted content is kept before it is purged
+	// for good. Zero keeps it forever.
+	RetentionDays  int    `env:"RETENTION_DAYS" envDefault:"30"`
+	PurgeSchedule  string `env:"PURGE_SCHEDULE" envDefault:"@daily"`
+	PurgeBatchSize int    `env:"PURGE_BATCH_SIZE" envDefault:"500"`
+}
+
+type PublishConfig struct {
+	// Schedule is how often scheduled episodes whose publish date has come
+	// are published.
+	Schedule  string `env:"SCHEDULE" envDefault:"@every 1m"`
+	BatchSize int    `env:"BATCH_SIZE" envDefault:"100"`
+}
@@ -0,0 +1,281 @@
+package tasks
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"th-application-technical-assignment/pkg/audit"
+	"th-application-technical-assignment/pkg/database"
+	"th-application-technical-assignment/pkg/storage"
+	"th-application-technical-assignment/sqlc"
+
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+	"github.com/jackc/pgx/v5"
+	"github.com/pkg/errors"
+)
+
+const DefaultDeletionBatchSize = 500
+
+// errSeriesNotDeleted fails a job whose series was restored before or while
+// the job ran. Retrying would not help.
+var errSeriesNotDeleted = errors.New("series is not deleted")
+
+// Deletion job states, stored in deletion_jobs.status.
+const (
+	DeletionJobQueued    = "queued"
+	DeletionJobRunning   = "running"
+	DeletionJobSucceeded = "succeeded"
+	DeletionJobFailed    = "failed"
+)
+
+// DeletionError describes a step of a deletion job that failed. Errors that
+// abort the whole job are recorded without an asset.
+type DeletionError struct {
+	AssetID string `json:"asset_id,omitempty"`
+	Key     string `json:"key,omitempty"`
+	Error   string `json:"error"`
+}
+
+// DeletionResult counts what a deletion job removed.
+type DeletionResult struct {
+	Episodes int
+	Assets   int
+	Objects  int
+	Errors   []DeletionError
+}
+
+// DeleteSeriesContentTaskProcessor cascades the soft delete of a series to
+// its episodes and their assets. Everything is marked deleted with the
+// deletion time of the series, so the cascade can be told apart from
+// episodes deleted earlier on their own.
+//
+// Episodes are deleted in batches, each in one transaction together with the
+// outbox tasks that remove them from the search index. A failed job is
+// retried from where it stopped. Stored objects of uploaded assets are
+// removed afterwards when the job asks for it.
+type DeleteSeriesContentTaskProcessor struct {
+	store     *database.Store
+	objects   storage.ObjectStorage
+	batchSize int
+}
+
+func NewDeleteSeriesContentTaskProcessor(store *database.Store, objects storage.ObjectStorage) *DeleteSeriesContentTaskProcessor {
+	return &DeleteSeriesContentTaskProcessor{
+		store:     store,
+		objects:   objects,
+		batchSize: DefaultDeletionBatchSize,
+	}
+}
+
+func (p *DeleteSeriesContentTaskProcessor) ProcessTask(ctx context.Context, t *asynq.Task) error {
+	var payload DeleteSeriesContentPayload
+	if err := json.Unmarshal(t.Payload(), &payload); err != nil {
+		return errors.Wrap(err, "failed to unmarshal payload")
+	}
+
+	jobID, err := uuid.Parse(payload.JobID)
+	if err != nil {
+		return errors.Wrap(err, "invalid deletion job ID")
+	}
+
+	job, err := p.store.Queries.GetDeletionJob(ctx, jobID)
+	if err != nil {
+		return errors.Wrap(err, "failed to get deletion job")
+	}
+
+	if err := p.store.Queries.StartDeletionJob(ctx, job.ID); err != nil {
+		slog.WarnContext(ctx, "failed to mark deletion job running", "err", err, "job_id", job.ID)
+	}
+
+	var result DeletionResult
+	if err := p.run(ctx, job, &result); err != nil {
+		result.Errors = append(result.Errors, DeletionError{Error: err.Error()})
+		p.finishJob(ctx, job.ID, DeletionJobFailed, &result)
+		if errors.Is(err, errSeriesNotDeleted) {
+			slog.WarnContext(ctx, "skipped deletion job of restored series", "job_id", job.ID, "series_id", job.SeriesID)
+			return nil
+		}
+		return err
+	}
+
+	if len(result.Errors) > 0 {
+		p.finishJob(ctx, job.ID, DeletionJobFailed, &result)
+		return errors.Errorf("failed to remove %d stored objects", len(result.Errors))
+	}
+	p.finishJob(ctx, job.ID, DeletionJobSucceeded, &result)
+
+	slog.InfoContext(ctx, "deleted series content",
+		"job_id", job.ID,
+		"series_id", job.SeriesID,
+		"episodes", result.Episodes,
+		"assets", result.Assets,
+		"objects", result.Objects,
+	)
+	return nil
+}
+
+func (p *DeleteSeriesContentTaskProcessor) run(ctx context.Context, job sqlc.DeletionJob, result *DeletionResult) error {
+	series, err := p.store.Queries.GetDeletedSeries(ctx, job.SeriesID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errSeriesNotDeleted
+		}
+		return errors.Wrap(err, "failed to get series")
+	}
+
+	for {
+		n, err := p.deleteBatch(ctx, job, series)
+		if err != nil {
+			return err
+		}
+		if n < p.batchSize {
+			break
+		}
+	}
+
+	// counted afterwards, so a retried job reports the whole cascade
+	episodes, err := p.store.Queries.CountEpisodesDeletedWithSeries(ctx, sqlc.CountEpisodesDeletedWithSeriesParams{
+		SeriesID:  series.ID,
+		DeletedAt: series.DeletedAt,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to count deleted episodes")
+	}
+	assets, err := p.store.Queries.ListAssetsDeletedWithSeries(ctx, sqlc.ListAssetsDeletedWithSeriesParams{
+		SeriesID:  series.ID,
+		DeletedAt: series.DeletedAt,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to list deleted assets")
+	}
+	result.Episodes = int(episodes)
+	result.Assets = len(assets)
+
+	if job.DeleteObjects {
+		p.removeObjects(ctx, assets, result)
+	}
+	return nil
+}
+
+// deleteBatch soft deletes the next batch of live episodes of series and
+// their assets, and returns the number of episodes deleted. The series stays
+// locked while the batch is written, so it cannot be restored in between.
+func (p *DeleteSeriesContentTaskProcessor) deleteBatch(ctx context.Context, job sqlc.DeletionJob, series sqlc.Series) (int, error) {
+	n := 0
+	err := p.store.WithTx(ctx, func(q sqlc.Querier) error {
+		locked, err := q.GetDeletedSeries(ctx, series.ID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return errSeriesNotDeleted
+			}
+			return errors.Wrap(err, "failed to lock series")
+		}
+		// restored and deleted again, the job of the new deletion takes over
+		if locked.DeletedAt == nil || !locked.DeletedAt.Equal(*series.DeletedAt) {
+			return errSeriesNotDeleted
+		}
+
+		episodes, err := q.DeleteEpisodesBySeries(ctx, sqlc.DeleteEpisodesBySeriesParams{
+			DeletedAt: series.DeletedAt,
+			SeriesID:  series.ID,
+			RowLimit:  int32(p.batchSize),
+		})
+		if err != nil {
+			return errors.Wrap(err, "failed to delete episodes")
+		}
+		n = len(episodes)
+		if n == 0 {
+			return nil
+		}
+
+		ids := make([]uuid.UUID, 0, len(episodes))
+		for _, ep := range episodes {
+			ids = append(ids, ep.ID)
+		}
+		assets, err := q.DeleteAssetsByEpisodes(ctx, sqlc.DeleteAssetsByEpisodesParams{
+			DeletedAt:  series.DeletedAt,
+			EpisodeIds: ids,
+		})
+		if err != nil {
+			return errors.Wrap(err, "failed to delete assets")
+		}
+
+		outbox := NewOutboxQueue(q)
+		for _, ep := range episodes {
+			before := ep
+			before.DeletedAt = nil
+			if err := p.record(ctx, q, job, audit.EntityEpisode, ep.ID, before); err != nil {
+				return err
+			}
+			if err := outbox.EnqueueDeleteEpisode(ctx, ep.ID.String(), *ep.DeletedAt); err != nil {
+				return err
+			}
+		}
+		for _, a := range assets {
+			before := a
+			before.DeletedAt = nil
+			if err := p.record(ctx, q, job, audit.EntityAsset, a.ID, before); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return n, err
+}
+
+func (p *DeleteSeriesContentTaskProcessor) record(ctx context.Context, q sqlc.Querier, job sqlc.DeletionJob, entityType string, entityID uuid.UUID, before any) error {
+	return audit.Record(ctx, q, audit.Event{
+		Actor:      job.RequestedBy,
+		Action:     audit.ActionDelete,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Before:     before,
+	})
+}
+
+// removeObjects removes the stored objects of uploaded assets. Remote assets
+// point at files that are not ours to delete. A failed removal is recorded
+// and the remaining objects are still tried.
+func (p *DeleteSeriesContentTaskProcessor) removeObjects(ctx context.Context, assets []sqlc.EpisodeAsset, result *DeletionResult) {
+	for _, a := range assets {
+		if a.Url == nil || isRemoteAsset(a) {
+			continue
+		}
+
+		if p.objects == nil {
+			result.Errors = append(result.Errors, DeletionError{AssetID: a.ID.String(), Key: *a.Url, Error: "object storage is not configured"})
+			continue
+		}
+		if err := p.objects.RemoveObject(ctx, *a.Url); err != nil {
+			result.Errors = append(result.Errors, DeletionError{AssetID: a.ID.String(), Key: *a.Url, Error: err.Error()})
+			continue
+		}
+		result.Objects++
+	}
+}
+
+// finishJob records the outcome of the job. Like import jobs, the
+// bookkeeping is best effort and never fails the deletion itself.
+func (p *DeleteSeriesContentTaskProcessor) finishJob(ctx context.Context, jobID uuid.UUID, status string, result *DeletionResult) {
+	errs := result.Errors
+	if errs == nil {
+		errs = []DeletionError{}
+	}
+	data, err := json.Marshal(errs)
+	if err != nil {
+		data = []byte("[]")
+	}
+
+	params := sqlc.FinishDeletionJobParams{
+		ID:           jobID,
+		Status:       status,
+		EpisodeCount: int32(result.Episodes),
+		AssetCount:   int32(result.Assets),
+		ObjectCount:  int32(result.Objects),
+		Errors:       data,
+	}
+	if err := p.store.Queries.FinishDeletionJob(ctx, params); err != nil {
+		slog.WarnContext(ctx, "failed to finish deletion job", "err", err, "job_id", jobID, "status", status)
+	}
+}
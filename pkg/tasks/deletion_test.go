@@ -0,0 +1,225 @@
+package tasks
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"testing"
+	"th-application-technical-assignment/pkg/audit"
+	"th-application-technical-assignment/pkg/database"
+	"th-application-technical-assignment/sqlc"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mockObjectStorage struct {
+	mock.Mock
+}
+
+func (m *mockObjectStorage) EnsureBucket(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+func (m *mockObjectStorage) GeneratePresignedPutURL(ctx context.Context, key string, expiry time.Duration) (*url.URL, error) {
+	args := m.Called(ctx, key, expiry)
+	return args.Get(0).(*url.URL), args.Error(1)
+}
+
+func (m *mockObjectStorage) RemoveObject(ctx context.Context, key string) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
+
+func (m *mockObjectStorage) GenerateKey(seriesID, episodeID uuid.UUID, filename string) string {
+	args := m.Called(seriesID, episodeID, filename)
+	return args.String(0)
+}
+
+func (m *mockObjectStorage) GetBucketName() string {
+	args := m.Called()
+	return args.String(0)
+}
+
+func TestDeleteSeriesContentTaskProcessor_ProcessTask(t *testing.T) {
+	t.Parallel()
+
+	uploadedKey := "series/s1/episodes/e1/video.mp4"
+	remoteURL := "https://youtube.com/watch?v=test123"
+
+	tests := []struct {
+		name           string
+		deleteObjects  bool
+		seriesRestored bool
+		restoredInJob  bool
+		batchError     error
+		removeError    error
+		expectedStatus string
+		expectedCounts [3]int32
+		expectError    bool
+	}{
+		{
+			name:           "cascades in batches",
+			expectedStatus: DeletionJobSucceeded,
+			expectedCounts: [3]int32{3, 2, 0},
+		},
+		{
+			name:           "removes stored objects of uploaded assets",
+			deleteObjects:  true,
+			expectedStatus: DeletionJobSucceeded,
+			expectedCounts: [3]int32{3, 2, 1},
+		},
+		{
+			name:           "failed object removal fails the job",
+			deleteObjects:  true,
+			removeError:    assert.AnError,
+			expectedStatus: DeletionJobFailed,
+			expectedCounts: [3]int32{3, 2, 0},
+			expectError:    true,
+		},
+		{
+			name:           "failed batch is retried",
+			batchError:     assert.AnError,
+			expectedStatus: DeletionJobFailed,
+			expectError:    true,
+		},
+		{
+			name:           "restored series is skipped",
+			seriesRestored: true,
+			expectedStatus: DeletionJobFailed,
+		},
+		{
+			name:           "series restored while the job runs is skipped",
+			restoredInJob:  true,
+			expectedStatus: DeletionJobFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockQueries := new(database.MockQuerier)
+			mockStore := &database.Store{Queries: mockQueries}
+			mockObjects := new(mockObjectStorage)
+
+			processor := NewDeleteSeriesContentTaskProcessor(mockStore, mockObjects)
+			processor.batchSize = 2
+
+			deletedAt := time.Now()
+			series := sqlc.Series{ID: uuid.New(), Title: "Deleted Series", DeletedAt: &deletedAt}
+			job := sqlc.DeletionJob{
+				ID:            uuid.New(),
+				SeriesID:      series.ID,
+				RequestedBy:   "admin@example.com",
+				DeleteObjects: tt.deleteObjects,
+				Status:        DeletionJobQueued,
+			}
+
+			episodes := []sqlc.Episode{
+				{ID: uuid.New(), SeriesID: series.ID, DeletedAt: &deletedAt},
+				{ID: uuid.New(), SeriesID: series.ID, DeletedAt: &deletedAt},
+				{ID: uuid.New(), SeriesID: series.ID, DeletedAt: &deletedAt},
+			}
+			assets := []sqlc.EpisodeAsset{
+				{ID: uuid.New(), EpisodeID: episodes[0].ID, AssetType: "video", Url: &uploadedKey, DeletedAt: &deletedAt},
+				{ID: uuid.New(), EpisodeID: episodes[2].ID, AssetType: "video", Url: &remoteURL, DeletedAt: &deletedAt},
+			}
+
+			mockQueries.On("GetDeletionJob", mock.Anything, job.ID).Return(job, nil)
+			mockQueries.On("StartDeletionJob", mock.Anything, job.ID).Return(nil)
+
+			switch {
+			case tt.seriesRestored:
+				mockQueries.On("GetDeletedSeries", mock.Anything, series.ID).Return(sqlc.Series{}, pgx.ErrNoRows)
+			case tt.restoredInJob:
+				// the batch locks the series again and finds it restored
+				mockQueries.On("GetDeletedSeries", mock.Anything, series.ID).Return(series, nil).Once()
+				mockQueries.On("GetDeletedSeries", mock.Anything, series.ID).Return(sqlc.Series{}, pgx.ErrNoRows).Once()
+			default:
+				mockQueries.On("GetDeletedSeries", mock.Anything, series.ID).Return(series, nil)
+			}
+
+			if tt.batchError != nil {
+				mockQueries.On("DeleteEpisodesBySeries", mock.Anything, mock.Anything).Return([]sqlc.Episode(nil), tt.batchError)
+			} else if !tt.seriesRestored && !tt.restoredInJob {
+				batchParams := sqlc.DeleteEpisodesBySeriesParams{DeletedAt: &deletedAt, SeriesID: series.ID, RowLimit: 2}
+				mockQueries.On("DeleteEpisodesBySeries", mock.Anything, batchParams).Return(episodes[:2], nil).Once()
+				mockQueries.On("DeleteEpisodesBySeries", mock.Anything, batchParams).Return(episodes[2:], nil).Once()
+				mockQueries.On("DeleteAssetsByEpisodes", mock.Anything, sqlc.DeleteAssetsByEpisodesParams{
+					DeletedAt:  &deletedAt,
+					EpisodeIds: []uuid.UUID{episodes[0].ID, episodes[1].ID},
+				}).Return(assets[:1], nil).Once()
+				mockQueries.On("DeleteAssetsByEpisodes", mock.Anything, sqlc.DeleteAssetsByEpisodesParams{
+					DeletedAt:  &deletedAt,
+					EpisodeIds: []uuid.UUID{episodes[2].ID},
+				}).Return(assets[1:], nil).Once()
+
+				mockQueries.On("CreateAuditEvent", mock.Anything, mock.MatchedBy(func(params sqlc.CreateAuditEventParams) bool {
+					return params.Action == audit.ActionDelete && params.EntityType == audit.EntityEpisode && params.Actor == job.RequestedBy
+				})).Return(nil).Times(3)
+				mockQueries.On("CreateAuditEvent", mock.Anything, mock.MatchedBy(func(params sqlc.CreateAuditEventParams) bool {
+					return params.Action == audit.ActionDelete && params.EntityType == audit.EntityAsset && params.Actor == job.RequestedBy
+				})).Return(nil).Times(2)
+				mockQueries.On("CreateOutboxEvent", mock.Anything, mock.MatchedBy(func(params sqlc.CreateOutboxEventParams) bool {
+					return params.TaskType == TypeDeleteEpisode
+				})).Return(nil).Times(3)
+
+				mockQueries.On("CountEpisodesDeletedWithSeries", mock.Anything, sqlc.CountEpisodesDeletedWithSeriesParams{
+					SeriesID:  series.ID,
+					DeletedAt: &deletedAt,
+				}).Return(int64(3), nil)
+				mockQueries.On("ListAssetsDeletedWithSeries", mock.Anything, sqlc.ListAssetsDeletedWithSeriesParams{
+					SeriesID:  series.ID,
+					DeletedAt: &deletedAt,
+				}).Return(assets, nil)
+
+				if tt.deleteObjects {
+					// the remote asset is not ours to remove
+					mockObjects.On("RemoveObject", mock.Anything, uploadedKey).Return(tt.removeError)
+				}
+			}
+
+			var finished sqlc.FinishDeletionJobParams
+			mockQueries.On("FinishDeletionJob", mock.Anything, mock.MatchedBy(func(params sqlc.FinishDeletionJobParams) bool {
+				return params.ID == job.ID
+			})).Run(func(args mock.Arguments) {
+				finished = args.Get(1).(sqlc.FinishDeletionJobParams)
+			}).Return(nil)
+
+			payload, _ := json.Marshal(DeleteSeriesContentPayload{JobID: job.ID.String()})
+			err := processor.ProcessTask(context.Background(), asynq.NewTask(TypeDeleteSeriesContent, payload))
+
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.expectedStatus, finished.Status)
+			assert.Equal(t, tt.expectedCounts, [3]int32{finished.EpisodeCount, finished.AssetCount, finished.ObjectCount})
+
+			var errs []DeletionError
+			require.NoError(t, json.Unmarshal(finished.Errors, &errs))
+			if tt.expectedStatus == DeletionJobSucceeded {
+				assert.Empty(t, errs)
+			} else {
+				assert.NotEmpty(t, errs)
+			}
+			if tt.removeError != nil {
+				require.Len(t, errs, 1)
+				assert.Equal(t, assets[0].ID.String(), errs[0].AssetID)
+				assert.Equal(t, uploadedKey, errs[0].Key)
+			}
+
+			mockQueries.AssertExpectations(t)
+			mockObjects.AssertExpectations(t)
+		})
+	}
+}
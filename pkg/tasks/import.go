@@ -4,21 +4,108 @@ import (
 	"context"
 	"encoding/json"
 	"log/slog"
+	"strings"
+	"th-application-technical-assignment/pkg/audit"
 	"th-application-technical-assignment/pkg/database"
 	"th-application-technical-assignment/pkg/importer"
+	"th-application-technical-assignment/pkg/publishing"
+	"th-application-technical-assignment/pkg/revisions"
 	"th-application-technical-assignment/sqlc"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/hibiken/asynq"
+	"github.com/jackc/pgx/v5"
 	"github.com/pkg/errors"
 )
 
+const (
+	DefaultImportBatchSize = 50
+	DefaultImportMaxPages  = 100
+)
+
+// Outcomes reported for every imported item.
+const (
+	ImportCreated   = "created"
+	ImportUpdated   = "updated"
+	ImportUnchanged = "unchanged"
+)
+
+// ImportResult counts the outcome of every item seen by an import run.
+// NotModified, ETag and LastModified report what the source answered for
+// the first page.
+type ImportResult struct {
+	Created   int
+	Updated   int
+	Unchanged int
+	Failed    int
+	Errors    []ImportItemError
+
+	NotModified  bool
+	ETag         string
+	LastModified string
+}
+
+// Skipped is the number of items that were neither created nor updated.
+func (r *ImportResult) Skipped() int {
+	return r.Unchanged + r.Failed
+}
+
+func (r *ImportResult) fail(externalID, title string, err error) {
+	r.Failed++
+	if len(r.Errors) < maxImportJobErrors {
+		r.Errors = append(r.Errors, ImportItemError{ExternalID: externalID, Title: title, Error: err.Error()})
+	}
+}
+
+// abort records an error that stopped the whole run. When the errors are
+// capped already it takes the last slot, the reason the run failed matters
+// more than one more item.
+func (r *ImportResult) abort(err error) {
+	e := ImportItemError{Error: err.Error()}
+	if len(r.Errors) < maxImportJobErrors {
+		r.Errors = append(r.Errors, e)
+		return
+	}
+	r.Errors[len(r.Errors)-1] = e
+}
+
+func (r *ImportResult) add(outcome string) {
+	switch outcome {
+	case ImportCreated:
+		r.Created++
+	case ImportUpdated:
+		r.Updated++
+	case ImportUnchanged:
+		r.Unchanged++
+	}
+}
+
 type ImportEpisodeTaskProcessor struct {
-	store *database.Store
-	queue TaskQueue
+	store     *database.Store
+	queue     TaskQueue
+	batchSize int
+	maxPages  int
 }
 
-func NewImportEpisodeTaskProcessor(store *database.Store, queue TaskQueue) *ImportEpisodeTaskProcessor {
-	return &ImportEpisodeTaskProcessor{store, queue}
+func NewImportEpisodeTaskProcessor(store *database.Store, queue TaskQueue, cfg *ImportConfig) *ImportEpisodeTaskProcessor {
+	p := &ImportEpisodeTaskProcessor{
+		store:     store,
+		queue:     queue,
+		batchSize: DefaultImportBatchSize,
+		maxPages:  DefaultImportMaxPages,
+	}
+
+	if cfg != nil {
+		if cfg.BatchSize > 0 {
+			p.batchSize = cfg.BatchSize
+		}
+		if cfg.MaxPages > 0 {
+			p.maxPages = cfg.MaxPages
+		}
+	}
+
+	return p
 }
 
 func (p *ImportEpisodeTaskProcessor) ProcessTask(ctx context.Context, t *asynq.Task) error {
@@ -27,44 +114,353 @@ func (p *ImportEpisodeTaskProcessor) ProcessTask(ctx context.Context, t *asynq.T
 		return errors.Wrap(err, "failed to unmarshal payload")
 	}
 
+	// tasks enqueued without a job are imported untracked
+	jobID, _ := uuid.Parse(payload.JobID)
+	p.startJob(ctx, jobID)
+
+	var result ImportResult
+	if err := p.runImport(ctx, payload, jobID, &result); err != nil {
+		result.abort(err)
+		p.finishJob(ctx, jobID, ImportJobFailed, &result)
+		return err
+	}
+	p.finishJob(ctx, jobID, ImportJobSucceeded, &result)
+
+	if payload.Subscription {
+		p.recordSync(ctx, payload.SeriesID, &result)
+	}
+
+	slog.InfoContext(ctx, "imported episodes",
+		"job_id", payload.JobID,
+		"series_id", payload.SeriesID,
+		"source_type", payload.SourceType,
+		"created", result.Created,
+		"updated", result.Updated,
+		"unchanged", result.Unchanged,
+		"failed", result.Failed,
+		"not_modified", result.NotModified,
+	)
+	return nil
+}
+
+func (p *ImportEpisodeTaskProcessor) runImport(ctx context.Context, payload ImportContentPayload, jobID uuid.UUID, result *ImportResult) error {
 	var i importer.Importer
 	i, err := importer.GetImporter(payload.SourceType)
 	if err != nil {
 		return errors.Wrap(err, "unsupported import source")
 	}
 
-	ep, asset, err := i.FetchEpisode(ctx, payload.SourceURL, payload.SeriesID)
-	if err != nil {
-		return errors.Wrap(err, "failed to fetch episodes")
+	req := importer.FetchRequest{
+		URL:          payload.SourceURL,
+		SeriesID:     payload.SeriesID,
+		ETag:         payload.ETag,
+		LastModified: payload.LastModified,
 	}
 
-	params := sqlc.CreateEpisodeParams{
-		SeriesID: ep.SeriesID,
-		Title:    ep.Title,
+	seen := map[string]bool{}
+	for pages := 0; pages < p.maxPages; pages++ {
+		page, err := i.FetchPage(ctx, req)
+		if err != nil {
+			return errors.Wrap(err, "failed to fetch episodes")
+		}
+
+		if pages == 0 {
+			result.NotModified = page.NotModified
+			result.ETag = page.ETag
+			result.LastModified = page.LastModified
+			if page.NotModified {
+				return nil
+			}
+		}
+
+		for start := 0; start < len(page.Items); start += p.batchSize {
+			end := min(start+p.batchSize, len(page.Items))
+			p.importBatch(ctx, payload.SourceType, page.Items[start:end], result)
+			p.saveProgress(ctx, jobID, result)
+		}
+
+		if page.NextCursor == "" || seen[page.NextCursor] {
+			break
+		}
+		seen[page.NextCursor] = true
+		req.Cursor = page.NextCursor
+	}
+
+	return nil
+}
+
+// importBatch persists a batch of items. Each item is stored in its own
+// transaction together with the outbox event that indexes it, so an item is
+// never stored without being indexed. Items that fail are recorded on the
+// result and do not stop the rest of the batch.
+func (p *ImportEpisodeTaskProcessor) importBatch(ctx context.Context, sourceType string, items []importer.Item, result *ImportResult) {
+	for _, item := range items {
+		var externalID string
+		if item.Episode.ExternalID != nil {
+			externalID = *item.Episode.ExternalID
+		}
+
+		var episode sqlc.Episode
+		var outcome string
+		err := p.store.WithTx(ctx, func(q sqlc.Querier) error {
+			var assets []sqlc.EpisodeAsset
+			var err error
+			episode, assets, outcome, err = p.importItem(ctx, q, sourceType, item)
+			if err != nil || outcome == ImportUnchanged {
+				return err
+			}
+			return NewOutboxQueue(q).EnqueueIndexEpisode(ctx, episode, assets)
+		})
+		if err != nil {
+			slog.WarnContext(ctx, "failed to import item", "err", err, "external_id", externalID, "title", item.Episode.Title)
+			result.fail(externalID, item.Episode.Title, err)
+			continue
+		}
+
+		result.add(outcome)
+		slog.DebugContext(ctx, "imported item", "episode_id", episode.ID, "external_id", externalID, "outcome", outcome)
 	}
+}
 
-	episode, err := p.store.Queries.CreateEpisode(ctx, params)
+// importItem stores a single item with q. Items with an external ID are
+// upserted on their source identity so retries and repeated imports of the
+// same source update the existing episode instead of duplicating it. It runs
+// in a transaction per item, so an episode is never left without some of its
+// assets.
+func (p *ImportEpisodeTaskProcessor) importItem(ctx context.Context, q sqlc.Querier, sourceType string, item importer.Item) (sqlc.Episode, []sqlc.EpisodeAsset, string, error) {
+	ep := item.Episode
+
+	// only used when the episode is inserted, an existing one keeps its slug
+	// and status. Imported episodes go live on their publish date.
+	status := publishing.PublishTarget(ep.PublishDate, time.Now())
+
+	if ep.ExternalID == nil {
+		slug, err := database.EpisodeSlug(ctx, q, ep.SeriesID, ep.Title, "")
+		if err != nil {
+			return sqlc.Episode{}, nil, "", err
+		}
+
+		params := sqlc.CreateEpisodeParams{
+			SeriesID:        ep.SeriesID,
+			Title:           ep.Title,
+			Description:     ep.Description,
+			DurationSeconds: ep.DurationSeconds,
+			PublishDate:     ep.PublishDate,
+			Slug:            slug,
+			Status:          status,
+		}
+
+		episode, err := q.CreateEpisode(ctx, params)
+		if err != nil {
+			return sqlc.Episode{}, nil, "", errors.Wrap(err, "failed to create episode")
+		}
+		if err := recordEpisodeRevision(ctx, q, episode.ID); err != nil {
+			return sqlc.Episode{}, nil, "", err
+		}
+
+		assets, err := p.createAssets(ctx, q, episode.ID, item.Assets)
+		return episode, assets, ImportCreated, err
+	}
+
+	// most items of a re-import exist already, their slug is not derived again
+	existing, err := q.GetEpisodeBySource(ctx, sqlc.GetEpisodeBySourceParams{
+		SeriesID:   ep.SeriesID,
+		SourceType: &sourceType,
+		ExternalID: ep.ExternalID,
+	})
+	found := err == nil
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return sqlc.Episode{}, nil, "", errors.Wrap(err, "failed to get episode")
+	}
+
+	slug := existing.Slug
+	if !found {
+		slug, err = database.EpisodeSlug(ctx, q, ep.SeriesID, ep.Title, "")
+		if err != nil {
+			return sqlc.Episode{}, nil, "", err
+		}
+	}
+
+	params := sqlc.UpsertImportedEpisodeParams{
+		SeriesID:        ep.SeriesID,
+		Title:           ep.Title,
+		Description:     ep.Description,
+		DurationSeconds: ep.DurationSeconds,
+		PublishDate:     ep.PublishDate,
+		SourceType:      &sourceType,
+		ExternalID:      ep.ExternalID,
+		Slug:            slug,
+		Status:          status,
+	}
+
+	var episode sqlc.Episode
+	var outcome string
+
+	row, err := q.UpsertImportedEpisode(ctx, params)
+	switch {
+	case err == nil && row.Inserted:
+		episode = episodeFromUpsert(row)
+		if err := recordEpisodeRevision(ctx, q, episode.ID); err != nil {
+			return sqlc.Episode{}, nil, "", err
+		}
+		assets, err := p.createAssets(ctx, q, episode.ID, item.Assets)
+		return episode, assets, ImportCreated, err
+	case err == nil:
+		episode = episodeFromUpsert(row)
+		outcome = ImportUpdated
+		if err := recordEpisodeRevision(ctx, q, episode.ID); err != nil {
+			return sqlc.Episode{}, nil, "", err
+		}
+	case errors.Is(err, pgx.ErrNoRows):
+		if !found {
+			// deleted in the CMS, a re-import must not bring it back
+			return sqlc.Episode{}, nil, ImportUnchanged, nil
+		}
+		episode = existing
+		outcome = ImportUnchanged
+	default:
+		return sqlc.Episode{}, nil, "", errors.Wrap(err, "failed to upsert episode")
+	}
+
+	assets, changed, err := p.syncAssets(ctx, q, episode.ID, item.Assets)
 	if err != nil {
-		return errors.Wrap(err, "failed to create episode")
+		return sqlc.Episode{}, nil, "", err
 	}
+	if changed {
+		outcome = ImportUpdated
+	}
+
+	return episode, assets, outcome, nil
+}
 
-	asset.EpisodeID = episode.ID
-	assetParams := sqlc.CreateAssetParams{
-		EpisodeID: asset.EpisodeID,
-		AssetType: asset.AssetType,
-		MimeType:  asset.MimeType,
-		Url:       asset.Url,
+func (p *ImportEpisodeTaskProcessor) createAssets(ctx context.Context, q sqlc.Querier, episodeID uuid.UUID, assets []sqlc.EpisodeAsset) ([]sqlc.EpisodeAsset, error) {
+	created := make([]sqlc.EpisodeAsset, 0, len(assets))
+	for _, asset := range assets {
+		dbAsset, err := q.CreateAsset(ctx, sqlc.CreateAssetParams{
+			EpisodeID: episodeID,
+			AssetType: asset.AssetType,
+			MimeType:  asset.MimeType,
+			SizeBytes: asset.SizeBytes,
+			Url:       asset.Url,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create asset")
+		}
+		created = append(created, dbAsset)
 	}
+	return created, nil
+}
 
-	dbAsset, err := p.store.Queries.CreateAsset(ctx, assetParams)
+// syncAssets makes the imported assets of an existing episode match the
+// source. Assets uploaded through the CMS are stored under an object key
+// rather than a remote URL and are left alone.
+func (p *ImportEpisodeTaskProcessor) syncAssets(ctx context.Context, q sqlc.Querier, episodeID uuid.UUID, wanted []sqlc.EpisodeAsset) ([]sqlc.EpisodeAsset, bool, error) {
+	existing, err := q.ListAssetsByEpisode(ctx, episodeID)
 	if err != nil {
-		return errors.Wrap(err, "failed to create asset")
+		return nil, false, errors.Wrap(err, "failed to list assets")
 	}
 
-	if err := p.queue.EnqueueIndexEpisode(ctx, episode, []sqlc.EpisodeAsset{dbAsset}); err != nil {
-		return errors.Wrap(err, "failed to enqueue index episode task")
+	assets := make([]sqlc.EpisodeAsset, 0, len(existing)+len(wanted))
+	current := map[string]sqlc.EpisodeAsset{}
+	for _, a := range existing {
+		if !isRemoteAsset(a) {
+			assets = append(assets, a)
+			continue
+		}
+		current[assetKey(a)] = a
 	}
 
-	slog.InfoContext(ctx, "imported episode", "episode_id", episode.ID.String())
-	return nil
+	changed := false
+	seen := map[string]bool{}
+	for _, w := range wanted {
+		key := assetKey(w)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		a, ok := current[key]
+		delete(current, key)
+
+		switch {
+		case !ok:
+			created, err := p.createAssets(ctx, q, episodeID, []sqlc.EpisodeAsset{w})
+			if err != nil {
+				return nil, false, err
+			}
+			a = created[0]
+			changed = true
+		case a.MimeType != w.MimeType || !sameSize(a.SizeBytes, w.SizeBytes):
+			a, err = q.UpdateAsset(ctx, sqlc.UpdateAssetParams{
+				ID:        a.ID,
+				MimeType:  w.MimeType,
+				SizeBytes: w.SizeBytes,
+				Url:       a.Url,
+				Storage:   a.Storage,
+			})
+			if err != nil {
+				return nil, false, errors.Wrap(err, "failed to update asset")
+			}
+			changed = true
+		}
+
+		assets = append(assets, a)
+	}
+
+	for _, stale := range current {
+		if err := q.DeleteAsset(ctx, stale.ID); err != nil {
+			return nil, false, errors.Wrap(err, "failed to delete asset")
+		}
+		changed = true
+	}
+
+	return assets, changed, nil
+}
+
+// recordEpisodeRevision stores an episode the importer or the scheduler
+// wrote as its next revision, authored by the system actor.
+func recordEpisodeRevision(ctx context.Context, q sqlc.Querier, episodeID uuid.UUID) error {
+	_, err := revisions.Record(ctx, q, revisions.Revision{
+		Author:     audit.SystemActor,
+		EntityType: audit.EntityEpisode,
+		EntityID:   episodeID,
+	})
+	return err
+}
+
+func episodeFromUpsert(row sqlc.UpsertImportedEpisodeRow) sqlc.Episode {
+	return sqlc.Episode{
+		ID:              row.ID,
+		SeriesID:        row.SeriesID,
+		Title:           row.Title,
+		Description:     row.Description,
+		DurationSeconds: row.DurationSeconds,
+		PublishDate:     row.PublishDate,
+		CreatedAt:       row.CreatedAt,
+		UpdatedAt:       row.UpdatedAt,
+		DeletedAt:       row.DeletedAt,
+		SourceType:      row.SourceType,
+		ExternalID:      row.ExternalID,
+		Slug:            row.Slug,
+		PreviousSlugs:   row.PreviousSlugs,
+		Status:          row.Status,
+	}
+}
+
+func isRemoteAsset(a sqlc.EpisodeAsset) bool {
+	return a.Url != nil && (strings.HasPrefix(*a.Url, "http://") || strings.HasPrefix(*a.Url, "https://"))
+}
+
+func assetKey(a sqlc.EpisodeAsset) string {
+	if a.Url == nil {
+		return a.AssetType
+	}
+	return a.AssetType + " " + *a.Url
+}
+
+func sameSize(a, b *int64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
 }
@@ -0,0 +1,92 @@
+package tasks
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"th-application-technical-assignment/sqlc"
+
+	"github.com/google/uuid"
+)
+
+// Import job states, stored in import_jobs.status.
+const (
+	ImportJobQueued    = "queued"
+	ImportJobRunning   = "running"
+	ImportJobSucceeded = "succeeded"
+	ImportJobFailed    = "failed"
+)
+
+// maxImportJobErrors caps the errors stored on a job so a broken source
+// cannot grow the row without bound. ImportResult.Failed still counts every
+// failed item.
+const maxImportJobErrors = 100
+
+// ImportItemError describes an item that could not be imported. Errors that
+// abort the whole job are recorded without an external ID or title.
+type ImportItemError struct {
+	ExternalID string `json:"external_id,omitempty"`
+	Title      string `json:"title,omitempty"`
+	Error      string `json:"error"`
+}
+
+// startJob marks the job running. Job bookkeeping is best effort: a failure
+// to record progress is logged and never fails the import itself.
+func (p *ImportEpisodeTaskProcessor) startJob(ctx context.Context, jobID uuid.UUID) {
+	if jobID == uuid.Nil {
+		return
+	}
+
+	if err := p.store.Queries.StartImportJob(ctx, jobID); err != nil {
+		slog.WarnContext(ctx, "failed to mark import job running", "err", err, "job_id", jobID)
+	}
+}
+
+func (p *ImportEpisodeTaskProcessor) saveProgress(ctx context.Context, jobID uuid.UUID, result *ImportResult) {
+	if jobID == uuid.Nil {
+		return
+	}
+
+	params := sqlc.UpdateImportJobProgressParams{
+		ID:           jobID,
+		CreatedCount: int32(result.Created),
+		UpdatedCount: int32(result.Updated),
+		SkippedCount: int32(result.Skipped()),
+		Errors:       importErrorsJSON(result.Errors),
+	}
+
+	if err := p.store.Queries.UpdateImportJobProgress(ctx, params); err != nil {
+		slog.WarnContext(ctx, "failed to update import job progress", "err", err, "job_id", jobID)
+	}
+}
+
+func (p *ImportEpisodeTaskProcessor) finishJob(ctx context.Context, jobID uuid.UUID, status string, result *ImportResult) {
+	if jobID == uuid.Nil {
+		return
+	}
+
+	params := sqlc.FinishImportJobParams{
+		ID:           jobID,
+		Status:       status,
+		CreatedCount: int32(result.Created),
+		UpdatedCount: int32(result.Updated),
+		SkippedCount: int32(result.Skipped()),
+		Errors:       importErrorsJSON(result.Errors),
+	}
+
+	if err := p.store.Queries.FinishImportJob(ctx, params); err != nil {
+		slog.WarnContext(ctx, "failed to finish import job", "err", err, "job_id", jobID, "status", status)
+	}
+}
+
+func importErrorsJSON(errs []ImportItemError) []byte {
+	if errs == nil {
+		errs = []ImportItemError{}
+	}
+
+	data, err := json.Marshal(errs)
+	if err != nil {
+		return []byte("[]")
+	}
+	return data
+}
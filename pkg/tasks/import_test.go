@@ -3,14 +3,22 @@ package tasks
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"th-application-technical-assignment/pkg/audit"
 	"th-application-technical-assignment/pkg/database"
+	"th-application-technical-assignment/pkg/publishing"
 	"th-application-technical-assignment/sqlc"
 
 	"github.com/google/uuid"
 	"github.com/hibiken/asynq"
+	"github.com/jackc/pgx/v5"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestImportEpisodeTaskProcessor_ProcessTask(t *testing.T) {
@@ -22,7 +30,7 @@ func TestImportEpisodeTaskProcessor_ProcessTask(t *testing.T) {
 		importerError    error
 		createEpError    error
 		createAssetError error
-		enqueueError     error
+		outboxError      error
 		expectError      bool
 	}{
 		{
@@ -35,34 +43,34 @@ func TestImportEpisodeTaskProcessor_ProcessTask(t *testing.T) {
 			expectError: false,
 		},
 		{
-			name: "database create episode fails",
+			name: "database create episode fails is recorded as item error",
 			payload: ImportContentPayload{
 				SourceType: "youtube",
 				SourceURL:  "https://youtube.com/watch?v=test123",
 				SeriesID:   uuid.New().String(),
 			},
 			createEpError: assert.AnError,
-			expectError:   true,
+			expectError:   false,
 		},
 		{
-			name: "database create asset fails",
+			name: "database create asset fails is recorded as item error",
 			payload: ImportContentPayload{
 				SourceType: "youtube",
 				SourceURL:  "https://youtube.com/watch?v=test123",
 				SeriesID:   uuid.New().String(),
 			},
 			createAssetError: assert.AnError,
-			expectError:      true,
+			expectError:      false,
 		},
 		{
-			name: "enqueue index task fails",
+			name: "outbox write fails is recorded as item error",
 			payload: ImportContentPayload{
 				SourceType: "youtube",
 				SourceURL:  "https://youtube.com/watch?v=test123",
 				SeriesID:   uuid.New().String(),
 			},
-			enqueueError: assert.AnError,
-			expectError:  true,
+			outboxError: assert.AnError,
+			expectError: false,
 		},
 	}
 
@@ -74,7 +82,7 @@ func TestImportEpisodeTaskProcessor_ProcessTask(t *testing.T) {
 			mockStore := &database.Store{Queries: mockQueries}
 			mockQueue := new(MockQueue)
 
-			processor := NewImportEpisodeTaskProcessor(mockStore, mockQueue)
+			processor := NewImportEpisodeTaskProcessor(mockStore, mockQueue, nil)
 
 			payloadJSON, _ := json.Marshal(tt.payload)
 			task := asynq.NewTask(TypeImportContent, payloadJSON)
@@ -96,16 +104,36 @@ func TestImportEpisodeTaskProcessor_ProcessTask(t *testing.T) {
 			}
 
 			if tt.importerError == nil {
+				sourceType := "youtube"
+				externalID := "test123"
 				createdEpisode := sqlc.Episode{
-					ID:       episodeID,
-					SeriesID: seriesUUID,
-					Title:    expectedEpisode.Title,
+					ID:         episodeID,
+					SeriesID:   seriesUUID,
+					Title:      expectedEpisode.Title,
+					SourceType: &sourceType,
+					ExternalID: &externalID,
 				}
-				mockQueries.On("CreateEpisode", mock.Anything, mock.MatchedBy(func(params sqlc.CreateEpisodeParams) bool {
-					return params.SeriesID == seriesUUID && params.Title == "YouTube Import"
-				})).Return(createdEpisode, tt.createEpError)
+				mockQueries.On("GetEpisodeBySource", mock.Anything, mock.Anything).Return(sqlc.Episode{}, pgx.ErrNoRows)
+				mockQueries.On("ListEpisodeSlugs", mock.Anything, mock.Anything).Return([]string{}, nil)
+				mockQueries.On("UpsertImportedEpisode", mock.Anything, mock.MatchedBy(func(params sqlc.UpsertImportedEpisodeParams) bool {
+					return params.SeriesID == seriesUUID && params.Title == "YouTube Import" &&
+						*params.SourceType == sourceType && *params.ExternalID == externalID &&
+						params.Slug == "youtube-import" && params.Status == publishing.StatusPublished
+				})).Return(sqlc.UpsertImportedEpisodeRow{
+					ID:         episodeID,
+					SeriesID:   seriesUUID,
+					Title:      expectedEpisode.Title,
+					SourceType: &sourceType,
+					ExternalID: &externalID,
+					Inserted:   true,
+				}, tt.createEpError)
 
 				if tt.createEpError == nil {
+					mockQueries.On("CreateEpisodeRevision", mock.Anything, sqlc.CreateEpisodeRevisionParams{
+						Author: audit.SystemActor,
+						ID:     episodeID,
+					}).Return(sqlc.ContentRevision{EntityID: episodeID, Revision: 1}, nil)
+
 					createdAsset := sqlc.EpisodeAsset{
 						ID:        assetID,
 						EpisodeID: episodeID,
@@ -118,7 +146,10 @@ func TestImportEpisodeTaskProcessor_ProcessTask(t *testing.T) {
 					})).Return(createdAsset, tt.createAssetError)
 
 					if tt.createAssetError == nil {
-						mockQueue.On("EnqueueIndexEpisode", mock.Anything, createdEpisode, []sqlc.EpisodeAsset{createdAsset}).Return(tt.enqueueError)
+						mockQueries.On("ListSeriesLanguages", mock.Anything, mock.Anything).Return([]sqlc.ListSeriesLanguagesRow{}, nil)
+						mockQueries.On("ListEpisodeTranslations", mock.Anything, mock.Anything).Return([]sqlc.EpisodeTranslation{}, nil)
+						mockQueries.On("CreateOutboxEvent", mock.Anything, indexEpisodeEvent(createdEpisode, createdAsset)).
+							Return(tt.outboxError)
 					}
 				}
 			}
@@ -136,3 +167,328 @@ func TestImportEpisodeTaskProcessor_ProcessTask(t *testing.T) {
 		})
 	}
 }
+
+func TestImportEpisodeTaskProcessor_ProcessTask_Reimport(t *testing.T) {
+	t.Parallel()
+
+	sourceURL := "https://youtube.com/watch?v=test123"
+	sourceType := "youtube"
+	externalID := "test123"
+
+	tests := []struct {
+		name        string
+		setup       func(m *database.MockQuerier, q *MockQueue, episode sqlc.Episode, asset sqlc.EpisodeAsset)
+		expectError bool
+	}{
+		{
+			name: "unchanged episode is not re-indexed",
+			setup: func(m *database.MockQuerier, q *MockQueue, episode sqlc.Episode, asset sqlc.EpisodeAsset) {
+				m.On("GetEpisodeBySource", mock.Anything, sqlc.GetEpisodeBySourceParams{
+					SeriesID:   episode.SeriesID,
+					SourceType: &sourceType,
+					ExternalID: &externalID,
+				}).Return(episode, nil)
+				m.On("UpsertImportedEpisode", mock.Anything, mock.MatchedBy(func(params sqlc.UpsertImportedEpisodeParams) bool {
+					// the slug of an existing episode is not derived again
+					return params.Slug == episode.Slug
+				})).Return(sqlc.UpsertImportedEpisodeRow{}, pgx.ErrNoRows)
+				m.On("ListAssetsByEpisode", mock.Anything, episode.ID).Return([]sqlc.EpisodeAsset{asset}, nil)
+			},
+		},
+		{
+			name: "changed metadata updates and re-indexes episode",
+			setup: func(m *database.MockQuerier, q *MockQueue, episode sqlc.Episode, asset sqlc.EpisodeAsset) {
+				m.On("GetEpisodeBySource", mock.Anything, mock.Anything).Return(episode, nil)
+				m.On("UpsertImportedEpisode", mock.Anything, mock.Anything).Return(sqlc.UpsertImportedEpisodeRow{
+					ID:         episode.ID,
+					SeriesID:   episode.SeriesID,
+					Title:      episode.Title,
+					Slug:       episode.Slug,
+					SourceType: &sourceType,
+					ExternalID: &externalID,
+					Inserted:   false,
+				}, nil)
+				m.On("CreateEpisodeRevision", mock.Anything, sqlc.CreateEpisodeRevisionParams{
+					Author: audit.SystemActor,
+					ID:     episode.ID,
+				}).Return(sqlc.ContentRevision{EntityID: episode.ID, Revision: 2}, nil)
+				m.On("ListAssetsByEpisode", mock.Anything, episode.ID).Return([]sqlc.EpisodeAsset{asset}, nil)
+				m.On("ListSeriesLanguages", mock.Anything, mock.Anything).Return([]sqlc.ListSeriesLanguagesRow{}, nil)
+				m.On("ListEpisodeTranslations", mock.Anything, mock.Anything).Return([]sqlc.EpisodeTranslation{}, nil)
+				m.On("CreateOutboxEvent", mock.Anything, indexEpisodeEvent(episode, asset)).Return(nil)
+			},
+		},
+		{
+			name: "changed assets are replaced",
+			setup: func(m *database.MockQuerier, q *MockQueue, episode sqlc.Episode, asset sqlc.EpisodeAsset) {
+				staleURL := "https://youtube.com/watch?v=old"
+				stale := sqlc.EpisodeAsset{ID: uuid.New(), EpisodeID: episode.ID, AssetType: "video", MimeType: "video/mp4", Url: &staleURL}
+				uploadKey := "series/episode/upload.mp3"
+				uploaded := sqlc.EpisodeAsset{ID: uuid.New(), EpisodeID: episode.ID, AssetType: "audio", MimeType: "audio/mpeg", Url: &uploadKey}
+
+				m.On("GetEpisodeBySource", mock.Anything, mock.Anything).Return(episode, nil)
+				m.On("UpsertImportedEpisode", mock.Anything, mock.Anything).Return(sqlc.UpsertImportedEpisodeRow{}, pgx.ErrNoRows)
+				m.On("ListAssetsByEpisode", mock.Anything, episode.ID).Return([]sqlc.EpisodeAsset{stale, uploaded}, nil)
+				m.On("CreateAsset", mock.Anything, mock.MatchedBy(func(params sqlc.CreateAssetParams) bool {
+					return params.EpisodeID == episode.ID && *params.Url == sourceURL
+				})).Return(asset, nil)
+				m.On("DeleteAsset", mock.Anything, stale.ID).Return(nil)
+				m.On("ListSeriesLanguages", mock.Anything, mock.Anything).Return([]sqlc.ListSeriesLanguagesRow{}, nil)
+				m.On("ListEpisodeTranslations", mock.Anything, mock.Anything).Return([]sqlc.EpisodeTranslation{}, nil)
+				m.On("CreateOutboxEvent", mock.Anything, indexEpisodeEvent(episode, uploaded, asset)).Return(nil)
+			},
+		},
+		{
+			name: "deleted episode is not recreated",
+			setup: func(m *database.MockQuerier, q *MockQueue, episode sqlc.Episode, asset sqlc.EpisodeAsset) {
+				m.On("GetEpisodeBySource", mock.Anything, mock.Anything).Return(sqlc.Episode{}, pgx.ErrNoRows)
+				m.On("ListEpisodeSlugs", mock.Anything, mock.Anything).Return([]string{}, nil)
+				m.On("UpsertImportedEpisode", mock.Anything, mock.Anything).Return(sqlc.UpsertImportedEpisodeRow{}, pgx.ErrNoRows)
+			},
+		},
+		{
+			name: "upsert fails",
+			setup: func(m *database.MockQuerier, q *MockQueue, episode sqlc.Episode, asset sqlc.EpisodeAsset) {
+				m.On("GetEpisodeBySource", mock.Anything, mock.Anything).Return(episode, nil)
+				m.On("UpsertImportedEpisode", mock.Anything, mock.Anything).Return(sqlc.UpsertImportedEpisodeRow{}, assert.AnError)
+			},
+		},
+		{
+			name: "lookup fails",
+			setup: func(m *database.MockQuerier, q *MockQueue, episode sqlc.Episode, asset sqlc.EpisodeAsset) {
+				m.On("GetEpisodeBySource", mock.Anything, mock.Anything).Return(sqlc.Episode{}, assert.AnError)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockQueries := new(database.MockQuerier)
+			mockStore := &database.Store{Queries: mockQueries}
+			mockQueue := new(MockQueue)
+
+			processor := NewImportEpisodeTaskProcessor(mockStore, mockQueue, nil)
+
+			seriesID := uuid.New()
+			episode := sqlc.Episode{
+				ID:         uuid.New(),
+				SeriesID:   seriesID,
+				Title:      "YouTube Import",
+				Slug:       "youtube-import-2",
+				SourceType: &sourceType,
+				ExternalID: &externalID,
+			}
+			asset := sqlc.EpisodeAsset{
+				ID:        uuid.New(),
+				EpisodeID: episode.ID,
+				AssetType: "video",
+				MimeType:  "video/mp4",
+				Url:       &sourceURL,
+			}
+			tt.setup(mockQueries, mockQueue, episode, asset)
+
+			payloadJSON, _ := json.Marshal(ImportContentPayload{SourceType: sourceType, SourceURL: sourceURL, SeriesID: seriesID.String()})
+			err := processor.ProcessTask(context.Background(), asynq.NewTask(TypeImportContent, payloadJSON))
+
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			mockQueries.AssertExpectations(t)
+			mockQueue.AssertExpectations(t)
+		})
+	}
+}
+
+func TestImportEpisodeTaskProcessor_ProcessTask_TracksJob(t *testing.T) {
+	t.Parallel()
+
+	sourceURL := "https://youtube.com/watch?v=test123"
+
+	tests := []struct {
+		name           string
+		upsertError    error
+		outboxError    error
+		expectError    bool
+		expectedStatus string
+		expectedCounts [3]int32
+		expectedErrors string
+	}{
+		{
+			name:           "succeeded",
+			expectedStatus: ImportJobSucceeded,
+			expectedCounts: [3]int32{1, 0, 0},
+			expectedErrors: `[]`,
+		},
+		{
+			name:           "item error is recorded and job succeeds",
+			upsertError:    errors.New("boom"),
+			expectedStatus: ImportJobSucceeded,
+			expectedCounts: [3]int32{0, 0, 1},
+			expectedErrors: `[{"external_id":"test123","title":"YouTube Import","error":"failed to upsert episode: boom"}]`,
+		},
+		{
+			name:           "outbox error rolls back the item",
+			outboxError:    errors.New("db down"),
+			expectedStatus: ImportJobSucceeded,
+			expectedCounts: [3]int32{0, 0, 1},
+			expectedErrors: `[{"external_id":"test123","title":"YouTube Import","error":"failed to write outbox event: db down"}]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockQueries := new(database.MockQuerier)
+			mockStore := &database.Store{Queries: mockQueries}
+			mockQueue := new(MockQueue)
+
+			processor := NewImportEpisodeTaskProcessor(mockStore, mockQueue, nil)
+
+			jobID := uuid.New()
+			seriesID := uuid.New()
+			episodeID := uuid.New()
+
+			mockQueries.On("StartImportJob", mock.Anything, jobID).Return(nil)
+			mockQueries.On("GetEpisodeBySource", mock.Anything, mock.Anything).Return(sqlc.Episode{}, pgx.ErrNoRows)
+			mockQueries.On("ListEpisodeSlugs", mock.Anything, mock.Anything).Return([]string{}, nil)
+			mockQueries.On("UpsertImportedEpisode", mock.Anything, mock.Anything).
+				Return(sqlc.UpsertImportedEpisodeRow{ID: episodeID, SeriesID: seriesID, Inserted: true}, tt.upsertError)
+			if tt.upsertError == nil {
+				mockQueries.On("CreateEpisodeRevision", mock.Anything, mock.Anything).Return(sqlc.ContentRevision{}, nil)
+				mockQueries.On("ListSeriesLanguages", mock.Anything, mock.Anything).Return([]sqlc.ListSeriesLanguagesRow{}, nil)
+				mockQueries.On("ListEpisodeTranslations", mock.Anything, mock.Anything).Return([]sqlc.EpisodeTranslation{}, nil)
+				mockQueries.On("CreateAsset", mock.Anything, mock.Anything).Return(sqlc.EpisodeAsset{ID: uuid.New(), EpisodeID: episodeID}, nil)
+				mockQueries.On("CreateOutboxEvent", mock.Anything, mock.Anything).Return(tt.outboxError)
+			}
+			mockQueries.On("UpdateImportJobProgress", mock.Anything, mock.MatchedBy(func(params sqlc.UpdateImportJobProgressParams) bool {
+				return params.ID == jobID
+			})).Return(nil)
+			mockQueries.On("FinishImportJob", mock.Anything, mock.MatchedBy(func(params sqlc.FinishImportJobParams) bool {
+				return params.ID == jobID &&
+					params.Status == tt.expectedStatus &&
+					[3]int32{params.CreatedCount, params.UpdatedCount, params.SkippedCount} == tt.expectedCounts &&
+					string(params.Errors) == tt.expectedErrors
+			})).Return(nil)
+
+			payloadJSON, _ := json.Marshal(ImportContentPayload{
+				JobID:      jobID.String(),
+				SourceType: "youtube",
+				SourceURL:  sourceURL,
+				SeriesID:   seriesID.String(),
+			})
+			err := processor.ProcessTask(context.Background(), asynq.NewTask(TypeImportContent, payloadJSON))
+
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			mockQueries.AssertExpectations(t)
+			mockQueue.AssertExpectations(t)
+		})
+	}
+}
+
+func TestImportResult_Abort(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		failed        int
+		expectedCount int
+	}{
+		{
+			name:          "appends the error",
+			failed:        3,
+			expectedCount: 4,
+		},
+		{
+			name:          "replaces the last error when capped",
+			failed:        maxImportJobErrors + 5,
+			expectedCount: maxImportJobErrors,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var result ImportResult
+			for i := 0; i < tt.failed; i++ {
+				result.fail("item", "Item", errors.New("boom"))
+			}
+			result.abort(errors.New("source unavailable"))
+
+			require.Len(t, result.Errors, tt.expectedCount)
+			assert.Equal(t, ImportItemError{Error: "source unavailable"}, result.Errors[len(result.Errors)-1])
+			assert.Equal(t, tt.failed, result.Failed)
+		})
+	}
+}
+
+func TestImportEpisodeTaskProcessor_ProcessTask_PagedFeed(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/feed", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "2" {
+			fmt.Fprint(w, `<rss><channel>
+				<item><title>Episode 2</title></item>
+				<item><title>Episode 1</title></item>
+				<item><title>Trailer</title></item>
+			</channel></rss>`)
+			return
+		}
+		fmt.Fprint(w, `<rss xmlns:atom="http://www.w3.org/2005/Atom"><channel>
+			<atom:link rel="next" href="/feed?page=2"/>
+			<item><title>Episode 5</title></item>
+			<item><title>Episode 4</title></item>
+			<item><title>Episode 3</title></item>
+		</channel></rss>`)
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	mockQueries := new(database.MockQuerier)
+	mockStore := &database.Store{Queries: mockQueries}
+	mockQueue := new(MockQueue)
+
+	processor := NewImportEpisodeTaskProcessor(mockStore, mockQueue, &ImportConfig{BatchSize: 2, MaxPages: 10})
+
+	seriesID := uuid.New()
+	payloadJSON, _ := json.Marshal(ImportContentPayload{SourceType: "rss", SourceURL: srv.URL + "/feed", SeriesID: seriesID.String()})
+	task := asynq.NewTask(TypeImportContent, payloadJSON)
+
+	mockQueries.On("ListEpisodeSlugs", mock.Anything, mock.Anything).Return([]string{}, nil)
+	mockQueries.On("CreateEpisode", mock.Anything, mock.MatchedBy(func(params sqlc.CreateEpisodeParams) bool {
+		// items without a publish date are published right away
+		return params.SeriesID == seriesID && params.Status == publishing.StatusPublished
+	})).Return(sqlc.Episode{ID: uuid.New(), SeriesID: seriesID}, nil)
+	mockQueries.On("CreateEpisodeRevision", mock.Anything, mock.Anything).Return(sqlc.ContentRevision{}, nil)
+	mockQueries.On("ListSeriesLanguages", mock.Anything, mock.Anything).Return([]sqlc.ListSeriesLanguagesRow{}, nil)
+	mockQueries.On("ListEpisodeTranslations", mock.Anything, mock.Anything).Return([]sqlc.EpisodeTranslation{}, nil)
+	mockQueries.On("CreateOutboxEvent", mock.Anything, mock.MatchedBy(func(params sqlc.CreateOutboxEventParams) bool {
+		return params.TaskType == TypeIndexEpisode
+	})).Return(nil)
+
+	err := processor.ProcessTask(context.Background(), task)
+	assert.NoError(t, err)
+
+	mockQueries.AssertNumberOfCalls(t, "CreateEpisode", 6)
+	mockQueries.AssertNumberOfCalls(t, "CreateEpisodeRevision", 6)
+	mockQueries.AssertNumberOfCalls(t, "CreateOutboxEvent", 6)
+	mockQueue.AssertExpectations(t)
+}
+
+// indexEpisodeEvent is the outbox event written for an imported episode.
+func indexEpisodeEvent(episode sqlc.Episode, assets ...sqlc.EpisodeAsset) sqlc.CreateOutboxEventParams {
+	payload, _ := json.Marshal(IndexEpisodePayload{Episode: episode, Assets: assets})
+	return sqlc.CreateOutboxEventParams{TaskType: TypeIndexEpisode, Payload: payload}
+}
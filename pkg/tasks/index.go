@@ -3,8 +3,9 @@ package tasks
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 	"log/slog"
+	"th-application-technical-assignment/pkg/mapping"
+	"th-application-technical-assignment/pkg/publishing"
 	"th-application-technical-assignment/pkg/search"
 
 	"github.com/hibiken/asynq"
@@ -23,113 +24,221 @@ func NewHandler(searchClient search.Searcher, config *search.Config) *Handler {
 	}
 }
 
-func (h *Handler) HandleIndexSeries(ctx context.Context, t *asynq.Task) error {
-	var payload IndexSeriesPayload
-	if err := json.Unmarshal(t.Payload(), &payload); err != nil {
-		return errors.Wrap(err, "failed to unmarshal payload")
-	}
-
-	series := payload.Series
-
-	doc := search.SeriesDocument{
-		ID:          series.ID.String(),
-		Title:       series.Title,
-		Description: series.Description,
-		CategoryID:  series.CategoryID.String(),
-		Language:    series.Language,
-		Type:        string(series.SeriesType),
-		CreatedAt:   series.CreatedAt,
-		UpdatedAt:   series.UpdatedAt,
+// AggregateSearchTasks is the asynq group aggregator of SearchGroup. It
+// combines the search tasks of the group, oldest first, into one
+// TypeSearchBatch task.
+func AggregateSearchTasks(group string, tasks []*asynq.Task) *asynq.Task {
+	payload := SearchBatchPayload{Tasks: make([]BatchedTask, 0, len(tasks))}
+	for _, t := range tasks {
+		payload.Tasks = append(payload.Tasks, BatchedTask{Type: t.Type(), Payload: t.Payload()})
 	}
 
-	docJSON, err := doc.ToJSON()
+	data, err := json.Marshal(payload)
 	if err != nil {
-		return errors.Wrap(err, "failed to convert document to JSON")
+		// the payloads are JSON already, this cannot happen
+		slog.Error("failed to aggregate search tasks", "err", err, "group", group)
 	}
-
-	index := fmt.Sprintf("%s-series", h.config.IndexPrefix)
-	err = h.searchClient.IndexDocument(ctx, index, series.ID.String(), docJSON)
-	if err != nil {
-		return errors.Wrap(err, "failed to index series document")
-	}
-
-	slog.InfoContext(ctx, "indexed series document", "series_id", series.ID.String(), "index", index)
-	return nil
+	return asynq.NewTask(TypeSearchBatch, data)
 }
 
-func (h *Handler) HandleIndexEpisode(ctx context.Context, t *asynq.Task) error {
-	var payload IndexEpisodePayload
+func (h *Handler) HandleSearchBatch(ctx context.Context, t *asynq.Task) error {
+	var payload SearchBatchPayload
 	if err := json.Unmarshal(t.Payload(), &payload); err != nil {
 		return errors.Wrap(err, "failed to unmarshal payload")
 	}
 
-	episode := payload.Episode
-	assets := payload.Assets
-
-	doc := search.EpisodeDocument{
-		ID:              episode.ID.String(),
-		SeriesID:        episode.SeriesID.String(),
-		Title:           episode.Title,
-		Description:     episode.Description,
-		DurationSeconds: episode.DurationSeconds,
-		PublishDate:     episode.PublishDate,
-		CreatedAt:       episode.CreatedAt,
-		UpdatedAt:       episode.UpdatedAt,
-	}
+	return h.process(ctx, payload.Tasks)
+}
 
-	for _, a := range assets {
-		doc.Assets = append(doc.Assets, search.AssetDocument{
-			ID:        a.ID.String(),
-			AssetType: a.AssetType,
-			MimeType:  a.MimeType,
-			SizeBytes: a.SizeBytes,
-			URL:       a.Url,
-		})
-	}
+// HandleSearchTask handles a single search task that was not aggregated,
+// such as one enqueued before search tasks were grouped.
+func (h *Handler) HandleSearchTask(ctx context.Context, t *asynq.Task) error {
+	return h.process(ctx, []BatchedTask{{Type: t.Type(), Payload: t.Payload()}})
+}
 
-	docJSON, err := doc.ToJSON()
-	if err != nil {
-		return errors.Wrap(err, "failed to convert document to JSON")
-	}
+type documentKey struct {
+	index string
+	id    string
+}
 
-	index := fmt.Sprintf("%s-episodes", h.config.IndexPrefix)
-	err = h.searchClient.IndexDocument(ctx, index, episode.ID.String(), docJSON)
-	if err != nil {
-		return errors.Wrap(err, "failed to index episode document")
+// process applies the search tasks with as few bulk requests as possible.
+// Only the newest task for a document counts, so the requests can be split
+// by index and action without reordering changes to the same document.
+//
+// Every action carries the version of its row, so OpenSearch ignores one
+// that is older than the stored document. Batches run concurrently and are
+// retried as a whole, which applies their tasks out of order.
+//
+// Documents rejected by OpenSearch are logged and dropped, sending them
+// again would not help. When OpenSearch was overloaded or unavailable for
+// some documents, an error is returned and the whole batch is retried;
+// indexing is idempotent.
+func (h *Handler) process(ctx context.Context, tasks []BatchedTask) error {
+	latest := make(map[documentKey]search.BulkOperation)
+	var order []documentKey
+
+	for _, t := range tasks {
+		ops, err := h.operations(t)
+		if err != nil {
+			slog.WarnContext(ctx, "dropped invalid search task", "err", err, "type", t.Type)
+			continue
+		}
+		for _, op := range ops {
+			key := documentKey{index: op.Index, id: op.ID}
+			prev, ok := latest[key]
+			if !ok {
+				order = append(order, key)
+			} else if op.Version < prev.Version {
+				continue
+			}
+			latest[key] = op
+		}
 	}
 
-	slog.InfoContext(ctx, "indexed episode document", "episode_id", episode.ID.String(), "index", index)
-	return nil
-}
+	var indices []string
+	docs := make(map[string][]search.BulkDocument)
+	deletes := make(map[string][]search.BulkDocument)
+	for _, key := range order {
+		op := latest[key]
+		if _, ok := docs[key.index]; !ok {
+			indices = append(indices, key.index)
+			docs[key.index] = nil
+		}
+		doc := search.BulkDocument{ID: op.ID, Document: op.Document, Version: op.Version}
+		if op.Document == nil {
+			deletes[key.index] = append(deletes[key.index], doc)
+		} else {
+			docs[key.index] = append(docs[key.index], doc)
+		}
+	}
 
-func (h *Handler) HandleDeleteSeries(ctx context.Context, t *asynq.Task) error {
-	var payload DeleteSeriesPayload
-	if err := json.Unmarshal(t.Payload(), &payload); err != nil {
-		return errors.Wrap(err, "failed to unmarshal payload")
+	var items []search.BulkItem
+	for _, index := range indices {
+		if len(docs[index]) > 0 {
+			res, err := h.searchClient.BulkIndex(ctx, index, docs[index])
+			if err != nil {
+				return errors.Wrap(err, "failed to index documents")
+			}
+			items = append(items, res.Items...)
+		}
+		if len(deletes[index]) > 0 {
+			res, err := h.searchClient.BulkDelete(ctx, index, deletes[index])
+			if err != nil {
+				return errors.Wrap(err, "failed to delete documents")
+			}
+			items = append(items, res.Items...)
+		}
 	}
 
-	index := fmt.Sprintf("%s-series", h.config.IndexPrefix)
-	err := h.searchClient.DeleteDocument(ctx, index, payload.SeriesID)
-	if err != nil {
-		return errors.Wrap(err, "failed to delete series document")
+	retry := 0
+	for _, item := range items {
+		if item.Outdated() {
+			slog.DebugContext(ctx, "skipped outdated search document", "index", item.Index, "id", item.ID, "action", item.Action)
+			continue
+		}
+		if !item.Failed() {
+			continue
+		}
+		if item.Retryable() {
+			retry++
+			continue
+		}
+		reason := ""
+		if item.Error != nil {
+			reason = item.Error.Type + ": " + item.Error.Reason
+		}
+		slog.ErrorContext(ctx, "search document rejected", "index", item.Index, "id", item.ID, "action", item.Action, "status", item.Status, "reason", reason)
+	}
+	if retry > 0 {
+		return errors.Errorf("%d of %d search documents failed and will be retried", retry, len(order))
 	}
 
-	slog.InfoContext(ctx, "deleted series document", "series_id", payload.SeriesID, "index", index)
+	slog.InfoContext(ctx, "applied search tasks", "tasks", len(tasks), "documents", len(order))
 	return nil
 }
 
-func (h *Handler) HandleDeleteEpisode(ctx context.Context, t *asynq.Task) error {
-	var payload DeleteEpisodePayload
-	if err := json.Unmarshal(t.Payload(), &payload); err != nil {
-		return errors.Wrap(err, "failed to unmarshal payload")
+// operations returns the bulk operations a search task stands for.
+func (h *Handler) operations(t BatchedTask) ([]search.BulkOperation, error) {
+	seriesIndex := search.SeriesIndex.WriteAlias(h.config.IndexPrefix)
+	episodesIndex := search.EpisodesIndex.WriteAlias(h.config.IndexPrefix)
+
+	switch t.Type {
+	case TypeIndexSeries:
+		var payload IndexSeriesPayload
+		if err := json.Unmarshal(t.Payload, &payload); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal payload")
+		}
+		doc, err := mapping.SeriesDocument(payload.Series, payload.CategoryPath, payload.Translations).ToJSON()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to convert document to JSON")
+		}
+		return []search.BulkOperation{{
+			Index:    seriesIndex,
+			ID:       payload.Series.ID.String(),
+			Document: doc,
+			Version:  search.ExternalVersion(payload.Series.UpdatedAt),
+		}}, nil
+
+	case TypeIndexEpisode:
+		var payload IndexEpisodePayload
+		if err := json.Unmarshal(t.Payload, &payload); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal payload")
+		}
+		op, err := episodeOperation(episodesIndex, payload)
+		if err != nil {
+			return nil, err
+		}
+		return []search.BulkOperation{op}, nil
+
+	case TypeIndexEpisodes:
+		var payload IndexEpisodesPayload
+		if err := json.Unmarshal(t.Payload, &payload); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal payload")
+		}
+		ops := make([]search.BulkOperation, 0, len(payload.Episodes))
+		for _, ep := range payload.Episodes {
+			op, err := episodeOperation(episodesIndex, ep)
+			if err != nil {
+				return nil, err
+			}
+			ops = append(ops, op)
+		}
+		return ops, nil
+
+	case TypeDeleteSeries:
+		var payload DeleteSeriesPayload
+		if err := json.Unmarshal(t.Payload, &payload); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal payload")
+		}
+		return []search.BulkOperation{{Index: seriesIndex, ID: payload.SeriesID, Version: search.ExternalVersion(payload.DeletedAt)}}, nil
+
+	case TypeDeleteEpisode:
+		var payload DeleteEpisodePayload
+		if err := json.Unmarshal(t.Payload, &payload); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal payload")
+		}
+		return []search.BulkOperation{{Index: episodesIndex, ID: payload.EpisodeID, Version: search.ExternalVersion(payload.DeletedAt)}}, nil
+
+	default:
+		return nil, errors.Errorf("unknown search task type %q", t.Type)
 	}
+}
 
-	index := fmt.Sprintf("%s-episodes", h.config.IndexPrefix)
-	err := h.searchClient.DeleteDocument(ctx, index, payload.EpisodeID)
+// episodeOperation indexes a published episode and removes any other from
+// the index, so unpublishing an episode takes it out of search.
+func episodeOperation(index string, payload IndexEpisodePayload) (search.BulkOperation, error) {
+	op := search.BulkOperation{
+		Index:   index,
+		ID:      payload.Episode.ID.String(),
+		Version: search.ExternalVersion(payload.Episode.UpdatedAt),
+	}
+	if payload.Episode.Status != publishing.StatusPublished {
+		return op, nil
+	}
+	doc, err := mapping.EpisodeDocument(payload.Episode, payload.Language, payload.Assets, payload.Translations).ToJSON()
 	if err != nil {
-		return errors.Wrap(err, "failed to delete episode document")
+		return search.BulkOperation{}, errors.Wrap(err, "failed to convert document to JSON")
 	}
-
-	slog.InfoContext(ctx, "deleted episode document", "episode_id", payload.EpisodeID, "index", index)
-	return nil
+	op.Document = doc
+	return op, nil
 }
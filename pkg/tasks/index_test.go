@@ -0,0 +1,213 @@
+package tasks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"th-application-technical-assignment/pkg/publishing"
+	"th-application-technical-assignment/pkg/search"
+	"th-application-technical-assignment/sqlc"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func batchedTask(t *testing.T, typ string, payload any) BatchedTask {
+	t.Helper()
+
+	data, err := json.Marshal(payload)
+	require.NoError(t, err)
+	return BatchedTask{Type: typ, Payload: data}
+}
+
+func TestHandler_HandleSearchBatch(t *testing.T) {
+	t.Parallel()
+
+	cfg := &search.Config{IndexPrefix: "th"}
+	series := sqlc.Series{ID: uuid.New(), Title: "Series", UpdatedAt: time.Date(2025, 9, 10, 12, 0, 0, 0, time.UTC)}
+	// a later change of the series, whose task is applied before the older one
+	updated := series
+	updated.Title, updated.UpdatedAt = "Renamed", series.UpdatedAt.Add(time.Minute)
+	deletedAt := series.UpdatedAt.Add(time.Hour)
+	kept := sqlc.Episode{ID: uuid.New(), SeriesID: series.ID, Title: "Kept", Status: publishing.StatusPublished}
+	removed := sqlc.Episode{ID: uuid.New(), SeriesID: series.ID, Title: "Removed", Status: publishing.StatusPublished}
+	draft := sqlc.Episode{ID: uuid.New(), SeriesID: series.ID, Title: "Draft", Status: publishing.StatusDraft}
+	unpublished := sqlc.Episode{ID: uuid.New(), SeriesID: series.ID, Title: "Unpublished", Status: publishing.StatusUnpublished}
+
+	docIDs := func(docs []search.BulkDocument) []string {
+		ids := make([]string, 0, len(docs))
+		for _, d := range docs {
+			ids = append(ids, d.ID)
+		}
+		return ids
+	}
+
+	tests := []struct {
+		name        string
+		tasks       []BatchedTask
+		setupMock   func(*search.MockSearcher)
+		expectError bool
+	}{
+		{
+			name: "only the last task per document is applied",
+			tasks: []BatchedTask{
+				batchedTask(t, TypeIndexSeries, IndexSeriesPayload{Series: series}),
+				batchedTask(t, TypeIndexEpisodes, IndexEpisodesPayload{Episodes: []IndexEpisodePayload{{Episode: kept}, {Episode: removed}}}),
+				batchedTask(t, TypeDeleteEpisode, DeleteEpisodePayload{EpisodeID: removed.ID.String()}),
+				batchedTask(t, TypeIndexEpisode, IndexEpisodePayload{Episode: kept}),
+			},
+			setupMock: func(m *search.MockSearcher) {
+				m.On("BulkIndex", mock.Anything, "th-series-write", mock.MatchedBy(func(docs []search.BulkDocument) bool {
+					return assert.ObjectsAreEqual([]string{series.ID.String()}, docIDs(docs))
+				})).Return(&search.BulkResponse{Items: []search.BulkItem{{ID: series.ID.String(), Action: "index", Status: http.StatusOK}}}, nil)
+				m.On("BulkIndex", mock.Anything, "th-episodes-write", mock.MatchedBy(func(docs []search.BulkDocument) bool {
+					return assert.ObjectsAreEqual([]string{kept.ID.String()}, docIDs(docs))
+				})).Return(&search.BulkResponse{Items: []search.BulkItem{{ID: kept.ID.String(), Action: "index", Status: http.StatusOK}}}, nil)
+				m.On("BulkDelete", mock.Anything, "th-episodes-write", []search.BulkDocument{{ID: removed.ID.String()}}).
+					Return(&search.BulkResponse{Items: []search.BulkItem{{ID: removed.ID.String(), Action: "delete", Status: http.StatusNotFound}}}, nil)
+			},
+		},
+		{
+			name: "episodes that are not published are removed",
+			tasks: []BatchedTask{
+				batchedTask(t, TypeIndexEpisodes, IndexEpisodesPayload{Episodes: []IndexEpisodePayload{{Episode: kept}, {Episode: draft}}}),
+				batchedTask(t, TypeIndexEpisode, IndexEpisodePayload{Episode: unpublished}),
+			},
+			setupMock: func(m *search.MockSearcher) {
+				m.On("BulkIndex", mock.Anything, "th-episodes-write", mock.MatchedBy(func(docs []search.BulkDocument) bool {
+					return assert.ObjectsAreEqual([]string{kept.ID.String()}, docIDs(docs))
+				})).Return(&search.BulkResponse{Items: []search.BulkItem{{ID: kept.ID.String(), Action: "index", Status: http.StatusOK}}}, nil)
+				m.On("BulkDelete", mock.Anything, "th-episodes-write", []search.BulkDocument{{ID: draft.ID.String()}, {ID: unpublished.ID.String()}}).
+					Return(&search.BulkResponse{Items: []search.BulkItem{
+						{ID: draft.ID.String(), Action: "delete", Status: http.StatusNotFound},
+						{ID: unpublished.ID.String(), Action: "delete", Status: http.StatusOK},
+					}}, nil)
+			},
+		},
+		{
+			name: "invalid tasks are dropped",
+			tasks: []BatchedTask{
+				{Type: TypeIndexSeries, Payload: json.RawMessage(`"not an object"`)},
+				{Type: "search:unknown", Payload: json.RawMessage(`{}`)},
+				batchedTask(t, TypeDeleteSeries, DeleteSeriesPayload{SeriesID: series.ID.String()}),
+			},
+			setupMock: func(m *search.MockSearcher) {
+				m.On("BulkDelete", mock.Anything, "th-series-write", []search.BulkDocument{{ID: series.ID.String()}}).
+					Return(&search.BulkResponse{Items: []search.BulkItem{{ID: series.ID.String(), Action: "delete", Status: http.StatusOK}}}, nil)
+			},
+		},
+		{
+			name: "the newest task per document is applied",
+			tasks: []BatchedTask{
+				batchedTask(t, TypeIndexSeries, IndexSeriesPayload{Series: updated}),
+				batchedTask(t, TypeIndexSeries, IndexSeriesPayload{Series: series}),
+				batchedTask(t, TypeDeleteEpisode, DeleteEpisodePayload{EpisodeID: kept.ID.String(), DeletedAt: deletedAt}),
+			},
+			setupMock: func(m *search.MockSearcher) {
+				m.On("BulkIndex", mock.Anything, "th-series-write", mock.MatchedBy(func(docs []search.BulkDocument) bool {
+					return len(docs) == 1 && docs[0].Version == search.ExternalVersion(updated.UpdatedAt)
+				})).Return(&search.BulkResponse{Items: []search.BulkItem{{ID: series.ID.String(), Action: "index", Status: http.StatusOK}}}, nil)
+				m.On("BulkDelete", mock.Anything, "th-episodes-write", []search.BulkDocument{{ID: kept.ID.String(), Version: search.ExternalVersion(deletedAt)}}).
+					Return(&search.BulkResponse{Items: []search.BulkItem{{ID: kept.ID.String(), Action: "delete", Status: http.StatusOK}}}, nil)
+			},
+		},
+		{
+			name: "outdated documents are not retried",
+			tasks: []BatchedTask{
+				batchedTask(t, TypeIndexSeries, IndexSeriesPayload{Series: series}),
+			},
+			setupMock: func(m *search.MockSearcher) {
+				m.On("BulkIndex", mock.Anything, "th-series-write", mock.Anything).
+					Return(&search.BulkResponse{Items: []search.BulkItem{{
+						ID:     series.ID.String(),
+						Action: "index",
+						Status: http.StatusConflict,
+						Error:  &search.BulkItemError{Type: "version_conflict_engine_exception", Reason: "current version is higher"},
+					}}}, nil)
+			},
+		},
+		{
+			name: "rejected documents are dropped",
+			tasks: []BatchedTask{
+				batchedTask(t, TypeIndexSeries, IndexSeriesPayload{Series: series}),
+			},
+			setupMock: func(m *search.MockSearcher) {
+				m.On("BulkIndex", mock.Anything, "th-series-write", mock.Anything).
+					Return(&search.BulkResponse{Items: []search.BulkItem{{
+						ID:     series.ID.String(),
+						Action: "index",
+						Status: http.StatusBadRequest,
+						Error:  &search.BulkItemError{Type: "mapper_parsing_exception", Reason: "failed to parse"},
+					}}}, nil)
+			},
+		},
+		{
+			name: "retryable failures fail the batch",
+			tasks: []BatchedTask{
+				batchedTask(t, TypeIndexSeries, IndexSeriesPayload{Series: series}),
+			},
+			setupMock: func(m *search.MockSearcher) {
+				m.On("BulkIndex", mock.Anything, "th-series-write", mock.Anything).
+					Return(&search.BulkResponse{Items: []search.BulkItem{{ID: series.ID.String(), Action: "index", Status: http.StatusTooManyRequests}}}, nil)
+			},
+			expectError: true,
+		},
+		{
+			name: "request error fails the batch",
+			tasks: []BatchedTask{
+				batchedTask(t, TypeDeleteSeries, DeleteSeriesPayload{SeriesID: series.ID.String()}),
+			},
+			setupMock: func(m *search.MockSearcher) {
+				m.On("BulkDelete", mock.Anything, "th-series-write", mock.Anything).
+					Return((*search.BulkResponse)(nil), errors.New("connection refused"))
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockSearcher := new(search.MockSearcher)
+			tt.setupMock(mockSearcher)
+
+			data, err := json.Marshal(SearchBatchPayload{Tasks: tt.tasks})
+			require.NoError(t, err)
+
+			handler := NewHandler(mockSearcher, cfg)
+			err = handler.HandleSearchBatch(context.Background(), asynq.NewTask(TypeSearchBatch, data))
+
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			mockSearcher.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAggregateSearchTasks(t *testing.T) {
+	t.Parallel()
+
+	task := AggregateSearchTasks(SearchGroup, []*asynq.Task{
+		asynq.NewTask(TypeDeleteSeries, []byte(`{"series_id":"a"}`)),
+		asynq.NewTask(TypeDeleteEpisode, []byte(`{"episode_id":"b"}`)),
+	})
+
+	assert.Equal(t, TypeSearchBatch, task.Type())
+
+	var payload SearchBatchPayload
+	require.NoError(t, json.Unmarshal(task.Payload(), &payload))
+	assert.Equal(t, []BatchedTask{
+		{Type: TypeDeleteSeries, Payload: json.RawMessage(`{"series_id":"a"}`)},
+		{Type: TypeDeleteEpisode, Payload: json.RawMessage(`{"episode_id":"b"}`)},
+	}, payload.Tasks)
+}
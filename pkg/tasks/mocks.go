@@ -3,6 +3,7 @@ package tasks
 import (
 	"context"
 	"th-application-technical-assignment/sqlc"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -27,13 +28,18 @@ func (m *MockQueue) EnqueueIndexEpisode(ctx context.Context, episode sqlc.Episod
 	return args.Error(0)
 }
 
-func (m *MockQueue) EnqueueDeleteSeries(ctx context.Context, seriesID string) error {
-    args := m.Called(ctx, seriesID)
+func (m *MockQueue) EnqueueIndexEpisodes(ctx context.Context, episodes []IndexEpisodePayload) error {
+	args := m.Called(ctx, episodes)
+	return args.Error(0)
+}
+
+func (m *MockQueue) EnqueueDeleteSeries(ctx context.Context, seriesID string, deletedAt time.Time) error {
+    args := m.Called(ctx, seriesID, deletedAt)
     return args.Error(0)
 }
 
-func (m *MockQueue) EnqueueDeleteEpisode(ctx context.Context, episodeID string) error {
-    args := m.Called(ctx, episodeID)
+func (m *MockQueue) EnqueueDeleteEpisode(ctx context.Context, episodeID string, deletedAt time.Time) error {
+    args := m.Called(ctx, episodeID, deletedAt)
     return args.Error(0)
 }
 
@@ -42,6 +48,11 @@ func (m *MockQueue) EnqueueImportContent(ctx context.Context, payload ImportCont
     return args.Error(0)
 }
 
+func (m *MockQueue) EnqueueDeleteSeriesContent(ctx context.Context, jobID string) error {
+    args := m.Called(ctx, jobID)
+    return args.Error(0)
+}
+
 func (m *MockQueue) Close() error {
     args := m.Called()
     return args.Error(0)
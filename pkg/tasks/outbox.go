@@ -0,0 +1,190 @@
+package tasks
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"th-application-technical-assignment/sqlc"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// maxEpisodesPerTask caps the episodes of one index task written by
+// EnqueueIndexSeriesEpisodes.
+const maxEpisodesPerTask = 100
+
+// OutboxQueue is a TaskQueue that writes tasks to the outbox_events table
+// instead of Redis. Built on the queries of a transaction, the tasks are
+// committed or rolled back together with the change that caused them, and
+// the relay publishes them to the queue afterwards.
+type OutboxQueue struct {
+	q sqlc.Querier
+}
+
+func NewOutboxQueue(q sqlc.Querier) *OutboxQueue {
+	return &OutboxQueue{q: q}
+}
+
+func (o *OutboxQueue) Close() error {
+	return nil
+}
+
+func (o *OutboxQueue) Enqueue(ctx context.Context, typename string, taskPayload any) error {
+	data, err := json.Marshal(taskPayload)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal payload")
+	}
+
+	if err := o.q.CreateOutboxEvent(ctx, sqlc.CreateOutboxEventParams{
+		TaskType: typename,
+		Payload:  data,
+	}); err != nil {
+		return errors.Wrap(err, "failed to write outbox event")
+	}
+
+	return nil
+}
+
+// EnqueueIndexSeries writes the series together with the path of its
+// category and its translations, read in the same transaction.
+func (o *OutboxQueue) EnqueueIndexSeries(ctx context.Context, series sqlc.Series) error {
+	path, err := o.q.ListCategoryPath(ctx, series.CategoryID)
+	if err != nil {
+		return errors.Wrap(err, "failed to list category path")
+	}
+
+	translations, err := o.q.ListSeriesTranslations(ctx, []uuid.UUID{series.ID})
+	if err != nil {
+		return errors.Wrap(err, "failed to list series translations")
+	}
+
+	payload := IndexSeriesPayload{Series: series, CategoryPath: path, Translations: translations}
+	return o.Enqueue(ctx, TypeIndexSeries, payload)
+}
+
+// EnqueueIndexEpisode writes the episode together with the language of its
+// series and its translations, read in the same transaction.
+func (o *OutboxQueue) EnqueueIndexEpisode(ctx context.Context, episode sqlc.Episode, assets []sqlc.EpisodeAsset) error {
+	languages, err := o.seriesLanguages(ctx, []uuid.UUID{episode.SeriesID})
+	if err != nil {
+		return err
+	}
+
+	translations, err := o.q.ListEpisodeTranslations(ctx, []uuid.UUID{episode.ID})
+	if err != nil {
+		return errors.Wrap(err, "failed to list episode translations")
+	}
+
+	payload := IndexEpisodePayload{
+		Episode:      episode,
+		Assets:       assets,
+		Language:     languages[episode.SeriesID],
+		Translations: translations,
+	}
+	return o.Enqueue(ctx, TypeIndexEpisode, payload)
+}
+
+// EnqueueIndexEpisodes writes the episodes together with the languages of
+// their series and their translations, read in the same transaction with
+// one query each.
+func (o *OutboxQueue) EnqueueIndexEpisodes(ctx context.Context, episodes []IndexEpisodePayload) error {
+	ids := make([]uuid.UUID, len(episodes))
+	var seriesIDs []uuid.UUID
+	for i, ep := range episodes {
+		ids[i] = ep.Episode.ID
+		if !slices.Contains(seriesIDs, ep.Episode.SeriesID) {
+			seriesIDs = append(seriesIDs, ep.Episode.SeriesID)
+		}
+	}
+
+	languages, err := o.seriesLanguages(ctx, seriesIDs)
+	if err != nil {
+		return err
+	}
+
+	translations, err := o.q.ListEpisodeTranslations(ctx, ids)
+	if err != nil {
+		return errors.Wrap(err, "failed to list episode translations")
+	}
+	byEpisode := make(map[uuid.UUID][]sqlc.EpisodeTranslation)
+	for _, t := range translations {
+		byEpisode[t.EpisodeID] = append(byEpisode[t.EpisodeID], t)
+	}
+
+	episodes = slices.Clone(episodes)
+	for i := range episodes {
+		episodes[i].Language = languages[episodes[i].Episode.SeriesID]
+		episodes[i].Translations = byEpisode[episodes[i].Episode.ID]
+	}
+
+	payload := IndexEpisodesPayload{Episodes: episodes}
+	return o.Enqueue(ctx, TypeIndexEpisodes, payload)
+}
+
+// EnqueueIndexSeriesEpisodes writes index tasks for the live episodes of a
+// series, at most maxEpisodesPerTask per task. Episode documents copy the
+// language of their series, so they are indexed again when it changes.
+func (o *OutboxQueue) EnqueueIndexSeriesEpisodes(ctx context.Context, seriesID uuid.UUID) error {
+	episodes, err := o.q.ListEpisodesBySeries(ctx, seriesID)
+	if err != nil {
+		return errors.Wrap(err, "failed to list series episodes")
+	}
+
+	for chunk := range slices.Chunk(episodes, maxEpisodesPerTask) {
+		ids := make([]uuid.UUID, len(chunk))
+		for i, ep := range chunk {
+			ids[i] = ep.ID
+		}
+		assets, err := o.q.ListAssetsByEpisodes(ctx, ids)
+		if err != nil {
+			return errors.Wrap(err, "failed to list assets")
+		}
+		byEpisode := make(map[uuid.UUID][]sqlc.EpisodeAsset, len(chunk))
+		for _, a := range assets {
+			byEpisode[a.EpisodeID] = append(byEpisode[a.EpisodeID], a)
+		}
+
+		payloads := make([]IndexEpisodePayload, len(chunk))
+		for i, ep := range chunk {
+			payloads[i] = IndexEpisodePayload{Episode: ep, Assets: byEpisode[ep.ID]}
+		}
+		if err := o.EnqueueIndexEpisodes(ctx, payloads); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (o *OutboxQueue) seriesLanguages(ctx context.Context, seriesIDs []uuid.UUID) (map[uuid.UUID]*string, error) {
+	rows, err := o.q.ListSeriesLanguages(ctx, seriesIDs)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list series languages")
+	}
+
+	languages := make(map[uuid.UUID]*string, len(rows))
+	for _, row := range rows {
+		languages[row.ID] = row.Language
+	}
+	return languages, nil
+}
+
+func (o *OutboxQueue) EnqueueDeleteSeries(ctx context.Context, seriesID string, deletedAt time.Time) error {
+	payload := DeleteSeriesPayload{SeriesID: seriesID, DeletedAt: deletedAt}
+	return o.Enqueue(ctx, TypeDeleteSeries, payload)
+}
+
+func (o *OutboxQueue) EnqueueDeleteEpisode(ctx context.Context, episodeID string, deletedAt time.Time) error {
+	payload := DeleteEpisodePayload{EpisodeID: episodeID, DeletedAt: deletedAt}
+	return o.Enqueue(ctx, TypeDeleteEpisode, payload)
+}
+
+func (o *OutboxQueue) EnqueueImportContent(ctx context.Context, payload ImportContentPayload) error {
+	return o.Enqueue(ctx, TypeImportContent, payload)
+}
+
+func (o *OutboxQueue) EnqueueDeleteSeriesContent(ctx context.Context, jobID string) error {
+	payload := DeleteSeriesContentPayload{JobID: jobID}
+	return o.Enqueue(ctx, TypeDeleteSeriesContent, payload)
+}
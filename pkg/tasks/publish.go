@@ -0,0 +1,123 @@
+package tasks
+
+import (
+	"context"
+	"log/slog"
+	"th-application-technical-assignment/pkg/audit"
+	"th-application-technical-assignment/pkg/database"
+	"th-application-technical-assignment/pkg/publishing"
+	"th-application-technical-assignment/sqlc"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+	"github.com/pkg/errors"
+)
+
+const DefaultPublishBatchSize = 100
+
+// PublishScheduledTaskProcessor handles the periodic publish task. It
+// publishes the scheduled episodes whose publish date has come, records
+// each change in the audit log and as a revision and indexes the episodes,
+// in one transaction per batch.
+//
+// Batches are claimed with SKIP LOCKED, so overlapping runs publish every
+// episode once.
+type PublishScheduledTaskProcessor struct {
+	store     *database.Store
+	batchSize int
+	now       func() time.Time
+}
+
+func NewPublishScheduledTaskProcessor(store *database.Store, cfg *PublishConfig) *PublishScheduledTaskProcessor {
+	p := &PublishScheduledTaskProcessor{
+		store:     store,
+		batchSize: DefaultPublishBatchSize,
+		now:       time.Now,
+	}
+
+	if cfg != nil && cfg.BatchSize > 0 {
+		p.batchSize = cfg.BatchSize
+	}
+
+	return p
+}
+
+func (p *PublishScheduledTaskProcessor) ProcessTask(ctx context.Context, t *asynq.Task) error {
+	now := p.now()
+
+	total := 0
+	for {
+		n, err := p.publishBatch(ctx, now)
+		if err != nil {
+			return err
+		}
+		total += n
+		if n < p.batchSize {
+			break
+		}
+	}
+
+	if total > 0 {
+		slog.InfoContext(ctx, "published scheduled episodes", "due_before", now, "episodes", total)
+	}
+	return nil
+}
+
+// publishBatch publishes up to a batch of episodes due before now and
+// returns how many it published.
+func (p *PublishScheduledTaskProcessor) publishBatch(ctx context.Context, now time.Time) (int, error) {
+	n := 0
+	err := p.store.WithTx(ctx, func(q sqlc.Querier) error {
+		episodes, err := q.PublishDueEpisodes(ctx, sqlc.PublishDueEpisodesParams{
+			DueBefore: now,
+			RowLimit:  int32(p.batchSize),
+		})
+		if err != nil {
+			return err
+		}
+		n = len(episodes)
+		if n == 0 {
+			return nil
+		}
+
+		ids := make([]uuid.UUID, n)
+		for i, ep := range episodes {
+			ids[i] = ep.ID
+			before := ep
+			before.Status = publishing.StatusScheduled
+			if err := audit.Record(ctx, q, audit.Event{
+				Actor:      audit.SystemActor,
+				Action:     audit.ActionPublish,
+				EntityType: audit.EntityEpisode,
+				EntityID:   ep.ID,
+				Before:     before,
+				After:      ep,
+			}); err != nil {
+				return err
+			}
+			if err := recordEpisodeRevision(ctx, q, ep.ID); err != nil {
+				return err
+			}
+		}
+
+		assets, err := q.ListAssetsByEpisodes(ctx, ids)
+		if err != nil {
+			return errors.Wrap(err, "failed to list assets")
+		}
+		byEpisode := make(map[uuid.UUID][]sqlc.EpisodeAsset, n)
+		for _, a := range assets {
+			byEpisode[a.EpisodeID] = append(byEpisode[a.EpisodeID], a)
+		}
+
+		payloads := make([]IndexEpisodePayload, n)
+		for i, ep := range episodes {
+			payloads[i] = IndexEpisodePayload{Episode: ep, Assets: byEpisode[ep.ID]}
+		}
+		return NewOutboxQueue(q).EnqueueIndexEpisodes(ctx, payloads)
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to publish scheduled episodes")
+	}
+	return n, nil
+}
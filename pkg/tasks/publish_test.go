@@ -0,0 +1,128 @@
+package tasks
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"th-application-technical-assignment/pkg/audit"
+	"th-application-technical-assignment/pkg/database"
+	"th-application-technical-assignment/pkg/publishing"
+	"th-application-technical-assignment/sqlc"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPublishScheduledTaskProcessor_ProcessTask(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	seriesID := uuid.New()
+	language := "en"
+
+	published := func() sqlc.Episode {
+		return sqlc.Episode{ID: uuid.New(), SeriesID: seriesID, Title: "Pilot", PublishDate: &now, Status: publishing.StatusPublished}
+	}
+	first, second, third := published(), published(), published()
+	asset := sqlc.EpisodeAsset{ID: uuid.New(), EpisodeID: first.ID, AssetType: "video"}
+
+	// indexed reports whether params is an index task for the published
+	// episodes
+	indexed := func(episodes ...sqlc.Episode) func(sqlc.CreateOutboxEventParams) bool {
+		return func(params sqlc.CreateOutboxEventParams) bool {
+			var payload IndexEpisodesPayload
+			if params.TaskType != TypeIndexEpisodes || json.Unmarshal(params.Payload, &payload) != nil || len(payload.Episodes) != len(episodes) {
+				return false
+			}
+			for i, ep := range payload.Episodes {
+				if ep.Episode.ID != episodes[i].ID || ep.Episode.Status != publishing.StatusPublished || *ep.Language != language {
+					return false
+				}
+			}
+			return true
+		}
+	}
+
+	tests := []struct {
+		name        string
+		setupMock   func(*database.MockQuerier)
+		expectError bool
+	}{
+		{
+			name: "publishes due episodes in batches",
+			setupMock: func(m *database.MockQuerier) {
+				m.On("PublishDueEpisodes", mock.Anything, sqlc.PublishDueEpisodesParams{DueBefore: now, RowLimit: 2}).
+					Return([]sqlc.Episode{first, second}, nil).Once()
+				m.On("PublishDueEpisodes", mock.Anything, sqlc.PublishDueEpisodesParams{DueBefore: now, RowLimit: 2}).
+					Return([]sqlc.Episode{third}, nil).Once()
+
+				m.On("CreateAuditEvent", mock.Anything, mock.MatchedBy(func(params sqlc.CreateAuditEventParams) bool {
+					return params.Action == audit.ActionPublish && params.EntityType == audit.EntityEpisode && params.Actor == audit.SystemActor
+				})).Return(nil).Times(3)
+				for _, ep := range []sqlc.Episode{first, second, third} {
+					m.On("CreateEpisodeRevision", mock.Anything, sqlc.CreateEpisodeRevisionParams{Author: audit.SystemActor, ID: ep.ID}).
+						Return(sqlc.ContentRevision{EntityID: ep.ID, Revision: 2}, nil).Once()
+				}
+
+				m.On("ListAssetsByEpisodes", mock.Anything, []uuid.UUID{first.ID, second.ID}).Return([]sqlc.EpisodeAsset{asset}, nil)
+				m.On("ListAssetsByEpisodes", mock.Anything, []uuid.UUID{third.ID}).Return([]sqlc.EpisodeAsset{}, nil)
+				m.On("ListSeriesLanguages", mock.Anything, []uuid.UUID{seriesID}).
+					Return([]sqlc.ListSeriesLanguagesRow{{ID: seriesID, Language: &language}}, nil)
+				m.On("ListEpisodeTranslations", mock.Anything, mock.Anything).Return([]sqlc.EpisodeTranslation{}, nil)
+				m.On("CreateOutboxEvent", mock.Anything, mock.MatchedBy(indexed(first, second))).Return(nil).Once()
+				m.On("CreateOutboxEvent", mock.Anything, mock.MatchedBy(indexed(third))).Return(nil).Once()
+			},
+		},
+		{
+			name: "nothing due",
+			setupMock: func(m *database.MockQuerier) {
+				m.On("PublishDueEpisodes", mock.Anything, mock.Anything).Return([]sqlc.Episode{}, nil).Once()
+			},
+		},
+		{
+			name: "database error",
+			setupMock: func(m *database.MockQuerier) {
+				m.On("PublishDueEpisodes", mock.Anything, mock.Anything).Return([]sqlc.Episode{}, assert.AnError).Once()
+			},
+			expectError: true,
+		},
+		{
+			name: "outbox error",
+			setupMock: func(m *database.MockQuerier) {
+				m.On("PublishDueEpisodes", mock.Anything, mock.Anything).Return([]sqlc.Episode{third}, nil).Once()
+				m.On("CreateAuditEvent", mock.Anything, mock.Anything).Return(nil)
+				m.On("CreateEpisodeRevision", mock.Anything, mock.Anything).Return(sqlc.ContentRevision{}, nil)
+				m.On("ListAssetsByEpisodes", mock.Anything, mock.Anything).Return([]sqlc.EpisodeAsset{}, nil)
+				m.On("ListSeriesLanguages", mock.Anything, mock.Anything).Return([]sqlc.ListSeriesLanguagesRow{}, nil)
+				m.On("ListEpisodeTranslations", mock.Anything, mock.Anything).Return([]sqlc.EpisodeTranslation{}, nil)
+				m.On("CreateOutboxEvent", mock.Anything, mock.Anything).Return(assert.AnError)
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockQueries := new(database.MockQuerier)
+			mockStore := &database.Store{Queries: mockQueries}
+			tt.setupMock(mockQueries)
+
+			processor := NewPublishScheduledTaskProcessor(mockStore, &PublishConfig{BatchSize: 2})
+			processor.now = func() time.Time { return now }
+
+			err := processor.ProcessTask(context.Background(), asynq.NewTask(TypePublishScheduled, nil))
+
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			mockQueries.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,218 @@
+package tasks
+
+import (
+	"context"
+	"log/slog"
+	"th-application-technical-assignment/pkg/audit"
+	"th-application-technical-assignment/pkg/database"
+	"th-application-technical-assignment/pkg/storage"
+	"th-application-technical-assignment/sqlc"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+	"github.com/pkg/errors"
+)
+
+const DefaultPurgeBatchSize = 500
+
+// PurgeResult counts what a purge removed for good.
+type PurgeResult struct {
+	Categories int
+	Series     int
+	Episodes   int
+	Assets     int
+	Objects    int
+	// Failed counts the stored objects that could not be removed. Their
+	// assets are kept and tried again by the next purge.
+	Failed int
+}
+
+// PurgeTrashTaskProcessor handles the periodic purge task. It permanently
+// deletes the content that was soft deleted longer than the retention period
+// ago, children first: the stored objects and rows of assets, then episodes,
+// series and finally categories no series uses anymore.
+//
+// Everything deleted together with a deleted parent is purged with it, even
+// when it was not marked deleted itself. Overlapping runs are harmless.
+type PurgeTrashTaskProcessor struct {
+	store     *database.Store
+	objects   storage.ObjectStorage
+	retention time.Duration
+	batchSize int
+	now       func() time.Time
+}
+
+func NewPurgeTrashTaskProcessor(store *database.Store, objects storage.ObjectStorage, cfg *TrashConfig) *PurgeTrashTaskProcessor {
+	p := &PurgeTrashTaskProcessor{
+		store:     store,
+		objects:   objects,
+		batchSize: DefaultPurgeBatchSize,
+		now:       time.Now,
+	}
+
+	if cfg != nil {
+		p.retention = time.Duration(cfg.RetentionDays) * 24 * time.Hour
+		if cfg.PurgeBatchSize > 0 {
+			p.batchSize = cfg.PurgeBatchSize
+		}
+	}
+
+	return p
+}
+
+func (p *PurgeTrashTaskProcessor) ProcessTask(ctx context.Context, t *asynq.Task) error {
+	// without a retention period deleted content is kept forever
+	if p.retention <= 0 {
+		return nil
+	}
+
+	result, err := p.Purge(ctx, p.now().Add(-p.retention))
+	if err != nil {
+		return err
+	}
+
+	if result.Failed > 0 {
+		return errors.Errorf("failed to remove %d stored objects", result.Failed)
+	}
+	return nil
+}
+
+// Purge permanently deletes the content deleted before cutoff.
+func (p *PurgeTrashTaskProcessor) Purge(ctx context.Context, cutoff time.Time) (PurgeResult, error) {
+	var result PurgeResult
+
+	if err := p.purgeAssets(ctx, cutoff, &result); err != nil {
+		return result, err
+	}
+
+	var err error
+	result.Episodes, err = p.purgeRows(ctx, audit.EntityEpisode, func(ctx context.Context, q sqlc.Querier) ([]uuid.UUID, error) {
+		return q.PurgeEpisodes(ctx, sqlc.PurgeEpisodesParams{DeletedBefore: cutoff, RowLimit: int32(p.batchSize)})
+	})
+	if err != nil {
+		return result, err
+	}
+	result.Series, err = p.purgeRows(ctx, audit.EntitySeries, func(ctx context.Context, q sqlc.Querier) ([]uuid.UUID, error) {
+		return q.PurgeSeries(ctx, sqlc.PurgeSeriesParams{DeletedBefore: cutoff, RowLimit: int32(p.batchSize)})
+	})
+	if err != nil {
+		return result, err
+	}
+	result.Categories, err = p.purgeRows(ctx, audit.EntityCategory, func(ctx context.Context, q sqlc.Querier) ([]uuid.UUID, error) {
+		return q.PurgeCategories(ctx, sqlc.PurgeCategoriesParams{DeletedBefore: cutoff, RowLimit: int32(p.batchSize)})
+	})
+	if err != nil {
+		return result, err
+	}
+
+	if result != (PurgeResult{}) {
+		slog.InfoContext(ctx, "purged trash",
+			"deleted_before", cutoff,
+			"categories", result.Categories,
+			"series", result.Series,
+			"episodes", result.Episodes,
+			"assets", result.Assets,
+			"objects", result.Objects,
+			"failed", result.Failed,
+		)
+	}
+	return result, nil
+}
+
+// purgeAssets removes the stored objects of purgeable uploaded assets, then
+// the asset rows. An asset whose object could not be removed is kept, so its
+// episode is kept as well and the object is not lost track of.
+func (p *PurgeTrashTaskProcessor) purgeAssets(ctx context.Context, cutoff time.Time, result *PurgeResult) error {
+	after := uuid.Nil
+	for {
+		assets, err := p.store.Queries.ListPurgeableAssets(ctx, sqlc.ListPurgeableAssetsParams{
+			After:         after,
+			DeletedBefore: cutoff,
+			RowLimit:      int32(p.batchSize),
+		})
+		if err != nil {
+			return errors.Wrap(err, "failed to list purgeable assets")
+		}
+		if len(assets) == 0 {
+			return nil
+		}
+		after = assets[len(assets)-1].ID
+
+		ids := make([]uuid.UUID, 0, len(assets))
+		for _, a := range assets {
+			if a.Url != nil && !isRemoteAsset(a) {
+				if err := p.removeObject(ctx, *a.Url); err != nil {
+					slog.WarnContext(ctx, "failed to remove stored object", "err", err, "asset_id", a.ID, "key", *a.Url)
+					result.Failed++
+					continue
+				}
+				result.Objects++
+			}
+			ids = append(ids, a.ID)
+		}
+
+		if len(ids) > 0 {
+			err := p.store.WithTx(ctx, func(q sqlc.Querier) error {
+				n, err := q.PurgeAssets(ctx, ids)
+				if err != nil {
+					return err
+				}
+				result.Assets += int(n)
+				return p.record(ctx, q, audit.EntityAsset, ids)
+			})
+			if err != nil {
+				return errors.Wrap(err, "failed to purge assets")
+			}
+		}
+
+		if len(assets) < p.batchSize {
+			return nil
+		}
+	}
+}
+
+func (p *PurgeTrashTaskProcessor) removeObject(ctx context.Context, key string) error {
+	if p.objects == nil {
+		return errors.New("object storage is not configured")
+	}
+	return p.objects.RemoveObject(ctx, key)
+}
+
+// purgeRows calls purge in batches until it deletes fewer rows than the batch
+// size, and returns the number of rows deleted.
+func (p *PurgeTrashTaskProcessor) purgeRows(ctx context.Context, entityType string, purge func(ctx context.Context, q sqlc.Querier) ([]uuid.UUID, error)) (int, error) {
+	total := 0
+	for {
+		n := 0
+		err := p.store.WithTx(ctx, func(q sqlc.Querier) error {
+			ids, err := purge(ctx, q)
+			if err != nil {
+				return err
+			}
+			n = len(ids)
+			return p.record(ctx, q, entityType, ids)
+		})
+		if err != nil {
+			return total, errors.Wrapf(err, "failed to purge %s", entityType)
+		}
+		total += n
+		if n < p.batchSize {
+			return total, nil
+		}
+	}
+}
+
+func (p *PurgeTrashTaskProcessor) record(ctx context.Context, q sqlc.Querier, entityType string, ids []uuid.UUID) error {
+	for _, id := range ids {
+		if err := audit.Record(ctx, q, audit.Event{
+			Actor:      audit.SystemActor,
+			Action:     audit.ActionPurge,
+			EntityType: entityType,
+			EntityID:   id,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package tasks
+
+import (
+	"context"
+	"testing"
+	"th-application-technical-assignment/pkg/audit"
+	"th-application-technical-assignment/pkg/database"
+	"th-application-technical-assignment/sqlc"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPurgeTrashTaskProcessor_ProcessTask(t *testing.T) {
+	t.Parallel()
+
+	uploadedKey := "series/s1/episodes/e1/video.mp4"
+	remoteURL := "https://youtube.com/watch?v=test123"
+
+	tests := []struct {
+		name          string
+		retentionDays int
+		listError     error
+		removeError   error
+		expectPurge   bool
+		expectError   bool
+	}{
+		{
+			name:          "purges expired content",
+			retentionDays: 30,
+			expectPurge:   true,
+		},
+		{
+			name:          "keeps assets whose object could not be removed",
+			retentionDays: 30,
+			removeError:   assert.AnError,
+			expectPurge:   true,
+			expectError:   true,
+		},
+		{
+			name:          "database error",
+			retentionDays: 30,
+			listError:     assert.AnError,
+			expectError:   true,
+		},
+		{
+			name:          "no retention keeps everything",
+			retentionDays: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockQueries := new(database.MockQuerier)
+			mockStore := &database.Store{Queries: mockQueries}
+			mockObjects := new(mockObjectStorage)
+
+			processor := NewPurgeTrashTaskProcessor(mockStore, mockObjects, &TrashConfig{RetentionDays: tt.retentionDays, PurgeBatchSize: 2})
+			now := time.Now()
+			processor.now = func() time.Time { return now }
+			cutoff := now.Add(-time.Duration(tt.retentionDays) * 24 * time.Hour)
+
+			uploaded := sqlc.EpisodeAsset{ID: uuid.New(), AssetType: "video", Url: &uploadedKey}
+			remote := sqlc.EpisodeAsset{ID: uuid.New(), AssetType: "video", Url: &remoteURL}
+			episodeID, seriesID := uuid.New(), uuid.New()
+
+			if tt.retentionDays > 0 {
+				mockQueries.On("ListPurgeableAssets", mock.Anything, sqlc.ListPurgeableAssetsParams{
+					After:         uuid.Nil,
+					DeletedBefore: cutoff,
+					RowLimit:      2,
+				}).Return([]sqlc.EpisodeAsset{uploaded, remote}, tt.listError)
+			}
+
+			if tt.expectPurge {
+				mockQueries.On("ListPurgeableAssets", mock.Anything, sqlc.ListPurgeableAssetsParams{
+					After:         remote.ID,
+					DeletedBefore: cutoff,
+					RowLimit:      2,
+				}).Return([]sqlc.EpisodeAsset{}, nil)
+				// the remote asset is not ours to remove
+				mockObjects.On("RemoveObject", mock.Anything, uploadedKey).Return(tt.removeError)
+
+				purged := []uuid.UUID{uploaded.ID, remote.ID}
+				if tt.removeError != nil {
+					purged = []uuid.UUID{remote.ID}
+				}
+				mockQueries.On("PurgeAssets", mock.Anything, purged).Return(int64(len(purged)), nil)
+				mockQueries.On("CreateAuditEvent", mock.Anything, mock.MatchedBy(func(params sqlc.CreateAuditEventParams) bool {
+					return params.Action == audit.ActionPurge && params.EntityType == audit.EntityAsset && params.Actor == audit.SystemActor
+				})).Return(nil).Times(len(purged))
+
+				mockQueries.On("PurgeEpisodes", mock.Anything, sqlc.PurgeEpisodesParams{DeletedBefore: cutoff, RowLimit: 2}).
+					Return([]uuid.UUID{episodeID}, nil)
+				mockQueries.On("PurgeSeries", mock.Anything, sqlc.PurgeSeriesParams{DeletedBefore: cutoff, RowLimit: 2}).
+					Return([]uuid.UUID{seriesID}, nil)
+				mockQueries.On("PurgeCategories", mock.Anything, sqlc.PurgeCategoriesParams{DeletedBefore: cutoff, RowLimit: 2}).
+					Return([]uuid.UUID{}, nil)
+				mockQueries.On("CreateAuditEvent", mock.Anything, mock.MatchedBy(func(params sqlc.CreateAuditEventParams) bool {
+					return params.Action == audit.ActionPurge && params.EntityType == audit.EntityEpisode && params.EntityID == episodeID
+				})).Return(nil)
+				mockQueries.On("CreateAuditEvent", mock.Anything, mock.MatchedBy(func(params sqlc.CreateAuditEventParams) bool {
+					return params.Action == audit.ActionPurge && params.EntityType == audit.EntitySeries && params.EntityID == seriesID
+				})).Return(nil)
+			}
+
+			err := processor.ProcessTask(context.Background(), asynq.NewTask(TypePurgeTrash, nil))
+
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			mockQueries.AssertExpectations(t)
+			mockObjects.AssertExpectations(t)
+		})
+	}
+}
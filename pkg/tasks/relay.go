@@ -0,0 +1,228 @@
+package tasks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"th-application-technical-assignment/pkg/database"
+	"th-application-technical-assignment/sqlc"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	DefaultRelayBatchSize   = 100
+	DefaultRelayInterval    = time.Second
+	DefaultRelayMaxAttempts = 10
+)
+
+type RelayConfig struct {
+	BatchSize   int           `env:"BATCH_SIZE" envDefault:"100"`
+	Interval    time.Duration `env:"INTERVAL" envDefault:"1s"`
+	Retention   time.Duration `env:"RETENTION" envDefault:"24h"`
+	MaxAttempts int           `env:"MAX_ATTEMPTS" envDefault:"10"`
+	MetricsAddr string        `env:"METRICS_ADDR" envDefault:":9100"`
+}
+
+// Relay publishes outbox events to the task queue. Events are marked sent in
+// the same transaction as they are published. An event published just before
+// a failed commit is published again later: delivery is at least once and
+// task handlers must be idempotent, which indexing already is.
+//
+// Each batch takes an advisory lock first: several relays can be deployed for
+// availability, but only one publishes at a time.
+//
+// An event that cannot be published does not hold up the ones after it. Once
+// it failed maxAttempts times it is parked: it keeps its last error for
+// inspection and is not claimed again.
+type Relay struct {
+	store       *database.Store
+	queue       TaskQueue
+	batchSize   int
+	interval    time.Duration
+	retention   time.Duration
+	maxAttempts int
+}
+
+// Backlog describes the events still waiting to be published and the ones
+// that were parked.
+type Backlog struct {
+	Pending   int64
+	Parked    int64
+	OldestAge time.Duration
+}
+
+func NewRelay(store *database.Store, queue TaskQueue, cfg *RelayConfig) *Relay {
+	r := &Relay{
+		store:       store,
+		queue:       queue,
+		batchSize:   DefaultRelayBatchSize,
+		interval:    DefaultRelayInterval,
+		maxAttempts: DefaultRelayMaxAttempts,
+	}
+
+	if cfg != nil {
+		if cfg.BatchSize > 0 {
+			r.batchSize = cfg.BatchSize
+		}
+		if cfg.Interval > 0 {
+			r.interval = cfg.Interval
+		}
+		r.retention = cfg.Retention
+		if cfg.MaxAttempts > 0 {
+			r.maxAttempts = cfg.MaxAttempts
+		}
+	}
+
+	return r
+}
+
+// Run relays events until ctx is canceled. Full batches are followed by the
+// next one right away, otherwise the relay waits for its interval.
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		sent, err := r.RelayBatch(ctx)
+		if err != nil {
+			slog.WarnContext(ctx, "failed to relay outbox events", "err", err)
+		}
+		if err == nil && sent == r.batchSize {
+			continue
+		}
+
+		r.purge(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RelayBatch publishes the next batch of events and returns how many were
+// sent. Failed events are skipped and their attempts counted. When nothing in
+// the batch could be published the queue is most likely down, so failures
+// only park events in batches where others went through.
+func (r *Relay) RelayBatch(ctx context.Context) (int, error) {
+	var sent []int64
+	var publishErr error
+
+	err := r.store.WithTx(ctx, func(q sqlc.Querier) error {
+		sent, publishErr = nil, nil
+		var failed []sqlc.OutboxEvent
+		var failures []string
+
+		locked, err := q.TryLockOutboxRelay(ctx)
+		if err != nil {
+			return errors.Wrap(err, "failed to take the relay lock")
+		}
+		if !locked {
+			// another relay is publishing
+			return nil
+		}
+
+		events, err := q.ClaimOutboxEvents(ctx, int32(r.batchSize))
+		if err != nil {
+			return errors.Wrap(err, "failed to claim outbox events")
+		}
+
+		for _, e := range events {
+			if err := r.queue.Enqueue(ctx, e.TaskType, json.RawMessage(e.Payload)); err != nil {
+				if publishErr == nil {
+					publishErr = errors.Wrapf(err, "failed to publish outbox event %d", e.ID)
+				}
+				failed = append(failed, e)
+				failures = append(failures, err.Error())
+				continue
+			}
+			sent = append(sent, e.ID)
+		}
+
+		for i, e := range failed {
+			msg := failures[i]
+			failedAt, err := q.RecordOutboxEventFailure(ctx, sqlc.RecordOutboxEventFailureParams{
+				LastError:   &msg,
+				Park:        len(sent) > 0,
+				MaxAttempts: int32(r.maxAttempts),
+				ID:          e.ID,
+			})
+			if err != nil {
+				return errors.Wrap(err, "failed to record outbox failure")
+			}
+			if failedAt != nil {
+				slog.WarnContext(ctx, "parked outbox event", "id", e.ID, "task_type", e.TaskType, "attempts", e.Attempts+1, "err", msg)
+			}
+		}
+
+		if len(sent) == 0 {
+			return nil
+		}
+		return errors.Wrap(q.MarkOutboxEventsSent(ctx, sent), "failed to mark outbox events sent")
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return len(sent), publishErr
+}
+
+// Backlog returns the number of unsent events, the age of the oldest one and
+// the number of parked events.
+func (r *Relay) Backlog(ctx context.Context) (Backlog, error) {
+	row, err := r.store.Queries.GetOutboxBacklog(ctx)
+	if err != nil {
+		return Backlog{}, errors.Wrap(err, "failed to get outbox backlog")
+	}
+
+	return Backlog{
+		Pending:   row.Pending,
+		Parked:    row.Parked,
+		OldestAge: time.Duration(row.OldestAgeSeconds * float64(time.Second)),
+	}, nil
+}
+
+// MetricsHandler serves the backlog as gauges in the Prometheus text format.
+func (r *Relay) MetricsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		backlog, err := r.Backlog(req.Context())
+		if err != nil {
+			slog.ErrorContext(req.Context(), "failed to read outbox backlog", "err", err)
+			http.Error(w, "failed to read outbox backlog", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		fmt.Fprintf(w, "# HELP outbox_backlog_events Outbox events waiting to be published.\n")
+		fmt.Fprintf(w, "# TYPE outbox_backlog_events gauge\n")
+		fmt.Fprintf(w, "outbox_backlog_events %d\n", backlog.Pending)
+		fmt.Fprintf(w, "# HELP outbox_oldest_event_age_seconds Age of the oldest outbox event waiting to be published.\n")
+		fmt.Fprintf(w, "# TYPE outbox_oldest_event_age_seconds gauge\n")
+		fmt.Fprintf(w, "outbox_oldest_event_age_seconds %g\n", backlog.OldestAge.Seconds())
+		fmt.Fprintf(w, "# HELP outbox_parked_events Outbox events parked after failing too often.\n")
+		fmt.Fprintf(w, "# TYPE outbox_parked_events gauge\n")
+		fmt.Fprintf(w, "outbox_parked_events %d\n", backlog.Parked)
+	})
+}
+
+// purge deletes events sent longer ago than the retention. A zero retention
+// keeps them forever.
+func (r *Relay) purge(ctx context.Context) {
+	if r.retention <= 0 {
+		return
+	}
+
+	deleted, err := r.store.Queries.DeleteSentOutboxEvents(ctx, time.Now().Add(-r.retention))
+	if err != nil {
+		slog.WarnContext(ctx, "failed to purge sent outbox events", "err", err)
+		return
+	}
+	if deleted > 0 {
+		slog.DebugContext(ctx, "purged sent outbox events", "deleted", deleted)
+	}
+}
@@ -0,0 +1,220 @@
+package tasks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"th-application-technical-assignment/pkg/database"
+	"th-application-technical-assignment/sqlc"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRelay_RelayBatch(t *testing.T) {
+	t.Parallel()
+
+	parkedAt := time.Date(2025, 9, 19, 9, 0, 0, 0, time.UTC)
+
+	events := []sqlc.OutboxEvent{
+		{ID: 1, TaskType: TypeIndexSeries, Payload: []byte(`{"series":{"id":"a"}}`)},
+		{ID: 2, TaskType: TypeDeleteEpisode, Payload: []byte(`{"episode_id":"b"}`), Attempts: 2},
+		{ID: 3, TaskType: TypeIndexSeries, Payload: []byte(`{"series":{"id":"c"}}`)},
+	}
+
+	tests := []struct {
+		name          string
+		lockHeld      bool
+		claimError    error
+		failing       []int64
+		park          bool
+		parkedAt      *time.Time
+		expectedSent  []int64
+		expectedCount int
+		expectError   bool
+	}{
+		{
+			name:          "publishes and marks every event",
+			expectedSent:  []int64{1, 2, 3},
+			expectedCount: 3,
+		},
+		{
+			name:          "skips past a failing event",
+			failing:       []int64{2},
+			park:          true,
+			expectedSent:  []int64{1, 3},
+			expectedCount: 2,
+			expectError:   true,
+		},
+		{
+			name:          "parks an event that failed too often",
+			failing:       []int64{2},
+			park:          true,
+			parkedAt:      &parkedAt,
+			expectedSent:  []int64{1, 3},
+			expectedCount: 2,
+			expectError:   true,
+		},
+		{
+			name:        "does not park events when nothing is published",
+			failing:     []int64{1, 2, 3},
+			expectError: true,
+		},
+		{
+			name:     "another relay holds the lock",
+			lockHeld: true,
+		},
+		{
+			name:        "claim error",
+			claimError:  errors.New("database error"),
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockQueries := new(database.MockQuerier)
+			mockQueue := new(MockQueue)
+			relay := NewRelay(&database.Store{Queries: mockQueries}, mockQueue, &RelayConfig{BatchSize: 10, MaxAttempts: 3})
+
+			mockQueries.On("TryLockOutboxRelay", mock.Anything).Return(!tt.lockHeld, nil)
+			if !tt.lockHeld {
+				mockQueries.On("ClaimOutboxEvents", mock.Anything, int32(10)).Return(events, tt.claimError)
+			}
+			if tt.claimError == nil && !tt.lockHeld {
+				for _, e := range events {
+					var err error
+					if slices.Contains(tt.failing, e.ID) {
+						err = errors.New("redis down")
+					}
+					mockQueue.On("Enqueue", mock.Anything, e.TaskType, json.RawMessage(e.Payload)).Return(err)
+				}
+			}
+			for _, id := range tt.failing {
+				mockQueries.On("RecordOutboxEventFailure", mock.Anything, mock.MatchedBy(func(params sqlc.RecordOutboxEventFailureParams) bool {
+					return params.ID == id && params.LastError != nil && *params.LastError == "redis down" &&
+						params.Park == tt.park && params.MaxAttempts == 3
+				})).Return(tt.parkedAt, nil).Once()
+			}
+			if len(tt.expectedSent) > 0 {
+				mockQueries.On("MarkOutboxEventsSent", mock.Anything, tt.expectedSent).Return(nil)
+			}
+
+			sent, err := relay.RelayBatch(context.Background())
+
+			assert.Equal(t, tt.expectedCount, sent)
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			mockQueries.AssertExpectations(t)
+			mockQueue.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRelay_MetricsHandler(t *testing.T) {
+	t.Parallel()
+
+	mockQueries := new(database.MockQuerier)
+	relay := NewRelay(&database.Store{Queries: mockQueries}, new(MockQueue), nil)
+
+	mockQueries.On("GetOutboxBacklog", mock.Anything).Return(sqlc.GetOutboxBacklogRow{Pending: 42, Parked: 2, OldestAgeSeconds: 1.5}, nil)
+
+	recorder := httptest.NewRecorder()
+	relay.MetricsHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "outbox_backlog_events 42\n")
+	assert.Contains(t, recorder.Body.String(), "outbox_oldest_event_age_seconds 1.5\n")
+	assert.Contains(t, recorder.Body.String(), "outbox_parked_events 2\n")
+}
+
+func TestOutboxQueue_Enqueue(t *testing.T) {
+	t.Parallel()
+
+	mockQueries := new(database.MockQuerier)
+	mockQueries.On("CreateOutboxEvent", mock.Anything, sqlc.CreateOutboxEventParams{
+		TaskType: TypeDeleteSeries,
+		Payload:  []byte(`{"series_id":"abc","deleted_at":"2025-09-10T12:00:00Z"}`),
+	}).Return(nil)
+
+	err := NewOutboxQueue(mockQueries).EnqueueDeleteSeries(context.Background(), "abc", time.Date(2025, 9, 10, 12, 0, 0, 0, time.UTC))
+
+	assert.NoError(t, err)
+	mockQueries.AssertExpectations(t)
+}
+
+func TestOutboxQueue_EnqueueIndexSeries(t *testing.T) {
+	t.Parallel()
+
+	root, child := uuid.New(), uuid.New()
+	series := sqlc.Series{ID: uuid.New(), Title: "Astronomy Hour", CategoryID: child}
+
+	mockQueries := new(database.MockQuerier)
+	mockQueries.On("ListCategoryPath", mock.Anything, child).Return([]uuid.UUID{root, child}, nil)
+	mockQueries.On("ListSeriesTranslations", mock.Anything, []uuid.UUID{series.ID}).Return([]sqlc.SeriesTranslation{
+		{SeriesID: series.ID, Locale: "ar", Title: "ساعة الفلك"},
+	}, nil)
+	mockQueries.On("CreateOutboxEvent", mock.Anything, mock.MatchedBy(func(params sqlc.CreateOutboxEventParams) bool {
+		var payload IndexSeriesPayload
+		return params.TaskType == TypeIndexSeries &&
+			json.Unmarshal(params.Payload, &payload) == nil &&
+			payload.Series.ID == series.ID &&
+			assert.ObjectsAreEqual([]uuid.UUID{root, child}, payload.CategoryPath) &&
+			len(payload.Translations) == 1 && payload.Translations[0].Locale == "ar"
+	})).Return(nil)
+
+	err := NewOutboxQueue(mockQueries).EnqueueIndexSeries(context.Background(), series)
+
+	assert.NoError(t, err)
+	mockQueries.AssertExpectations(t)
+}
+
+func TestOutboxQueue_EnqueueIndexEpisodes(t *testing.T) {
+	t.Parallel()
+
+	seriesID := uuid.New()
+	english := "en"
+	translated := sqlc.Episode{ID: uuid.New(), SeriesID: seriesID, Title: "Pilot"}
+	untranslated := sqlc.Episode{ID: uuid.New(), SeriesID: seriesID, Title: "Finale"}
+
+	mockQueries := new(database.MockQuerier)
+	// both episodes are of one series, its language is read once
+	mockQueries.On("ListSeriesLanguages", mock.Anything, []uuid.UUID{seriesID}).Return([]sqlc.ListSeriesLanguagesRow{
+		{ID: seriesID, Language: &english},
+	}, nil)
+	mockQueries.On("ListEpisodeTranslations", mock.Anything, []uuid.UUID{translated.ID, untranslated.ID}).Return([]sqlc.EpisodeTranslation{
+		{EpisodeID: translated.ID, Locale: "ar", Title: "الحلقة الأولى"},
+		{EpisodeID: translated.ID, Locale: "fr", Title: "Épisode pilote"},
+	}, nil)
+	mockQueries.On("CreateOutboxEvent", mock.Anything, mock.MatchedBy(func(params sqlc.CreateOutboxEventParams) bool {
+		var payload IndexEpisodesPayload
+		return params.TaskType == TypeIndexEpisodes &&
+			json.Unmarshal(params.Payload, &payload) == nil &&
+			len(payload.Episodes) == 2 &&
+			len(payload.Episodes[0].Translations) == 2 &&
+			len(payload.Episodes[1].Translations) == 0 &&
+			*payload.Episodes[0].Language == "en" &&
+			*payload.Episodes[1].Language == "en"
+	})).Return(nil)
+
+	err := NewOutboxQueue(mockQueries).EnqueueIndexEpisodes(context.Background(), []IndexEpisodePayload{
+		{Episode: translated},
+		{Episode: untranslated},
+	})
+
+	assert.NoError(t, err)
+	mockQueries.AssertExpectations(t)
+}
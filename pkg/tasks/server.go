@@ -2,7 +2,6 @@ package tasks
 
 import (
 	"context"
-	"fmt"
 	"log/slog"
 	"th-application-technical-assignment/pkg/search"
 	"time"
@@ -14,10 +13,11 @@ import (
 type Server struct {
 	server  *asynq.Server
 	handler *Handler
+	indices search.IndexManager
 	mux     *asynq.ServeMux
 }
 
-func NewServer(redisCfg *RedisConfig, queueCfg *QueueConfig, searchClient search.Searcher, searchConfig *search.Config) (*Server, error) {
+func NewServer(redisCfg *RedisConfig, queueCfg *QueueConfig, searchClient search.Client, searchConfig *search.Config) (*Server, error) {
 	redisOpt := asynq.RedisClientOpt{
 		Addr:     redisCfg.RedisAddr,
 		Password: redisCfg.RedisPassword,
@@ -29,19 +29,26 @@ func NewServer(redisCfg *RedisConfig, queueCfg *QueueConfig, searchClient search
 		RetryDelayFunc: func(n int, err error, task *asynq.Task) time.Duration {
 			return queueCfg.RetryDelay
 		},
+		GroupAggregator:  asynq.GroupAggregatorFunc(AggregateSearchTasks),
+		GroupMaxSize:     queueCfg.BatchMaxSize,
+		GroupGracePeriod: queueCfg.BatchGracePeriod,
+		GroupMaxDelay:    queueCfg.BatchMaxDelay,
 	})
 
 	handler := NewHandler(searchClient, searchConfig)
 	mux := asynq.NewServeMux()
 
-	mux.HandleFunc(TypeIndexSeries, handler.HandleIndexSeries)
-	mux.HandleFunc(TypeIndexEpisode, handler.HandleIndexEpisode)
-	mux.HandleFunc(TypeDeleteSeries, handler.HandleDeleteSeries)
-	mux.HandleFunc(TypeDeleteEpisode, handler.HandleDeleteEpisode)
+	mux.HandleFunc(TypeSearchBatch, handler.HandleSearchBatch)
+	mux.HandleFunc(TypeIndexSeries, handler.HandleSearchTask)
+	mux.HandleFunc(TypeIndexEpisode, handler.HandleSearchTask)
+	mux.HandleFunc(TypeIndexEpisodes, handler.HandleSearchTask)
+	mux.HandleFunc(TypeDeleteSeries, handler.HandleSearchTask)
+	mux.HandleFunc(TypeDeleteEpisode, handler.HandleSearchTask)
 
 	return &Server{
 		server:  server,
 		handler: handler,
+		indices: searchClient,
 		mux:     mux,
 	}, nil
 }
@@ -61,24 +68,30 @@ func (s *Server) Shutdown() {
 	s.server.Shutdown()
 }
 
+// initializeIndices creates the search indices and their aliases on first
+// start. Indices built from an older or edited mapping keep serving; they
+// are reported so the reindex command can migrate them.
 func (s *Server) initializeIndices(ctx context.Context) error {
-	indices := map[string]string{
-		fmt.Sprintf("%s-series", s.handler.config.IndexPrefix):   search.SeriesMapping,
-		fmt.Sprintf("%s-episodes", s.handler.config.IndexPrefix): search.EpisodeMapping,
-	}
+	prefix := s.handler.config.IndexPrefix
 
-	for index, mapping := range indices {
-		exists, err := s.handler.searchClient.IndexExists(ctx, index)
+	for _, spec := range []search.IndexSpec{search.SeriesIndex, search.EpisodesIndex} {
+		status, err := search.EnsureIndex(ctx, s.indices, prefix, spec, time.Now())
 		if err != nil {
-			return errors.Wrapf(err, "failed to check if index %s exists", index)
+			return errors.Wrapf(err, "failed to initialize index %s", spec.ReadAlias(prefix))
 		}
 
-		if !exists {
-			err = s.handler.searchClient.CreateIndex(ctx, index, mapping)
-			if err != nil {
-				return errors.Wrapf(err, "failed to create index %s", index)
-			}
-			slog.InfoContext(ctx, "created search index", "index", index)
+		if status.Created {
+			slog.InfoContext(ctx, "created search index", "index", status.Index, "version", spec.Version)
+			continue
+		}
+		if !status.Current(spec) {
+			slog.WarnContext(ctx, "search index mapping has drifted, run the reindex command to migrate it",
+				"index", status.Index,
+				"version", status.Meta.Version,
+				"expected_version", spec.Version,
+				"checksum", status.Meta.Checksum,
+				"expected_checksum", spec.Meta().Checksum,
+			)
 		}
 	}
 
@@ -0,0 +1,128 @@
+package tasks
+
+import (
+	"context"
+	"log/slog"
+	"th-application-technical-assignment/pkg/database"
+	"th-application-technical-assignment/sqlc"
+
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+	"github.com/pkg/errors"
+)
+
+const DefaultSyncBatchSize = 100
+
+// SyncSubscriptionsTaskProcessor handles the periodic sync task. It claims
+// the series subscriptions that are due and starts a tracked import job for
+// each of them. Claiming moves the next sync of a subscription forward, so
+// overlapping runs never start the same sync twice.
+type SyncSubscriptionsTaskProcessor struct {
+	store     *database.Store
+	queue     TaskQueue
+	batchSize int
+}
+
+func NewSyncSubscriptionsTaskProcessor(store *database.Store, queue TaskQueue, cfg *ImportConfig) *SyncSubscriptionsTaskProcessor {
+	p := &SyncSubscriptionsTaskProcessor{
+		store:     store,
+		queue:     queue,
+		batchSize: DefaultSyncBatchSize,
+	}
+
+	if cfg != nil && cfg.SyncBatchSize > 0 {
+		p.batchSize = cfg.SyncBatchSize
+	}
+
+	return p
+}
+
+func (p *SyncSubscriptionsTaskProcessor) ProcessTask(ctx context.Context, t *asynq.Task) error {
+	subs, err := p.store.Queries.ClaimDueSubscriptions(ctx, int32(p.batchSize))
+	if err != nil {
+		return errors.Wrap(err, "failed to claim due subscriptions")
+	}
+
+	started := 0
+	for _, sub := range subs {
+		// a failed sync is not retried early, it runs again at its next interval
+		if err := p.startSync(ctx, sub); err != nil {
+			slog.WarnContext(ctx, "failed to start subscription sync", "err", err, "series_id", sub.SeriesID)
+			continue
+		}
+		started++
+	}
+
+	if len(subs) > 0 {
+		slog.InfoContext(ctx, "started subscription syncs", "due", len(subs), "started", started)
+	}
+	return nil
+}
+
+func (p *SyncSubscriptionsTaskProcessor) startSync(ctx context.Context, sub sqlc.SeriesSubscription) error {
+	job, err := p.store.Queries.CreateImportJob(ctx, sqlc.CreateImportJobParams{
+		SeriesID:   sub.SeriesID,
+		SourceType: sub.SourceType,
+		SourceUrl:  sub.SourceUrl,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to create import job")
+	}
+
+	if err := p.store.Queries.SetSubscriptionLastJob(ctx, sqlc.SetSubscriptionLastJobParams{
+		SeriesID:  sub.SeriesID,
+		LastJobID: &job.ID,
+	}); err != nil {
+		return errors.Wrap(err, "failed to link import job")
+	}
+
+	payload := ImportContentPayload{
+		JobID:        job.ID.String(),
+		SourceType:   sub.SourceType,
+		SourceURL:    sub.SourceUrl,
+		SeriesID:     sub.SeriesID.String(),
+		Subscription: true,
+	}
+	if sub.Etag != nil {
+		payload.ETag = *sub.Etag
+	}
+	if sub.LastModified != nil {
+		payload.LastModified = *sub.LastModified
+	}
+
+	if err := p.queue.EnqueueImportContent(ctx, payload); err != nil {
+		failed := sqlc.FinishImportJobParams{
+			ID:     job.ID,
+			Status: ImportJobFailed,
+			Errors: importErrorsJSON([]ImportItemError{{Error: "could not queue import task"}}),
+		}
+		if err := p.store.Queries.FinishImportJob(ctx, failed); err != nil {
+			slog.WarnContext(ctx, "failed to mark import job failed", "err", err, "job_id", job.ID)
+		}
+		return errors.Wrap(err, "failed to enqueue import task")
+	}
+
+	return nil
+}
+
+// recordSync stores the validators of a successful subscription import so
+// the next sync can make a conditional request. Like the job bookkeeping it
+// is best effort.
+func (p *ImportEpisodeTaskProcessor) recordSync(ctx context.Context, seriesID string, result *ImportResult) {
+	id, err := uuid.Parse(seriesID)
+	if err != nil {
+		return
+	}
+
+	params := sqlc.UpdateSubscriptionSyncParams{SeriesID: id}
+	if result.ETag != "" {
+		params.Etag = &result.ETag
+	}
+	if result.LastModified != "" {
+		params.LastModified = &result.LastModified
+	}
+
+	if err := p.store.Queries.UpdateSubscriptionSync(ctx, params); err != nil {
+		slog.WarnContext(ctx, "failed to record subscription sync", "err", err, "series_id", seriesID)
+	}
+}
@@ -0,0 +1,193 @@
+package tasks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"th-application-technical-assignment/pkg/database"
+	"th-application-technical-assignment/sqlc"
+
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncSubscriptionsTaskProcessor_ProcessTask(t *testing.T) {
+	t.Parallel()
+
+	etag := `"abc"`
+	lastModified := "Tue, 12 Aug 2025 08:00:00 GMT"
+
+	tests := []struct {
+		name         string
+		claimError   error
+		enqueueError error
+		expectError  bool
+	}{
+		{
+			name: "starts a job per due subscription",
+		},
+		{
+			name:        "claim error",
+			claimError:  errors.New("database error"),
+			expectError: true,
+		},
+		{
+			name:         "enqueue error fails the job",
+			enqueueError: errors.New("redis down"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockQueries := new(database.MockQuerier)
+			mockStore := &database.Store{Queries: mockQueries}
+			mockQueue := new(MockQueue)
+
+			processor := NewSyncSubscriptionsTaskProcessor(mockStore, mockQueue, &ImportConfig{SyncBatchSize: 10})
+
+			sub := sqlc.SeriesSubscription{
+				SeriesID:     uuid.New(),
+				SourceType:   "rss",
+				SourceUrl:    "https://example.com/feed.xml",
+				Etag:         &etag,
+				LastModified: &lastModified,
+			}
+			jobID := uuid.New()
+
+			mockQueries.On("ClaimDueSubscriptions", mock.Anything, int32(10)).Return([]sqlc.SeriesSubscription{sub}, tt.claimError)
+			if tt.claimError == nil {
+				mockQueries.On("CreateImportJob", mock.Anything, sqlc.CreateImportJobParams{
+					SeriesID:   sub.SeriesID,
+					SourceType: sub.SourceType,
+					SourceUrl:  sub.SourceUrl,
+				}).Return(sqlc.ImportJob{ID: jobID, SeriesID: sub.SeriesID}, nil)
+				mockQueries.On("SetSubscriptionLastJob", mock.Anything, sqlc.SetSubscriptionLastJobParams{
+					SeriesID:  sub.SeriesID,
+					LastJobID: &jobID,
+				}).Return(nil)
+				mockQueue.On("EnqueueImportContent", mock.Anything, ImportContentPayload{
+					JobID:        jobID.String(),
+					SourceType:   "rss",
+					SourceURL:    sub.SourceUrl,
+					SeriesID:     sub.SeriesID.String(),
+					Subscription: true,
+					ETag:         etag,
+					LastModified: lastModified,
+				}).Return(tt.enqueueError)
+			}
+			if tt.enqueueError != nil {
+				mockQueries.On("FinishImportJob", mock.Anything, mock.MatchedBy(func(params sqlc.FinishImportJobParams) bool {
+					return params.ID == jobID && params.Status == ImportJobFailed
+				})).Return(nil)
+			}
+
+			err := processor.ProcessTask(context.Background(), asynq.NewTask(TypeSyncSubscriptions, nil))
+
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			mockQueries.AssertExpectations(t)
+			mockQueue.AssertExpectations(t)
+		})
+	}
+}
+
+func TestImportEpisodeTaskProcessor_ProcessTask_Subscription(t *testing.T) {
+	t.Parallel()
+
+	const etag = `"v2"`
+	feed, err := os.ReadFile("../importer/testdata/podcast.rss.xml")
+	require.NoError(t, err)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		w.Write(feed)
+	}))
+	t.Cleanup(srv.Close)
+
+	tests := []struct {
+		name          string
+		etag          string
+		expectImports bool
+	}{
+		{
+			name:          "changed feed is imported and validators stored",
+			etag:          `"v1"`,
+			expectImports: true,
+		},
+		{
+			name: "unchanged feed is not imported",
+			etag: etag,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockQueries := new(database.MockQuerier)
+			mockStore := &database.Store{Queries: mockQueries}
+			mockQueue := new(MockQueue)
+
+			processor := NewImportEpisodeTaskProcessor(mockStore, mockQueue, nil)
+
+			jobID := uuid.New()
+			seriesID := uuid.New()
+
+			mockQueries.On("StartImportJob", mock.Anything, jobID).Return(nil)
+			if tt.expectImports {
+				mockQueries.On("GetEpisodeBySource", mock.Anything, mock.Anything).Return(sqlc.Episode{}, pgx.ErrNoRows)
+				mockQueries.On("ListEpisodeSlugs", mock.Anything, mock.Anything).Return([]string{}, nil)
+				mockQueries.On("UpsertImportedEpisode", mock.Anything, mock.Anything).
+					Return(sqlc.UpsertImportedEpisodeRow{ID: uuid.New(), SeriesID: seriesID, Inserted: true}, nil)
+				mockQueries.On("CreateEpisode", mock.Anything, mock.Anything).Return(sqlc.Episode{ID: uuid.New(), SeriesID: seriesID}, nil)
+				mockQueries.On("CreateEpisodeRevision", mock.Anything, mock.Anything).Return(sqlc.ContentRevision{}, nil)
+				mockQueries.On("CreateAsset", mock.Anything, mock.Anything).Return(sqlc.EpisodeAsset{ID: uuid.New()}, nil)
+				mockQueries.On("UpdateImportJobProgress", mock.Anything, mock.Anything).Return(nil)
+				mockQueries.On("ListSeriesLanguages", mock.Anything, mock.Anything).Return([]sqlc.ListSeriesLanguagesRow{}, nil)
+				mockQueries.On("ListEpisodeTranslations", mock.Anything, mock.Anything).Return([]sqlc.EpisodeTranslation{}, nil)
+				mockQueries.On("CreateOutboxEvent", mock.Anything, mock.Anything).Return(nil)
+			}
+			mockQueries.On("FinishImportJob", mock.Anything, mock.MatchedBy(func(params sqlc.FinishImportJobParams) bool {
+				return params.ID == jobID && params.Status == ImportJobSucceeded
+			})).Return(nil)
+			mockQueries.On("UpdateSubscriptionSync", mock.Anything, mock.MatchedBy(func(params sqlc.UpdateSubscriptionSyncParams) bool {
+				return params.SeriesID == seriesID && params.Etag != nil && *params.Etag == etag && params.LastModified == nil
+			})).Return(nil)
+
+			payloadJSON, _ := json.Marshal(ImportContentPayload{
+				JobID:        jobID.String(),
+				SourceType:   "rss",
+				SourceURL:    srv.URL,
+				SeriesID:     seriesID.String(),
+				Subscription: true,
+				ETag:         tt.etag,
+			})
+			err := processor.ProcessTask(context.Background(), asynq.NewTask(TypeImportContent, payloadJSON))
+
+			assert.NoError(t, err)
+			mockQueries.AssertExpectations(t)
+			mockQueue.AssertExpectations(t)
+			if !tt.expectImports {
+				mockQueries.AssertNotCalled(t, "UpsertImportedEpisode", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
@@ -2,16 +2,76 @@ package util
 
 import (
 	"regexp"
+	"strconv"
 	"strings"
+
+	"github.com/gosimple/unidecode"
+	"github.com/pkg/errors"
+)
+
+// MaxSlugLength matches the length of the slug columns.
+const MaxSlugLength = 100
+
+// ErrEmptySlug is returned for names without a letter or digit that can be
+// transliterated, such as "!!!" or an emoji.
+var ErrEmptySlug = errors.New("name does not produce a slug")
+
+var (
+	// apostrophes join the letters around them, "don't" becomes "dont"
+	slugApostrophes = regexp.MustCompile("['`\"]+")
+	slugSeparators  = regexp.MustCompile(`[^a-z0-9]+`)
 )
 
-func CreateSlug(name string) string {
-	name = strings.ToLower(name)
+// CreateSlug builds a URL slug from name. Letters of other scripts are
+// transliterated to ASCII, "Crème brûlée" becomes "creme-brulee" and
+// "Новости" becomes "novosti". Everything but letters and digits separates
+// words; separators are collapsed into one dash and trimmed from both ends.
+// Slugs longer than MaxSlugLength are cut, preferably at a dash.
+func CreateSlug(name string) (string, error) {
+	slug := strings.ToLower(unidecode.Unidecode(name))
+	slug = slugApostrophes.ReplaceAllString(slug, "")
+	slug = slugSeparators.ReplaceAllString(slug, "-")
+	slug = truncateSlug(strings.Trim(slug, "-"), MaxSlugLength)
 
-	name = strings.ReplaceAll(name, " ", "-")
+	if slug == "" {
+		return "", ErrEmptySlug
+	}
+	return slug, nil
+}
+
+// truncateSlug cuts slug to at most max bytes. A word is only split when the
+// first word alone is too long.
+func truncateSlug(slug string, max int) string {
+	if len(slug) <= max {
+		return slug
+	}
+
+	cut := slug[:max]
+	if slug[max] != '-' {
+		if i := strings.LastIndexByte(cut, '-'); i > 0 {
+			cut = cut[:i]
+		}
+	}
+	return strings.TrimRight(cut, "-")
+}
 
-	reg := regexp.MustCompile(`[^a-z0-9\-]+`)
-	name = reg.ReplaceAllString(name, "")
+// UniqueSlug returns slug when it is not taken, otherwise the first of
+// slug-2, slug-3 and so on that is free. The slug is shortened when the
+// suffix would exceed MaxSlugLength.
+func UniqueSlug(slug string, taken []string) string {
+	used := make(map[string]bool, len(taken))
+	for _, s := range taken {
+		used[s] = true
+	}
+	if !used[slug] {
+		return slug
+	}
 
-	return name
+	for n := 2; ; n++ {
+		suffix := "-" + strconv.Itoa(n)
+		candidate := truncateSlug(slug, MaxSlugLength-len(suffix)) + suffix
+		if !used[candidate] {
+			return candidate
+		}
+	}
 }
@@ -1,18 +1,21 @@
 package util
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestCreateSlug(t *testing.T) {
 	t.Parallel()
 
 	tests := []struct {
-		name     string
-		input    string
-		expected string
+		name        string
+		input       string
+		expected    string
+		expectError bool
 	}{
 		{
 			name:     "with spaces",
@@ -22,32 +25,179 @@ func TestCreateSlug(t *testing.T) {
 		{
 			name:     "with special characters",
 			input:    "Hello@#$%world!",
-			expected: "helloworld",
+			expected: "hello-world",
 		},
 		{
 			name:     "with numbers",
 			input:    "Test 123 test",
 			expected: "test-123-test",
 		},
-		{
-			name:     "empty string",
-			input:    "",
-			expected: "",
-		},
 		{
 			name:     "already slug",
 			input:    "hello-world",
 			expected: "hello-world",
 		},
+		{
+			name:     "repeated and surrounding separators",
+			input:    "  --Health &  Wellness!-- ",
+			expected: "health-wellness",
+		},
+		{
+			name:     "apostrophes join words",
+			input:    "Don't Panic",
+			expected: "dont-panic",
+		},
+		{
+			name:     "french accents",
+			input:    "Crème Brûlée",
+			expected: "creme-brulee",
+		},
+		{
+			name:     "german sharp s",
+			input:    "Straße",
+			expected: "strasse",
+		},
+		{
+			name:     "polish",
+			input:    "Łódź",
+			expected: "lodz",
+		},
+		{
+			name:     "turkish",
+			input:    "Çağ İstanbul",
+			expected: "cag-istanbul",
+		},
+		{
+			name:     "russian",
+			input:    "Новости",
+			expected: "novosti",
+		},
+		{
+			name:     "greek",
+			input:    "Ελληνικά",
+			expected: "ellenika",
+		},
+		{
+			name:     "arabic",
+			input:    "أخبار",
+			expected: "khbr",
+		},
+		{
+			name:     "chinese",
+			input:    "新闻",
+			expected: "xin-wen",
+		},
+		{
+			name:     "japanese",
+			input:    "ニュース",
+			expected: "niyusu",
+		},
+		{
+			name:     "korean",
+			input:    "뉴스",
+			expected: "nyuseu",
+		},
+		{
+			name:     "fullwidth latin",
+			input:    "Ｎｅｗｓ",
+			expected: "news",
+		},
+		{
+			name:     "cut at a dash",
+			input:    strings.Repeat("abcd ", 30),
+			expected: strings.TrimSuffix(strings.Repeat("abcd-", 20), "-"),
+		},
+		{
+			name:     "long word is cut",
+			input:    strings.Repeat("a", 150),
+			expected: strings.Repeat("a", MaxSlugLength),
+		},
+		{
+			name:        "empty string",
+			input:       "",
+			expectError: true,
+		},
+		{
+			name:        "only symbols",
+			input:       "!!! ???",
+			expectError: true,
+		},
+		{
+			name:        "only emoji",
+			input:       "😀",
+			expectError: true,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			result := CreateSlug(tt.input)
+			result, err := CreateSlug(tt.input)
+			if tt.expectError {
+				assert.ErrorIs(t, err, ErrEmptySlug)
+				return
+			}
 
+			require.NoError(t, err)
 			assert.Equal(t, tt.expected, result, "CreateSlug(%q) should return %q, got %q", tt.input, tt.expected, result)
+			assert.LessOrEqual(t, len(result), MaxSlugLength)
+		})
+	}
+}
+
+func TestUniqueSlug(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		slug     string
+		taken    []string
+		expected string
+	}{
+		{
+			name:     "free slug",
+			slug:     "news",
+			taken:    nil,
+			expected: "news",
+		},
+		{
+			name:     "taken slug",
+			slug:     "news",
+			taken:    []string{"news"},
+			expected: "news-2",
+		},
+		{
+			name:     "next free suffix",
+			slug:     "news",
+			taken:    []string{"news", "news-2", "news-3"},
+			expected: "news-4",
+		},
+		{
+			name:     "gap in suffixes",
+			slug:     "news",
+			taken:    []string{"news", "news-3"},
+			expected: "news-2",
+		},
+		{
+			name:     "suffix keeps the maximum length",
+			slug:     strings.Repeat("a", MaxSlugLength),
+			taken:    []string{strings.Repeat("a", MaxSlugLength)},
+			expected: strings.Repeat("a", MaxSlugLength-2) + "-2",
+		},
+		{
+			name:     "free slug with taken variants",
+			slug:     "news",
+			taken:    []string{"news-2", "news-flash"},
+			expected: "news",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expected, UniqueSlug(tt.slug, tt.taken))
 		})
 	}
 }
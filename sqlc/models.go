@@ -10,12 +10,59 @@ import (
 	"github.com/google/uuid"
 )
 
+type AuditEvent struct {
+	ID         uuid.UUID `json:"id"`
+	Actor      string    `json:"actor"`
+	Action     string    `json:"action"`
+	EntityType string    `json:"entity_type"`
+	EntityID   uuid.UUID `json:"entity_id"`
+	Changes    []byte    `json:"changes"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
 type Category struct {
 	ID        uuid.UUID  `json:"id"`
 	Slug      string     `json:"slug"`
 	CreatedAt time.Time  `json:"created_at"`
 	UpdatedAt time.Time  `json:"updated_at"`
 	DeletedAt *time.Time `json:"deleted_at"`
+	Name      string     `json:"name"`
+	ParentID  *uuid.UUID `json:"parent_id"`
+}
+
+type CategoryTranslation struct {
+	CategoryID uuid.UUID `json:"category_id"`
+	Locale     string    `json:"locale"`
+	Name       string    `json:"name"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+type ContentRevision struct {
+	ID         uuid.UUID `json:"id"`
+	EntityType string    `json:"entity_type"`
+	EntityID   uuid.UUID `json:"entity_id"`
+	Revision   int32     `json:"revision"`
+	Author     string    `json:"author"`
+	Snapshot   []byte    `json:"snapshot"`
+	RollbackOf *int32    `json:"rollback_of"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type DeletionJob struct {
+	ID            uuid.UUID  `json:"id"`
+	SeriesID      uuid.UUID  `json:"series_id"`
+	RequestedBy   string     `json:"requested_by"`
+	DeleteObjects bool       `json:"delete_objects"`
+	Status        string     `json:"status"`
+	EpisodeCount  int32      `json:"episode_count"`
+	AssetCount    int32      `json:"asset_count"`
+	ObjectCount   int32      `json:"object_count"`
+	Errors        []byte     `json:"errors"`
+	StartedAt     *time.Time `json:"started_at"`
+	FinishedAt    *time.Time `json:"finished_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
 }
 
 type Episode struct {
@@ -28,27 +75,94 @@ type Episode struct {
 	CreatedAt       time.Time  `json:"created_at"`
 	UpdatedAt       time.Time  `json:"updated_at"`
 	DeletedAt       *time.Time `json:"deleted_at"`
+	SourceType      *string    `json:"source_type"`
+	ExternalID      *string    `json:"external_id"`
+	Slug            string     `json:"slug"`
+	PreviousSlugs   []string   `json:"previous_slugs"`
+	Status          string     `json:"status"`
 }
 
 type EpisodeAsset struct {
-	ID        uuid.UUID `json:"id"`
-	EpisodeID uuid.UUID `json:"episode_id"`
-	AssetType string    `json:"asset_type"`
-	MimeType  string    `json:"mime_type"`
-	SizeBytes *int64    `json:"size_bytes"`
-	Url       *string   `json:"url"`
-	Storage   []byte    `json:"storage"`
-	CreatedAt time.Time `json:"created_at"`
+	ID        uuid.UUID  `json:"id"`
+	EpisodeID uuid.UUID  `json:"episode_id"`
+	AssetType string     `json:"asset_type"`
+	MimeType  string     `json:"mime_type"`
+	SizeBytes *int64     `json:"size_bytes"`
+	Url       *string    `json:"url"`
+	Storage   []byte     `json:"storage"`
+	CreatedAt time.Time  `json:"created_at"`
+	DeletedAt *time.Time `json:"deleted_at"`
+}
+
+type EpisodeTranslation struct {
+	EpisodeID   uuid.UUID `json:"episode_id"`
+	Locale      string    `json:"locale"`
+	Title       string    `json:"title"`
+	Description *string   `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type ImportJob struct {
+	ID           uuid.UUID  `json:"id"`
+	SeriesID     uuid.UUID  `json:"series_id"`
+	SourceType   string     `json:"source_type"`
+	SourceUrl    string     `json:"source_url"`
+	Status       string     `json:"status"`
+	CreatedCount int32      `json:"created_count"`
+	UpdatedCount int32      `json:"updated_count"`
+	SkippedCount int32      `json:"skipped_count"`
+	Errors       []byte     `json:"errors"`
+	StartedAt    *time.Time `json:"started_at"`
+	FinishedAt   *time.Time `json:"finished_at"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+type OutboxEvent struct {
+	ID        int64      `json:"id"`
+	TaskType  string     `json:"task_type"`
+	Payload   []byte     `json:"payload"`
+	Attempts  int32      `json:"attempts"`
+	LastError *string    `json:"last_error"`
+	CreatedAt time.Time  `json:"created_at"`
+	SentAt    *time.Time `json:"sent_at"`
+	FailedAt  *time.Time `json:"failed_at"`
 }
 
 type Series struct {
-	ID          uuid.UUID  `json:"id"`
-	Title       string     `json:"title"`
-	Description *string    `json:"description"`
-	CategoryID  uuid.UUID  `json:"category_id"`
-	Language    *string    `json:"language"`
-	SeriesType  string     `json:"series_type"`
-	CreatedAt   time.Time  `json:"created_at"`
-	UpdatedAt   time.Time  `json:"updated_at"`
-	DeletedAt   *time.Time `json:"deleted_at"`
+	ID            uuid.UUID  `json:"id"`
+	Title         string     `json:"title"`
+	Description   *string    `json:"description"`
+	CategoryID    uuid.UUID  `json:"category_id"`
+	Language      *string    `json:"language"`
+	SeriesType    string     `json:"series_type"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+	DeletedAt     *time.Time `json:"deleted_at"`
+	Slug          string     `json:"slug"`
+	PreviousSlugs []string   `json:"previous_slugs"`
+}
+
+type SeriesSubscription struct {
+	SeriesID        uuid.UUID  `json:"series_id"`
+	SourceType      string     `json:"source_type"`
+	SourceUrl       string     `json:"source_url"`
+	IntervalSeconds int32      `json:"interval_seconds"`
+	Etag            *string    `json:"etag"`
+	LastModified    *string    `json:"last_modified"`
+	NextSyncAt      time.Time  `json:"next_sync_at"`
+	LastSyncedAt    *time.Time `json:"last_synced_at"`
+	LastJobID       *uuid.UUID `json:"last_job_id"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+type SeriesTranslation struct {
+	SeriesID    uuid.UUID `json:"series_id"`
+	Locale      string    `json:"locale"`
+	Title       string    `json:"title"`
+	Description *string   `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
 }
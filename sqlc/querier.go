@@ -6,43 +6,227 @@ package sqlc
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type Querier interface {
+	// Series Subscriptions
+	// Pushes the next sync of due subscriptions forward by their interval and
+	// returns them. Rows locked by a concurrent claim are skipped, so several
+	// schedulers can run this at the same time.
+	ClaimDueSubscriptions(ctx context.Context, limit int32) ([]SeriesSubscription, error)
+	// Locks the oldest unsent events until the end of the transaction, skipping
+	// parked ones. Callers hold the relay lock, see TryLockOutboxRelay.
+	ClaimOutboxEvents(ctx context.Context, limit int32) ([]OutboxEvent, error)
+	// Audit Events
+	CountAuditEventsByEntity(ctx context.Context, entityID uuid.UUID) (int64, error)
 	// Categories
 	CountCategories(ctx context.Context) (int64, error)
+	CountChildCategories(ctx context.Context, parentID *uuid.UUID) (int64, error)
+	CountContentRevisions(ctx context.Context, arg CountContentRevisionsParams) (int64, error)
 	// Episodes
 	CountEpisodesBySeries(ctx context.Context, seriesID uuid.UUID) (int64, error)
+	CountEpisodesDeletedWithSeries(ctx context.Context, arg CountEpisodesDeletedWithSeriesParams) (int64, error)
+	// Import Jobs
+	CountImportJobsBySeries(ctx context.Context, seriesID uuid.UUID) (int64, error)
 	// Series
 	CountSeries(ctx context.Context) (int64, error)
+	CountSeriesByCategory(ctx context.Context, categoryID uuid.UUID) (int64, error)
+	// Trash
+	CountTrash(ctx context.Context, entityType *string) (int64, error)
 	CreateAsset(ctx context.Context, arg CreateAssetParams) (EpisodeAsset, error)
-	CreateCategory(ctx context.Context, slug string) (Category, error)
+	CreateAuditEvent(ctx context.Context, arg CreateAuditEventParams) error
+	CreateCategory(ctx context.Context, arg CreateCategoryParams) (Category, error)
+	// Deletion Jobs
+	CreateDeletionJob(ctx context.Context, arg CreateDeletionJobParams) (DeletionJob, error)
 	CreateEpisode(ctx context.Context, arg CreateEpisodeParams) (Episode, error)
+	// Stores the current row of the episode as its next revision. Callers hold
+	// the row lock of the episode, so concurrent changes cannot take the same
+	// number.
+	CreateEpisodeRevision(ctx context.Context, arg CreateEpisodeRevisionParams) (ContentRevision, error)
+	CreateImportJob(ctx context.Context, arg CreateImportJobParams) (ImportJob, error)
+	// Outbox
+	CreateOutboxEvent(ctx context.Context, arg CreateOutboxEventParams) error
 	CreateSeries(ctx context.Context, arg CreateSeriesParams) (Series, error)
+	// Stores the current row of the series as its next revision. Callers hold
+	// the row lock of the series, so concurrent changes cannot take the same
+	// number.
+	CreateSeriesRevision(ctx context.Context, arg CreateSeriesRevisionParams) (ContentRevision, error)
 	DeleteAsset(ctx context.Context, id uuid.UUID) error
+	DeleteAssetsByEpisodes(ctx context.Context, arg DeleteAssetsByEpisodesParams) ([]EpisodeAsset, error)
 	DeleteCategory(ctx context.Context, id uuid.UUID) error
-	DeleteEpisode(ctx context.Context, id uuid.UUID) error
-	DeleteSeries(ctx context.Context, id uuid.UUID) error
+	DeleteCategoryTranslation(ctx context.Context, arg DeleteCategoryTranslationParams) (CategoryTranslation, error)
+	DeleteEpisode(ctx context.Context, id uuid.UUID) (*time.Time, error)
+	DeleteEpisodeTranslation(ctx context.Context, arg DeleteEpisodeTranslationParams) (EpisodeTranslation, error)
+	// Soft deletes up to row_limit live episodes of a series with the deletion
+	// time of the series, which marks them as deleted together with it.
+	DeleteEpisodesBySeries(ctx context.Context, arg DeleteEpisodesBySeriesParams) ([]Episode, error)
+	DeleteSentOutboxEvents(ctx context.Context, before time.Time) (int64, error)
+	DeleteSeries(ctx context.Context, id uuid.UUID) (*time.Time, error)
+	DeleteSeriesSubscription(ctx context.Context, seriesID uuid.UUID) error
+	DeleteSeriesTranslation(ctx context.Context, arg DeleteSeriesTranslationParams) (SeriesTranslation, error)
+	FinishDeletionJob(ctx context.Context, arg FinishDeletionJobParams) error
+	FinishImportJob(ctx context.Context, arg FinishImportJobParams) error
 	GetAsset(ctx context.Context, id uuid.UUID) (EpisodeAsset, error)
 	GetCategory(ctx context.Context, id uuid.UUID) (Category, error)
+	// Keeps the live category from being deleted until the end of the
+	// transaction that places a series in it.
+	GetCategoryForShare(ctx context.Context, id uuid.UUID) (Category, error)
+	// Locks the live category until the end of the transaction, so no series
+	// can be created in or moved into it while it is deleted.
+	GetCategoryForUpdate(ctx context.Context, id uuid.UUID) (Category, error)
+	GetCategoryTranslation(ctx context.Context, arg GetCategoryTranslationParams) (CategoryTranslation, error)
+	GetContentRevision(ctx context.Context, arg GetContentRevisionParams) (ContentRevision, error)
+	GetDeletedCategory(ctx context.Context, id uuid.UUID) (Category, error)
+	GetDeletedEpisode(ctx context.Context, id uuid.UUID) (Episode, error)
+	// Locks the series until the end of the transaction, so a restore and a
+	// deletion job never work on it at the same time.
+	GetDeletedSeries(ctx context.Context, id uuid.UUID) (Series, error)
+	GetDeletionJob(ctx context.Context, id uuid.UUID) (DeletionJob, error)
 	GetEpisode(ctx context.Context, id uuid.UUID) (Episode, error)
+	// Finds the live episode of a series that used slug before. When several
+	// did, the one renamed last wins.
+	GetEpisodeByPreviousSlug(ctx context.Context, arg GetEpisodeByPreviousSlugParams) (Episode, error)
+	GetEpisodeBySlug(ctx context.Context, arg GetEpisodeBySlugParams) (Episode, error)
+	GetEpisodeBySource(ctx context.Context, arg GetEpisodeBySourceParams) (Episode, error)
+	GetEpisodeTranslation(ctx context.Context, arg GetEpisodeTranslationParams) (EpisodeTranslation, error)
 	GetEpisodeWithAssets(ctx context.Context, id uuid.UUID) ([]GetEpisodeWithAssetsRow, error)
+	GetImportJob(ctx context.Context, id uuid.UUID) (ImportJob, error)
+	GetOutboxBacklog(ctx context.Context) (GetOutboxBacklogRow, error)
 	GetSeries(ctx context.Context, id uuid.UUID) (Series, error)
+	// Finds the live series that used slug before. When several did, the one
+	// renamed last wins.
+	GetSeriesByPreviousSlug(ctx context.Context, slug string) (Series, error)
+	GetSeriesBySlug(ctx context.Context, slug string) (Series, error)
+	GetSeriesSubscription(ctx context.Context, seriesID uuid.UUID) (SeriesSubscription, error)
+	GetSeriesTranslation(ctx context.Context, arg GetSeriesTranslationParams) (SeriesTranslation, error)
 	// Episode Assets
 	ListAssetsByEpisode(ctx context.Context, episodeID uuid.UUID) ([]EpisodeAsset, error)
+	ListAssetsByEpisodes(ctx context.Context, episodeIds []uuid.UUID) ([]EpisodeAsset, error)
+	ListAssetsDeletedWithSeries(ctx context.Context, arg ListAssetsDeletedWithSeriesParams) ([]EpisodeAsset, error)
+	ListAuditEventsByEntityPaginated(ctx context.Context, arg ListAuditEventsByEntityPaginatedParams) ([]AuditEvent, error)
 	ListCategories(ctx context.Context) ([]Category, error)
 	ListCategoriesPaginated(ctx context.Context, arg ListCategoriesPaginatedParams) ([]Category, error)
+	// Lists the ids of the category and of all categories below it, deleted
+	// ones included.
+	ListCategoryDescendantIDs(ctx context.Context, id uuid.UUID) ([]uuid.UUID, error)
+	// Lists the ids from the root category down to the category.
+	ListCategoryPath(ctx context.Context, id uuid.UUID) ([]uuid.UUID, error)
+	// Lists every category with the ids from its root category down to it.
+	ListCategoryPaths(ctx context.Context) ([]ListCategoryPathsRow, error)
+	// Lists the slugs equal to slug or numbered variants of it, such as
+	// slug-2. Deleted categories keep their slug and are included.
+	ListCategorySlugs(ctx context.Context, slug string) ([]string, error)
+	ListCategoryTranslations(ctx context.Context, categoryIds []uuid.UUID) ([]CategoryTranslation, error)
+	ListContentRevisionsPaginated(ctx context.Context, arg ListContentRevisionsPaginatedParams) ([]ContentRevision, error)
+	// Lists the slugs of a series equal to slug or numbered variants of it,
+	// deleted episodes included.
+	ListEpisodeSlugs(ctx context.Context, arg ListEpisodeSlugsParams) ([]string, error)
+	ListEpisodeTranslations(ctx context.Context, episodeIds []uuid.UUID) ([]EpisodeTranslation, error)
+	// Pages through the live episodes of live series in id order for a full
+	// reindex.
+	ListEpisodesAfter(ctx context.Context, arg ListEpisodesAfterParams) ([]Episode, error)
 	ListEpisodesBySeries(ctx context.Context, seriesID uuid.UUID) ([]Episode, error)
 	ListEpisodesBySeriesPaginated(ctx context.Context, arg ListEpisodesBySeriesPaginatedParams) ([]Episode, error)
+	// Pages through the episodes whose search document changed at or after
+	// since: the episode was written or deleted, its series was deleted or it
+	// got a new asset. Removed reports whether the document has to go.
+	ListEpisodesChangedSince(ctx context.Context, arg ListEpisodesChangedSinceParams) ([]ListEpisodesChangedSinceRow, error)
 	ListEpisodesWithAssetsBySeriesPaginated(ctx context.Context, arg ListEpisodesWithAssetsBySeriesPaginatedParams) ([]ListEpisodesWithAssetsBySeriesPaginatedRow, error)
+	ListImportJobsBySeriesPaginated(ctx context.Context, arg ListImportJobsBySeriesPaginatedParams) ([]ImportJob, error)
+	// Pages through the assets that were deleted before deleted_before, or
+	// whose episode or series was, in id order.
+	ListPurgeableAssets(ctx context.Context, arg ListPurgeableAssetsParams) ([]EpisodeAsset, error)
 	ListSeries(ctx context.Context) ([]Series, error)
+	// Reindex
+	// Pages through live series in id order for a full reindex.
+	ListSeriesAfter(ctx context.Context, arg ListSeriesAfterParams) ([]Series, error)
+	ListSeriesByCategory(ctx context.Context, arg ListSeriesByCategoryParams) ([]Series, error)
+	// Pages through the series written or deleted at or after since.
+	ListSeriesChangedSince(ctx context.Context, arg ListSeriesChangedSinceParams) ([]Series, error)
+	// Lists the languages of series, deleted ones included. Episodes are
+	// analyzed in the language of their series.
+	ListSeriesLanguages(ctx context.Context, ids []uuid.UUID) ([]ListSeriesLanguagesRow, error)
 	ListSeriesPaginated(ctx context.Context, arg ListSeriesPaginatedParams) ([]Series, error)
+	// Lists the slugs equal to slug or numbered variants of it. Deleted series
+	// keep their slug and are included.
+	ListSeriesSlugs(ctx context.Context, slug string) ([]string, error)
+	ListSeriesTranslations(ctx context.Context, seriesIds []uuid.UUID) ([]SeriesTranslation, error)
+	// Lists deleted categories, series and episodes, most recently deleted
+	// first. Title is the name of a category.
+	ListTrashPaginated(ctx context.Context, arg ListTrashPaginatedParams) ([]ListTrashPaginatedRow, error)
+	MarkOutboxEventsSent(ctx context.Context, ids []int64) error
+	MoveCategory(ctx context.Context, arg MoveCategoryParams) (Category, error)
+	// Publishes up to row_limit scheduled episodes of live series whose publish
+	// date is not after due_before. Episodes another run is publishing are
+	// skipped.
+	PublishDueEpisodes(ctx context.Context, arg PublishDueEpisodesParams) ([]Episode, error)
+	PurgeAssets(ctx context.Context, ids []uuid.UUID) (int64, error)
+	// Deletes up to row_limit categories that were deleted before
+	// deleted_before and are not used by any series, deleted or not, nor
+	// the parent of another category.
+	PurgeCategories(ctx context.Context, arg PurgeCategoriesParams) ([]uuid.UUID, error)
+	// Deletes up to row_limit episodes that were deleted before deleted_before,
+	// or whose series was. Episodes that still have assets are kept, their
+	// stored objects could not be removed yet.
+	PurgeEpisodes(ctx context.Context, arg PurgeEpisodesParams) ([]uuid.UUID, error)
+	// Deletes up to row_limit series that were deleted before deleted_before
+	// and have no episodes left.
+	PurgeSeries(ctx context.Context, arg PurgeSeriesParams) ([]uuid.UUID, error)
+	// Moves the live series of a category to another one.
+	ReassignSeriesCategory(ctx context.Context, arg ReassignSeriesCategoryParams) ([]Series, error)
+	// Counts a failed attempt. With park set, an event that reached max_attempts
+	// is parked and returns its failed_at.
+	RecordOutboxEventFailure(ctx context.Context, arg RecordOutboxEventFailureParams) (*time.Time, error)
+	// Restores the assets that were deleted together with their episode.
+	RestoreAssetsByEpisodes(ctx context.Context, arg RestoreAssetsByEpisodesParams) ([]EpisodeAsset, error)
+	RestoreCategory(ctx context.Context, id uuid.UUID) (Category, error)
+	RestoreEpisode(ctx context.Context, id uuid.UUID) (Episode, error)
+	// Restores the episodes that were deleted together with their series.
+	RestoreEpisodesBySeries(ctx context.Context, arg RestoreEpisodesBySeriesParams) ([]Episode, error)
+	RestoreSeries(ctx context.Context, id uuid.UUID) (Series, error)
+	// Moves a live episode from from_status to status. No row is returned when
+	// its status changed in the meantime, so concurrent changes do not
+	// overwrite each other.
+	SetEpisodeStatus(ctx context.Context, arg SetEpisodeStatusParams) (Episode, error)
+	SetSubscriptionLastJob(ctx context.Context, arg SetSubscriptionLastJobParams) error
+	StartDeletionJob(ctx context.Context, id uuid.UUID) error
+	StartImportJob(ctx context.Context, id uuid.UUID) error
+	// Marks a live episode as updated when its search document changes without
+	// the row, such as with its translations.
+	TouchEpisode(ctx context.Context, id uuid.UUID) (Episode, error)
+	// Marks a live series as updated when its search document changes without
+	// the row, such as with its translations.
+	TouchSeries(ctx context.Context, id uuid.UUID) (Series, error)
+	// Marks the live series of any of the categories as updated, their search
+	// documents change with the category path. Reindex catch-up and reconcile
+	// compare updated_at.
+	TouchSeriesByCategories(ctx context.Context, categoryIds []uuid.UUID) ([]Series, error)
+	// Takes the relay lock until the end of the transaction, false when another
+	// relay holds it.
+	TryLockOutboxRelay(ctx context.Context) (bool, error)
 	UpdateAsset(ctx context.Context, arg UpdateAssetParams) (EpisodeAsset, error)
 	UpdateCategory(ctx context.Context, arg UpdateCategoryParams) (Category, error)
+	// A changed slug moves the current one to previous_slugs, so links using
+	// it can be redirected.
 	UpdateEpisode(ctx context.Context, arg UpdateEpisodeParams) (Episode, error)
+	UpdateImportJobProgress(ctx context.Context, arg UpdateImportJobProgressParams) error
+	// A changed slug moves the current one to previous_slugs, so links using
+	// it can be redirected.
 	UpdateSeries(ctx context.Context, arg UpdateSeriesParams) (Series, error)
+	UpdateSubscriptionSync(ctx context.Context, arg UpdateSubscriptionSyncParams) error
+	UpsertCategoryTranslation(ctx context.Context, arg UpsertCategoryTranslationParams) (CategoryTranslation, error)
+	UpsertEpisodeTranslation(ctx context.Context, arg UpsertEpisodeTranslationParams) (EpisodeTranslation, error)
+	// Inserts an imported episode or updates the one with the same source
+	// identity. No row is returned when the stored episode is already up to
+	// date or has been deleted. The slug is only set on insert.
+	UpsertImportedEpisode(ctx context.Context, arg UpsertImportedEpisodeParams) (UpsertImportedEpisodeRow, error)
+	// Validators are kept only while the URL stays the same. A changed
+	// subscription is synced on the next scheduler tick.
+	UpsertSeriesSubscription(ctx context.Context, arg UpsertSeriesSubscriptionParams) (SeriesSubscription, error)
+	UpsertSeriesTranslation(ctx context.Context, arg UpsertSeriesTranslationParams) (SeriesTranslation, error)
 }
 
 var _ Querier = (*Queries)(nil)
@@ -4,15 +4,15 @@
 SELECT COUNT(*) FROM categories WHERE deleted_at IS NULL;
 
 -- name: ListCategoriesPaginated :many
-SELECT id, slug, created_at, updated_at, deleted_at
+SELECT id, slug, created_at, updated_at, deleted_at, name, parent_id
 FROM categories
 WHERE deleted_at IS NULL
 ORDER BY updated_at
 LIMIT $1 OFFSET $2;
 
 -- name: CreateCategory :one
-INSERT INTO categories (slug)
-VALUES ($1)
+INSERT INTO categories (name, slug, parent_id)
+VALUES ($1, $2, $3)
 RETURNING *;
 
 -- name: GetCategory :one
@@ -20,6 +20,22 @@ SELECT * FROM categories
 WHERE id = $1
   AND deleted_at IS NULL;
 
+-- name: GetCategoryForUpdate :one
+-- Locks the live category until the end of the transaction, so no series
+-- can be created in or moved into it while it is deleted.
+SELECT * FROM categories
+WHERE id = $1
+  AND deleted_at IS NULL
+FOR UPDATE;
+
+-- name: GetCategoryForShare :one
+-- Keeps the live category from being deleted until the end of the
+-- transaction that places a series in it.
+SELECT * FROM categories
+WHERE id = $1
+  AND deleted_at IS NULL
+FOR SHARE;
+
 -- name: ListCategories :many
 SELECT * FROM categories
 WHERE deleted_at IS NULL
@@ -27,18 +43,102 @@ ORDER BY updated_at;
 
 -- name: UpdateCategory :one
 UPDATE categories
-SET slug = $2,
+SET name = $2,
+    slug = $3,
     updated_at = NOW()
 WHERE id = $1
   AND deleted_at IS NULL
 RETURNING *;
 
+-- name: ListCategorySlugs :many
+-- Lists the slugs equal to slug or numbered variants of it, such as
+-- slug-2. Deleted categories keep their slug and are included.
+SELECT slug FROM categories
+WHERE slug = @slug::text
+   OR slug LIKE @slug::text || '-%';
+
+-- name: MoveCategory :one
+UPDATE categories
+SET parent_id = $2,
+    updated_at = NOW()
+WHERE id = $1
+  AND deleted_at IS NULL
+RETURNING *;
+
+-- name: ListCategoryDescendantIDs :many
+-- Lists the ids of the category and of all categories below it, deleted
+-- ones included.
+WITH RECURSIVE tree AS (
+    SELECT id FROM categories WHERE categories.id = @id
+    UNION
+    SELECT c.id FROM categories c
+    JOIN tree t ON c.parent_id = t.id
+)
+SELECT id FROM tree;
+
+-- name: ListCategoryPath :many
+-- Lists the ids from the root category down to the category.
+WITH RECURSIVE path AS (
+    SELECT id, parent_id, 0 AS depth FROM categories WHERE categories.id = @id
+    UNION ALL
+    SELECT c.id, c.parent_id, p.depth + 1 FROM categories c
+    JOIN path p ON c.id = p.parent_id
+)
+SELECT id FROM path
+ORDER BY depth DESC;
+
+-- name: ListCategoryPaths :many
+-- Lists every category with the ids from its root category down to it.
+WITH RECURSIVE paths AS (
+    SELECT id, ARRAY[id] AS path FROM categories WHERE parent_id IS NULL
+    UNION ALL
+    SELECT c.id, p.path || c.id FROM categories c
+    JOIN paths p ON c.parent_id = p.id
+)
+SELECT id, path::uuid[] AS path FROM paths;
+
+-- name: CountChildCategories :one
+SELECT COUNT(*) FROM categories
+WHERE parent_id = $1
+  AND deleted_at IS NULL;
+
+-- name: TouchSeriesByCategories :many
+-- Marks the live series of any of the categories as updated, their search
+-- documents change with the category path. Reindex catch-up and reconcile
+-- compare updated_at.
+UPDATE series
+SET updated_at = NOW()
+WHERE category_id = ANY(@category_ids::uuid[])
+  AND deleted_at IS NULL
+RETURNING *;
+
 -- name: DeleteCategory :exec
 UPDATE categories
 SET deleted_at = NOW()
 WHERE id = $1
   AND deleted_at IS NULL;
 
+-- name: CountSeriesByCategory :one
+SELECT COUNT(*) FROM series
+WHERE category_id = $1
+  AND deleted_at IS NULL;
+
+-- name: ListSeriesByCategory :many
+SELECT * FROM series
+WHERE category_id = $1
+  AND deleted_at IS NULL
+ORDER BY title, id
+LIMIT $2;
+
+-- name: ReassignSeriesCategory :many
+-- Moves the live series of a category to another one.
+UPDATE series
+SET category_id = @to_category_id,
+    updated_at = NOW()
+WHERE category_id = @from_category_id
+  AND deleted_at IS NULL
+RETURNING *;
+
 -- Series
 
 -- name: CountSeries :one
@@ -46,15 +146,15 @@ SELECT COUNT(*) FROM series WHERE deleted_at IS NULL;
 
 -- name: ListSeriesPaginated :many
 SELECT id, title, description, category_id, language, series_type,
-       created_at, updated_at, deleted_at 
+       created_at, updated_at, deleted_at, slug, previous_slugs
 FROM series
 WHERE deleted_at IS NULL
 ORDER BY created_at DESC
 LIMIT $1 OFFSET $2;
 
 -- name: CreateSeries :one
-INSERT INTO series (title, description, category_id, language, series_type)
-VALUES ($1, $2, $3, $4, $5)
+INSERT INTO series (title, description, category_id, language, series_type, slug)
+VALUES ($1, $2, $3, $4, $5, $6)
 RETURNING *;
 
 -- name: GetSeries :one
@@ -68,23 +168,56 @@ WHERE deleted_at IS NULL
 ORDER BY created_at DESC;
 
 -- name: UpdateSeries :one
+-- A changed slug moves the current one to previous_slugs, so links using
+-- it can be redirected.
 UPDATE series
 SET title = $2,
     description = $3,
     category_id = $4,
     language = $5,
     series_type = $6,
+    slug = $7,
+    previous_slugs = CASE WHEN slug = $7 THEN previous_slugs
+                          ELSE array_append(array_remove(previous_slugs, $7::text), slug) END,
     updated_at = NOW()
 WHERE id = $1
   AND deleted_at IS NULL
 RETURNING *;
 
--- name: DeleteSeries :exec
+-- name: DeleteSeries :one
 UPDATE series
 SET deleted_at = NOW()
 WHERE id = $1
+  AND deleted_at IS NULL
+RETURNING deleted_at;
+
+-- name: GetSeriesBySlug :one
+SELECT * FROM series
+WHERE slug = $1
   AND deleted_at IS NULL;
 
+-- name: GetSeriesByPreviousSlug :one
+-- Finds the live series that used slug before. When several did, the one
+-- renamed last wins.
+SELECT * FROM series
+WHERE previous_slugs @> ARRAY[@slug::text]
+  AND deleted_at IS NULL
+ORDER BY updated_at DESC
+LIMIT 1;
+
+-- name: ListSeriesSlugs :many
+-- Lists the slugs equal to slug or numbered variants of it. Deleted series
+-- keep their slug and are included.
+SELECT slug FROM series
+WHERE slug = @slug::text
+   OR slug LIKE @slug::text || '-%';
+
+-- name: ListSeriesLanguages :many
+-- Lists the languages of series, deleted ones included. Episodes are
+-- analyzed in the language of their series.
+SELECT id, language FROM series
+WHERE id = ANY(@ids::uuid[]);
+
 -- Episodes
 
 -- name: CountEpisodesBySeries :one
@@ -93,7 +226,8 @@ WHERE series_id = $1 AND deleted_at IS NULL;
 
 -- name: ListEpisodesBySeriesPaginated :many
 SELECT id, series_id, title, description, duration_seconds,
-       publish_date, created_at, updated_at, deleted_at
+       publish_date, created_at, updated_at, deleted_at,
+       source_type, external_id, slug, previous_slugs, status
 FROM episodes
 WHERE series_id = $1 AND deleted_at IS NULL
 ORDER BY publish_date DESC
@@ -102,9 +236,9 @@ LIMIT $2 OFFSET $3;
 -- name: CreateEpisode :one
 INSERT INTO episodes (
     series_id, title, description,
-    duration_seconds, publish_date
+    duration_seconds, publish_date, slug, status
 )
-VALUES ($1, $2, $3, $4, $5)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
 RETURNING *;
 
 -- name: GetEpisode :one
@@ -112,6 +246,36 @@ SELECT * FROM episodes
 WHERE id = $1
   AND deleted_at IS NULL;
 
+-- name: GetEpisodeBySource :one
+SELECT * FROM episodes
+WHERE series_id = $1
+  AND source_type = $2
+  AND external_id = $3
+  AND deleted_at IS NULL;
+
+-- name: UpsertImportedEpisode :one
+-- Inserts an imported episode or updates the one with the same source
+-- identity. No row is returned when the stored episode is already up to
+-- date or has been deleted. The slug and status are only set on insert,
+-- so an update keeps the status the episode got in the CMS.
+INSERT INTO episodes (
+    series_id, title, description,
+    duration_seconds, publish_date,
+    source_type, external_id, slug, status
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+ON CONFLICT (series_id, source_type, external_id) DO UPDATE
+SET title = EXCLUDED.title,
+    description = EXCLUDED.description,
+    duration_seconds = EXCLUDED.duration_seconds,
+    publish_date = EXCLUDED.publish_date,
+    updated_at = NOW()
+WHERE episodes.deleted_at IS NULL
+  AND (episodes.title, episodes.description, episodes.duration_seconds, episodes.publish_date)
+      IS DISTINCT FROM
+      (EXCLUDED.title, EXCLUDED.description, EXCLUDED.duration_seconds, EXCLUDED.publish_date)
+RETURNING *, (xmax = 0) AS inserted;
+
 -- name: ListEpisodesBySeries :many
 SELECT * FROM episodes
 WHERE series_id = $1
@@ -119,27 +283,90 @@ WHERE series_id = $1
 ORDER BY publish_date DESC;
 
 -- name: UpdateEpisode :one
+-- A changed slug moves the current one to previous_slugs, so links using
+-- it can be redirected.
 UPDATE episodes
 SET title = $2,
     description = $3,
     duration_seconds = $4,
     publish_date = $5,
+    slug = $6,
+    previous_slugs = CASE WHEN slug = $6 THEN previous_slugs
+                          ELSE array_append(array_remove(previous_slugs, $6::text), slug) END,
     updated_at = NOW()
 WHERE id = $1
   AND deleted_at IS NULL
 RETURNING *;
 
--- name: DeleteEpisode :exec
+-- name: DeleteEpisode :one
 UPDATE episodes
 SET deleted_at = NOW()
 WHERE id = $1
+  AND deleted_at IS NULL
+RETURNING deleted_at;
+
+-- name: GetEpisodeBySlug :one
+SELECT * FROM episodes
+WHERE series_id = $1
+  AND slug = $2
   AND deleted_at IS NULL;
 
+-- name: GetEpisodeByPreviousSlug :one
+-- Finds the live episode of a series that used slug before. When several
+-- did, the one renamed last wins.
+SELECT * FROM episodes
+WHERE series_id = @series_id
+  AND previous_slugs @> ARRAY[@slug::text]
+  AND deleted_at IS NULL
+ORDER BY updated_at DESC
+LIMIT 1;
+
+-- name: ListEpisodeSlugs :many
+-- Lists the slugs of a series equal to slug or numbered variants of it,
+-- deleted episodes included.
+SELECT slug FROM episodes
+WHERE series_id = @series_id
+  AND (slug = @slug::text OR slug LIKE @slug::text || '-%');
+
+-- name: SetEpisodeStatus :one
+-- Moves a live episode from from_status to status. No row is returned when
+-- its status changed in the meantime, so concurrent changes do not
+-- overwrite each other.
+UPDATE episodes
+SET status = @status,
+    publish_date = @publish_date,
+    updated_at = NOW()
+WHERE id = @id
+  AND status = @from_status
+  AND deleted_at IS NULL
+RETURNING *;
+
+-- name: PublishDueEpisodes :many
+-- Publishes up to row_limit scheduled episodes of live series whose publish
+-- date is not after due_before. Episodes another run is publishing are
+-- skipped.
+UPDATE episodes
+SET status = 'published',
+    updated_at = NOW()
+WHERE id IN (
+    SELECT e.id FROM episodes e
+    JOIN series s ON s.id = e.series_id
+    WHERE e.status = 'scheduled'
+      AND e.publish_date <= @due_before::timestamptz
+      AND e.deleted_at IS NULL
+      AND s.deleted_at IS NULL
+    ORDER BY e.publish_date
+    LIMIT @row_limit
+    FOR UPDATE OF e SKIP LOCKED
+)
+RETURNING *;
+
 -- Episode Assets
 
 -- name: ListAssetsByEpisode :many
 SELECT * FROM episode_assets
-WHERE episode_id = $1;
+WHERE episode_id = $1
+  AND deleted_at IS NULL;
 
 -- name: CreateAsset :one
 INSERT INTO episode_assets (
@@ -150,7 +377,8 @@ RETURNING *;
 
 -- name: GetAsset :one
 SELECT * FROM episode_assets
-WHERE id = $1;
+WHERE id = $1
+  AND deleted_at IS NULL;
 
 -- name: UpdateAsset :one
 UPDATE episode_assets
@@ -170,6 +398,7 @@ SELECT
     e.id               AS episode_id,
     e.series_id,
     e.title,
+    e.slug,
     e.description,
     e.duration_seconds,
     e.publish_date,
@@ -183,7 +412,7 @@ SELECT
     a.storage,
     a.created_at       AS asset_created_at
 FROM episodes e
-LEFT JOIN episode_assets a ON e.id = a.episode_id
+LEFT JOIN episode_assets a ON e.id = a.episode_id AND a.deleted_at IS NULL
 WHERE e.id = $1 AND e.deleted_at IS NULL;
 
 -- name: ListEpisodesWithAssetsBySeriesPaginated :many
@@ -191,9 +420,11 @@ SELECT
     e.id AS episode_id,
     e.series_id,
     e.title,
+    e.slug,
     e.description,
     e.duration_seconds,
     e.publish_date,
+    e.status,
     e.created_at AS episode_created_at,
     e.updated_at AS episode_updated_at,
     a.id AS asset_id,
@@ -206,9 +437,592 @@ SELECT
 FROM
     episodes e
 LEFT JOIN
-    episode_assets a ON e.id = a.episode_id
+    episode_assets a ON e.id = a.episode_id AND a.deleted_at IS NULL
 WHERE
     e.series_id = $1 AND e.deleted_at IS NULL
 ORDER BY
     e.publish_date DESC
-LIMIT $2 OFFSET $3;
\ No newline at end of file
+LIMIT $2 OFFSET $3;
+
+-- Import Jobs
+
+-- name: CountImportJobsBySeries :one
+SELECT COUNT(*) FROM import_jobs
+WHERE series_id = $1;
+
+-- name: ListImportJobsBySeriesPaginated :many
+SELECT * FROM import_jobs
+WHERE series_id = $1
+ORDER BY created_at DESC
+LIMIT $2 OFFSET $3;
+
+-- name: CreateImportJob :one
+INSERT INTO import_jobs (series_id, source_type, source_url)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: GetImportJob :one
+SELECT * FROM import_jobs
+WHERE id = $1;
+
+-- name: StartImportJob :exec
+UPDATE import_jobs
+SET status = 'running',
+    created_count = 0,
+    updated_count = 0,
+    skipped_count = 0,
+    errors = '[]',
+    started_at = COALESCE(started_at, NOW()),
+    finished_at = NULL,
+    updated_at = NOW()
+WHERE id = $1;
+
+-- name: UpdateImportJobProgress :exec
+UPDATE import_jobs
+SET created_count = $2,
+    updated_count = $3,
+    skipped_count = $4,
+    errors = $5,
+    updated_at = NOW()
+WHERE id = $1;
+
+-- name: FinishImportJob :exec
+UPDATE import_jobs
+SET status = $2,
+    created_count = $3,
+    updated_count = $4,
+    skipped_count = $5,
+    errors = $6,
+    finished_at = NOW(),
+    updated_at = NOW()
+WHERE id = $1;
+
+-- Series Subscriptions
+
+-- name: ClaimDueSubscriptions :many
+-- Pushes the next sync of due subscriptions forward by their interval and
+-- returns them. Rows locked by a concurrent claim are skipped, so several
+-- schedulers can run this at the same time.
+UPDATE series_subscriptions
+SET next_sync_at = NOW() + make_interval(secs => interval_seconds),
+    updated_at = NOW()
+WHERE series_id IN (
+    SELECT sub.series_id
+    FROM series_subscriptions sub
+    JOIN series s ON s.id = sub.series_id
+    WHERE sub.next_sync_at <= NOW()
+      AND s.deleted_at IS NULL
+    ORDER BY sub.next_sync_at
+    LIMIT $1
+    FOR UPDATE OF sub SKIP LOCKED
+)
+RETURNING *;
+
+-- name: UpsertSeriesSubscription :one
+-- Validators are kept only while the URL stays the same. A changed
+-- subscription is synced on the next scheduler tick.
+INSERT INTO series_subscriptions (series_id, source_type, source_url, interval_seconds)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (series_id) DO UPDATE
+SET source_type = EXCLUDED.source_type,
+    source_url = EXCLUDED.source_url,
+    interval_seconds = EXCLUDED.interval_seconds,
+    etag = CASE WHEN series_subscriptions.source_url = EXCLUDED.source_url THEN series_subscriptions.etag END,
+    last_modified = CASE WHEN series_subscriptions.source_url = EXCLUDED.source_url THEN series_subscriptions.last_modified END,
+    next_sync_at = NOW(),
+    updated_at = NOW()
+RETURNING *;
+
+-- name: GetSeriesSubscription :one
+SELECT * FROM series_subscriptions
+WHERE series_id = $1;
+
+-- name: SetSubscriptionLastJob :exec
+UPDATE series_subscriptions
+SET last_job_id = $2,
+    updated_at = NOW()
+WHERE series_id = $1;
+
+-- name: UpdateSubscriptionSync :exec
+UPDATE series_subscriptions
+SET etag = $2,
+    last_modified = $3,
+    last_synced_at = NOW(),
+    updated_at = NOW()
+WHERE series_id = $1;
+
+-- name: DeleteSeriesSubscription :exec
+DELETE FROM series_subscriptions
+WHERE series_id = $1;
+
+-- Audit Events
+
+-- name: CountAuditEventsByEntity :one
+SELECT COUNT(*) FROM audit_events
+WHERE entity_id = $1;
+
+-- name: ListAuditEventsByEntityPaginated :many
+SELECT * FROM audit_events
+WHERE entity_id = $1
+ORDER BY created_at DESC, id
+LIMIT $2 OFFSET $3;
+
+-- name: CreateAuditEvent :exec
+INSERT INTO audit_events (actor, action, entity_type, entity_id, changes)
+VALUES ($1, $2, $3, $4, $5);
+
+-- Outbox
+
+-- name: CreateOutboxEvent :exec
+INSERT INTO outbox_events (task_type, payload)
+VALUES ($1, $2);
+
+-- name: TryLockOutboxRelay :one
+-- Takes the relay lock until the end of the transaction, false when another
+-- relay holds it.
+SELECT pg_try_advisory_xact_lock(hashtext('outbox_relay'))::bool AS locked;
+
+-- name: ClaimOutboxEvents :many
+-- Locks the oldest unsent events until the end of the transaction, skipping
+-- parked ones. Callers hold the relay lock, see TryLockOutboxRelay.
+SELECT * FROM outbox_events
+WHERE sent_at IS NULL
+  AND failed_at IS NULL
+ORDER BY id
+LIMIT $1
+FOR UPDATE SKIP LOCKED;
+
+-- name: MarkOutboxEventsSent :exec
+UPDATE outbox_events
+SET sent_at = NOW()
+WHERE id = ANY(@ids::bigint[]);
+
+-- name: RecordOutboxEventFailure :one
+-- Counts a failed attempt. With park set, an event that reached max_attempts
+-- is parked and returns its failed_at.
+UPDATE outbox_events
+SET attempts = attempts + 1,
+    last_error = @last_error,
+    failed_at = CASE WHEN @park::bool AND attempts + 1 >= @max_attempts::int THEN NOW() END
+WHERE id = @id
+RETURNING failed_at;
+
+-- name: GetOutboxBacklog :one
+SELECT COUNT(*) FILTER (WHERE failed_at IS NULL) AS pending,
+       COUNT(*) FILTER (WHERE failed_at IS NOT NULL) AS parked,
+       COALESCE(EXTRACT(EPOCH FROM NOW() - MIN(created_at) FILTER (WHERE failed_at IS NULL)), 0)::float8 AS oldest_age_seconds
+FROM outbox_events
+WHERE sent_at IS NULL;
+
+-- name: DeleteSentOutboxEvents :execrows
+DELETE FROM outbox_events
+WHERE sent_at < @before::timestamptz;
+
+-- Reindex
+
+-- name: ListSeriesAfter :many
+-- Pages through live series in id order for a full reindex.
+SELECT * FROM series
+WHERE deleted_at IS NULL
+  AND id > $1
+ORDER BY id
+LIMIT $2;
+
+-- name: ListEpisodesAfter :many
+-- Pages through the published episodes of live series in id order for a
+-- full reindex.
+SELECT e.* FROM episodes e
+JOIN series s ON s.id = e.series_id
+WHERE e.deleted_at IS NULL
+  AND s.deleted_at IS NULL
+  AND e.status = 'published'
+  AND e.id > $1
+ORDER BY e.id
+LIMIT $2;
+
+-- name: ListAssetsByEpisodes :many
+SELECT * FROM episode_assets
+WHERE episode_id = ANY(@episode_ids::uuid[])
+  AND deleted_at IS NULL
+ORDER BY episode_id, created_at;
+
+-- name: ListSeriesChangedSince :many
+-- Pages through the series written or deleted at or after since.
+SELECT * FROM series
+WHERE (updated_at >= @since OR deleted_at >= @since)
+  AND id > @after_id
+ORDER BY id
+LIMIT @row_limit;
+
+-- name: ListEpisodesChangedSince :many
+-- Pages through the episodes whose search document changed at or after
+-- since: the episode was written or deleted, its series was deleted or it
+-- got a new asset. Removed reports whether the document has to go: the
+-- episode or its series was deleted or the episode is not published.
+SELECT e.*, (e.deleted_at IS NOT NULL OR s.deleted_at IS NOT NULL OR e.status <> 'published') AS removed
+FROM episodes e
+JOIN series s ON s.id = e.series_id
+WHERE (e.updated_at >= @since
+       OR e.deleted_at >= @since
+       OR s.deleted_at >= @since
+       OR EXISTS (SELECT 1 FROM episode_assets a
+                  WHERE a.episode_id = e.id AND a.created_at >= @since))
+  AND e.id > @after_id
+ORDER BY e.id
+LIMIT @row_limit;
+
+-- Deletion Jobs
+
+-- name: CreateDeletionJob :one
+INSERT INTO deletion_jobs (series_id, requested_by, delete_objects)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: GetDeletionJob :one
+SELECT * FROM deletion_jobs
+WHERE id = $1;
+
+-- name: StartDeletionJob :exec
+UPDATE deletion_jobs
+SET status = 'running',
+    errors = '[]',
+    started_at = COALESCE(started_at, NOW()),
+    finished_at = NULL,
+    updated_at = NOW()
+WHERE id = $1;
+
+-- name: FinishDeletionJob :exec
+UPDATE deletion_jobs
+SET status = $2,
+    episode_count = $3,
+    asset_count = $4,
+    object_count = $5,
+    errors = $6,
+    finished_at = NOW(),
+    updated_at = NOW()
+WHERE id = $1;
+
+-- name: GetDeletedSeries :one
+-- Locks the series until the end of the transaction, so a restore and a
+-- deletion job never work on it at the same time.
+SELECT * FROM series
+WHERE id = $1
+  AND deleted_at IS NOT NULL
+FOR UPDATE;
+
+-- name: DeleteEpisodesBySeries :many
+-- Soft deletes up to row_limit live episodes of a series with the deletion
+-- time of the series, which marks them as deleted together with it.
+UPDATE episodes
+SET deleted_at = @deleted_at
+WHERE id IN (
+    SELECT id FROM episodes
+    WHERE series_id = @series_id
+      AND deleted_at IS NULL
+    ORDER BY id
+    LIMIT @row_limit
+)
+RETURNING *;
+
+-- name: DeleteAssetsByEpisodes :many
+UPDATE episode_assets
+SET deleted_at = @deleted_at
+WHERE episode_id = ANY(@episode_ids::uuid[])
+  AND deleted_at IS NULL
+RETURNING *;
+
+-- name: CountEpisodesDeletedWithSeries :one
+SELECT COUNT(*) FROM episodes
+WHERE series_id = @series_id
+  AND deleted_at = @deleted_at;
+
+-- name: ListAssetsDeletedWithSeries :many
+SELECT a.* FROM episode_assets a
+JOIN episodes e ON e.id = a.episode_id
+WHERE e.series_id = @series_id
+  AND e.deleted_at = @deleted_at
+  AND a.deleted_at = @deleted_at
+ORDER BY a.id;
+
+-- Trash
+
+-- name: CountTrash :one
+SELECT COUNT(*) FROM (
+    SELECT 'category' AS entity_type FROM categories WHERE deleted_at IS NOT NULL
+    UNION ALL
+    SELECT 'series' FROM series WHERE deleted_at IS NOT NULL
+    UNION ALL
+    SELECT 'episode' FROM episodes WHERE deleted_at IS NOT NULL
+) t
+WHERE sqlc.narg(entity_type)::text IS NULL
+   OR t.entity_type = sqlc.narg(entity_type)::text;
+
+-- name: ListTrashPaginated :many
+-- Lists deleted categories, series and episodes, most recently deleted
+-- first. Title is the name of a category.
+SELECT t.entity_type, t.id, t.title, t.series_id, t.deleted_at FROM (
+    SELECT 'category'::text AS entity_type, id, name::text AS title, NULL::uuid AS series_id, deleted_at
+    FROM categories WHERE deleted_at IS NOT NULL
+    UNION ALL
+    SELECT 'series', id, title::text, NULL::uuid, deleted_at
+    FROM series WHERE deleted_at IS NOT NULL
+    UNION ALL
+    SELECT 'episode', id, title::text, series_id, deleted_at
+    FROM episodes WHERE deleted_at IS NOT NULL
+) t
+WHERE sqlc.narg(entity_type)::text IS NULL
+   OR t.entity_type = sqlc.narg(entity_type)::text
+ORDER BY t.deleted_at DESC, t.id
+LIMIT @row_limit OFFSET @row_offset;
+
+-- name: GetDeletedCategory :one
+SELECT * FROM categories
+WHERE id = $1
+  AND deleted_at IS NOT NULL
+FOR UPDATE;
+
+-- name: RestoreCategory :one
+UPDATE categories
+SET deleted_at = NULL,
+    updated_at = NOW()
+WHERE id = $1
+  AND deleted_at IS NOT NULL
+RETURNING *;
+
+-- name: RestoreSeries :one
+UPDATE series
+SET deleted_at = NULL,
+    updated_at = NOW()
+WHERE id = $1
+  AND deleted_at IS NOT NULL
+RETURNING *;
+
+-- name: GetDeletedEpisode :one
+SELECT * FROM episodes
+WHERE id = $1
+  AND deleted_at IS NOT NULL
+FOR UPDATE;
+
+-- name: RestoreEpisode :one
+UPDATE episodes
+SET deleted_at = NULL,
+    updated_at = NOW()
+WHERE id = $1
+  AND deleted_at IS NOT NULL
+RETURNING *;
+
+-- name: RestoreEpisodesBySeries :many
+-- Restores the episodes that were deleted together with their series.
+UPDATE episodes
+SET deleted_at = NULL,
+    updated_at = NOW()
+WHERE series_id = @series_id
+  AND deleted_at = @deleted_at
+RETURNING *;
+
+-- name: RestoreAssetsByEpisodes :many
+-- Restores the assets that were deleted together with their episode.
+UPDATE episode_assets
+SET deleted_at = NULL
+WHERE episode_id = ANY(@episode_ids::uuid[])
+  AND deleted_at = @deleted_at
+RETURNING *;
+
+-- name: ListPurgeableAssets :many
+-- Pages through the assets that were deleted before deleted_before, or
+-- whose episode or series was, in id order.
+SELECT a.* FROM episode_assets a
+JOIN episodes e ON e.id = a.episode_id
+JOIN series s ON s.id = e.series_id
+WHERE a.id > @after
+  AND (a.deleted_at < @deleted_before::timestamptz
+       OR e.deleted_at < @deleted_before::timestamptz
+       OR s.deleted_at < @deleted_before::timestamptz)
+ORDER BY a.id
+LIMIT @row_limit;
+
+-- name: PurgeAssets :execrows
+DELETE FROM episode_assets
+WHERE id = ANY(@ids::uuid[]);
+
+-- name: PurgeEpisodes :many
+-- Deletes up to row_limit episodes that were deleted before deleted_before,
+-- or whose series was. Episodes that still have assets are kept, their
+-- stored objects could not be removed yet.
+DELETE FROM episodes
+WHERE id IN (
+    SELECT e.id FROM episodes e
+    JOIN series s ON s.id = e.series_id
+    WHERE (e.deleted_at < @deleted_before::timestamptz
+           OR s.deleted_at < @deleted_before::timestamptz)
+      AND NOT EXISTS (SELECT 1 FROM episode_assets a WHERE a.episode_id = e.id)
+    ORDER BY e.id
+    LIMIT @row_limit
+)
+RETURNING id;
+
+-- name: PurgeSeries :many
+-- Deletes up to row_limit series that were deleted before deleted_before
+-- and have no episodes left.
+DELETE FROM series
+WHERE id IN (
+    SELECT s.id FROM series s
+    WHERE s.deleted_at < @deleted_before::timestamptz
+      AND NOT EXISTS (SELECT 1 FROM episodes e WHERE e.series_id = s.id)
+    ORDER BY s.id
+    LIMIT @row_limit
+)
+RETURNING id;
+
+-- name: PurgeCategories :many
+-- Deletes up to row_limit categories that were deleted before
+-- deleted_before and are not used by any series, deleted or not, nor
+-- the parent of another category.
+DELETE FROM categories
+WHERE id IN (
+    SELECT c.id FROM categories c
+    WHERE c.deleted_at < @deleted_before::timestamptz
+      AND NOT EXISTS (SELECT 1 FROM series s WHERE s.category_id = c.id)
+      AND NOT EXISTS (SELECT 1 FROM categories child WHERE child.parent_id = c.id)
+    ORDER BY c.id
+    LIMIT @row_limit
+)
+RETURNING id;
+
+-- name: TouchSeries :one
+-- Marks a live series as updated when its search document changes without
+-- the row, such as with its translations.
+UPDATE series
+SET updated_at = NOW()
+WHERE id = $1
+  AND deleted_at IS NULL
+RETURNING *;
+
+-- name: TouchEpisode :one
+-- Marks a live episode as updated when its search document changes without
+-- the row, such as with its translations.
+UPDATE episodes
+SET updated_at = NOW()
+WHERE id = $1
+  AND deleted_at IS NULL
+RETURNING *;
+
+-- name: GetSeriesTranslation :one
+SELECT * FROM series_translations
+WHERE series_id = $1
+  AND locale = $2;
+
+-- name: ListSeriesTranslations :many
+SELECT * FROM series_translations
+WHERE series_id = ANY(@series_ids::uuid[])
+ORDER BY series_id, locale;
+
+-- name: UpsertSeriesTranslation :one
+INSERT INTO series_translations (series_id, locale, title, description)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (series_id, locale) DO UPDATE
+SET title = EXCLUDED.title,
+    description = EXCLUDED.description,
+    updated_at = NOW()
+RETURNING *;
+
+-- name: DeleteSeriesTranslation :one
+DELETE FROM series_translations
+WHERE series_id = $1
+  AND locale = $2
+RETURNING *;
+
+-- name: GetEpisodeTranslation :one
+SELECT * FROM episode_translations
+WHERE episode_id = $1
+  AND locale = $2;
+
+-- name: ListEpisodeTranslations :many
+SELECT * FROM episode_translations
+WHERE episode_id = ANY(@episode_ids::uuid[])
+ORDER BY episode_id, locale;
+
+-- name: UpsertEpisodeTranslation :one
+INSERT INTO episode_translations (episode_id, locale, title, description)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (episode_id, locale) DO UPDATE
+SET title = EXCLUDED.title,
+    description = EXCLUDED.description,
+    updated_at = NOW()
+RETURNING *;
+
+-- name: DeleteEpisodeTranslation :one
+DELETE FROM episode_translations
+WHERE episode_id = $1
+  AND locale = $2
+RETURNING *;
+
+-- name: GetCategoryTranslation :one
+SELECT * FROM category_translations
+WHERE category_id = $1
+  AND locale = $2;
+
+-- name: ListCategoryTranslations :many
+SELECT * FROM category_translations
+WHERE category_id = ANY(@category_ids::uuid[])
+ORDER BY category_id, locale;
+
+-- name: UpsertCategoryTranslation :one
+INSERT INTO category_translations (category_id, locale, name)
+VALUES ($1, $2, $3)
+ON CONFLICT (category_id, locale) DO UPDATE
+SET name = EXCLUDED.name,
+    updated_at = NOW()
+RETURNING *;
+
+-- name: DeleteCategoryTranslation :one
+DELETE FROM category_translations
+WHERE category_id = $1
+  AND locale = $2
+RETURNING *;
+
+-- Revisions
+
+-- name: CreateSeriesRevision :one
+-- Stores the current row of the series as its next revision. Callers hold
+-- the row lock of the series, so concurrent changes cannot take the same
+-- number.
+INSERT INTO content_revisions (entity_type, entity_id, revision, author, snapshot, rollback_of)
+SELECT 'series', s.id,
+       (SELECT COALESCE(MAX(r.revision), 0) + 1 FROM content_revisions r
+        WHERE r.entity_type = 'series' AND r.entity_id = s.id),
+       @author::text, to_jsonb(s), sqlc.narg(rollback_of)::int
+FROM series s
+WHERE s.id = @id
+RETURNING *;
+
+-- name: CreateEpisodeRevision :one
+-- Stores the current row of the episode as its next revision. Callers hold
+-- the row lock of the episode, so concurrent changes cannot take the same
+-- number.
+INSERT INTO content_revisions (entity_type, entity_id, revision, author, snapshot, rollback_of)
+SELECT 'episode', e.id,
+       (SELECT COALESCE(MAX(r.revision), 0) + 1 FROM content_revisions r
+        WHERE r.entity_type = 'episode' AND r.entity_id = e.id),
+       @author::text, to_jsonb(e), sqlc.narg(rollback_of)::int
+FROM episodes e
+WHERE e.id = @id
+RETURNING *;
+
+-- name: GetContentRevision :one
+SELECT * FROM content_revisions
+WHERE entity_type = $1
+  AND entity_id = $2
+  AND revision = $3;
+
+-- name: CountContentRevisions :one
+SELECT COUNT(*) FROM content_revisions
+WHERE entity_type = $1
+  AND entity_id = $2;
+
+-- name: ListContentRevisionsPaginated :many
+SELECT * FROM content_revisions
+WHERE entity_type = $1
+  AND entity_id = $2
+ORDER BY revision DESC
+LIMIT $3 OFFSET $4;
@@ -12,6 +12,113 @@ import (
 	"github.com/google/uuid"
 )
 
+const claimDueSubscriptions = `-- name: ClaimDueSubscriptions :many
+
+UPDATE series_subscriptions
+SET next_sync_at = NOW() + make_interval(secs => interval_seconds),
+    updated_at = NOW()
+WHERE series_id IN (
+    SELECT sub.series_id
+    FROM series_subscriptions sub
+    JOIN series s ON s.id = sub.series_id
+    WHERE sub.next_sync_at <= NOW()
+      AND s.deleted_at IS NULL
+    ORDER BY sub.next_sync_at
+    LIMIT $1
+    FOR UPDATE OF sub SKIP LOCKED
+)
+RETURNING series_id, source_type, source_url, interval_seconds, etag, last_modified, next_sync_at, last_synced_at, last_job_id, created_at, updated_at
+`
+
+// Series Subscriptions
+// Pushes the next sync of due subscriptions forward by their interval and
+// returns them. Rows locked by a concurrent claim are skipped, so several
+// schedulers can run this at the same time.
+func (q *Queries) ClaimDueSubscriptions(ctx context.Context, limit int32) ([]SeriesSubscription, error) {
+	rows, err := q.db.Query(ctx, claimDueSubscriptions, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SeriesSubscription{}
+	for rows.Next() {
+		var i SeriesSubscription
+		if err := rows.Scan(
+			&i.SeriesID,
+			&i.SourceType,
+			&i.SourceUrl,
+			&i.IntervalSeconds,
+			&i.Etag,
+			&i.LastModified,
+			&i.NextSyncAt,
+			&i.LastSyncedAt,
+			&i.LastJobID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const claimOutboxEvents = `-- name: ClaimOutboxEvents :many
+SELECT id, task_type, payload, attempts, last_error, created_at, sent_at, failed_at FROM outbox_events
+WHERE sent_at IS NULL
+  AND failed_at IS NULL
+ORDER BY id
+LIMIT $1
+FOR UPDATE SKIP LOCKED
+`
+
+// Locks the oldest unsent events until the end of the transaction, skipping
+// parked ones. Callers hold the relay lock, see TryLockOutboxRelay.
+func (q *Queries) ClaimOutboxEvents(ctx context.Context, limit int32) ([]OutboxEvent, error) {
+	rows, err := q.db.Query(ctx, claimOutboxEvents, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []OutboxEvent{}
+	for rows.Next() {
+		var i OutboxEvent
+		if err := rows.Scan(
+			&i.ID,
+			&i.TaskType,
+			&i.Payload,
+			&i.Attempts,
+			&i.LastError,
+			&i.CreatedAt,
+			&i.SentAt,
+			&i.FailedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const countAuditEventsByEntity = `-- name: CountAuditEventsByEntity :one
+SELECT COUNT(*) FROM audit_events
+WHERE entity_id = $1
+`
+
+// Audit Events
+func (q *Queries) CountAuditEventsByEntity(ctx context.Context, entityID uuid.UUID) (int64, error) {
+	row := q.db.QueryRow(ctx, countAuditEventsByEntity, entityID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const countCategories = `-- name: CountCategories :one
 
 SELECT COUNT(*) FROM categories WHERE deleted_at IS NULL
@@ -25,6 +132,37 @@ func (q *Queries) CountCategories(ctx context.Context) (int64, error) {
 	return count, err
 }
 
+const countChildCategories = `-- name: CountChildCategories :one
+SELECT COUNT(*) FROM categories
+WHERE parent_id = $1
+  AND deleted_at IS NULL
+`
+
+func (q *Queries) CountChildCategories(ctx context.Context, parentID *uuid.UUID) (int64, error) {
+	row := q.db.QueryRow(ctx, countChildCategories, parentID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countContentRevisions = `-- name: CountContentRevisions :one
+SELECT COUNT(*) FROM content_revisions
+WHERE entity_type = $1
+  AND entity_id = $2
+`
+
+type CountContentRevisionsParams struct {
+	EntityType string    `json:"entity_type"`
+	EntityID   uuid.UUID `json:"entity_id"`
+}
+
+func (q *Queries) CountContentRevisions(ctx context.Context, arg CountContentRevisionsParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countContentRevisions, arg.EntityType, arg.EntityID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const countEpisodesBySeries = `-- name: CountEpisodesBySeries :one
 
 SELECT COUNT(*) FROM episodes
@@ -39,6 +177,38 @@ func (q *Queries) CountEpisodesBySeries(ctx context.Context, seriesID uuid.UUID)
 	return count, err
 }
 
+const countEpisodesDeletedWithSeries = `-- name: CountEpisodesDeletedWithSeries :one
+SELECT COUNT(*) FROM episodes
+WHERE series_id = $1
+  AND deleted_at = $2
+`
+
+type CountEpisodesDeletedWithSeriesParams struct {
+	SeriesID  uuid.UUID  `json:"series_id"`
+	DeletedAt *time.Time `json:"deleted_at"`
+}
+
+func (q *Queries) CountEpisodesDeletedWithSeries(ctx context.Context, arg CountEpisodesDeletedWithSeriesParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countEpisodesDeletedWithSeries, arg.SeriesID, arg.DeletedAt)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countImportJobsBySeries = `-- name: CountImportJobsBySeries :one
+
+SELECT COUNT(*) FROM import_jobs
+WHERE series_id = $1
+`
+
+// Import Jobs
+func (q *Queries) CountImportJobsBySeries(ctx context.Context, seriesID uuid.UUID) (int64, error) {
+	row := q.db.QueryRow(ctx, countImportJobsBySeries, seriesID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const countSeries = `-- name: CountSeries :one
 
 SELECT COUNT(*) FROM series WHERE deleted_at IS NULL
@@ -52,12 +222,46 @@ func (q *Queries) CountSeries(ctx context.Context) (int64, error) {
 	return count, err
 }
 
+const countSeriesByCategory = `-- name: CountSeriesByCategory :one
+SELECT COUNT(*) FROM series
+WHERE category_id = $1
+  AND deleted_at IS NULL
+`
+
+func (q *Queries) CountSeriesByCategory(ctx context.Context, categoryID uuid.UUID) (int64, error) {
+	row := q.db.QueryRow(ctx, countSeriesByCategory, categoryID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countTrash = `-- name: CountTrash :one
+
+SELECT COUNT(*) FROM (
+    SELECT 'category' AS entity_type FROM categories WHERE deleted_at IS NOT NULL
+    UNION ALL
+    SELECT 'series' FROM series WHERE deleted_at IS NOT NULL
+    UNION ALL
+    SELECT 'episode' FROM episodes WHERE deleted_at IS NOT NULL
+) t
+WHERE $1::text IS NULL
+   OR t.entity_type = $1::text
+`
+
+// Trash
+func (q *Queries) CountTrash(ctx context.Context, entityType *string) (int64, error) {
+	row := q.db.QueryRow(ctx, countTrash, entityType)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createAsset = `-- name: CreateAsset :one
 INSERT INTO episode_assets (
     episode_id, asset_type, mime_type, size_bytes, url, storage
 )
 VALUES ($1, $2, $3, $4, $5, $6)
-RETURNING id, episode_id, asset_type, mime_type, size_bytes, url, storage, created_at
+RETURNING id, episode_id, asset_type, mime_type, size_bytes, url, storage, created_at, deleted_at
 `
 
 type CreateAssetParams struct {
@@ -88,18 +292,49 @@ func (q *Queries) CreateAsset(ctx context.Context, arg CreateAssetParams) (Episo
 		&i.Url,
 		&i.Storage,
 		&i.CreatedAt,
+		&i.DeletedAt,
 	)
 	return i, err
 }
 
+const createAuditEvent = `-- name: CreateAuditEvent :exec
+INSERT INTO audit_events (actor, action, entity_type, entity_id, changes)
+VALUES ($1, $2, $3, $4, $5)
+`
+
+type CreateAuditEventParams struct {
+	Actor      string    `json:"actor"`
+	Action     string    `json:"action"`
+	EntityType string    `json:"entity_type"`
+	EntityID   uuid.UUID `json:"entity_id"`
+	Changes    []byte    `json:"changes"`
+}
+
+func (q *Queries) CreateAuditEvent(ctx context.Context, arg CreateAuditEventParams) error {
+	_, err := q.db.Exec(ctx, createAuditEvent,
+		arg.Actor,
+		arg.Action,
+		arg.EntityType,
+		arg.EntityID,
+		arg.Changes,
+	)
+	return err
+}
+
 const createCategory = `-- name: CreateCategory :one
-INSERT INTO categories (slug)
-VALUES ($1)
-RETURNING id, slug, created_at, updated_at, deleted_at
+INSERT INTO categories (name, slug, parent_id)
+VALUES ($1, $2, $3)
+RETURNING id, slug, created_at, updated_at, deleted_at, name, parent_id
 `
 
-func (q *Queries) CreateCategory(ctx context.Context, slug string) (Category, error) {
-	row := q.db.QueryRow(ctx, createCategory, slug)
+type CreateCategoryParams struct {
+	Name     string     `json:"name"`
+	Slug     string     `json:"slug"`
+	ParentID *uuid.UUID `json:"parent_id"`
+}
+
+func (q *Queries) CreateCategory(ctx context.Context, arg CreateCategoryParams) (Category, error) {
+	row := q.db.QueryRow(ctx, createCategory, arg.Name, arg.Slug, arg.ParentID)
 	var i Category
 	err := row.Scan(
 		&i.ID,
@@ -107,6 +342,43 @@ func (q *Queries) CreateCategory(ctx context.Context, slug string) (Category, er
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.Name,
+		&i.ParentID,
+	)
+	return i, err
+}
+
+const createDeletionJob = `-- name: CreateDeletionJob :one
+
+INSERT INTO deletion_jobs (series_id, requested_by, delete_objects)
+VALUES ($1, $2, $3)
+RETURNING id, series_id, requested_by, delete_objects, status, episode_count, asset_count, object_count, errors, started_at, finished_at, created_at, updated_at
+`
+
+type CreateDeletionJobParams struct {
+	SeriesID      uuid.UUID `json:"series_id"`
+	RequestedBy   string    `json:"requested_by"`
+	DeleteObjects bool      `json:"delete_objects"`
+}
+
+// Deletion Jobs
+func (q *Queries) CreateDeletionJob(ctx context.Context, arg CreateDeletionJobParams) (DeletionJob, error) {
+	row := q.db.QueryRow(ctx, createDeletionJob, arg.SeriesID, arg.RequestedBy, arg.DeleteObjects)
+	var i DeletionJob
+	err := row.Scan(
+		&i.ID,
+		&i.SeriesID,
+		&i.RequestedBy,
+		&i.DeleteObjects,
+		&i.Status,
+		&i.EpisodeCount,
+		&i.AssetCount,
+		&i.ObjectCount,
+		&i.Errors,
+		&i.StartedAt,
+		&i.FinishedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
 	)
 	return i, err
 }
@@ -114,10 +386,10 @@ func (q *Queries) CreateCategory(ctx context.Context, slug string) (Category, er
 const createEpisode = `-- name: CreateEpisode :one
 INSERT INTO episodes (
     series_id, title, description,
-    duration_seconds, publish_date
+    duration_seconds, publish_date, slug, status
 )
-VALUES ($1, $2, $3, $4, $5)
-RETURNING id, series_id, title, description, duration_seconds, publish_date, created_at, updated_at, deleted_at
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING id, series_id, title, description, duration_seconds, publish_date, created_at, updated_at, deleted_at, source_type, external_id, slug, previous_slugs, status
 `
 
 type CreateEpisodeParams struct {
@@ -126,6 +398,8 @@ type CreateEpisodeParams struct {
 	Description     *string    `json:"description"`
 	DurationSeconds *int32     `json:"duration_seconds"`
 	PublishDate     *time.Time `json:"publish_date"`
+	Slug            string     `json:"slug"`
+	Status          string     `json:"status"`
 }
 
 func (q *Queries) CreateEpisode(ctx context.Context, arg CreateEpisodeParams) (Episode, error) {
@@ -135,6 +409,8 @@ func (q *Queries) CreateEpisode(ctx context.Context, arg CreateEpisodeParams) (E
 		arg.Description,
 		arg.DurationSeconds,
 		arg.PublishDate,
+		arg.Slug,
+		arg.Status,
 	)
 	var i Episode
 	err := row.Scan(
@@ -147,14 +423,105 @@ func (q *Queries) CreateEpisode(ctx context.Context, arg CreateEpisodeParams) (E
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.SourceType,
+		&i.ExternalID,
+		&i.Slug,
+		&i.PreviousSlugs,
+		&i.Status,
+	)
+	return i, err
+}
+
+const createEpisodeRevision = `-- name: CreateEpisodeRevision :one
+INSERT INTO content_revisions (entity_type, entity_id, revision, author, snapshot, rollback_of)
+SELECT 'episode', e.id,
+       (SELECT COALESCE(MAX(r.revision), 0) + 1 FROM content_revisions r
+        WHERE r.entity_type = 'episode' AND r.entity_id = e.id),
+       $1::text, to_jsonb(e), $2::int
+FROM episodes e
+WHERE e.id = $3
+RETURNING id, entity_type, entity_id, revision, author, snapshot, rollback_of, created_at
+`
+
+type CreateEpisodeRevisionParams struct {
+	Author     string    `json:"author"`
+	RollbackOf *int32    `json:"rollback_of"`
+	ID         uuid.UUID `json:"id"`
+}
+
+// Stores the current row of the episode as its next revision. Callers hold
+// the row lock of the episode, so concurrent changes cannot take the same
+// number.
+func (q *Queries) CreateEpisodeRevision(ctx context.Context, arg CreateEpisodeRevisionParams) (ContentRevision, error) {
+	row := q.db.QueryRow(ctx, createEpisodeRevision, arg.Author, arg.RollbackOf, arg.ID)
+	var i ContentRevision
+	err := row.Scan(
+		&i.ID,
+		&i.EntityType,
+		&i.EntityID,
+		&i.Revision,
+		&i.Author,
+		&i.Snapshot,
+		&i.RollbackOf,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createImportJob = `-- name: CreateImportJob :one
+INSERT INTO import_jobs (series_id, source_type, source_url)
+VALUES ($1, $2, $3)
+RETURNING id, series_id, source_type, source_url, status, created_count, updated_count, skipped_count, errors, started_at, finished_at, created_at, updated_at
+`
+
+type CreateImportJobParams struct {
+	SeriesID   uuid.UUID `json:"series_id"`
+	SourceType string    `json:"source_type"`
+	SourceUrl  string    `json:"source_url"`
+}
+
+func (q *Queries) CreateImportJob(ctx context.Context, arg CreateImportJobParams) (ImportJob, error) {
+	row := q.db.QueryRow(ctx, createImportJob, arg.SeriesID, arg.SourceType, arg.SourceUrl)
+	var i ImportJob
+	err := row.Scan(
+		&i.ID,
+		&i.SeriesID,
+		&i.SourceType,
+		&i.SourceUrl,
+		&i.Status,
+		&i.CreatedCount,
+		&i.UpdatedCount,
+		&i.SkippedCount,
+		&i.Errors,
+		&i.StartedAt,
+		&i.FinishedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
 	)
 	return i, err
 }
 
+const createOutboxEvent = `-- name: CreateOutboxEvent :exec
+
+INSERT INTO outbox_events (task_type, payload)
+VALUES ($1, $2)
+`
+
+type CreateOutboxEventParams struct {
+	TaskType string `json:"task_type"`
+	Payload  []byte `json:"payload"`
+}
+
+// Outbox
+func (q *Queries) CreateOutboxEvent(ctx context.Context, arg CreateOutboxEventParams) error {
+	_, err := q.db.Exec(ctx, createOutboxEvent, arg.TaskType, arg.Payload)
+	return err
+}
+
 const createSeries = `-- name: CreateSeries :one
-INSERT INTO series (title, description, category_id, language, series_type)
-VALUES ($1, $2, $3, $4, $5)
-RETURNING id, title, description, category_id, language, series_type, created_at, updated_at, deleted_at
+INSERT INTO series (title, description, category_id, language, series_type, slug)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id, title, description, category_id, language, series_type, created_at, updated_at, deleted_at, slug, previous_slugs
 `
 
 type CreateSeriesParams struct {
@@ -163,6 +530,7 @@ type CreateSeriesParams struct {
 	CategoryID  uuid.UUID `json:"category_id"`
 	Language    *string   `json:"language"`
 	SeriesType  string    `json:"series_type"`
+	Slug        string    `json:"slug"`
 }
 
 func (q *Queries) CreateSeries(ctx context.Context, arg CreateSeriesParams) (Series, error) {
@@ -172,6 +540,7 @@ func (q *Queries) CreateSeries(ctx context.Context, arg CreateSeriesParams) (Ser
 		arg.CategoryID,
 		arg.Language,
 		arg.SeriesType,
+		arg.Slug,
 	)
 	var i Series
 	err := row.Scan(
@@ -184,6 +553,44 @@ func (q *Queries) CreateSeries(ctx context.Context, arg CreateSeriesParams) (Ser
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.Slug,
+		&i.PreviousSlugs,
+	)
+	return i, err
+}
+
+const createSeriesRevision = `-- name: CreateSeriesRevision :one
+INSERT INTO content_revisions (entity_type, entity_id, revision, author, snapshot, rollback_of)
+SELECT 'series', s.id,
+       (SELECT COALESCE(MAX(r.revision), 0) + 1 FROM content_revisions r
+        WHERE r.entity_type = 'series' AND r.entity_id = s.id),
+       $1::text, to_jsonb(s), $2::int
+FROM series s
+WHERE s.id = $3
+RETURNING id, entity_type, entity_id, revision, author, snapshot, rollback_of, created_at
+`
+
+type CreateSeriesRevisionParams struct {
+	Author     string    `json:"author"`
+	RollbackOf *int32    `json:"rollback_of"`
+	ID         uuid.UUID `json:"id"`
+}
+
+// Stores the current row of the series as its next revision. Callers hold
+// the row lock of the series, so concurrent changes cannot take the same
+// number.
+func (q *Queries) CreateSeriesRevision(ctx context.Context, arg CreateSeriesRevisionParams) (ContentRevision, error) {
+	row := q.db.QueryRow(ctx, createSeriesRevision, arg.Author, arg.RollbackOf, arg.ID)
+	var i ContentRevision
+	err := row.Scan(
+		&i.ID,
+		&i.EntityType,
+		&i.EntityID,
+		&i.Revision,
+		&i.Author,
+		&i.Snapshot,
+		&i.RollbackOf,
+		&i.CreatedAt,
 	)
 	return i, err
 }
@@ -198,170 +605,2390 @@ func (q *Queries) DeleteAsset(ctx context.Context, id uuid.UUID) error {
 	return err
 }
 
-const deleteCategory = `-- name: DeleteCategory :exec
-UPDATE categories
-SET deleted_at = NOW()
-WHERE id = $1
+const deleteAssetsByEpisodes = `-- name: DeleteAssetsByEpisodes :many
+UPDATE episode_assets
+SET deleted_at = $1
+WHERE episode_id = ANY($2::uuid[])
   AND deleted_at IS NULL
+RETURNING id, episode_id, asset_type, mime_type, size_bytes, url, storage, created_at, deleted_at
 `
 
-func (q *Queries) DeleteCategory(ctx context.Context, id uuid.UUID) error {
-	_, err := q.db.Exec(ctx, deleteCategory, id)
-	return err
+type DeleteAssetsByEpisodesParams struct {
+	DeletedAt  *time.Time  `json:"deleted_at"`
+	EpisodeIds []uuid.UUID `json:"episode_ids"`
 }
 
-const deleteEpisode = `-- name: DeleteEpisode :exec
-UPDATE episodes
-SET deleted_at = NOW()
-WHERE id = $1
-  AND deleted_at IS NULL
-`
-
-func (q *Queries) DeleteEpisode(ctx context.Context, id uuid.UUID) error {
-	_, err := q.db.Exec(ctx, deleteEpisode, id)
-	return err
+func (q *Queries) DeleteAssetsByEpisodes(ctx context.Context, arg DeleteAssetsByEpisodesParams) ([]EpisodeAsset, error) {
+	rows, err := q.db.Query(ctx, deleteAssetsByEpisodes, arg.DeletedAt, arg.EpisodeIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []EpisodeAsset{}
+	for rows.Next() {
+		var i EpisodeAsset
+		if err := rows.Scan(
+			&i.ID,
+			&i.EpisodeID,
+			&i.AssetType,
+			&i.MimeType,
+			&i.SizeBytes,
+			&i.Url,
+			&i.Storage,
+			&i.CreatedAt,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
-const deleteSeries = `-- name: DeleteSeries :exec
-UPDATE series
+const deleteCategory = `-- name: DeleteCategory :exec
+UPDATE categories
 SET deleted_at = NOW()
 WHERE id = $1
   AND deleted_at IS NULL
 `
 
-func (q *Queries) DeleteSeries(ctx context.Context, id uuid.UUID) error {
-	_, err := q.db.Exec(ctx, deleteSeries, id)
+func (q *Queries) DeleteCategory(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.Exec(ctx, deleteCategory, id)
 	return err
 }
 
-const getAsset = `-- name: GetAsset :one
-SELECT id, episode_id, asset_type, mime_type, size_bytes, url, storage, created_at FROM episode_assets
-WHERE id = $1
+const deleteCategoryTranslation = `-- name: DeleteCategoryTranslation :one
+DELETE FROM category_translations
+WHERE category_id = $1
+  AND locale = $2
+RETURNING category_id, locale, name, created_at, updated_at
 `
 
-func (q *Queries) GetAsset(ctx context.Context, id uuid.UUID) (EpisodeAsset, error) {
-	row := q.db.QueryRow(ctx, getAsset, id)
-	var i EpisodeAsset
+type DeleteCategoryTranslationParams struct {
+	CategoryID uuid.UUID `json:"category_id"`
+	Locale     string    `json:"locale"`
+}
+
+func (q *Queries) DeleteCategoryTranslation(ctx context.Context, arg DeleteCategoryTranslationParams) (CategoryTranslation, error) {
+	row := q.db.QueryRow(ctx, deleteCategoryTranslation,
+		arg.CategoryID,
+		arg.Locale,
+	)
+	var i CategoryTranslation
 	err := row.Scan(
-		&i.ID,
-		&i.EpisodeID,
-		&i.AssetType,
-		&i.MimeType,
-		&i.SizeBytes,
-		&i.Url,
-		&i.Storage,
+		&i.CategoryID,
+		&i.Locale,
+		&i.Name,
 		&i.CreatedAt,
+		&i.UpdatedAt,
 	)
 	return i, err
 }
 
-const getCategory = `-- name: GetCategory :one
-SELECT id, slug, created_at, updated_at, deleted_at FROM categories
+const deleteEpisode = `-- name: DeleteEpisode :one
+UPDATE episodes
+SET deleted_at = NOW()
 WHERE id = $1
   AND deleted_at IS NULL
+RETURNING deleted_at
 `
 
-func (q *Queries) GetCategory(ctx context.Context, id uuid.UUID) (Category, error) {
-	row := q.db.QueryRow(ctx, getCategory, id)
-	var i Category
-	err := row.Scan(
-		&i.ID,
-		&i.Slug,
-		&i.CreatedAt,
-		&i.UpdatedAt,
-		&i.DeletedAt,
+func (q *Queries) DeleteEpisode(ctx context.Context, id uuid.UUID) (*time.Time, error) {
+	row := q.db.QueryRow(ctx, deleteEpisode, id)
+	var deleted_at *time.Time
+	err := row.Scan(&deleted_at)
+	return deleted_at, err
+}
+
+const deleteEpisodesBySeries = `-- name: DeleteEpisodesBySeries :many
+UPDATE episodes
+SET deleted_at = $1
+WHERE id IN (
+    SELECT id FROM episodes
+    WHERE series_id = $2
+      AND deleted_at IS NULL
+    ORDER BY id
+    LIMIT $3
+)
+RETURNING id, series_id, title, description, duration_seconds, publish_date, created_at, updated_at, deleted_at, source_type, external_id, slug, previous_slugs, status
+`
+
+type DeleteEpisodesBySeriesParams struct {
+	DeletedAt *time.Time `json:"deleted_at"`
+	SeriesID  uuid.UUID  `json:"series_id"`
+	RowLimit  int32      `json:"row_limit"`
+}
+
+// Soft deletes up to row_limit live episodes of a series with the deletion
+// time of the series, which marks them as deleted together with it.
+func (q *Queries) DeleteEpisodesBySeries(ctx context.Context, arg DeleteEpisodesBySeriesParams) ([]Episode, error) {
+	rows, err := q.db.Query(ctx, deleteEpisodesBySeries, arg.DeletedAt, arg.SeriesID, arg.RowLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Episode{}
+	for rows.Next() {
+		var i Episode
+		if err := rows.Scan(
+			&i.ID,
+			&i.SeriesID,
+			&i.Title,
+			&i.Description,
+			&i.DurationSeconds,
+			&i.PublishDate,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.SourceType,
+			&i.ExternalID,
+			&i.Slug,
+			&i.PreviousSlugs,
+			&i.Status,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const deleteEpisodeTranslation = `-- name: DeleteEpisodeTranslation :one
+DELETE FROM episode_translations
+WHERE episode_id = $1
+  AND locale = $2
+RETURNING episode_id, locale, title, description, created_at, updated_at
+`
+
+type DeleteEpisodeTranslationParams struct {
+	EpisodeID uuid.UUID `json:"episode_id"`
+	Locale    string    `json:"locale"`
+}
+
+func (q *Queries) DeleteEpisodeTranslation(ctx context.Context, arg DeleteEpisodeTranslationParams) (EpisodeTranslation, error) {
+	row := q.db.QueryRow(ctx, deleteEpisodeTranslation,
+		arg.EpisodeID,
+		arg.Locale,
+	)
+	var i EpisodeTranslation
+	err := row.Scan(
+		&i.EpisodeID,
+		&i.Locale,
+		&i.Title,
+		&i.Description,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const deleteSentOutboxEvents = `-- name: DeleteSentOutboxEvents :execrows
+DELETE FROM outbox_events
+WHERE sent_at < $1::timestamptz
+`
+
+func (q *Queries) DeleteSentOutboxEvents(ctx context.Context, before time.Time) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteSentOutboxEvents, before)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteSeries = `-- name: DeleteSeries :one
+UPDATE series
+SET deleted_at = NOW()
+WHERE id = $1
+  AND deleted_at IS NULL
+RETURNING deleted_at
+`
+
+func (q *Queries) DeleteSeries(ctx context.Context, id uuid.UUID) (*time.Time, error) {
+	row := q.db.QueryRow(ctx, deleteSeries, id)
+	var deleted_at *time.Time
+	err := row.Scan(&deleted_at)
+	return deleted_at, err
+}
+
+const deleteSeriesSubscription = `-- name: DeleteSeriesSubscription :exec
+DELETE FROM series_subscriptions
+WHERE series_id = $1
+`
+
+func (q *Queries) DeleteSeriesSubscription(ctx context.Context, seriesID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, deleteSeriesSubscription, seriesID)
+	return err
+}
+
+const deleteSeriesTranslation = `-- name: DeleteSeriesTranslation :one
+DELETE FROM series_translations
+WHERE series_id = $1
+  AND locale = $2
+RETURNING series_id, locale, title, description, created_at, updated_at
+`
+
+type DeleteSeriesTranslationParams struct {
+	SeriesID uuid.UUID `json:"series_id"`
+	Locale   string    `json:"locale"`
+}
+
+func (q *Queries) DeleteSeriesTranslation(ctx context.Context, arg DeleteSeriesTranslationParams) (SeriesTranslation, error) {
+	row := q.db.QueryRow(ctx, deleteSeriesTranslation,
+		arg.SeriesID,
+		arg.Locale,
+	)
+	var i SeriesTranslation
+	err := row.Scan(
+		&i.SeriesID,
+		&i.Locale,
+		&i.Title,
+		&i.Description,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const finishDeletionJob = `-- name: FinishDeletionJob :exec
+UPDATE deletion_jobs
+SET status = $2,
+    episode_count = $3,
+    asset_count = $4,
+    object_count = $5,
+    errors = $6,
+    finished_at = NOW(),
+    updated_at = NOW()
+WHERE id = $1
+`
+
+type FinishDeletionJobParams struct {
+	ID           uuid.UUID `json:"id"`
+	Status       string    `json:"status"`
+	EpisodeCount int32     `json:"episode_count"`
+	AssetCount   int32     `json:"asset_count"`
+	ObjectCount  int32     `json:"object_count"`
+	Errors       []byte    `json:"errors"`
+}
+
+func (q *Queries) FinishDeletionJob(ctx context.Context, arg FinishDeletionJobParams) error {
+	_, err := q.db.Exec(ctx, finishDeletionJob,
+		arg.ID,
+		arg.Status,
+		arg.EpisodeCount,
+		arg.AssetCount,
+		arg.ObjectCount,
+		arg.Errors,
+	)
+	return err
+}
+
+const finishImportJob = `-- name: FinishImportJob :exec
+UPDATE import_jobs
+SET status = $2,
+    created_count = $3,
+    updated_count = $4,
+    skipped_count = $5,
+    errors = $6,
+    finished_at = NOW(),
+    updated_at = NOW()
+WHERE id = $1
+`
+
+type FinishImportJobParams struct {
+	ID           uuid.UUID `json:"id"`
+	Status       string    `json:"status"`
+	CreatedCount int32     `json:"created_count"`
+	UpdatedCount int32     `json:"updated_count"`
+	SkippedCount int32     `json:"skipped_count"`
+	Errors       []byte    `json:"errors"`
+}
+
+func (q *Queries) FinishImportJob(ctx context.Context, arg FinishImportJobParams) error {
+	_, err := q.db.Exec(ctx, finishImportJob,
+		arg.ID,
+		arg.Status,
+		arg.CreatedCount,
+		arg.UpdatedCount,
+		arg.SkippedCount,
+		arg.Errors,
+	)
+	return err
+}
+
+const getAsset = `-- name: GetAsset :one
+SELECT id, episode_id, asset_type, mime_type, size_bytes, url, storage, created_at, deleted_at FROM episode_assets
+WHERE id = $1
+  AND deleted_at IS NULL
+`
+
+func (q *Queries) GetAsset(ctx context.Context, id uuid.UUID) (EpisodeAsset, error) {
+	row := q.db.QueryRow(ctx, getAsset, id)
+	var i EpisodeAsset
+	err := row.Scan(
+		&i.ID,
+		&i.EpisodeID,
+		&i.AssetType,
+		&i.MimeType,
+		&i.SizeBytes,
+		&i.Url,
+		&i.Storage,
+		&i.CreatedAt,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const getCategory = `-- name: GetCategory :one
+SELECT id, slug, created_at, updated_at, deleted_at, name, parent_id FROM categories
+WHERE id = $1
+  AND deleted_at IS NULL
+`
+
+func (q *Queries) GetCategory(ctx context.Context, id uuid.UUID) (Category, error) {
+	row := q.db.QueryRow(ctx, getCategory, id)
+	var i Category
+	err := row.Scan(
+		&i.ID,
+		&i.Slug,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.Name,
+		&i.ParentID,
+	)
+	return i, err
+}
+
+const getCategoryForShare = `-- name: GetCategoryForShare :one
+SELECT id, slug, created_at, updated_at, deleted_at, name, parent_id FROM categories
+WHERE id = $1
+  AND deleted_at IS NULL
+FOR SHARE
+`
+
+// Keeps the live category from being deleted until the end of the
+// transaction that places a series in it.
+func (q *Queries) GetCategoryForShare(ctx context.Context, id uuid.UUID) (Category, error) {
+	row := q.db.QueryRow(ctx, getCategoryForShare, id)
+	var i Category
+	err := row.Scan(
+		&i.ID,
+		&i.Slug,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.Name,
+		&i.ParentID,
+	)
+	return i, err
+}
+
+const getCategoryForUpdate = `-- name: GetCategoryForUpdate :one
+SELECT id, slug, created_at, updated_at, deleted_at, name, parent_id FROM categories
+WHERE id = $1
+  AND deleted_at IS NULL
+FOR UPDATE
+`
+
+// Locks the live category until the end of the transaction, so no series
+// can be created in or moved into it while it is deleted.
+func (q *Queries) GetCategoryForUpdate(ctx context.Context, id uuid.UUID) (Category, error) {
+	row := q.db.QueryRow(ctx, getCategoryForUpdate, id)
+	var i Category
+	err := row.Scan(
+		&i.ID,
+		&i.Slug,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.Name,
+		&i.ParentID,
+	)
+	return i, err
+}
+
+const getCategoryTranslation = `-- name: GetCategoryTranslation :one
+SELECT category_id, locale, name, created_at, updated_at FROM category_translations
+WHERE category_id = $1
+  AND locale = $2
+`
+
+type GetCategoryTranslationParams struct {
+	CategoryID uuid.UUID `json:"category_id"`
+	Locale     string    `json:"locale"`
+}
+
+func (q *Queries) GetCategoryTranslation(ctx context.Context, arg GetCategoryTranslationParams) (CategoryTranslation, error) {
+	row := q.db.QueryRow(ctx, getCategoryTranslation,
+		arg.CategoryID,
+		arg.Locale,
+	)
+	var i CategoryTranslation
+	err := row.Scan(
+		&i.CategoryID,
+		&i.Locale,
+		&i.Name,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getContentRevision = `-- name: GetContentRevision :one
+SELECT id, entity_type, entity_id, revision, author, snapshot, rollback_of, created_at FROM content_revisions
+WHERE entity_type = $1
+  AND entity_id = $2
+  AND revision = $3
+`
+
+type GetContentRevisionParams struct {
+	EntityType string    `json:"entity_type"`
+	EntityID   uuid.UUID `json:"entity_id"`
+	Revision   int32     `json:"revision"`
+}
+
+func (q *Queries) GetContentRevision(ctx context.Context, arg GetContentRevisionParams) (ContentRevision, error) {
+	row := q.db.QueryRow(ctx, getContentRevision, arg.EntityType, arg.EntityID, arg.Revision)
+	var i ContentRevision
+	err := row.Scan(
+		&i.ID,
+		&i.EntityType,
+		&i.EntityID,
+		&i.Revision,
+		&i.Author,
+		&i.Snapshot,
+		&i.RollbackOf,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getDeletedCategory = `-- name: GetDeletedCategory :one
+SELECT id, slug, created_at, updated_at, deleted_at, name, parent_id FROM categories
+WHERE id = $1
+  AND deleted_at IS NOT NULL
+FOR UPDATE
+`
+
+func (q *Queries) GetDeletedCategory(ctx context.Context, id uuid.UUID) (Category, error) {
+	row := q.db.QueryRow(ctx, getDeletedCategory, id)
+	var i Category
+	err := row.Scan(
+		&i.ID,
+		&i.Slug,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.Name,
+		&i.ParentID,
+	)
+	return i, err
+}
+
+const getDeletedEpisode = `-- name: GetDeletedEpisode :one
+SELECT id, series_id, title, description, duration_seconds, publish_date, created_at, updated_at, deleted_at, source_type, external_id, slug, previous_slugs, status FROM episodes
+WHERE id = $1
+  AND deleted_at IS NOT NULL
+FOR UPDATE
+`
+
+func (q *Queries) GetDeletedEpisode(ctx context.Context, id uuid.UUID) (Episode, error) {
+	row := q.db.QueryRow(ctx, getDeletedEpisode, id)
+	var i Episode
+	err := row.Scan(
+		&i.ID,
+		&i.SeriesID,
+		&i.Title,
+		&i.Description,
+		&i.DurationSeconds,
+		&i.PublishDate,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.SourceType,
+		&i.ExternalID,
+		&i.Slug,
+		&i.PreviousSlugs,
+		&i.Status,
 	)
 	return i, err
 }
 
-const getEpisode = `-- name: GetEpisode :one
-SELECT id, series_id, title, description, duration_seconds, publish_date, created_at, updated_at, deleted_at FROM episodes
+const getDeletedSeries = `-- name: GetDeletedSeries :one
+SELECT id, title, description, category_id, language, series_type, created_at, updated_at, deleted_at, slug, previous_slugs FROM series
+WHERE id = $1
+  AND deleted_at IS NOT NULL
+FOR UPDATE
+`
+
+// Locks the series until the end of the transaction, so a restore and a
+// deletion job never work on it at the same time.
+func (q *Queries) GetDeletedSeries(ctx context.Context, id uuid.UUID) (Series, error) {
+	row := q.db.QueryRow(ctx, getDeletedSeries, id)
+	var i Series
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Description,
+		&i.CategoryID,
+		&i.Language,
+		&i.SeriesType,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.Slug,
+		&i.PreviousSlugs,
+	)
+	return i, err
+}
+
+const getDeletionJob = `-- name: GetDeletionJob :one
+SELECT id, series_id, requested_by, delete_objects, status, episode_count, asset_count, object_count, errors, started_at, finished_at, created_at, updated_at FROM deletion_jobs
+WHERE id = $1
+`
+
+func (q *Queries) GetDeletionJob(ctx context.Context, id uuid.UUID) (DeletionJob, error) {
+	row := q.db.QueryRow(ctx, getDeletionJob, id)
+	var i DeletionJob
+	err := row.Scan(
+		&i.ID,
+		&i.SeriesID,
+		&i.RequestedBy,
+		&i.DeleteObjects,
+		&i.Status,
+		&i.EpisodeCount,
+		&i.AssetCount,
+		&i.ObjectCount,
+		&i.Errors,
+		&i.StartedAt,
+		&i.FinishedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getEpisode = `-- name: GetEpisode :one
+SELECT id, series_id, title, description, duration_seconds, publish_date, created_at, updated_at, deleted_at, source_type, external_id, slug, previous_slugs, status FROM episodes
+WHERE id = $1
+  AND deleted_at IS NULL
+`
+
+func (q *Queries) GetEpisode(ctx context.Context, id uuid.UUID) (Episode, error) {
+	row := q.db.QueryRow(ctx, getEpisode, id)
+	var i Episode
+	err := row.Scan(
+		&i.ID,
+		&i.SeriesID,
+		&i.Title,
+		&i.Description,
+		&i.DurationSeconds,
+		&i.PublishDate,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.SourceType,
+		&i.ExternalID,
+		&i.Slug,
+		&i.PreviousSlugs,
+		&i.Status,
+	)
+	return i, err
+}
+
+const getEpisodeByPreviousSlug = `-- name: GetEpisodeByPreviousSlug :one
+SELECT id, series_id, title, description, duration_seconds, publish_date, created_at, updated_at, deleted_at, source_type, external_id, slug, previous_slugs, status FROM episodes
+WHERE series_id = $1
+  AND previous_slugs @> ARRAY[$2::text]
+  AND deleted_at IS NULL
+ORDER BY updated_at DESC
+LIMIT 1
+`
+
+type GetEpisodeByPreviousSlugParams struct {
+	SeriesID uuid.UUID `json:"series_id"`
+	Slug     string    `json:"slug"`
+}
+
+// Finds the live episode of a series that used slug before. When several
+// did, the one renamed last wins.
+func (q *Queries) GetEpisodeByPreviousSlug(ctx context.Context, arg GetEpisodeByPreviousSlugParams) (Episode, error) {
+	row := q.db.QueryRow(ctx, getEpisodeByPreviousSlug, arg.SeriesID, arg.Slug)
+	var i Episode
+	err := row.Scan(
+		&i.ID,
+		&i.SeriesID,
+		&i.Title,
+		&i.Description,
+		&i.DurationSeconds,
+		&i.PublishDate,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.SourceType,
+		&i.ExternalID,
+		&i.Slug,
+		&i.PreviousSlugs,
+		&i.Status,
+	)
+	return i, err
+}
+
+const getEpisodeBySlug = `-- name: GetEpisodeBySlug :one
+SELECT id, series_id, title, description, duration_seconds, publish_date, created_at, updated_at, deleted_at, source_type, external_id, slug, previous_slugs, status FROM episodes
+WHERE series_id = $1
+  AND slug = $2
+  AND deleted_at IS NULL
+`
+
+type GetEpisodeBySlugParams struct {
+	SeriesID uuid.UUID `json:"series_id"`
+	Slug     string    `json:"slug"`
+}
+
+func (q *Queries) GetEpisodeBySlug(ctx context.Context, arg GetEpisodeBySlugParams) (Episode, error) {
+	row := q.db.QueryRow(ctx, getEpisodeBySlug, arg.SeriesID, arg.Slug)
+	var i Episode
+	err := row.Scan(
+		&i.ID,
+		&i.SeriesID,
+		&i.Title,
+		&i.Description,
+		&i.DurationSeconds,
+		&i.PublishDate,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.SourceType,
+		&i.ExternalID,
+		&i.Slug,
+		&i.PreviousSlugs,
+		&i.Status,
+	)
+	return i, err
+}
+
+const getEpisodeBySource = `-- name: GetEpisodeBySource :one
+SELECT id, series_id, title, description, duration_seconds, publish_date, created_at, updated_at, deleted_at, source_type, external_id, slug, previous_slugs, status FROM episodes
+WHERE series_id = $1
+  AND source_type = $2
+  AND external_id = $3
+  AND deleted_at IS NULL
+`
+
+type GetEpisodeBySourceParams struct {
+	SeriesID   uuid.UUID `json:"series_id"`
+	SourceType *string   `json:"source_type"`
+	ExternalID *string   `json:"external_id"`
+}
+
+func (q *Queries) GetEpisodeBySource(ctx context.Context, arg GetEpisodeBySourceParams) (Episode, error) {
+	row := q.db.QueryRow(ctx, getEpisodeBySource, arg.SeriesID, arg.SourceType, arg.ExternalID)
+	var i Episode
+	err := row.Scan(
+		&i.ID,
+		&i.SeriesID,
+		&i.Title,
+		&i.Description,
+		&i.DurationSeconds,
+		&i.PublishDate,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.SourceType,
+		&i.ExternalID,
+		&i.Slug,
+		&i.PreviousSlugs,
+		&i.Status,
+	)
+	return i, err
+}
+
+const getEpisodeTranslation = `-- name: GetEpisodeTranslation :one
+SELECT episode_id, locale, title, description, created_at, updated_at FROM episode_translations
+WHERE episode_id = $1
+  AND locale = $2
+`
+
+type GetEpisodeTranslationParams struct {
+	EpisodeID uuid.UUID `json:"episode_id"`
+	Locale    string    `json:"locale"`
+}
+
+func (q *Queries) GetEpisodeTranslation(ctx context.Context, arg GetEpisodeTranslationParams) (EpisodeTranslation, error) {
+	row := q.db.QueryRow(ctx, getEpisodeTranslation,
+		arg.EpisodeID,
+		arg.Locale,
+	)
+	var i EpisodeTranslation
+	err := row.Scan(
+		&i.EpisodeID,
+		&i.Locale,
+		&i.Title,
+		&i.Description,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getEpisodeWithAssets = `-- name: GetEpisodeWithAssets :many
+SELECT 
+    e.id               AS episode_id,
+    e.series_id,
+    e.title,
+    e.slug,
+    e.description,
+    e.duration_seconds,
+    e.publish_date,
+    e.created_at       AS episode_created_at,
+    e.updated_at       AS episode_updated_at,
+    a.id               AS asset_id,
+    a.asset_type,
+    a.mime_type,
+    a.size_bytes,
+    a.url,
+    a.storage,
+    a.created_at       AS asset_created_at
+FROM episodes e
+LEFT JOIN episode_assets a ON e.id = a.episode_id AND a.deleted_at IS NULL
+WHERE e.id = $1 AND e.deleted_at IS NULL
+`
+
+type GetEpisodeWithAssetsRow struct {
+	EpisodeID        uuid.UUID  `json:"episode_id"`
+	SeriesID         uuid.UUID  `json:"series_id"`
+	Title            string     `json:"title"`
+	Slug             string     `json:"slug"`
+	Description      *string    `json:"description"`
+	DurationSeconds  *int32     `json:"duration_seconds"`
+	PublishDate      *time.Time `json:"publish_date"`
+	EpisodeCreatedAt time.Time  `json:"episode_created_at"`
+	EpisodeUpdatedAt time.Time  `json:"episode_updated_at"`
+	AssetID          *uuid.UUID `json:"asset_id"`
+	AssetType        *string    `json:"asset_type"`
+	MimeType         *string    `json:"mime_type"`
+	SizeBytes        *int64     `json:"size_bytes"`
+	Url              *string    `json:"url"`
+	Storage          []byte     `json:"storage"`
+	AssetCreatedAt   *time.Time `json:"asset_created_at"`
+}
+
+func (q *Queries) GetEpisodeWithAssets(ctx context.Context, id uuid.UUID) ([]GetEpisodeWithAssetsRow, error) {
+	rows, err := q.db.Query(ctx, getEpisodeWithAssets, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetEpisodeWithAssetsRow{}
+	for rows.Next() {
+		var i GetEpisodeWithAssetsRow
+		if err := rows.Scan(
+			&i.EpisodeID,
+			&i.SeriesID,
+			&i.Title,
+			&i.Slug,
+			&i.Description,
+			&i.DurationSeconds,
+			&i.PublishDate,
+			&i.EpisodeCreatedAt,
+			&i.EpisodeUpdatedAt,
+			&i.AssetID,
+			&i.AssetType,
+			&i.MimeType,
+			&i.SizeBytes,
+			&i.Url,
+			&i.Storage,
+			&i.AssetCreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getImportJob = `-- name: GetImportJob :one
+SELECT id, series_id, source_type, source_url, status, created_count, updated_count, skipped_count, errors, started_at, finished_at, created_at, updated_at FROM import_jobs
+WHERE id = $1
+`
+
+func (q *Queries) GetImportJob(ctx context.Context, id uuid.UUID) (ImportJob, error) {
+	row := q.db.QueryRow(ctx, getImportJob, id)
+	var i ImportJob
+	err := row.Scan(
+		&i.ID,
+		&i.SeriesID,
+		&i.SourceType,
+		&i.SourceUrl,
+		&i.Status,
+		&i.CreatedCount,
+		&i.UpdatedCount,
+		&i.SkippedCount,
+		&i.Errors,
+		&i.StartedAt,
+		&i.FinishedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getOutboxBacklog = `-- name: GetOutboxBacklog :one
+SELECT COUNT(*) FILTER (WHERE failed_at IS NULL) AS pending,
+       COUNT(*) FILTER (WHERE failed_at IS NOT NULL) AS parked,
+       COALESCE(EXTRACT(EPOCH FROM NOW() - MIN(created_at) FILTER (WHERE failed_at IS NULL)), 0)::float8 AS oldest_age_seconds
+FROM outbox_events
+WHERE sent_at IS NULL
+`
+
+type GetOutboxBacklogRow struct {
+	Pending          int64   `json:"pending"`
+	Parked           int64   `json:"parked"`
+	OldestAgeSeconds float64 `json:"oldest_age_seconds"`
+}
+
+func (q *Queries) GetOutboxBacklog(ctx context.Context) (GetOutboxBacklogRow, error) {
+	row := q.db.QueryRow(ctx, getOutboxBacklog)
+	var i GetOutboxBacklogRow
+	err := row.Scan(&i.Pending, &i.Parked, &i.OldestAgeSeconds)
+	return i, err
+}
+
+const getSeries = `-- name: GetSeries :one
+SELECT id, title, description, category_id, language, series_type, created_at, updated_at, deleted_at, slug, previous_slugs FROM series
+WHERE id = $1
+  AND deleted_at IS NULL
+`
+
+func (q *Queries) GetSeries(ctx context.Context, id uuid.UUID) (Series, error) {
+	row := q.db.QueryRow(ctx, getSeries, id)
+	var i Series
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Description,
+		&i.CategoryID,
+		&i.Language,
+		&i.SeriesType,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.Slug,
+		&i.PreviousSlugs,
+	)
+	return i, err
+}
+
+const getSeriesByPreviousSlug = `-- name: GetSeriesByPreviousSlug :one
+SELECT id, title, description, category_id, language, series_type, created_at, updated_at, deleted_at, slug, previous_slugs FROM series
+WHERE previous_slugs @> ARRAY[$1::text]
+  AND deleted_at IS NULL
+ORDER BY updated_at DESC
+LIMIT 1
+`
+
+// Finds the live series that used slug before. When several did, the one
+// renamed last wins.
+func (q *Queries) GetSeriesByPreviousSlug(ctx context.Context, slug string) (Series, error) {
+	row := q.db.QueryRow(ctx, getSeriesByPreviousSlug, slug)
+	var i Series
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Description,
+		&i.CategoryID,
+		&i.Language,
+		&i.SeriesType,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.Slug,
+		&i.PreviousSlugs,
+	)
+	return i, err
+}
+
+const getSeriesBySlug = `-- name: GetSeriesBySlug :one
+SELECT id, title, description, category_id, language, series_type, created_at, updated_at, deleted_at, slug, previous_slugs FROM series
+WHERE slug = $1
+  AND deleted_at IS NULL
+`
+
+func (q *Queries) GetSeriesBySlug(ctx context.Context, slug string) (Series, error) {
+	row := q.db.QueryRow(ctx, getSeriesBySlug, slug)
+	var i Series
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Description,
+		&i.CategoryID,
+		&i.Language,
+		&i.SeriesType,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.Slug,
+		&i.PreviousSlugs,
+	)
+	return i, err
+}
+
+const getSeriesSubscription = `-- name: GetSeriesSubscription :one
+SELECT series_id, source_type, source_url, interval_seconds, etag, last_modified, next_sync_at, last_synced_at, last_job_id, created_at, updated_at FROM series_subscriptions
+WHERE series_id = $1
+`
+
+func (q *Queries) GetSeriesSubscription(ctx context.Context, seriesID uuid.UUID) (SeriesSubscription, error) {
+	row := q.db.QueryRow(ctx, getSeriesSubscription, seriesID)
+	var i SeriesSubscription
+	err := row.Scan(
+		&i.SeriesID,
+		&i.SourceType,
+		&i.SourceUrl,
+		&i.IntervalSeconds,
+		&i.Etag,
+		&i.LastModified,
+		&i.NextSyncAt,
+		&i.LastSyncedAt,
+		&i.LastJobID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getSeriesTranslation = `-- name: GetSeriesTranslation :one
+SELECT series_id, locale, title, description, created_at, updated_at FROM series_translations
+WHERE series_id = $1
+  AND locale = $2
+`
+
+type GetSeriesTranslationParams struct {
+	SeriesID uuid.UUID `json:"series_id"`
+	Locale   string    `json:"locale"`
+}
+
+func (q *Queries) GetSeriesTranslation(ctx context.Context, arg GetSeriesTranslationParams) (SeriesTranslation, error) {
+	row := q.db.QueryRow(ctx, getSeriesTranslation,
+		arg.SeriesID,
+		arg.Locale,
+	)
+	var i SeriesTranslation
+	err := row.Scan(
+		&i.SeriesID,
+		&i.Locale,
+		&i.Title,
+		&i.Description,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const listAssetsByEpisode = `-- name: ListAssetsByEpisode :many
+
+SELECT id, episode_id, asset_type, mime_type, size_bytes, url, storage, created_at, deleted_at FROM episode_assets
+WHERE episode_id = $1
+  AND deleted_at IS NULL
+`
+
+// Episode Assets
+func (q *Queries) ListAssetsByEpisode(ctx context.Context, episodeID uuid.UUID) ([]EpisodeAsset, error) {
+	rows, err := q.db.Query(ctx, listAssetsByEpisode, episodeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []EpisodeAsset{}
+	for rows.Next() {
+		var i EpisodeAsset
+		if err := rows.Scan(
+			&i.ID,
+			&i.EpisodeID,
+			&i.AssetType,
+			&i.MimeType,
+			&i.SizeBytes,
+			&i.Url,
+			&i.Storage,
+			&i.CreatedAt,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAssetsByEpisodes = `-- name: ListAssetsByEpisodes :many
+SELECT id, episode_id, asset_type, mime_type, size_bytes, url, storage, created_at, deleted_at FROM episode_assets
+WHERE episode_id = ANY($1::uuid[])
+  AND deleted_at IS NULL
+ORDER BY episode_id, created_at
+`
+
+func (q *Queries) ListAssetsByEpisodes(ctx context.Context, episodeIds []uuid.UUID) ([]EpisodeAsset, error) {
+	rows, err := q.db.Query(ctx, listAssetsByEpisodes, episodeIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []EpisodeAsset{}
+	for rows.Next() {
+		var i EpisodeAsset
+		if err := rows.Scan(
+			&i.ID,
+			&i.EpisodeID,
+			&i.AssetType,
+			&i.MimeType,
+			&i.SizeBytes,
+			&i.Url,
+			&i.Storage,
+			&i.CreatedAt,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAssetsDeletedWithSeries = `-- name: ListAssetsDeletedWithSeries :many
+SELECT a.id, a.episode_id, a.asset_type, a.mime_type, a.size_bytes, a.url, a.storage, a.created_at, a.deleted_at FROM episode_assets a
+JOIN episodes e ON e.id = a.episode_id
+WHERE e.series_id = $1
+  AND e.deleted_at = $2
+  AND a.deleted_at = $2
+ORDER BY a.id
+`
+
+type ListAssetsDeletedWithSeriesParams struct {
+	SeriesID  uuid.UUID  `json:"series_id"`
+	DeletedAt *time.Time `json:"deleted_at"`
+}
+
+func (q *Queries) ListAssetsDeletedWithSeries(ctx context.Context, arg ListAssetsDeletedWithSeriesParams) ([]EpisodeAsset, error) {
+	rows, err := q.db.Query(ctx, listAssetsDeletedWithSeries, arg.SeriesID, arg.DeletedAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []EpisodeAsset{}
+	for rows.Next() {
+		var i EpisodeAsset
+		if err := rows.Scan(
+			&i.ID,
+			&i.EpisodeID,
+			&i.AssetType,
+			&i.MimeType,
+			&i.SizeBytes,
+			&i.Url,
+			&i.Storage,
+			&i.CreatedAt,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAuditEventsByEntityPaginated = `-- name: ListAuditEventsByEntityPaginated :many
+SELECT id, actor, action, entity_type, entity_id, changes, created_at FROM audit_events
+WHERE entity_id = $1
+ORDER BY created_at DESC, id
+LIMIT $2 OFFSET $3
+`
+
+type ListAuditEventsByEntityPaginatedParams struct {
+	EntityID uuid.UUID `json:"entity_id"`
+	Limit    int32     `json:"limit"`
+	Offset   int32     `json:"offset"`
+}
+
+func (q *Queries) ListAuditEventsByEntityPaginated(ctx context.Context, arg ListAuditEventsByEntityPaginatedParams) ([]AuditEvent, error) {
+	rows, err := q.db.Query(ctx, listAuditEventsByEntityPaginated, arg.EntityID, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []AuditEvent{}
+	for rows.Next() {
+		var i AuditEvent
+		if err := rows.Scan(
+			&i.ID,
+			&i.Actor,
+			&i.Action,
+			&i.EntityType,
+			&i.EntityID,
+			&i.Changes,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCategories = `-- name: ListCategories :many
+SELECT id, slug, created_at, updated_at, deleted_at, name, parent_id FROM categories
+WHERE deleted_at IS NULL
+ORDER BY updated_at
+`
+
+func (q *Queries) ListCategories(ctx context.Context) ([]Category, error) {
+	rows, err := q.db.Query(ctx, listCategories)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Category{}
+	for rows.Next() {
+		var i Category
+		if err := rows.Scan(
+			&i.ID,
+			&i.Slug,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.Name,
+			&i.ParentID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCategoriesPaginated = `-- name: ListCategoriesPaginated :many
+SELECT id, slug, created_at, updated_at, deleted_at, name, parent_id
+FROM categories
+WHERE deleted_at IS NULL
+ORDER BY updated_at
+LIMIT $1 OFFSET $2
+`
+
+type ListCategoriesPaginatedParams struct {
+	Limit  int32 `json:"limit"`
+	Offset int32 `json:"offset"`
+}
+
+func (q *Queries) ListCategoriesPaginated(ctx context.Context, arg ListCategoriesPaginatedParams) ([]Category, error) {
+	rows, err := q.db.Query(ctx, listCategoriesPaginated, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Category{}
+	for rows.Next() {
+		var i Category
+		if err := rows.Scan(
+			&i.ID,
+			&i.Slug,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.Name,
+			&i.ParentID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCategoryDescendantIDs = `-- name: ListCategoryDescendantIDs :many
+WITH RECURSIVE tree AS (
+    SELECT id FROM categories WHERE categories.id = $1
+    UNION
+    SELECT c.id FROM categories c
+    JOIN tree t ON c.parent_id = t.id
+)
+SELECT id FROM tree
+`
+
+// Lists the ids of the category and of all categories below it, deleted
+// ones included.
+func (q *Queries) ListCategoryDescendantIDs(ctx context.Context, id uuid.UUID) ([]uuid.UUID, error) {
+	rows, err := q.db.Query(ctx, listCategoryDescendantIDs, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []uuid.UUID{}
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCategoryPath = `-- name: ListCategoryPath :many
+WITH RECURSIVE path AS (
+    SELECT id, parent_id, 0 AS depth FROM categories WHERE categories.id = $1
+    UNION ALL
+    SELECT c.id, c.parent_id, p.depth + 1 FROM categories c
+    JOIN path p ON c.id = p.parent_id
+)
+SELECT id FROM path
+ORDER BY depth DESC
+`
+
+// Lists the ids from the root category down to the category.
+func (q *Queries) ListCategoryPath(ctx context.Context, id uuid.UUID) ([]uuid.UUID, error) {
+	rows, err := q.db.Query(ctx, listCategoryPath, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []uuid.UUID{}
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCategoryPaths = `-- name: ListCategoryPaths :many
+WITH RECURSIVE paths AS (
+    SELECT id, ARRAY[id] AS path FROM categories WHERE parent_id IS NULL
+    UNION ALL
+    SELECT c.id, p.path || c.id FROM categories c
+    JOIN paths p ON c.parent_id = p.id
+)
+SELECT id, path::uuid[] AS path FROM paths
+`
+
+type ListCategoryPathsRow struct {
+	ID   uuid.UUID   `json:"id"`
+	Path []uuid.UUID `json:"path"`
+}
+
+// Lists every category with the ids from its root category down to it.
+func (q *Queries) ListCategoryPaths(ctx context.Context) ([]ListCategoryPathsRow, error) {
+	rows, err := q.db.Query(ctx, listCategoryPaths)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListCategoryPathsRow{}
+	for rows.Next() {
+		var i ListCategoryPathsRow
+		if err := rows.Scan(&i.ID, &i.Path); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCategorySlugs = `-- name: ListCategorySlugs :many
+SELECT slug FROM categories
+WHERE slug = $1::text
+   OR slug LIKE $1::text || '-%'
+`
+
+// Lists the slugs equal to slug or numbered variants of it, such as
+// slug-2. Deleted categories keep their slug and are included.
+func (q *Queries) ListCategorySlugs(ctx context.Context, slug string) ([]string, error) {
+	rows, err := q.db.Query(ctx, listCategorySlugs, slug)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var slug string
+		if err := rows.Scan(&slug); err != nil {
+			return nil, err
+		}
+		items = append(items, slug)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCategoryTranslations = `-- name: ListCategoryTranslations :many
+SELECT category_id, locale, name, created_at, updated_at FROM category_translations
+WHERE category_id = ANY($1::uuid[])
+ORDER BY category_id, locale
+`
+
+func (q *Queries) ListCategoryTranslations(ctx context.Context, categoryIds []uuid.UUID) ([]CategoryTranslation, error) {
+	rows, err := q.db.Query(ctx, listCategoryTranslations, categoryIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CategoryTranslation{}
+	for rows.Next() {
+		var i CategoryTranslation
+		if err := rows.Scan(
+			&i.CategoryID,
+			&i.Locale,
+			&i.Name,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listContentRevisionsPaginated = `-- name: ListContentRevisionsPaginated :many
+SELECT id, entity_type, entity_id, revision, author, snapshot, rollback_of, created_at FROM content_revisions
+WHERE entity_type = $1
+  AND entity_id = $2
+ORDER BY revision DESC
+LIMIT $3 OFFSET $4
+`
+
+type ListContentRevisionsPaginatedParams struct {
+	EntityType string    `json:"entity_type"`
+	EntityID   uuid.UUID `json:"entity_id"`
+	Limit      int32     `json:"limit"`
+	Offset     int32     `json:"offset"`
+}
+
+func (q *Queries) ListContentRevisionsPaginated(ctx context.Context, arg ListContentRevisionsPaginatedParams) ([]ContentRevision, error) {
+	rows, err := q.db.Query(ctx, listContentRevisionsPaginated,
+		arg.EntityType,
+		arg.EntityID,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ContentRevision{}
+	for rows.Next() {
+		var i ContentRevision
+		if err := rows.Scan(
+			&i.ID,
+			&i.EntityType,
+			&i.EntityID,
+			&i.Revision,
+			&i.Author,
+			&i.Snapshot,
+			&i.RollbackOf,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEpisodesAfter = `-- name: ListEpisodesAfter :many
+SELECT e.id, e.series_id, e.title, e.description, e.duration_seconds, e.publish_date, e.created_at, e.updated_at, e.deleted_at, e.source_type, e.external_id, e.slug, e.previous_slugs, e.status FROM episodes e
+JOIN series s ON s.id = e.series_id
+WHERE e.deleted_at IS NULL
+  AND s.deleted_at IS NULL
+  AND e.status = 'published'
+  AND e.id > $1
+ORDER BY e.id
+LIMIT $2
+`
+
+type ListEpisodesAfterParams struct {
+	ID    uuid.UUID `json:"id"`
+	Limit int32     `json:"limit"`
+}
+
+// Pages through the published episodes of live series in id order for a
+// full reindex.
+func (q *Queries) ListEpisodesAfter(ctx context.Context, arg ListEpisodesAfterParams) ([]Episode, error) {
+	rows, err := q.db.Query(ctx, listEpisodesAfter, arg.ID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Episode{}
+	for rows.Next() {
+		var i Episode
+		if err := rows.Scan(
+			&i.ID,
+			&i.SeriesID,
+			&i.Title,
+			&i.Description,
+			&i.DurationSeconds,
+			&i.PublishDate,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.SourceType,
+			&i.ExternalID,
+			&i.Slug,
+			&i.PreviousSlugs,
+			&i.Status,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEpisodesBySeries = `-- name: ListEpisodesBySeries :many
+SELECT id, series_id, title, description, duration_seconds, publish_date, created_at, updated_at, deleted_at, source_type, external_id, slug, previous_slugs, status FROM episodes
+WHERE series_id = $1
+  AND deleted_at IS NULL
+ORDER BY publish_date DESC
+`
+
+func (q *Queries) ListEpisodesBySeries(ctx context.Context, seriesID uuid.UUID) ([]Episode, error) {
+	rows, err := q.db.Query(ctx, listEpisodesBySeries, seriesID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Episode{}
+	for rows.Next() {
+		var i Episode
+		if err := rows.Scan(
+			&i.ID,
+			&i.SeriesID,
+			&i.Title,
+			&i.Description,
+			&i.DurationSeconds,
+			&i.PublishDate,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.SourceType,
+			&i.ExternalID,
+			&i.Slug,
+			&i.PreviousSlugs,
+			&i.Status,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEpisodesBySeriesPaginated = `-- name: ListEpisodesBySeriesPaginated :many
+SELECT id, series_id, title, description, duration_seconds,
+       publish_date, created_at, updated_at, deleted_at,
+       source_type, external_id, slug, previous_slugs, status
+FROM episodes
+WHERE series_id = $1 AND deleted_at IS NULL
+ORDER BY publish_date DESC
+LIMIT $2 OFFSET $3
+`
+
+type ListEpisodesBySeriesPaginatedParams struct {
+	SeriesID uuid.UUID `json:"series_id"`
+	Limit    int32     `json:"limit"`
+	Offset   int32     `json:"offset"`
+}
+
+func (q *Queries) ListEpisodesBySeriesPaginated(ctx context.Context, arg ListEpisodesBySeriesPaginatedParams) ([]Episode, error) {
+	rows, err := q.db.Query(ctx, listEpisodesBySeriesPaginated, arg.SeriesID, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Episode{}
+	for rows.Next() {
+		var i Episode
+		if err := rows.Scan(
+			&i.ID,
+			&i.SeriesID,
+			&i.Title,
+			&i.Description,
+			&i.DurationSeconds,
+			&i.PublishDate,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.SourceType,
+			&i.ExternalID,
+			&i.Slug,
+			&i.PreviousSlugs,
+			&i.Status,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEpisodesChangedSince = `-- name: ListEpisodesChangedSince :many
+SELECT e.id, e.series_id, e.title, e.description, e.duration_seconds, e.publish_date, e.created_at, e.updated_at, e.deleted_at, e.source_type, e.external_id, e.slug, e.previous_slugs, e.status, (e.deleted_at IS NOT NULL OR s.deleted_at IS NOT NULL OR e.status <> 'published') AS removed
+FROM episodes e
+JOIN series s ON s.id = e.series_id
+WHERE (e.updated_at >= $1
+       OR e.deleted_at >= $1
+       OR s.deleted_at >= $1
+       OR EXISTS (SELECT 1 FROM episode_assets a
+                  WHERE a.episode_id = e.id AND a.created_at >= $1))
+  AND e.id > $2
+ORDER BY e.id
+LIMIT $3
+`
+
+type ListEpisodesChangedSinceParams struct {
+	Since    time.Time `json:"since"`
+	AfterID  uuid.UUID `json:"after_id"`
+	RowLimit int32     `json:"row_limit"`
+}
+
+type ListEpisodesChangedSinceRow struct {
+	ID              uuid.UUID  `json:"id"`
+	SeriesID        uuid.UUID  `json:"series_id"`
+	Title           string     `json:"title"`
+	Description     *string    `json:"description"`
+	DurationSeconds *int32     `json:"duration_seconds"`
+	PublishDate     *time.Time `json:"publish_date"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+	DeletedAt       *time.Time `json:"deleted_at"`
+	SourceType      *string    `json:"source_type"`
+	ExternalID      *string    `json:"external_id"`
+	Slug            string     `json:"slug"`
+	PreviousSlugs   []string   `json:"previous_slugs"`
+	Status          string     `json:"status"`
+	Removed         bool       `json:"removed"`
+}
+
+// Pages through the episodes whose search document changed at or after
+// since: the episode was written or deleted, its series was deleted or it
+// got a new asset. Removed reports whether the document has to go: the
+// episode or its series was deleted or the episode is not published.
+func (q *Queries) ListEpisodesChangedSince(ctx context.Context, arg ListEpisodesChangedSinceParams) ([]ListEpisodesChangedSinceRow, error) {
+	rows, err := q.db.Query(ctx, listEpisodesChangedSince, arg.Since, arg.AfterID, arg.RowLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListEpisodesChangedSinceRow{}
+	for rows.Next() {
+		var i ListEpisodesChangedSinceRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.SeriesID,
+			&i.Title,
+			&i.Description,
+			&i.DurationSeconds,
+			&i.PublishDate,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.SourceType,
+			&i.ExternalID,
+			&i.Slug,
+			&i.PreviousSlugs,
+			&i.Status,
+			&i.Removed,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEpisodeSlugs = `-- name: ListEpisodeSlugs :many
+SELECT slug FROM episodes
+WHERE series_id = $1
+  AND (slug = $2::text OR slug LIKE $2::text || '-%')
+`
+
+type ListEpisodeSlugsParams struct {
+	SeriesID uuid.UUID `json:"series_id"`
+	Slug     string    `json:"slug"`
+}
+
+// Lists the slugs of a series equal to slug or numbered variants of it,
+// deleted episodes included.
+func (q *Queries) ListEpisodeSlugs(ctx context.Context, arg ListEpisodeSlugsParams) ([]string, error) {
+	rows, err := q.db.Query(ctx, listEpisodeSlugs, arg.SeriesID, arg.Slug)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var slug string
+		if err := rows.Scan(&slug); err != nil {
+			return nil, err
+		}
+		items = append(items, slug)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEpisodesWithAssetsBySeriesPaginated = `-- name: ListEpisodesWithAssetsBySeriesPaginated :many
+SELECT
+    e.id AS episode_id,
+    e.series_id,
+    e.title,
+    e.slug,
+    e.description,
+    e.duration_seconds,
+    e.publish_date,
+    e.status,
+    e.created_at AS episode_created_at,
+    e.updated_at AS episode_updated_at,
+    a.id AS asset_id,
+    a.asset_type,
+    a.mime_type,
+    a.size_bytes,
+    a.url,
+    a.storage,
+    a.created_at AS asset_created_at
+FROM
+    episodes e
+LEFT JOIN
+    episode_assets a ON e.id = a.episode_id AND a.deleted_at IS NULL
+WHERE
+    e.series_id = $1 AND e.deleted_at IS NULL
+ORDER BY
+    e.publish_date DESC
+LIMIT $2 OFFSET $3
+`
+
+type ListEpisodesWithAssetsBySeriesPaginatedParams struct {
+	SeriesID uuid.UUID `json:"series_id"`
+	Limit    int32     `json:"limit"`
+	Offset   int32     `json:"offset"`
+}
+
+type ListEpisodesWithAssetsBySeriesPaginatedRow struct {
+	EpisodeID        uuid.UUID  `json:"episode_id"`
+	SeriesID         uuid.UUID  `json:"series_id"`
+	Title            string     `json:"title"`
+	Slug             string     `json:"slug"`
+	Description      *string    `json:"description"`
+	DurationSeconds  *int32     `json:"duration_seconds"`
+	PublishDate      *time.Time `json:"publish_date"`
+	Status           string     `json:"status"`
+	EpisodeCreatedAt time.Time  `json:"episode_created_at"`
+	EpisodeUpdatedAt time.Time  `json:"episode_updated_at"`
+	AssetID          *uuid.UUID `json:"asset_id"`
+	AssetType        *string    `json:"asset_type"`
+	MimeType         *string    `json:"mime_type"`
+	SizeBytes        *int64     `json:"size_bytes"`
+	Url              *string    `json:"url"`
+	Storage          []byte     `json:"storage"`
+	AssetCreatedAt   *time.Time `json:"asset_created_at"`
+}
+
+func (q *Queries) ListEpisodesWithAssetsBySeriesPaginated(ctx context.Context, arg ListEpisodesWithAssetsBySeriesPaginatedParams) ([]ListEpisodesWithAssetsBySeriesPaginatedRow, error) {
+	rows, err := q.db.Query(ctx, listEpisodesWithAssetsBySeriesPaginated, arg.SeriesID, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListEpisodesWithAssetsBySeriesPaginatedRow{}
+	for rows.Next() {
+		var i ListEpisodesWithAssetsBySeriesPaginatedRow
+		if err := rows.Scan(
+			&i.EpisodeID,
+			&i.SeriesID,
+			&i.Title,
+			&i.Slug,
+			&i.Description,
+			&i.DurationSeconds,
+			&i.PublishDate,
+			&i.Status,
+			&i.EpisodeCreatedAt,
+			&i.EpisodeUpdatedAt,
+			&i.AssetID,
+			&i.AssetType,
+			&i.MimeType,
+			&i.SizeBytes,
+			&i.Url,
+			&i.Storage,
+			&i.AssetCreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEpisodeTranslations = `-- name: ListEpisodeTranslations :many
+SELECT episode_id, locale, title, description, created_at, updated_at FROM episode_translations
+WHERE episode_id = ANY($1::uuid[])
+ORDER BY episode_id, locale
+`
+
+func (q *Queries) ListEpisodeTranslations(ctx context.Context, episodeIds []uuid.UUID) ([]EpisodeTranslation, error) {
+	rows, err := q.db.Query(ctx, listEpisodeTranslations, episodeIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []EpisodeTranslation{}
+	for rows.Next() {
+		var i EpisodeTranslation
+		if err := rows.Scan(
+			&i.EpisodeID,
+			&i.Locale,
+			&i.Title,
+			&i.Description,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listImportJobsBySeriesPaginated = `-- name: ListImportJobsBySeriesPaginated :many
+SELECT id, series_id, source_type, source_url, status, created_count, updated_count, skipped_count, errors, started_at, finished_at, created_at, updated_at FROM import_jobs
+WHERE series_id = $1
+ORDER BY created_at DESC
+LIMIT $2 OFFSET $3
+`
+
+type ListImportJobsBySeriesPaginatedParams struct {
+	SeriesID uuid.UUID `json:"series_id"`
+	Limit    int32     `json:"limit"`
+	Offset   int32     `json:"offset"`
+}
+
+func (q *Queries) ListImportJobsBySeriesPaginated(ctx context.Context, arg ListImportJobsBySeriesPaginatedParams) ([]ImportJob, error) {
+	rows, err := q.db.Query(ctx, listImportJobsBySeriesPaginated, arg.SeriesID, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ImportJob{}
+	for rows.Next() {
+		var i ImportJob
+		if err := rows.Scan(
+			&i.ID,
+			&i.SeriesID,
+			&i.SourceType,
+			&i.SourceUrl,
+			&i.Status,
+			&i.CreatedCount,
+			&i.UpdatedCount,
+			&i.SkippedCount,
+			&i.Errors,
+			&i.StartedAt,
+			&i.FinishedAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPurgeableAssets = `-- name: ListPurgeableAssets :many
+SELECT a.id, a.episode_id, a.asset_type, a.mime_type, a.size_bytes, a.url, a.storage, a.created_at, a.deleted_at FROM episode_assets a
+JOIN episodes e ON e.id = a.episode_id
+JOIN series s ON s.id = e.series_id
+WHERE a.id > $1
+  AND (a.deleted_at < $2::timestamptz
+       OR e.deleted_at < $2::timestamptz
+       OR s.deleted_at < $2::timestamptz)
+ORDER BY a.id
+LIMIT $3
+`
+
+type ListPurgeableAssetsParams struct {
+	After         uuid.UUID `json:"after"`
+	DeletedBefore time.Time `json:"deleted_before"`
+	RowLimit      int32     `json:"row_limit"`
+}
+
+// Pages through the assets that were deleted before deleted_before, or
+// whose episode or series was, in id order.
+func (q *Queries) ListPurgeableAssets(ctx context.Context, arg ListPurgeableAssetsParams) ([]EpisodeAsset, error) {
+	rows, err := q.db.Query(ctx, listPurgeableAssets, arg.After, arg.DeletedBefore, arg.RowLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []EpisodeAsset{}
+	for rows.Next() {
+		var i EpisodeAsset
+		if err := rows.Scan(
+			&i.ID,
+			&i.EpisodeID,
+			&i.AssetType,
+			&i.MimeType,
+			&i.SizeBytes,
+			&i.Url,
+			&i.Storage,
+			&i.CreatedAt,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSeries = `-- name: ListSeries :many
+SELECT id, title, description, category_id, language, series_type, created_at, updated_at, deleted_at, slug, previous_slugs FROM series
+WHERE deleted_at IS NULL
+ORDER BY created_at DESC
+`
+
+func (q *Queries) ListSeries(ctx context.Context) ([]Series, error) {
+	rows, err := q.db.Query(ctx, listSeries)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Series{}
+	for rows.Next() {
+		var i Series
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Description,
+			&i.CategoryID,
+			&i.Language,
+			&i.SeriesType,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.Slug,
+			&i.PreviousSlugs,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSeriesAfter = `-- name: ListSeriesAfter :many
+
+SELECT id, title, description, category_id, language, series_type, created_at, updated_at, deleted_at, slug, previous_slugs FROM series
+WHERE deleted_at IS NULL
+  AND id > $1
+ORDER BY id
+LIMIT $2
+`
+
+type ListSeriesAfterParams struct {
+	ID    uuid.UUID `json:"id"`
+	Limit int32     `json:"limit"`
+}
+
+// Reindex
+// Pages through live series in id order for a full reindex.
+func (q *Queries) ListSeriesAfter(ctx context.Context, arg ListSeriesAfterParams) ([]Series, error) {
+	rows, err := q.db.Query(ctx, listSeriesAfter, arg.ID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Series{}
+	for rows.Next() {
+		var i Series
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Description,
+			&i.CategoryID,
+			&i.Language,
+			&i.SeriesType,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.Slug,
+			&i.PreviousSlugs,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSeriesByCategory = `-- name: ListSeriesByCategory :many
+SELECT id, title, description, category_id, language, series_type, created_at, updated_at, deleted_at, slug, previous_slugs FROM series
+WHERE category_id = $1
+  AND deleted_at IS NULL
+ORDER BY title, id
+LIMIT $2
+`
+
+type ListSeriesByCategoryParams struct {
+	CategoryID uuid.UUID `json:"category_id"`
+	Limit      int32     `json:"limit"`
+}
+
+func (q *Queries) ListSeriesByCategory(ctx context.Context, arg ListSeriesByCategoryParams) ([]Series, error) {
+	rows, err := q.db.Query(ctx, listSeriesByCategory, arg.CategoryID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Series{}
+	for rows.Next() {
+		var i Series
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Description,
+			&i.CategoryID,
+			&i.Language,
+			&i.SeriesType,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.Slug,
+			&i.PreviousSlugs,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSeriesChangedSince = `-- name: ListSeriesChangedSince :many
+SELECT id, title, description, category_id, language, series_type, created_at, updated_at, deleted_at, slug, previous_slugs FROM series
+WHERE (updated_at >= $1 OR deleted_at >= $1)
+  AND id > $2
+ORDER BY id
+LIMIT $3
+`
+
+type ListSeriesChangedSinceParams struct {
+	Since    time.Time `json:"since"`
+	AfterID  uuid.UUID `json:"after_id"`
+	RowLimit int32     `json:"row_limit"`
+}
+
+// Pages through the series written or deleted at or after since.
+func (q *Queries) ListSeriesChangedSince(ctx context.Context, arg ListSeriesChangedSinceParams) ([]Series, error) {
+	rows, err := q.db.Query(ctx, listSeriesChangedSince, arg.Since, arg.AfterID, arg.RowLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Series{}
+	for rows.Next() {
+		var i Series
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Description,
+			&i.CategoryID,
+			&i.Language,
+			&i.SeriesType,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.Slug,
+			&i.PreviousSlugs,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSeriesLanguages = `-- name: ListSeriesLanguages :many
+SELECT id, language FROM series
+WHERE id = ANY($1::uuid[])
+`
+
+type ListSeriesLanguagesRow struct {
+	ID       uuid.UUID `json:"id"`
+	Language *string   `json:"language"`
+}
+
+// Lists the languages of series, deleted ones included. Episodes are
+// analyzed in the language of their series.
+func (q *Queries) ListSeriesLanguages(ctx context.Context, ids []uuid.UUID) ([]ListSeriesLanguagesRow, error) {
+	rows, err := q.db.Query(ctx, listSeriesLanguages, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListSeriesLanguagesRow{}
+	for rows.Next() {
+		var i ListSeriesLanguagesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Language,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSeriesPaginated = `-- name: ListSeriesPaginated :many
+SELECT id, title, description, category_id, language, series_type,
+       created_at, updated_at, deleted_at, slug, previous_slugs
+FROM series
+WHERE deleted_at IS NULL
+ORDER BY created_at DESC
+LIMIT $1 OFFSET $2
+`
+
+type ListSeriesPaginatedParams struct {
+	Limit  int32 `json:"limit"`
+	Offset int32 `json:"offset"`
+}
+
+func (q *Queries) ListSeriesPaginated(ctx context.Context, arg ListSeriesPaginatedParams) ([]Series, error) {
+	rows, err := q.db.Query(ctx, listSeriesPaginated, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Series{}
+	for rows.Next() {
+		var i Series
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Description,
+			&i.CategoryID,
+			&i.Language,
+			&i.SeriesType,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.Slug,
+			&i.PreviousSlugs,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSeriesSlugs = `-- name: ListSeriesSlugs :many
+SELECT slug FROM series
+WHERE slug = $1::text
+   OR slug LIKE $1::text || '-%'
+`
+
+// Lists the slugs equal to slug or numbered variants of it. Deleted series
+// keep their slug and are included.
+func (q *Queries) ListSeriesSlugs(ctx context.Context, slug string) ([]string, error) {
+	rows, err := q.db.Query(ctx, listSeriesSlugs, slug)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var slug string
+		if err := rows.Scan(&slug); err != nil {
+			return nil, err
+		}
+		items = append(items, slug)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSeriesTranslations = `-- name: ListSeriesTranslations :many
+SELECT series_id, locale, title, description, created_at, updated_at FROM series_translations
+WHERE series_id = ANY($1::uuid[])
+ORDER BY series_id, locale
+`
+
+func (q *Queries) ListSeriesTranslations(ctx context.Context, seriesIds []uuid.UUID) ([]SeriesTranslation, error) {
+	rows, err := q.db.Query(ctx, listSeriesTranslations, seriesIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SeriesTranslation{}
+	for rows.Next() {
+		var i SeriesTranslation
+		if err := rows.Scan(
+			&i.SeriesID,
+			&i.Locale,
+			&i.Title,
+			&i.Description,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTrashPaginated = `-- name: ListTrashPaginated :many
+SELECT t.entity_type, t.id, t.title, t.series_id, t.deleted_at FROM (
+    SELECT 'category'::text AS entity_type, id, name::text AS title, NULL::uuid AS series_id, deleted_at
+    FROM categories WHERE deleted_at IS NOT NULL
+    UNION ALL
+    SELECT 'series', id, title::text, NULL::uuid, deleted_at
+    FROM series WHERE deleted_at IS NOT NULL
+    UNION ALL
+    SELECT 'episode', id, title::text, series_id, deleted_at
+    FROM episodes WHERE deleted_at IS NOT NULL
+) t
+WHERE $1::text IS NULL
+   OR t.entity_type = $1::text
+ORDER BY t.deleted_at DESC, t.id
+LIMIT $2 OFFSET $3
+`
+
+type ListTrashPaginatedParams struct {
+	EntityType *string `json:"entity_type"`
+	RowLimit   int32   `json:"row_limit"`
+	RowOffset  int32   `json:"row_offset"`
+}
+
+type ListTrashPaginatedRow struct {
+	EntityType string     `json:"entity_type"`
+	ID         uuid.UUID  `json:"id"`
+	Title      string     `json:"title"`
+	SeriesID   *uuid.UUID `json:"series_id"`
+	DeletedAt  *time.Time `json:"deleted_at"`
+}
+
+// Lists deleted categories, series and episodes, most recently deleted
+// first. Title is the name of a category.
+func (q *Queries) ListTrashPaginated(ctx context.Context, arg ListTrashPaginatedParams) ([]ListTrashPaginatedRow, error) {
+	rows, err := q.db.Query(ctx, listTrashPaginated, arg.EntityType, arg.RowLimit, arg.RowOffset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListTrashPaginatedRow{}
+	for rows.Next() {
+		var i ListTrashPaginatedRow
+		if err := rows.Scan(
+			&i.EntityType,
+			&i.ID,
+			&i.Title,
+			&i.SeriesID,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markOutboxEventsSent = `-- name: MarkOutboxEventsSent :exec
+UPDATE outbox_events
+SET sent_at = NOW()
+WHERE id = ANY($1::bigint[])
+`
+
+func (q *Queries) MarkOutboxEventsSent(ctx context.Context, ids []int64) error {
+	_, err := q.db.Exec(ctx, markOutboxEventsSent, ids)
+	return err
+}
+
+const moveCategory = `-- name: MoveCategory :one
+UPDATE categories
+SET parent_id = $2,
+    updated_at = NOW()
 WHERE id = $1
   AND deleted_at IS NULL
+RETURNING id, slug, created_at, updated_at, deleted_at, name, parent_id
 `
 
-func (q *Queries) GetEpisode(ctx context.Context, id uuid.UUID) (Episode, error) {
-	row := q.db.QueryRow(ctx, getEpisode, id)
-	var i Episode
+type MoveCategoryParams struct {
+	ID       uuid.UUID  `json:"id"`
+	ParentID *uuid.UUID `json:"parent_id"`
+}
+
+func (q *Queries) MoveCategory(ctx context.Context, arg MoveCategoryParams) (Category, error) {
+	row := q.db.QueryRow(ctx, moveCategory, arg.ID, arg.ParentID)
+	var i Category
 	err := row.Scan(
 		&i.ID,
-		&i.SeriesID,
-		&i.Title,
-		&i.Description,
-		&i.DurationSeconds,
-		&i.PublishDate,
+		&i.Slug,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.Name,
+		&i.ParentID,
 	)
 	return i, err
 }
 
-const getEpisodeWithAssets = `-- name: GetEpisodeWithAssets :many
-SELECT 
-    e.id               AS episode_id,
-    e.series_id,
-    e.title,
-    e.description,
-    e.duration_seconds,
-    e.publish_date,
-    e.created_at       AS episode_created_at,
-    e.updated_at       AS episode_updated_at,
-    a.id               AS asset_id,
-    a.asset_type,
-    a.mime_type,
-    a.size_bytes,
-    a.url,
-    a.storage,
-    a.created_at       AS asset_created_at
-FROM episodes e
-LEFT JOIN episode_assets a ON e.id = a.episode_id
-WHERE e.id = $1 AND e.deleted_at IS NULL
+const publishDueEpisodes = `-- name: PublishDueEpisodes :many
+UPDATE episodes
+SET status = 'published',
+    updated_at = NOW()
+WHERE id IN (
+    SELECT e.id FROM episodes e
+    JOIN series s ON s.id = e.series_id
+    WHERE e.status = 'scheduled'
+      AND e.publish_date <= $1::timestamptz
+      AND e.deleted_at IS NULL
+      AND s.deleted_at IS NULL
+    ORDER BY e.publish_date
+    LIMIT $2
+    FOR UPDATE OF e SKIP LOCKED
+)
+RETURNING id, series_id, title, description, duration_seconds, publish_date, created_at, updated_at, deleted_at, source_type, external_id, slug, previous_slugs, status
 `
 
-type GetEpisodeWithAssetsRow struct {
-	EpisodeID        uuid.UUID  `json:"episode_id"`
-	SeriesID         uuid.UUID  `json:"series_id"`
-	Title            string     `json:"title"`
-	Description      *string    `json:"description"`
-	DurationSeconds  *int32     `json:"duration_seconds"`
-	PublishDate      *time.Time `json:"publish_date"`
-	EpisodeCreatedAt time.Time  `json:"episode_created_at"`
-	EpisodeUpdatedAt time.Time  `json:"episode_updated_at"`
-	AssetID          *uuid.UUID `json:"asset_id"`
-	AssetType        *string    `json:"asset_type"`
-	MimeType         *string    `json:"mime_type"`
-	SizeBytes        *int64     `json:"size_bytes"`
-	Url              *string    `json:"url"`
-	Storage          []byte     `json:"storage"`
-	AssetCreatedAt   *time.Time `json:"asset_created_at"`
+type PublishDueEpisodesParams struct {
+	DueBefore time.Time `json:"due_before"`
+	RowLimit  int32     `json:"row_limit"`
 }
 
-func (q *Queries) GetEpisodeWithAssets(ctx context.Context, id uuid.UUID) ([]GetEpisodeWithAssetsRow, error) {
-	rows, err := q.db.Query(ctx, getEpisodeWithAssets, id)
+// Publishes up to row_limit scheduled episodes of live series whose publish
+// date is not after due_before. Episodes another run is publishing are
+// skipped.
+func (q *Queries) PublishDueEpisodes(ctx context.Context, arg PublishDueEpisodesParams) ([]Episode, error) {
+	rows, err := q.db.Query(ctx, publishDueEpisodes, arg.DueBefore, arg.RowLimit)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []GetEpisodeWithAssetsRow{}
+	items := []Episode{}
 	for rows.Next() {
-		var i GetEpisodeWithAssetsRow
+		var i Episode
 		if err := rows.Scan(
-			&i.EpisodeID,
+			&i.ID,
 			&i.SeriesID,
 			&i.Title,
 			&i.Description,
 			&i.DurationSeconds,
 			&i.PublishDate,
-			&i.EpisodeCreatedAt,
-			&i.EpisodeUpdatedAt,
-			&i.AssetID,
-			&i.AssetType,
-			&i.MimeType,
-			&i.SizeBytes,
-			&i.Url,
-			&i.Storage,
-			&i.AssetCreatedAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.SourceType,
+			&i.ExternalID,
+			&i.Slug,
+			&i.PreviousSlugs,
+			&i.Status,
 		); err != nil {
 			return nil, err
 		}
@@ -373,58 +3000,95 @@ func (q *Queries) GetEpisodeWithAssets(ctx context.Context, id uuid.UUID) ([]Get
 	return items, nil
 }
 
-const getSeries = `-- name: GetSeries :one
-SELECT id, title, description, category_id, language, series_type, created_at, updated_at, deleted_at FROM series
-WHERE id = $1
-  AND deleted_at IS NULL
+const purgeAssets = `-- name: PurgeAssets :execrows
+DELETE FROM episode_assets
+WHERE id = ANY($1::uuid[])
 `
 
-func (q *Queries) GetSeries(ctx context.Context, id uuid.UUID) (Series, error) {
-	row := q.db.QueryRow(ctx, getSeries, id)
-	var i Series
-	err := row.Scan(
-		&i.ID,
-		&i.Title,
-		&i.Description,
-		&i.CategoryID,
-		&i.Language,
-		&i.SeriesType,
-		&i.CreatedAt,
-		&i.UpdatedAt,
-		&i.DeletedAt,
-	)
-	return i, err
+func (q *Queries) PurgeAssets(ctx context.Context, ids []uuid.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, purgeAssets, ids)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
-const listAssetsByEpisode = `-- name: ListAssetsByEpisode :many
+const purgeCategories = `-- name: PurgeCategories :many
+DELETE FROM categories
+WHERE id IN (
+    SELECT c.id FROM categories c
+    WHERE c.deleted_at < $1::timestamptz
+      AND NOT EXISTS (SELECT 1 FROM series s WHERE s.category_id = c.id)
+      AND NOT EXISTS (SELECT 1 FROM categories child WHERE child.parent_id = c.id)
+    ORDER BY c.id
+    LIMIT $2
+)
+RETURNING id
+`
 
-SELECT id, episode_id, asset_type, mime_type, size_bytes, url, storage, created_at FROM episode_assets
-WHERE episode_id = $1
+type PurgeCategoriesParams struct {
+	DeletedBefore time.Time `json:"deleted_before"`
+	RowLimit      int32     `json:"row_limit"`
+}
+
+// Deletes up to row_limit categories that were deleted before
+// deleted_before and are not used by any series, deleted or not, nor
+// the parent of another category.
+func (q *Queries) PurgeCategories(ctx context.Context, arg PurgeCategoriesParams) ([]uuid.UUID, error) {
+	rows, err := q.db.Query(ctx, purgeCategories, arg.DeletedBefore, arg.RowLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []uuid.UUID{}
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const purgeEpisodes = `-- name: PurgeEpisodes :many
+DELETE FROM episodes
+WHERE id IN (
+    SELECT e.id FROM episodes e
+    JOIN series s ON s.id = e.series_id
+    WHERE (e.deleted_at < $1::timestamptz
+           OR s.deleted_at < $1::timestamptz)
+      AND NOT EXISTS (SELECT 1 FROM episode_assets a WHERE a.episode_id = e.id)
+    ORDER BY e.id
+    LIMIT $2
+)
+RETURNING id
 `
 
-// Episode Assets
-func (q *Queries) ListAssetsByEpisode(ctx context.Context, episodeID uuid.UUID) ([]EpisodeAsset, error) {
-	rows, err := q.db.Query(ctx, listAssetsByEpisode, episodeID)
+type PurgeEpisodesParams struct {
+	DeletedBefore time.Time `json:"deleted_before"`
+	RowLimit      int32     `json:"row_limit"`
+}
+
+// Deletes up to row_limit episodes that were deleted before deleted_before,
+// or whose series was. Episodes that still have assets are kept, their
+// stored objects could not be removed yet.
+func (q *Queries) PurgeEpisodes(ctx context.Context, arg PurgeEpisodesParams) ([]uuid.UUID, error) {
+	rows, err := q.db.Query(ctx, purgeEpisodes, arg.DeletedBefore, arg.RowLimit)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []EpisodeAsset{}
+	items := []uuid.UUID{}
 	for rows.Next() {
-		var i EpisodeAsset
-		if err := rows.Scan(
-			&i.ID,
-			&i.EpisodeID,
-			&i.AssetType,
-			&i.MimeType,
-			&i.SizeBytes,
-			&i.Url,
-			&i.Storage,
-			&i.CreatedAt,
-		); err != nil {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
 			return nil, err
 		}
-		items = append(items, i)
+		items = append(items, id)
 	}
 	if err := rows.Err(); err != nil {
 		return nil, err
@@ -432,31 +3096,38 @@ func (q *Queries) ListAssetsByEpisode(ctx context.Context, episodeID uuid.UUID)
 	return items, nil
 }
 
-const listCategories = `-- name: ListCategories :many
-SELECT id, slug, created_at, updated_at, deleted_at FROM categories
-WHERE deleted_at IS NULL
-ORDER BY updated_at
+const purgeSeries = `-- name: PurgeSeries :many
+DELETE FROM series
+WHERE id IN (
+    SELECT s.id FROM series s
+    WHERE s.deleted_at < $1::timestamptz
+      AND NOT EXISTS (SELECT 1 FROM episodes e WHERE e.series_id = s.id)
+    ORDER BY s.id
+    LIMIT $2
+)
+RETURNING id
 `
 
-func (q *Queries) ListCategories(ctx context.Context) ([]Category, error) {
-	rows, err := q.db.Query(ctx, listCategories)
+type PurgeSeriesParams struct {
+	DeletedBefore time.Time `json:"deleted_before"`
+	RowLimit      int32     `json:"row_limit"`
+}
+
+// Deletes up to row_limit series that were deleted before deleted_before
+// and have no episodes left.
+func (q *Queries) PurgeSeries(ctx context.Context, arg PurgeSeriesParams) ([]uuid.UUID, error) {
+	rows, err := q.db.Query(ctx, purgeSeries, arg.DeletedBefore, arg.RowLimit)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []Category{}
+	items := []uuid.UUID{}
 	for rows.Next() {
-		var i Category
-		if err := rows.Scan(
-			&i.ID,
-			&i.Slug,
-			&i.CreatedAt,
-			&i.UpdatedAt,
-			&i.DeletedAt,
-		); err != nil {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
 			return nil, err
 		}
-		items = append(items, i)
+		items = append(items, id)
 	}
 	if err := rows.Err(); err != nil {
 		return nil, err
@@ -464,34 +3135,42 @@ func (q *Queries) ListCategories(ctx context.Context) ([]Category, error) {
 	return items, nil
 }
 
-const listCategoriesPaginated = `-- name: ListCategoriesPaginated :many
-SELECT id, slug, created_at, updated_at, deleted_at
-FROM categories
-WHERE deleted_at IS NULL
-ORDER BY updated_at
-LIMIT $1 OFFSET $2
+const reassignSeriesCategory = `-- name: ReassignSeriesCategory :many
+UPDATE series
+SET category_id = $1,
+    updated_at = NOW()
+WHERE category_id = $2
+  AND deleted_at IS NULL
+RETURNING id, title, description, category_id, language, series_type, created_at, updated_at, deleted_at, slug, previous_slugs
 `
 
-type ListCategoriesPaginatedParams struct {
-	Limit  int32 `json:"limit"`
-	Offset int32 `json:"offset"`
+type ReassignSeriesCategoryParams struct {
+	ToCategoryID   uuid.UUID `json:"to_category_id"`
+	FromCategoryID uuid.UUID `json:"from_category_id"`
 }
 
-func (q *Queries) ListCategoriesPaginated(ctx context.Context, arg ListCategoriesPaginatedParams) ([]Category, error) {
-	rows, err := q.db.Query(ctx, listCategoriesPaginated, arg.Limit, arg.Offset)
+// Moves the live series of a category to another one.
+func (q *Queries) ReassignSeriesCategory(ctx context.Context, arg ReassignSeriesCategoryParams) ([]Series, error) {
+	rows, err := q.db.Query(ctx, reassignSeriesCategory, arg.ToCategoryID, arg.FromCategoryID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []Category{}
+	items := []Series{}
 	for rows.Next() {
-		var i Category
+		var i Series
 		if err := rows.Scan(
 			&i.ID,
-			&i.Slug,
+			&i.Title,
+			&i.Description,
+			&i.CategoryID,
+			&i.Language,
+			&i.SeriesType,
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.Slug,
+			&i.PreviousSlugs,
 		); err != nil {
 			return nil, err
 		}
@@ -503,31 +3182,68 @@ func (q *Queries) ListCategoriesPaginated(ctx context.Context, arg ListCategorie
 	return items, nil
 }
 
-const listEpisodesBySeries = `-- name: ListEpisodesBySeries :many
-SELECT id, series_id, title, description, duration_seconds, publish_date, created_at, updated_at, deleted_at FROM episodes
-WHERE series_id = $1
-  AND deleted_at IS NULL
-ORDER BY publish_date DESC
+const recordOutboxEventFailure = `-- name: RecordOutboxEventFailure :one
+UPDATE outbox_events
+SET attempts = attempts + 1,
+    last_error = $1,
+    failed_at = CASE WHEN $2::bool AND attempts + 1 >= $3::int THEN NOW() END
+WHERE id = $4
+RETURNING failed_at
 `
 
-func (q *Queries) ListEpisodesBySeries(ctx context.Context, seriesID uuid.UUID) ([]Episode, error) {
-	rows, err := q.db.Query(ctx, listEpisodesBySeries, seriesID)
+type RecordOutboxEventFailureParams struct {
+	LastError   *string `json:"last_error"`
+	Park        bool    `json:"park"`
+	MaxAttempts int32   `json:"max_attempts"`
+	ID          int64   `json:"id"`
+}
+
+// Counts a failed attempt. With park set, an event that reached max_attempts
+// is parked and returns its failed_at.
+func (q *Queries) RecordOutboxEventFailure(ctx context.Context, arg RecordOutboxEventFailureParams) (*time.Time, error) {
+	row := q.db.QueryRow(ctx, recordOutboxEventFailure,
+		arg.LastError,
+		arg.Park,
+		arg.MaxAttempts,
+		arg.ID,
+	)
+	var failed_at *time.Time
+	err := row.Scan(&failed_at)
+	return failed_at, err
+}
+
+const restoreAssetsByEpisodes = `-- name: RestoreAssetsByEpisodes :many
+UPDATE episode_assets
+SET deleted_at = NULL
+WHERE episode_id = ANY($1::uuid[])
+  AND deleted_at = $2
+RETURNING id, episode_id, asset_type, mime_type, size_bytes, url, storage, created_at, deleted_at
+`
+
+type RestoreAssetsByEpisodesParams struct {
+	EpisodeIds []uuid.UUID `json:"episode_ids"`
+	DeletedAt  *time.Time  `json:"deleted_at"`
+}
+
+// Restores the assets that were deleted together with their episode.
+func (q *Queries) RestoreAssetsByEpisodes(ctx context.Context, arg RestoreAssetsByEpisodesParams) ([]EpisodeAsset, error) {
+	rows, err := q.db.Query(ctx, restoreAssetsByEpisodes, arg.EpisodeIds, arg.DeletedAt)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []Episode{}
+	items := []EpisodeAsset{}
 	for rows.Next() {
-		var i Episode
+		var i EpisodeAsset
 		if err := rows.Scan(
 			&i.ID,
-			&i.SeriesID,
-			&i.Title,
-			&i.Description,
-			&i.DurationSeconds,
-			&i.PublishDate,
+			&i.EpisodeID,
+			&i.AssetType,
+			&i.MimeType,
+			&i.SizeBytes,
+			&i.Url,
+			&i.Storage,
 			&i.CreatedAt,
-			&i.UpdatedAt,
 			&i.DeletedAt,
 		); err != nil {
 			return nil, err
@@ -540,23 +3256,78 @@ func (q *Queries) ListEpisodesBySeries(ctx context.Context, seriesID uuid.UUID)
 	return items, nil
 }
 
-const listEpisodesBySeriesPaginated = `-- name: ListEpisodesBySeriesPaginated :many
-SELECT id, series_id, title, description, duration_seconds,
-       publish_date, created_at, updated_at, deleted_at
-FROM episodes
-WHERE series_id = $1 AND deleted_at IS NULL
-ORDER BY publish_date DESC
-LIMIT $2 OFFSET $3
+const restoreCategory = `-- name: RestoreCategory :one
+UPDATE categories
+SET deleted_at = NULL,
+    updated_at = NOW()
+WHERE id = $1
+  AND deleted_at IS NOT NULL
+RETURNING id, slug, created_at, updated_at, deleted_at, name, parent_id
 `
 
-type ListEpisodesBySeriesPaginatedParams struct {
-	SeriesID uuid.UUID `json:"series_id"`
-	Limit    int32     `json:"limit"`
-	Offset   int32     `json:"offset"`
+func (q *Queries) RestoreCategory(ctx context.Context, id uuid.UUID) (Category, error) {
+	row := q.db.QueryRow(ctx, restoreCategory, id)
+	var i Category
+	err := row.Scan(
+		&i.ID,
+		&i.Slug,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.Name,
+		&i.ParentID,
+	)
+	return i, err
 }
 
-func (q *Queries) ListEpisodesBySeriesPaginated(ctx context.Context, arg ListEpisodesBySeriesPaginatedParams) ([]Episode, error) {
-	rows, err := q.db.Query(ctx, listEpisodesBySeriesPaginated, arg.SeriesID, arg.Limit, arg.Offset)
+const restoreEpisode = `-- name: RestoreEpisode :one
+UPDATE episodes
+SET deleted_at = NULL,
+    updated_at = NOW()
+WHERE id = $1
+  AND deleted_at IS NOT NULL
+RETURNING id, series_id, title, description, duration_seconds, publish_date, created_at, updated_at, deleted_at, source_type, external_id, slug, previous_slugs, status
+`
+
+func (q *Queries) RestoreEpisode(ctx context.Context, id uuid.UUID) (Episode, error) {
+	row := q.db.QueryRow(ctx, restoreEpisode, id)
+	var i Episode
+	err := row.Scan(
+		&i.ID,
+		&i.SeriesID,
+		&i.Title,
+		&i.Description,
+		&i.DurationSeconds,
+		&i.PublishDate,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.SourceType,
+		&i.ExternalID,
+		&i.Slug,
+		&i.PreviousSlugs,
+		&i.Status,
+	)
+	return i, err
+}
+
+const restoreEpisodesBySeries = `-- name: RestoreEpisodesBySeries :many
+UPDATE episodes
+SET deleted_at = NULL,
+    updated_at = NOW()
+WHERE series_id = $1
+  AND deleted_at = $2
+RETURNING id, series_id, title, description, duration_seconds, publish_date, created_at, updated_at, deleted_at, source_type, external_id, slug, previous_slugs, status
+`
+
+type RestoreEpisodesBySeriesParams struct {
+	SeriesID  uuid.UUID  `json:"series_id"`
+	DeletedAt *time.Time `json:"deleted_at"`
+}
+
+// Restores the episodes that were deleted together with their series.
+func (q *Queries) RestoreEpisodesBySeries(ctx context.Context, arg RestoreEpisodesBySeriesParams) ([]Episode, error) {
+	rows, err := q.db.Query(ctx, restoreEpisodesBySeries, arg.SeriesID, arg.DeletedAt)
 	if err != nil {
 		return nil, err
 	}
@@ -574,6 +3345,11 @@ func (q *Queries) ListEpisodesBySeriesPaginated(ctx context.Context, arg ListEpi
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.SourceType,
+			&i.ExternalID,
+			&i.Slug,
+			&i.PreviousSlugs,
+			&i.Status,
 		); err != nil {
 			return nil, err
 		}
@@ -585,102 +3361,206 @@ func (q *Queries) ListEpisodesBySeriesPaginated(ctx context.Context, arg ListEpi
 	return items, nil
 }
 
-const listEpisodesWithAssetsBySeriesPaginated = `-- name: ListEpisodesWithAssetsBySeriesPaginated :many
-SELECT
-    e.id AS episode_id,
-    e.series_id,
-    e.title,
-    e.description,
-    e.duration_seconds,
-    e.publish_date,
-    e.created_at AS episode_created_at,
-    e.updated_at AS episode_updated_at,
-    a.id AS asset_id,
-    a.asset_type,
-    a.mime_type,
-    a.size_bytes,
-    a.url,
-    a.storage,
-    a.created_at AS asset_created_at
-FROM
-    episodes e
-LEFT JOIN
-    episode_assets a ON e.id = a.episode_id
-WHERE
-    e.series_id = $1 AND e.deleted_at IS NULL
-ORDER BY
-    e.publish_date DESC
-LIMIT $2 OFFSET $3
+const restoreSeries = `-- name: RestoreSeries :one
+UPDATE series
+SET deleted_at = NULL,
+    updated_at = NOW()
+WHERE id = $1
+  AND deleted_at IS NOT NULL
+RETURNING id, title, description, category_id, language, series_type, created_at, updated_at, deleted_at, slug, previous_slugs
 `
 
-type ListEpisodesWithAssetsBySeriesPaginatedParams struct {
-	SeriesID uuid.UUID `json:"series_id"`
-	Limit    int32     `json:"limit"`
-	Offset   int32     `json:"offset"`
+func (q *Queries) RestoreSeries(ctx context.Context, id uuid.UUID) (Series, error) {
+	row := q.db.QueryRow(ctx, restoreSeries, id)
+	var i Series
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Description,
+		&i.CategoryID,
+		&i.Language,
+		&i.SeriesType,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.Slug,
+		&i.PreviousSlugs,
+	)
+	return i, err
+}
+
+const setEpisodeStatus = `-- name: SetEpisodeStatus :one
+UPDATE episodes
+SET status = $1,
+    publish_date = $2,
+    updated_at = NOW()
+WHERE id = $3
+  AND status = $4
+  AND deleted_at IS NULL
+RETURNING id, series_id, title, description, duration_seconds, publish_date, created_at, updated_at, deleted_at, source_type, external_id, slug, previous_slugs, status
+`
+
+type SetEpisodeStatusParams struct {
+	Status      string     `json:"status"`
+	PublishDate *time.Time `json:"publish_date"`
+	ID          uuid.UUID  `json:"id"`
+	FromStatus  string     `json:"from_status"`
+}
+
+// Moves a live episode from from_status to status. No row is returned when
+// its status changed in the meantime, so concurrent changes do not
+// overwrite each other.
+func (q *Queries) SetEpisodeStatus(ctx context.Context, arg SetEpisodeStatusParams) (Episode, error) {
+	row := q.db.QueryRow(ctx, setEpisodeStatus,
+		arg.Status,
+		arg.PublishDate,
+		arg.ID,
+		arg.FromStatus,
+	)
+	var i Episode
+	err := row.Scan(
+		&i.ID,
+		&i.SeriesID,
+		&i.Title,
+		&i.Description,
+		&i.DurationSeconds,
+		&i.PublishDate,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.SourceType,
+		&i.ExternalID,
+		&i.Slug,
+		&i.PreviousSlugs,
+		&i.Status,
+	)
+	return i, err
+}
+
+const setSubscriptionLastJob = `-- name: SetSubscriptionLastJob :exec
+UPDATE series_subscriptions
+SET last_job_id = $2,
+    updated_at = NOW()
+WHERE series_id = $1
+`
+
+type SetSubscriptionLastJobParams struct {
+	SeriesID  uuid.UUID  `json:"series_id"`
+	LastJobID *uuid.UUID `json:"last_job_id"`
+}
+
+func (q *Queries) SetSubscriptionLastJob(ctx context.Context, arg SetSubscriptionLastJobParams) error {
+	_, err := q.db.Exec(ctx, setSubscriptionLastJob, arg.SeriesID, arg.LastJobID)
+	return err
+}
+
+const startDeletionJob = `-- name: StartDeletionJob :exec
+UPDATE deletion_jobs
+SET status = 'running',
+    errors = '[]',
+    started_at = COALESCE(started_at, NOW()),
+    finished_at = NULL,
+    updated_at = NOW()
+WHERE id = $1
+`
+
+func (q *Queries) StartDeletionJob(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.Exec(ctx, startDeletionJob, id)
+	return err
+}
+
+const startImportJob = `-- name: StartImportJob :exec
+UPDATE import_jobs
+SET status = 'running',
+    created_count = 0,
+    updated_count = 0,
+    skipped_count = 0,
+    errors = '[]',
+    started_at = COALESCE(started_at, NOW()),
+    finished_at = NULL,
+    updated_at = NOW()
+WHERE id = $1
+`
+
+func (q *Queries) StartImportJob(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.Exec(ctx, startImportJob, id)
+	return err
+}
+
+const touchEpisode = `-- name: TouchEpisode :one
+UPDATE episodes
+SET updated_at = NOW()
+WHERE id = $1
+  AND deleted_at IS NULL
+RETURNING id, series_id, title, description, duration_seconds, publish_date, created_at, updated_at, deleted_at, source_type, external_id, slug, previous_slugs, status
+`
+
+// Marks a live episode as updated when its search document changes without
+// the row, such as with its translations.
+func (q *Queries) TouchEpisode(ctx context.Context, id uuid.UUID) (Episode, error) {
+	row := q.db.QueryRow(ctx, touchEpisode, id)
+	var i Episode
+	err := row.Scan(
+		&i.ID,
+		&i.SeriesID,
+		&i.Title,
+		&i.Description,
+		&i.DurationSeconds,
+		&i.PublishDate,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.SourceType,
+		&i.ExternalID,
+		&i.Slug,
+		&i.PreviousSlugs,
+		&i.Status,
+	)
+	return i, err
 }
 
-type ListEpisodesWithAssetsBySeriesPaginatedRow struct {
-	EpisodeID        uuid.UUID  `json:"episode_id"`
-	SeriesID         uuid.UUID  `json:"series_id"`
-	Title            string     `json:"title"`
-	Description      *string    `json:"description"`
-	DurationSeconds  *int32     `json:"duration_seconds"`
-	PublishDate      *time.Time `json:"publish_date"`
-	EpisodeCreatedAt time.Time  `json:"episode_created_at"`
-	EpisodeUpdatedAt time.Time  `json:"episode_updated_at"`
-	AssetID          *uuid.UUID `json:"asset_id"`
-	AssetType        *string    `json:"asset_type"`
-	MimeType         *string    `json:"mime_type"`
-	SizeBytes        *int64     `json:"size_bytes"`
-	Url              *string    `json:"url"`
-	Storage          []byte     `json:"storage"`
-	AssetCreatedAt   *time.Time `json:"asset_created_at"`
-}
+const touchSeries = `-- name: TouchSeries :one
+UPDATE series
+SET updated_at = NOW()
+WHERE id = $1
+  AND deleted_at IS NULL
+RETURNING id, title, description, category_id, language, series_type, created_at, updated_at, deleted_at, slug, previous_slugs
+`
 
-func (q *Queries) ListEpisodesWithAssetsBySeriesPaginated(ctx context.Context, arg ListEpisodesWithAssetsBySeriesPaginatedParams) ([]ListEpisodesWithAssetsBySeriesPaginatedRow, error) {
-	rows, err := q.db.Query(ctx, listEpisodesWithAssetsBySeriesPaginated, arg.SeriesID, arg.Limit, arg.Offset)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-	items := []ListEpisodesWithAssetsBySeriesPaginatedRow{}
-	for rows.Next() {
-		var i ListEpisodesWithAssetsBySeriesPaginatedRow
-		if err := rows.Scan(
-			&i.EpisodeID,
-			&i.SeriesID,
-			&i.Title,
-			&i.Description,
-			&i.DurationSeconds,
-			&i.PublishDate,
-			&i.EpisodeCreatedAt,
-			&i.EpisodeUpdatedAt,
-			&i.AssetID,
-			&i.AssetType,
-			&i.MimeType,
-			&i.SizeBytes,
-			&i.Url,
-			&i.Storage,
-			&i.AssetCreatedAt,
-		); err != nil {
-			return nil, err
-		}
-		items = append(items, i)
-	}
-	if err := rows.Err(); err != nil {
-		return nil, err
-	}
-	return items, nil
+// Marks a live series as updated when its search document changes without
+// the row, such as with its translations.
+func (q *Queries) TouchSeries(ctx context.Context, id uuid.UUID) (Series, error) {
+	row := q.db.QueryRow(ctx, touchSeries, id)
+	var i Series
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Description,
+		&i.CategoryID,
+		&i.Language,
+		&i.SeriesType,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.Slug,
+		&i.PreviousSlugs,
+	)
+	return i, err
 }
 
-const listSeries = `-- name: ListSeries :many
-SELECT id, title, description, category_id, language, series_type, created_at, updated_at, deleted_at FROM series
-WHERE deleted_at IS NULL
-ORDER BY created_at DESC
+const touchSeriesByCategories = `-- name: TouchSeriesByCategories :many
+UPDATE series
+SET updated_at = NOW()
+WHERE category_id = ANY($1::uuid[])
+  AND deleted_at IS NULL
+RETURNING id, title, description, category_id, language, series_type, created_at, updated_at, deleted_at, slug, previous_slugs
 `
 
-func (q *Queries) ListSeries(ctx context.Context) ([]Series, error) {
-	rows, err := q.db.Query(ctx, listSeries)
+// Marks the live series of any of the categories as updated, their search
+// documents change with the category path. Reindex catch-up and reconcile
+// compare updated_at.
+func (q *Queries) TouchSeriesByCategories(ctx context.Context, categoryIds []uuid.UUID) ([]Series, error) {
+	rows, err := q.db.Query(ctx, touchSeriesByCategories, categoryIds)
 	if err != nil {
 		return nil, err
 	}
@@ -698,6 +3578,8 @@ func (q *Queries) ListSeries(ctx context.Context) ([]Series, error) {
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.Slug,
+			&i.PreviousSlugs,
 		); err != nil {
 			return nil, err
 		}
@@ -709,48 +3591,17 @@ func (q *Queries) ListSeries(ctx context.Context) ([]Series, error) {
 	return items, nil
 }
 
-const listSeriesPaginated = `-- name: ListSeriesPaginated :many
-SELECT id, title, description, category_id, language, series_type,
-       created_at, updated_at, deleted_at 
-FROM series
-WHERE deleted_at IS NULL
-ORDER BY created_at DESC
-LIMIT $1 OFFSET $2
+const tryLockOutboxRelay = `-- name: TryLockOutboxRelay :one
+SELECT pg_try_advisory_xact_lock(hashtext('outbox_relay'))::bool AS locked
 `
 
-type ListSeriesPaginatedParams struct {
-	Limit  int32 `json:"limit"`
-	Offset int32 `json:"offset"`
-}
-
-func (q *Queries) ListSeriesPaginated(ctx context.Context, arg ListSeriesPaginatedParams) ([]Series, error) {
-	rows, err := q.db.Query(ctx, listSeriesPaginated, arg.Limit, arg.Offset)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-	items := []Series{}
-	for rows.Next() {
-		var i Series
-		if err := rows.Scan(
-			&i.ID,
-			&i.Title,
-			&i.Description,
-			&i.CategoryID,
-			&i.Language,
-			&i.SeriesType,
-			&i.CreatedAt,
-			&i.UpdatedAt,
-			&i.DeletedAt,
-		); err != nil {
-			return nil, err
-		}
-		items = append(items, i)
-	}
-	if err := rows.Err(); err != nil {
-		return nil, err
-	}
-	return items, nil
+// Takes the relay lock until the end of the transaction, false when another
+// relay holds it.
+func (q *Queries) TryLockOutboxRelay(ctx context.Context) (bool, error) {
+	row := q.db.QueryRow(ctx, tryLockOutboxRelay)
+	var locked bool
+	err := row.Scan(&locked)
+	return locked, err
 }
 
 const updateAsset = `-- name: UpdateAsset :one
@@ -760,7 +3611,7 @@ SET mime_type = $2,
     url = $4,
     storage = $5
 WHERE id = $1
-RETURNING id, episode_id, asset_type, mime_type, size_bytes, url, storage, created_at
+RETURNING id, episode_id, asset_type, mime_type, size_bytes, url, storage, created_at, deleted_at
 `
 
 type UpdateAssetParams struct {
@@ -789,26 +3640,29 @@ func (q *Queries) UpdateAsset(ctx context.Context, arg UpdateAssetParams) (Episo
 		&i.Url,
 		&i.Storage,
 		&i.CreatedAt,
+		&i.DeletedAt,
 	)
 	return i, err
 }
 
 const updateCategory = `-- name: UpdateCategory :one
 UPDATE categories
-SET slug = $2,
+SET name = $2,
+    slug = $3,
     updated_at = NOW()
 WHERE id = $1
   AND deleted_at IS NULL
-RETURNING id, slug, created_at, updated_at, deleted_at
+RETURNING id, slug, created_at, updated_at, deleted_at, name, parent_id
 `
 
 type UpdateCategoryParams struct {
 	ID   uuid.UUID `json:"id"`
+	Name string    `json:"name"`
 	Slug string    `json:"slug"`
 }
 
 func (q *Queries) UpdateCategory(ctx context.Context, arg UpdateCategoryParams) (Category, error) {
-	row := q.db.QueryRow(ctx, updateCategory, arg.ID, arg.Slug)
+	row := q.db.QueryRow(ctx, updateCategory, arg.ID, arg.Name, arg.Slug)
 	var i Category
 	err := row.Scan(
 		&i.ID,
@@ -816,6 +3670,8 @@ func (q *Queries) UpdateCategory(ctx context.Context, arg UpdateCategoryParams)
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.Name,
+		&i.ParentID,
 	)
 	return i, err
 }
@@ -826,10 +3682,13 @@ SET title = $2,
     description = $3,
     duration_seconds = $4,
     publish_date = $5,
+    slug = $6,
+    previous_slugs = CASE WHEN slug = $6 THEN previous_slugs
+                          ELSE array_append(array_remove(previous_slugs, $6::text), slug) END,
     updated_at = NOW()
 WHERE id = $1
   AND deleted_at IS NULL
-RETURNING id, series_id, title, description, duration_seconds, publish_date, created_at, updated_at, deleted_at
+RETURNING id, series_id, title, description, duration_seconds, publish_date, created_at, updated_at, deleted_at, source_type, external_id, slug, previous_slugs, status
 `
 
 type UpdateEpisodeParams struct {
@@ -838,8 +3697,11 @@ type UpdateEpisodeParams struct {
 	Description     *string    `json:"description"`
 	DurationSeconds *int32     `json:"duration_seconds"`
 	PublishDate     *time.Time `json:"publish_date"`
+	Slug            string     `json:"slug"`
 }
 
+// A changed slug moves the current one to previous_slugs, so links using
+// it can be redirected.
 func (q *Queries) UpdateEpisode(ctx context.Context, arg UpdateEpisodeParams) (Episode, error) {
 	row := q.db.QueryRow(ctx, updateEpisode,
 		arg.ID,
@@ -847,6 +3709,7 @@ func (q *Queries) UpdateEpisode(ctx context.Context, arg UpdateEpisodeParams) (E
 		arg.Description,
 		arg.DurationSeconds,
 		arg.PublishDate,
+		arg.Slug,
 	)
 	var i Episode
 	err := row.Scan(
@@ -859,10 +3722,44 @@ func (q *Queries) UpdateEpisode(ctx context.Context, arg UpdateEpisodeParams) (E
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.SourceType,
+		&i.ExternalID,
+		&i.Slug,
+		&i.PreviousSlugs,
+		&i.Status,
 	)
 	return i, err
 }
 
+const updateImportJobProgress = `-- name: UpdateImportJobProgress :exec
+UPDATE import_jobs
+SET created_count = $2,
+    updated_count = $3,
+    skipped_count = $4,
+    errors = $5,
+    updated_at = NOW()
+WHERE id = $1
+`
+
+type UpdateImportJobProgressParams struct {
+	ID           uuid.UUID `json:"id"`
+	CreatedCount int32     `json:"created_count"`
+	UpdatedCount int32     `json:"updated_count"`
+	SkippedCount int32     `json:"skipped_count"`
+	Errors       []byte    `json:"errors"`
+}
+
+func (q *Queries) UpdateImportJobProgress(ctx context.Context, arg UpdateImportJobProgressParams) error {
+	_, err := q.db.Exec(ctx, updateImportJobProgress,
+		arg.ID,
+		arg.CreatedCount,
+		arg.UpdatedCount,
+		arg.SkippedCount,
+		arg.Errors,
+	)
+	return err
+}
+
 const updateSeries = `-- name: UpdateSeries :one
 UPDATE series
 SET title = $2,
@@ -870,10 +3767,13 @@ SET title = $2,
     category_id = $4,
     language = $5,
     series_type = $6,
+    slug = $7,
+    previous_slugs = CASE WHEN slug = $7 THEN previous_slugs
+                          ELSE array_append(array_remove(previous_slugs, $7::text), slug) END,
     updated_at = NOW()
 WHERE id = $1
   AND deleted_at IS NULL
-RETURNING id, title, description, category_id, language, series_type, created_at, updated_at, deleted_at
+RETURNING id, title, description, category_id, language, series_type, created_at, updated_at, deleted_at, slug, previous_slugs
 `
 
 type UpdateSeriesParams struct {
@@ -883,8 +3783,11 @@ type UpdateSeriesParams struct {
 	CategoryID  uuid.UUID `json:"category_id"`
 	Language    *string   `json:"language"`
 	SeriesType  string    `json:"series_type"`
+	Slug        string    `json:"slug"`
 }
 
+// A changed slug moves the current one to previous_slugs, so links using
+// it can be redirected.
 func (q *Queries) UpdateSeries(ctx context.Context, arg UpdateSeriesParams) (Series, error) {
 	row := q.db.QueryRow(ctx, updateSeries,
 		arg.ID,
@@ -893,6 +3796,7 @@ func (q *Queries) UpdateSeries(ctx context.Context, arg UpdateSeriesParams) (Ser
 		arg.CategoryID,
 		arg.Language,
 		arg.SeriesType,
+		arg.Slug,
 	)
 	var i Series
 	err := row.Scan(
@@ -905,6 +3809,266 @@ func (q *Queries) UpdateSeries(ctx context.Context, arg UpdateSeriesParams) (Ser
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.Slug,
+		&i.PreviousSlugs,
+	)
+	return i, err
+}
+
+const updateSubscriptionSync = `-- name: UpdateSubscriptionSync :exec
+UPDATE series_subscriptions
+SET etag = $2,
+    last_modified = $3,
+    last_synced_at = NOW(),
+    updated_at = NOW()
+WHERE series_id = $1
+`
+
+type UpdateSubscriptionSyncParams struct {
+	SeriesID     uuid.UUID `json:"series_id"`
+	Etag         *string   `json:"etag"`
+	LastModified *string   `json:"last_modified"`
+}
+
+func (q *Queries) UpdateSubscriptionSync(ctx context.Context, arg UpdateSubscriptionSyncParams) error {
+	_, err := q.db.Exec(ctx, updateSubscriptionSync, arg.SeriesID, arg.Etag, arg.LastModified)
+	return err
+}
+
+const upsertCategoryTranslation = `-- name: UpsertCategoryTranslation :one
+INSERT INTO category_translations (category_id, locale, name)
+VALUES ($1, $2, $3)
+ON CONFLICT (category_id, locale) DO UPDATE
+SET name = EXCLUDED.name,
+    updated_at = NOW()
+RETURNING category_id, locale, name, created_at, updated_at
+`
+
+type UpsertCategoryTranslationParams struct {
+	CategoryID uuid.UUID `json:"category_id"`
+	Locale     string    `json:"locale"`
+	Name       string    `json:"name"`
+}
+
+func (q *Queries) UpsertCategoryTranslation(ctx context.Context, arg UpsertCategoryTranslationParams) (CategoryTranslation, error) {
+	row := q.db.QueryRow(ctx, upsertCategoryTranslation,
+		arg.CategoryID,
+		arg.Locale,
+		arg.Name,
+	)
+	var i CategoryTranslation
+	err := row.Scan(
+		&i.CategoryID,
+		&i.Locale,
+		&i.Name,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const upsertEpisodeTranslation = `-- name: UpsertEpisodeTranslation :one
+INSERT INTO episode_translations (episode_id, locale, title, description)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (episode_id, locale) DO UPDATE
+SET title = EXCLUDED.title,
+    description = EXCLUDED.description,
+    updated_at = NOW()
+RETURNING episode_id, locale, title, description, created_at, updated_at
+`
+
+type UpsertEpisodeTranslationParams struct {
+	EpisodeID   uuid.UUID `json:"episode_id"`
+	Locale      string    `json:"locale"`
+	Title       string    `json:"title"`
+	Description *string   `json:"description"`
+}
+
+func (q *Queries) UpsertEpisodeTranslation(ctx context.Context, arg UpsertEpisodeTranslationParams) (EpisodeTranslation, error) {
+	row := q.db.QueryRow(ctx, upsertEpisodeTranslation,
+		arg.EpisodeID,
+		arg.Locale,
+		arg.Title,
+		arg.Description,
+	)
+	var i EpisodeTranslation
+	err := row.Scan(
+		&i.EpisodeID,
+		&i.Locale,
+		&i.Title,
+		&i.Description,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const upsertImportedEpisode = `-- name: UpsertImportedEpisode :one
+INSERT INTO episodes (
+    series_id, title, description,
+    duration_seconds, publish_date,
+    source_type, external_id, slug, status
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+ON CONFLICT (series_id, source_type, external_id) DO UPDATE
+SET title = EXCLUDED.title,
+    description = EXCLUDED.description,
+    duration_seconds = EXCLUDED.duration_seconds,
+    publish_date = EXCLUDED.publish_date,
+    updated_at = NOW()
+WHERE episodes.deleted_at IS NULL
+  AND (episodes.title, episodes.description, episodes.duration_seconds, episodes.publish_date)
+      IS DISTINCT FROM
+      (EXCLUDED.title, EXCLUDED.description, EXCLUDED.duration_seconds, EXCLUDED.publish_date)
+RETURNING id, series_id, title, description, duration_seconds, publish_date, created_at, updated_at, deleted_at, source_type, external_id, slug, previous_slugs, status, (xmax = 0) AS inserted
+`
+
+type UpsertImportedEpisodeParams struct {
+	SeriesID        uuid.UUID  `json:"series_id"`
+	Title           string     `json:"title"`
+	Description     *string    `json:"description"`
+	DurationSeconds *int32     `json:"duration_seconds"`
+	PublishDate     *time.Time `json:"publish_date"`
+	SourceType      *string    `json:"source_type"`
+	ExternalID      *string    `json:"external_id"`
+	Slug            string     `json:"slug"`
+	Status          string     `json:"status"`
+}
+
+type UpsertImportedEpisodeRow struct {
+	ID              uuid.UUID  `json:"id"`
+	SeriesID        uuid.UUID  `json:"series_id"`
+	Title           string     `json:"title"`
+	Description     *string    `json:"description"`
+	DurationSeconds *int32     `json:"duration_seconds"`
+	PublishDate     *time.Time `json:"publish_date"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+	DeletedAt       *time.Time `json:"deleted_at"`
+	SourceType      *string    `json:"source_type"`
+	ExternalID      *string    `json:"external_id"`
+	Slug            string     `json:"slug"`
+	PreviousSlugs   []string   `json:"previous_slugs"`
+	Status          string     `json:"status"`
+	Inserted        bool       `json:"inserted"`
+}
+
+// Inserts an imported episode or updates the one with the same source
+// identity. No row is returned when the stored episode is already up to
+// date or has been deleted. The slug and status are only set on insert,
+// so an update keeps the status the episode got in the CMS.
+func (q *Queries) UpsertImportedEpisode(ctx context.Context, arg UpsertImportedEpisodeParams) (UpsertImportedEpisodeRow, error) {
+	row := q.db.QueryRow(ctx, upsertImportedEpisode,
+		arg.SeriesID,
+		arg.Title,
+		arg.Description,
+		arg.DurationSeconds,
+		arg.PublishDate,
+		arg.SourceType,
+		arg.ExternalID,
+		arg.Slug,
+		arg.Status,
+	)
+	var i UpsertImportedEpisodeRow
+	err := row.Scan(
+		&i.ID,
+		&i.SeriesID,
+		&i.Title,
+		&i.Description,
+		&i.DurationSeconds,
+		&i.PublishDate,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.SourceType,
+		&i.ExternalID,
+		&i.Slug,
+		&i.PreviousSlugs,
+		&i.Status,
+		&i.Inserted,
+	)
+	return i, err
+}
+
+const upsertSeriesSubscription = `-- name: UpsertSeriesSubscription :one
+INSERT INTO series_subscriptions (series_id, source_type, source_url, interval_seconds)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (series_id) DO UPDATE
+SET source_type = EXCLUDED.source_type,
+    source_url = EXCLUDED.source_url,
+    interval_seconds = EXCLUDED.interval_seconds,
+    etag = CASE WHEN series_subscriptions.source_url = EXCLUDED.source_url THEN series_subscriptions.etag END,
+    last_modified = CASE WHEN series_subscriptions.source_url = EXCLUDED.source_url THEN series_subscriptions.last_modified END,
+    next_sync_at = NOW(),
+    updated_at = NOW()
+RETURNING series_id, source_type, source_url, interval_seconds, etag, last_modified, next_sync_at, last_synced_at, last_job_id, created_at, updated_at
+`
+
+type UpsertSeriesSubscriptionParams struct {
+	SeriesID        uuid.UUID `json:"series_id"`
+	SourceType      string    `json:"source_type"`
+	SourceUrl       string    `json:"source_url"`
+	IntervalSeconds int32     `json:"interval_seconds"`
+}
+
+// Validators are kept only while the URL stays the same. A changed
+// subscription is synced on the next scheduler tick.
+func (q *Queries) UpsertSeriesSubscription(ctx context.Context, arg UpsertSeriesSubscriptionParams) (SeriesSubscription, error) {
+	row := q.db.QueryRow(ctx, upsertSeriesSubscription,
+		arg.SeriesID,
+		arg.SourceType,
+		arg.SourceUrl,
+		arg.IntervalSeconds,
+	)
+	var i SeriesSubscription
+	err := row.Scan(
+		&i.SeriesID,
+		&i.SourceType,
+		&i.SourceUrl,
+		&i.IntervalSeconds,
+		&i.Etag,
+		&i.LastModified,
+		&i.NextSyncAt,
+		&i.LastSyncedAt,
+		&i.LastJobID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const upsertSeriesTranslation = `-- name: UpsertSeriesTranslation :one
+INSERT INTO series_translations (series_id, locale, title, description)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (series_id, locale) DO UPDATE
+SET title = EXCLUDED.title,
+    description = EXCLUDED.description,
+    updated_at = NOW()
+RETURNING series_id, locale, title, description, created_at, updated_at
+`
+
+type UpsertSeriesTranslationParams struct {
+	SeriesID    uuid.UUID `json:"series_id"`
+	Locale      string    `json:"locale"`
+	Title       string    `json:"title"`
+	Description *string   `json:"description"`
+}
+
+func (q *Queries) UpsertSeriesTranslation(ctx context.Context, arg UpsertSeriesTranslationParams) (SeriesTranslation, error) {
+	row := q.db.QueryRow(ctx, upsertSeriesTranslation,
+		arg.SeriesID,
+		arg.Locale,
+		arg.Title,
+		arg.Description,
+	)
+	var i SeriesTranslation
+	err := row.Scan(
+		&i.SeriesID,
+		&i.Locale,
+		&i.Title,
+		&i.Description,
+		&i.CreatedAt,
+		&i.UpdatedAt,
 	)
 	return i, err
 }